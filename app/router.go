@@ -6,15 +6,201 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewRouter(app *fiber.App, categoryController controller.CategoryController) {
-	authMiddleware := middleware.NewAuthMiddleware()
+func NewRouter(app *fiber.App,
+	authMiddleware fiber.Handler,
+	storeMiddleware fiber.Handler,
+	idempotencyMiddleware fiber.Handler,
+	storeController controller.StoreController,
+	categoryController controller.CategoryController,
+	customerController controller.CustomerController,
+	employeeController controller.EmployeeController,
+	productController controller.ProductController,
+	productImportController controller.ProductImportController,
+	productVariantController controller.ProductVariantController,
+	productBarcodeController controller.ProductBarcodeController,
+	orderController controller.OrderController,
+	priceListController controller.PriceListController,
+	priceScheduleController controller.PriceScheduleController,
+	shiftController controller.ShiftController,
+	stockController controller.StockController,
+	supplierController controller.SupplierController,
+	purchaseOrderController controller.PurchaseOrderController,
+	stocktakeController controller.StocktakeController,
+	webhookController controller.WebhookController,
+	syncController controller.SyncController,
+	eventController controller.EventController,
+	reportController controller.ReportController,
+	auditLogController controller.AuditLogController,
+) {
+	requestContextMiddleware := middleware.NewRequestContextMiddleware()
 
-	api := app.Group("/api", authMiddleware)
-	categories := api.Group("/categories")
+	api := app.Group("/api", requestContextMiddleware, authMiddleware, storeMiddleware, idempotencyMiddleware)
+
+	stores := api.Group("/stores")
+	stores.Get("/", storeController.FindAll)
+	stores.Get("/:storeId", storeController.FindById)
+	stores.Post("/", storeController.Create)
+	stores.Put("/:storeId", storeController.Update)
+	stores.Delete("/:storeId", storeController.Delete)
 
+	categories := api.Group("/categories")
 	categories.Get("/", categoryController.FindAll)
+	categories.Get("/export", categoryController.Export)
+	categories.Get("/tree", categoryController.FindTree)
 	categories.Get("/:categoryId", categoryController.FindById)
 	categories.Post("/", categoryController.Create)
 	categories.Put("/:categoryId", categoryController.Update)
 	categories.Delete("/:categoryId", categoryController.Delete)
+	categories.Put("/:categoryId/move", categoryController.Move)
+	categories.Post("/bulk", categoryController.Bulk)
+
+	customers := api.Group("/customers")
+	customers.Get("/", customerController.FindAll)
+	customers.Get("/export", customerController.Export)
+	customers.Get("/duplicates", customerController.FindDuplicates)
+	customers.Post("/merge", customerController.Merge)
+	customers.Get("/:customerId", customerController.FindById)
+	customers.Get("/:customerId/orders", customerController.FindOrders)
+	customers.Get("/:customerId/profile", customerController.Profile)
+	customers.Get("/:customerId/data-export", customerController.DataExport)
+	customers.Post("/:customerId/anonymise", customerController.Anonymise)
+	customers.Post("/", customerController.Create)
+	customers.Put("/:customerId", customerController.Update)
+	customers.Delete("/:customerId", customerController.Delete)
+	customers.Post("/bulk", customerController.Bulk)
+
+	employees := api.Group("/employees")
+	employees.Get("/", employeeController.FindAll)
+	employees.Get("/export", employeeController.Export)
+	employees.Get("/:employeeId", employeeController.FindById)
+	employees.Post("/", employeeController.Create)
+	employees.Put("/:employeeId", employeeController.Update)
+	employees.Delete("/:employeeId", employeeController.Delete)
+	employees.Post("/:employeeId/api-key", employeeController.IssueApiKey)
+
+	products := api.Group("/products")
+	products.Get("/", productController.FindAll)
+	products.Get("/export", productController.Export)
+	products.Get("/barcode/:code", productBarcodeController.Lookup)
+	products.Get("/barcodes/labels", productBarcodeController.Labels)
+	products.Post("/barcodes/generate", productBarcodeController.GenerateMissing)
+	products.Get("/:productId", productController.FindById)
+	products.Post("/", productController.Create)
+	products.Put("/:productId", productController.Update)
+	products.Delete("/:productId", productController.Delete)
+	products.Post("/bulk", productController.Bulk)
+	products.Post("/import", productImportController.Import)
+	products.Put("/:productId/options", productVariantController.SetOptions)
+	products.Get("/:productId/variants", productVariantController.FindAll)
+	products.Post("/:productId/variants/generate", productVariantController.GenerateVariants)
+	products.Put("/:productId/variants/:variantId", productVariantController.Update)
+	products.Delete("/:productId/variants/:variantId", productVariantController.Delete)
+	products.Get("/:productId/barcodes", productBarcodeController.FindAll)
+	products.Post("/:productId/barcodes", productBarcodeController.Create)
+	products.Delete("/:productId/barcodes/:code", productBarcodeController.Delete)
+	products.Get("/:productId/price-history", priceListController.FindPriceHistory)
+
+	priceLists := api.Group("/price-lists")
+	priceLists.Get("/", priceListController.FindAll)
+	priceLists.Get("/:priceListId", priceListController.FindById)
+	priceLists.Post("/", priceListController.Create)
+	priceLists.Put("/:priceListId", priceListController.Update)
+	priceLists.Delete("/:priceListId", priceListController.Delete)
+	priceLists.Get("/:priceListId/items", priceListController.FindItems)
+	priceLists.Put("/:priceListId/items/:productId", priceListController.SetItem)
+	priceLists.Delete("/:priceListId/items/:productId", priceListController.DeleteItem)
+
+	priceSchedules := api.Group("/price-schedules")
+	priceSchedules.Get("/", priceScheduleController.FindAll)
+	priceSchedules.Post("/", priceScheduleController.Create)
+	priceSchedules.Delete("/:scheduleId", priceScheduleController.Cancel)
+
+	api.Get("/prices/resolve", priceListController.ResolvePrice)
+
+	orders := api.Group("/orders")
+	orders.Get("/", orderController.FindAll)
+	orders.Get("/export", orderController.Export)
+	orders.Get("/offline", orderController.FindOffline)
+	orders.Post("/offline", orderController.UploadOffline)
+	orders.Get("/:orderId", orderController.FindById)
+	orders.Post("/", orderController.Create)
+	orders.Post("/:orderId/payments", orderController.AddPayment)
+
+	shifts := api.Group("/shifts")
+	shifts.Get("/", shiftController.FindAll)
+	shifts.Get("/current", shiftController.FindCurrent)
+	shifts.Get("/:shiftId", shiftController.FindById)
+	shifts.Post("/open", shiftController.Open)
+	shifts.Post("/:shiftId/close", shiftController.Close)
+	shifts.Post("/:shiftId/cash-movements", shiftController.RecordCashMovement)
+
+	stock := api.Group("/stock")
+	stock.Get("/", stockController.FindLevels)
+	stock.Get("/movements", stockController.FindMovements)
+	stock.Get("/inventory", stockController.FindInventories)
+	stock.Post("/:productId/restock", stockController.Restock)
+	stock.Put("/:productId/restock-level", stockController.SetRestockLevel)
+	stock.Put("/:productId", stockController.SetLevel)
+
+	stockTransfers := api.Group("/stock-transfers")
+	stockTransfers.Get("/", stockController.FindTransfers)
+	stockTransfers.Get("/:transferId", stockController.FindTransferById)
+	stockTransfers.Post("/", stockController.CreateTransfer)
+	stockTransfers.Post("/:transferId/receive", stockController.ReceiveTransfer)
+	stockTransfers.Post("/:transferId/cancel", stockController.CancelTransfer)
+
+	stocktakes := api.Group("/stocktakes")
+	stocktakes.Get("/", stocktakeController.FindAll)
+	stocktakes.Get("/:stocktakeId", stocktakeController.FindById)
+	stocktakes.Get("/:stocktakeId/variances", stocktakeController.Variances)
+	stocktakes.Post("/", stocktakeController.Create)
+	stocktakes.Post("/:stocktakeId/counts", stocktakeController.Count)
+	stocktakes.Post("/:stocktakeId/counts/upload", stocktakeController.Upload)
+	stocktakes.Post("/:stocktakeId/approve", stocktakeController.Approve)
+	stocktakes.Post("/:stocktakeId/cancel", stocktakeController.Cancel)
+
+	suppliers := api.Group("/suppliers")
+	suppliers.Get("/", supplierController.FindAll)
+	suppliers.Get("/:supplierId", supplierController.FindById)
+	suppliers.Post("/", supplierController.Create)
+	suppliers.Put("/:supplierId", supplierController.Update)
+	suppliers.Delete("/:supplierId", supplierController.Delete)
+
+	purchaseOrders := api.Group("/purchase-orders")
+	purchaseOrders.Get("/", purchaseOrderController.FindAll)
+	purchaseOrders.Get("/:purchaseOrderId", purchaseOrderController.FindById)
+	purchaseOrders.Post("/", purchaseOrderController.Create)
+	purchaseOrders.Post("/generate", purchaseOrderController.Generate)
+	purchaseOrders.Put("/:purchaseOrderId", purchaseOrderController.Update)
+	purchaseOrders.Delete("/:purchaseOrderId", purchaseOrderController.Delete)
+	purchaseOrders.Post("/:purchaseOrderId/send", purchaseOrderController.Send)
+	purchaseOrders.Post("/:purchaseOrderId/receive", purchaseOrderController.Receive)
+	purchaseOrders.Post("/:purchaseOrderId/close", purchaseOrderController.Close)
+
+	webhooks := api.Group("/webhooks")
+	webhooks.Get("/", webhookController.FindAll)
+	webhooks.Get("/deliveries", webhookController.FindDeliveries)
+	webhooks.Post("/deliveries/:deliveryId/redeliver", webhookController.Redeliver)
+	webhooks.Get("/:webhookId", webhookController.FindById)
+	webhooks.Post("/", webhookController.Create)
+	webhooks.Put("/:webhookId", webhookController.Update)
+	webhooks.Delete("/:webhookId", webhookController.Delete)
+
+	sync := api.Group("/sync")
+	sync.Get("/changes", syncController.Changes)
+
+	api.Get("/events/stream", eventController.Stream)
+
+	reports := api.Group("/reports")
+	reports.Get("/sales", reportController.Sales)
+	reports.Get("/top-products", reportController.TopProducts)
+	reports.Get("/categories", reportController.Categories)
+	reports.Get("/payment-types", reportController.PaymentTypes)
+	reports.Get("/taxes", reportController.Taxes)
+	reports.Get("/discounts", reportController.Discounts)
+	reports.Get("/margins", reportController.Margins)
+	reports.Get("/inventory-valuation", reportController.InventoryValuation)
+
+	audit := api.Group("/audit")
+	audit.Get("/", auditLogController.FindAll)
 }
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StartJob runs fn in the background every interval until ctx is cancelled. Runs never
+// overlap; a run that takes longer than the interval delays the next one. Errors are
+// logged and the job keeps going.
+func StartJob(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := fn(ctx); err != nil {
+				log.Printf("job %s: %v", name, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
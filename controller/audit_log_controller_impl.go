@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditLogControllerImpl struct {
+	AuditLogService service.AuditLogService
+}
+
+func NewAuditLogController(auditLogService service.AuditLogService) AuditLogController {
+	return &AuditLogControllerImpl{
+		AuditLogService: auditLogService,
+	}
+}
+
+// Find All Audit Logs, filtered by entity, actor and time range
+func (controller *AuditLogControllerImpl) FindAll(c *fiber.Ctx) error {
+	filterRequest := web.AuditLogFilterRequest{
+		EntityType: c.Query("entity_type"),
+		EntityId:   c.Query("entity_id"),
+		Actor:      c.Query("actor"),
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid From Time",
+			Data:   err.Error(),
+		})
+	}
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid To Time",
+			Data:   err.Error(),
+		})
+	}
+	filterRequest.From = from
+	filterRequest.To = to
+
+	auditLogResponses, err := controller.AuditLogService.FindAll(c.Context(), filterRequest)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   fiber.StatusInternalServerError,
+			Status: "Internal Server Error",
+			Data:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   auditLogResponses,
+	})
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"encoding/json"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func setupAuditLogTestApp(mockService *mocks.MockAuditLogService) *fiber.App {
+	app := fiber.New()
+	auditLogController := NewAuditLogController(mockService)
+
+	api := app.Group("/api")
+	api.Get("/audit", auditLogController.FindAll)
+
+	return app
+}
+
+func TestAuditLogController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuditLogService(ctrl)
+	app := setupAuditLogTestApp(mockService)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name: "Find audit logs - filtered",
+			url:  "/api/audit?entity_type=products&actor=employee:7&from=2024-01-01T00:00:00Z",
+			setupMock: func() {
+				mockService.EXPECT().
+					FindAll(gomock.Any(), web.AuditLogFilterRequest{EntityType: "products", Actor: "employee:7", From: &from}).
+					Return([]web.AuditLogResponse{{Id: 1, EntityType: "products", Action: "update"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Find audit logs - invalid time",
+			url:            "/api/audit?to=yesterday",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			resp, _ := app.Test(req)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			var respBody web.WebResponse
+			json.NewDecoder(resp.Body).Decode(&respBody)
+			assert.Equal(t, tt.expectedStatus, respBody.Code)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/gofiber/fiber/v2"
+)
+
+// bulkStatusCode is 200 when every item succeeded, 207 when a best-effort request
+// partially failed and 422 when an all-or-nothing request was rolled back.
+func bulkStatusCode(bulkResponse web.BulkResponse) int {
+	if bulkResponse.Failed == 0 {
+		return fiber.StatusOK
+	}
+	if bulkResponse.Mode == web.BulkModeBestEffort {
+		return fiber.StatusMultiStatus
+	}
+	return fiber.StatusUnprocessableEntity
+}
@@ -8,6 +8,10 @@ type CategoryController interface {
 	Create(c *fiber.Ctx) error
 	Update(c *fiber.Ctx) error
 	Delete(c *fiber.Ctx) error
+	Move(c *fiber.Ctx) error
 	FindById(c *fiber.Ctx) error
+	FindTree(c *fiber.Ctx) error
 	FindAll(c *fiber.Ctx) error
+	Export(c *fiber.Ctx) error
+	Bulk(c *fiber.Ctx) error
 }
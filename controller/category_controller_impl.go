@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/model/web"
 	"github.com/aronipurwanto/go-restful-api/service"
@@ -38,6 +39,7 @@ func (controller *CategoryControllerImpl) Create(c *fiber.Ctx) error {
 		})
 	}
 
+	setVersionETag(c, categoryResponse.Version)
 	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
 		Code:   fiber.StatusCreated,
 		Status: "Created",
@@ -66,6 +68,18 @@ func (controller *CategoryControllerImpl) Update(c *fiber.Ctx) error {
 	}
 	categoryUpdateRequest.Id = id
 
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		categoryUpdateRequest.Version = version
+	}
+
 	categoryResponse, err := controller.CategoryService.Update(c.Context(), *categoryUpdateRequest)
 	if err != nil {
 		if _, ok := err.(exception.NotFoundError); ok {
@@ -75,6 +89,13 @@ func (controller *CategoryControllerImpl) Update(c *fiber.Ctx) error {
 				Data:   err.Error(),
 			})
 		}
+		if _, ok := err.(exception.ConflictError); ok {
+			return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+				Code:   fiber.StatusConflict,
+				Status: "Conflict",
+				Data:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   fiber.StatusInternalServerError,
 			Status: "Internal Server Error",
@@ -82,6 +103,7 @@ func (controller *CategoryControllerImpl) Update(c *fiber.Ctx) error {
 		})
 	}
 
+	setVersionETag(c, categoryResponse.Version)
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
 		Code:   fiber.StatusOK,
 		Status: "OK",
@@ -100,7 +122,16 @@ func (controller *CategoryControllerImpl) Delete(c *fiber.Ctx) error {
 		})
 	}
 
-	err = controller.CategoryService.Delete(c.Context(), id)
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	err = controller.CategoryService.Delete(c.Context(), id, version)
 	if err != nil {
 		if _, ok := err.(exception.NotFoundError); ok {
 			return c.Status(fiber.StatusNotFound).JSON(web.WebResponse{
@@ -109,6 +140,13 @@ func (controller *CategoryControllerImpl) Delete(c *fiber.Ctx) error {
 				Data:   err.Error(),
 			})
 		}
+		if _, ok := err.(exception.ConflictError); ok {
+			return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+				Code:   fiber.StatusConflict,
+				Status: "Conflict",
+				Data:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   fiber.StatusInternalServerError,
 			Status: "Internal Server Error",
@@ -133,7 +171,8 @@ func (controller *CategoryControllerImpl) FindById(c *fiber.Ctx) error {
 		})
 	}
 
-	categoryResponse, err := controller.CategoryService.FindById(c.Context(), id)
+	withProductCounts := c.QueryBool("product_counts")
+	categoryResponse, err := controller.CategoryService.FindById(c.Context(), id, withProductCounts)
 	if err != nil {
 		if _, ok := err.(exception.NotFoundError); ok {
 			return c.Status(fiber.StatusNotFound).JSON(web.WebResponse{
@@ -149,6 +188,14 @@ func (controller *CategoryControllerImpl) FindById(c *fiber.Ctx) error {
 		})
 	}
 
+	// Product counts change without the category's version changing.
+	if !withProductCounts {
+		setVersionETag(c, categoryResponse.Version)
+		if c.Fresh() {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+	}
+
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
 		Code:   fiber.StatusOK,
 		Status: "OK",
@@ -156,9 +203,69 @@ func (controller *CategoryControllerImpl) FindById(c *fiber.Ctx) error {
 	})
 }
 
+// Move Category under another parent
+func (controller *CategoryControllerImpl) Move(c *fiber.Ctx) error {
+	categoryMoveRequest := new(web.CategoryMoveRequest)
+	if err := c.BodyParser(categoryMoveRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("categoryId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Category ID",
+			Data:   err.Error(),
+		})
+	}
+	categoryMoveRequest.Id = id
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		categoryMoveRequest.Version = version
+	}
+
+	categoryResponse, err := controller.CategoryService.Move(c.Context(), *categoryMoveRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, categoryResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   categoryResponse,
+	})
+}
+
+// Find Category Tree
+func (controller *CategoryControllerImpl) FindTree(c *fiber.Ctx) error {
+	categoryTree, err := controller.CategoryService.FindTree(c.Context(), c.QueryBool("product_counts"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   categoryTree,
+	})
+}
+
 // Find All Categories
 func (controller *CategoryControllerImpl) FindAll(c *fiber.Ctx) error {
-	categoryResponses, err := controller.CategoryService.FindAll(c.Context())
+	categoryResponses, err := controller.CategoryService.FindAll(c.Context(), c.QueryBool("product_counts"))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   fiber.StatusInternalServerError,
@@ -173,3 +280,46 @@ func (controller *CategoryControllerImpl) FindAll(c *fiber.Ctx) error {
 		Data:   categoryResponses,
 	})
 }
+
+// Bulk Create, Update and Delete Categories
+func (controller *CategoryControllerImpl) Bulk(c *fiber.Ctx) error {
+	categoryBulkRequest := new(web.CategoryBulkRequest)
+	if err := c.BodyParser(categoryBulkRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	bulkResponse, err := controller.CategoryService.Bulk(c.Context(), *categoryBulkRequest)
+	if err != nil {
+		if _, ok := err.(exception.BadRequestError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Bad Request",
+				Data:   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   fiber.StatusInternalServerError,
+			Status: "Internal Server Error",
+			Data:   err.Error(),
+		})
+	}
+
+	return c.Status(bulkStatusCode(bulkResponse)).JSON(web.WebResponse{
+		Code:   bulkStatusCode(bulkResponse),
+		Status: "OK",
+		Data:   bulkResponse,
+	})
+}
+
+// Export Categories as CSV, JSONL or XLSX
+func (controller *CategoryControllerImpl) Export(c *fiber.Ctx) error {
+	return streamExport(c, "categories", web.CategoryResponse{}, func(ctx context.Context, write func(record interface{}) error) error {
+		return controller.CategoryService.Export(ctx, func(response web.CategoryResponse) error {
+			return write(response)
+		})
+	})
+}
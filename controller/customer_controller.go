@@ -8,4 +8,12 @@ type CustomerController interface {
 	Delete(c *fiber.Ctx) error
 	FindById(c *fiber.Ctx) error
 	FindAll(c *fiber.Ctx) error
+	FindOrders(c *fiber.Ctx) error
+	Profile(c *fiber.Ctx) error
+	DataExport(c *fiber.Ctx) error
+	Anonymise(c *fiber.Ctx) error
+	Export(c *fiber.Ctx) error
+	FindDuplicates(c *fiber.Ctx) error
+	Merge(c *fiber.Ctx) error
+	Bulk(c *fiber.Ctx) error
 }
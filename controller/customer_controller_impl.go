@@ -1,10 +1,15 @@
 package controller
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
 	"github.com/aronipurwanto/go-restful-api/model/web"
 	"github.com/aronipurwanto/go-restful-api/service"
 	"github.com/gofiber/fiber/v2"
+	"strconv"
 )
 
 type CustomerControllerImpl struct {
@@ -30,13 +35,10 @@ func (controller *CustomerControllerImpl) Create(c *fiber.Ctx) error {
 
 	customerResponse, err := controller.CustomerService.Create(c.Context(), *customerCreateRequest)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
-			Code:   fiber.StatusInternalServerError,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		})
+		return errorResponse(c, err)
 	}
 
+	setVersionETag(c, customerResponse.Version)
 	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
 		Code:   fiber.StatusCreated,
 		Status: "Created",
@@ -55,22 +57,24 @@ func (controller *CustomerControllerImpl) Update(c *fiber.Ctx) error {
 		})
 	}
 
-	customerResponse, err := controller.CustomerService.Update(c.Context(), *customerUpdateRequest)
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		if _, ok := err.(exception.NotFoundError); ok {
-			return c.Status(fiber.StatusNotFound).JSON(web.WebResponse{
-				Code:   fiber.StatusNotFound,
-				Status: "Not Found",
-				Data:   err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
-			Code:   fiber.StatusInternalServerError,
-			Status: "Internal Server Error",
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
 			Data:   err.Error(),
 		})
 	}
+	if version != 0 {
+		customerUpdateRequest.Version = version
+	}
+
+	customerResponse, err := controller.CustomerService.Update(c.Context(), *customerUpdateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
 
+	setVersionETag(c, customerResponse.Version)
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
 		Code:   fiber.StatusOK,
 		Status: "OK",
@@ -82,7 +86,16 @@ func (controller *CustomerControllerImpl) Update(c *fiber.Ctx) error {
 func (controller *CustomerControllerImpl) Delete(c *fiber.Ctx) error {
 	customerID := c.Params("customerId")
 
-	err := controller.CustomerService.Delete(c.Context(), customerID)
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	err = controller.CustomerService.Delete(c.Context(), customerID, version)
 	if err != nil {
 		if _, ok := err.(exception.NotFoundError); ok {
 			return c.Status(fiber.StatusNotFound).JSON(web.WebResponse{
@@ -91,6 +104,13 @@ func (controller *CustomerControllerImpl) Delete(c *fiber.Ctx) error {
 				Data:   err.Error(),
 			})
 		}
+		if _, ok := err.(exception.ConflictError); ok {
+			return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+				Code:   fiber.StatusConflict,
+				Status: "Conflict",
+				Data:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   fiber.StatusInternalServerError,
 			Status: "Internal Server Error",
@@ -124,6 +144,11 @@ func (controller *CustomerControllerImpl) FindById(c *fiber.Ctx) error {
 		})
 	}
 
+	setVersionETag(c, customerResponse.Version)
+	if c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
 		Code:   fiber.StatusOK,
 		Status: "OK",
@@ -148,3 +173,177 @@ func (controller *CustomerControllerImpl) FindAll(c *fiber.Ctx) error {
 		Data:   customerResponses,
 	})
 }
+
+// Find a page of the Orders of a Customer
+func (controller *CustomerControllerImpl) FindOrders(c *fiber.Ctx) error {
+	customerID, err := strconv.ParseUint(c.Params("customerId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Customer ID",
+			Data:   err.Error(),
+		})
+	}
+
+	ordersResponse, err := controller.CustomerService.FindOrders(c.Context(), web.CustomerOrdersRequest{
+		CustomerID: customerID,
+		Page:       c.QueryInt("page"),
+		Size:       c.QueryInt("size"),
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   ordersResponse,
+	})
+}
+
+// Find the Profile of a Customer: lifetime value, favourite Products and loyalty points
+func (controller *CustomerControllerImpl) Profile(c *fiber.Ctx) error {
+	profileResponse, err := controller.CustomerService.Profile(c.Context(), c.Params("customerId"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   profileResponse,
+	})
+}
+
+// Export everything held on a Customer as a JSON file, or as a ZIP of JSON files with format=zip
+func (controller *CustomerControllerImpl) DataExport(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "zip" {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   fmt.Sprintf("unsupported data export format %q, expected json or zip", format),
+		})
+	}
+
+	exportResponse, err := controller.CustomerService.DataExport(c.Context(), c.Params("customerId"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	filename := fmt.Sprintf("customer-%d-%s.%s", exportResponse.Customer.CustomerID, exportResponse.ExportedAt.UTC().Format("20060102T150405Z"), format)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	if format == "json" {
+		return c.Status(fiber.StatusOK).JSON(exportResponse)
+	}
+
+	var archive bytes.Buffer
+	err = helper.WriteJSONZip(&archive, map[string]interface{}{
+		"customer.json":        exportResponse.Customer,
+		"orders.json":          exportResponse.Orders,
+		"loyalty_history.json": exportResponse.LoyaltyHistory,
+		"audit_log.json":       exportResponse.AuditLog,
+		"events.json":          exportResponse.Events,
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	c.Set(fiber.HeaderContentType, "application/zip")
+	return c.Status(fiber.StatusOK).Send(archive.Bytes())
+}
+
+// Anonymise a Customer, scrubbing their personal data but keeping their Orders
+func (controller *CustomerControllerImpl) Anonymise(c *fiber.Ctx) error {
+	anonymiseResponse, err := controller.CustomerService.Anonymise(c.Context(), c.Params("customerId"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, anonymiseResponse.Customer.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   anonymiseResponse,
+	})
+}
+
+// Find the pairs of Customers that are likely duplicates
+func (controller *CustomerControllerImpl) FindDuplicates(c *fiber.Ctx) error {
+	duplicateResponses, err := controller.CustomerService.FindDuplicates(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   duplicateResponses,
+	})
+}
+
+// Merge duplicate Customers into a surviving one
+func (controller *CustomerControllerImpl) Merge(c *fiber.Ctx) error {
+	customerMergeRequest := new(web.CustomerMergeRequest)
+	if err := c.BodyParser(customerMergeRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	mergeResponse, err := controller.CustomerService.Merge(c.Context(), *customerMergeRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, mergeResponse.Customer.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   mergeResponse,
+	})
+}
+
+// Bulk Create, Update and Delete Customers
+func (controller *CustomerControllerImpl) Bulk(c *fiber.Ctx) error {
+	customerBulkRequest := new(web.CustomerBulkRequest)
+	if err := c.BodyParser(customerBulkRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	bulkResponse, err := controller.CustomerService.Bulk(c.Context(), *customerBulkRequest)
+	if err != nil {
+		if _, ok := err.(exception.BadRequestError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Bad Request",
+				Data:   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   fiber.StatusInternalServerError,
+			Status: "Internal Server Error",
+			Data:   err.Error(),
+		})
+	}
+
+	return c.Status(bulkStatusCode(bulkResponse)).JSON(web.WebResponse{
+		Code:   bulkStatusCode(bulkResponse),
+		Status: "OK",
+		Data:   bulkResponse,
+	})
+}
+
+// Export Customers as CSV, JSONL or XLSX
+func (controller *CustomerControllerImpl) Export(c *fiber.Ctx) error {
+	return streamExport(c, "customers", web.CustomerResponse{}, func(ctx context.Context, write func(record interface{}) error) error {
+		return controller.CustomerService.Export(ctx, func(response web.CustomerResponse) error {
+			return write(response)
+		})
+	})
+}
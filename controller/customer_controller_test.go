@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/json"
 	"github.com/aronipurwanto/go-restful-api/model/web"
@@ -8,9 +9,11 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func setupCustomerTestApp(mockService *mocks.MockCustomerService) *fiber.App {
@@ -94,3 +97,52 @@ func TestCustomerController(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomerDataExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCustomerService(ctrl)
+	app := fiber.New()
+	app.Get("/api/customers/:customerId/data-export", NewCustomerController(mockService).DataExport)
+
+	exportResponse := web.CustomerDataExportResponse{
+		ExportedAt: time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC),
+		Customer:   web.CustomerResponse{CustomerID: 1, Name: "Budi"},
+		Orders:     []web.OrderResponse{{OrderID: "o-1"}},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		mockService.EXPECT().DataExport(gomock.Any(), "1").Return(exportResponse, nil)
+
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/customers/1/data-export", nil))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `attachment; filename="customer-1-20250501T080000Z.json"`, resp.Header.Get(fiber.HeaderContentDisposition))
+
+		var body web.CustomerDataExportResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "Budi", body.Customer.Name)
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		mockService.EXPECT().DataExport(gomock.Any(), "1").Return(exportResponse, nil)
+
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/customers/1/data-export?format=zip", nil))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/zip", resp.Header.Get(fiber.HeaderContentType))
+
+		body, _ := io.ReadAll(resp.Body)
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		assert.NoError(t, err)
+		var names []string
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+		}
+		assert.Equal(t, []string{"audit_log.json", "customer.json", "events.json", "loyalty_history.json", "orders.json"}, names)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/customers/1/data-export?format=csv", nil))
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
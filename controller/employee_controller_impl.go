@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/model/web"
 	"github.com/aronipurwanto/go-restful-api/service"
 	"github.com/gofiber/fiber/v2"
@@ -28,13 +30,10 @@ func (controller *EmployeeControllerImpl) Create(c *fiber.Ctx) error {
 
 	employeeResponse, err := controller.EmployeeService.Create(c.Context(), *employeeCreateRequest)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
-			Code:   fiber.StatusInternalServerError,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		})
+		return errorResponse(c, err)
 	}
 
+	setVersionETag(c, employeeResponse.Version)
 	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
 		Code:   fiber.StatusCreated,
 		Status: "Created",
@@ -52,15 +51,24 @@ func (controller *EmployeeControllerImpl) Update(c *fiber.Ctx) error {
 		})
 	}
 
-	employeeResponse, err := controller.EmployeeService.Update(c.Context(), *employeeUpdateRequest)
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
-			Code:   fiber.StatusInternalServerError,
-			Status: "Internal Server Error",
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
 			Data:   err.Error(),
 		})
 	}
+	if version != 0 {
+		employeeUpdateRequest.Version = version
+	}
+
+	employeeResponse, err := controller.EmployeeService.Update(c.Context(), *employeeUpdateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
 
+	setVersionETag(c, employeeResponse.Version)
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
 		Code:   fiber.StatusOK,
 		Status: "OK",
@@ -71,15 +79,27 @@ func (controller *EmployeeControllerImpl) Update(c *fiber.Ctx) error {
 func (controller *EmployeeControllerImpl) Delete(c *fiber.Ctx) error {
 	employeeId := c.Params("employeeId")
 
-	err := controller.EmployeeService.Delete(c.Context(), employeeId)
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
-			Code:   fiber.StatusInternalServerError,
-			Status: "Internal Server Error",
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
 			Data:   err.Error(),
 		})
 	}
 
+	err = controller.EmployeeService.Delete(c.Context(), employeeId, version)
+	if err != nil {
+		if _, ok := err.(exception.ConflictError); ok {
+			return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+				Code:   fiber.StatusConflict,
+				Status: "Conflict",
+				Data:   err.Error(),
+			})
+		}
+		return errorResponse(c, err)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
 		Code:   fiber.StatusOK,
 		Status: "Deleted Successfully",
@@ -98,6 +118,11 @@ func (controller *EmployeeControllerImpl) FindById(c *fiber.Ctx) error {
 		})
 	}
 
+	setVersionETag(c, employeeResponse.Version)
+	if c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
 		Code:   fiber.StatusOK,
 		Status: "OK",
@@ -108,11 +133,7 @@ func (controller *EmployeeControllerImpl) FindById(c *fiber.Ctx) error {
 func (controller *EmployeeControllerImpl) FindAll(c *fiber.Ctx) error {
 	employeeResponses, err := controller.EmployeeService.FindAll(c.Context())
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
-			Code:   fiber.StatusInternalServerError,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		})
+		return errorResponse(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
@@ -121,3 +142,26 @@ func (controller *EmployeeControllerImpl) FindAll(c *fiber.Ctx) error {
 		Data:   employeeResponses,
 	})
 }
+
+// Export Employees as CSV, JSONL or XLSX
+func (controller *EmployeeControllerImpl) Export(c *fiber.Ctx) error {
+	return streamExport(c, "employees", web.EmployeeResponse{}, func(ctx context.Context, write func(record interface{}) error) error {
+		return controller.EmployeeService.Export(ctx, func(response web.EmployeeResponse) error {
+			return write(response)
+		})
+	})
+}
+
+// IssueApiKey gives an Employee a new API key
+func (controller *EmployeeControllerImpl) IssueApiKey(c *fiber.Ctx) error {
+	apiKeyResponse, err := controller.EmployeeService.IssueApiKey(c.Context(), c.Params("employeeId"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   apiKeyResponse,
+	})
+}
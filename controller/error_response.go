@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// errorResponse writes the WebResponse matching the type of a service error. A conflict
+// over a unique value names the field, so that a form can point at it.
+func errorResponse(c *fiber.Ctx, err error) error {
+	if conflictError, ok := err.(exception.ConflictError); ok && conflictError.Field != "" {
+		return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+			Code:   fiber.StatusConflict,
+			Status: "Conflict",
+			Data:   web.FieldErrorResponse{Field: conflictError.Field, Message: conflictError.Message},
+		})
+	}
+
+	code, status := fiber.StatusInternalServerError, "Internal Server Error"
+	switch err.(type) {
+	case exception.NotFoundError:
+		code, status = fiber.StatusNotFound, "Not Found"
+	case exception.ConflictError:
+		code, status = fiber.StatusConflict, "Conflict"
+	case exception.ForbiddenError:
+		code, status = fiber.StatusForbidden, "Forbidden"
+	case exception.BadRequestError, validator.ValidationErrors:
+		code, status = fiber.StatusBadRequest, "Bad Request"
+	}
+
+	return c.Status(code).JSON(web.WebResponse{
+		Code:   code,
+		Status: status,
+		Data:   err.Error(),
+	})
+}
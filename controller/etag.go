@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+	"strings"
+)
+
+// setVersionETag exposes the entity version as a strong ETag.
+func setVersionETag(c *fiber.Ctx, version uint64) {
+	c.Set(fiber.HeaderETag, strconv.Quote(strconv.FormatUint(version, 10)))
+}
+
+// ifMatchVersion reads the version the client expects from If-Match. It returns 0 when
+// the header is absent or "*", meaning the write should not be conditional.
+func ifMatchVersion(c *fiber.Ctx) (uint64, error) {
+	value := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+	value = strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+	return strconv.ParseUint(value, 10, 64)
+}
@@ -0,0 +1,9 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type EventController interface {
+	Stream(c *fiber.Ctx) error
+}
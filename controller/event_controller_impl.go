@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+// eventStreamRetry is the reconnection delay in milliseconds that clients are told to use.
+const eventStreamRetry = 3000
+
+type EventControllerImpl struct {
+	EventStreamService service.EventStreamService
+}
+
+func NewEventController(eventStreamService service.EventStreamService) EventController {
+	return &EventControllerImpl{
+		EventStreamService: eventStreamService,
+	}
+}
+
+// Stream sends the live events as server-sent events, filtered by the comma separated
+// types and aggregate_types query parameters and by the store of the request. A client
+// that reconnects with the Last-Event-ID header (or last_event_id for the first
+// connection) gets the events it has missed first.
+func (controller *EventControllerImpl) Stream(c *fiber.Ctx) error {
+	request := web.EventStreamRequest{
+		Types:          splitQueryList(c.Query("types")),
+		AggregateTypes: splitQueryList(c.Query("aggregate_types")),
+	}
+	if lastEventId := c.Get("Last-Event-ID", c.Query("last_event_id")); lastEventId != "" {
+		var err error
+		if request.LastEventId, err = strconv.ParseUint(lastEventId, 10, 64); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Invalid Last-Event-ID",
+				Data:   err.Error(),
+			})
+		}
+	}
+
+	subscription, err := controller.EventStreamService.Subscribe(c.Context(), request)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	ctx := helper.DetachContext(c.Context())
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer controller.EventStreamService.Unsubscribe(subscription)
+		stream := &serverSentEventStream{writer: w}
+		if _, err := fmt.Fprintf(w, "retry: %d\n\n", eventStreamRetry); err != nil || w.Flush() != nil {
+			return
+		}
+		_ = controller.EventStreamService.Stream(ctx, subscription, stream)
+	})
+	return nil
+}
+
+// serverSentEventStream writes events in the text/event-stream format, flushing each one
+// so that it reaches the client right away.
+type serverSentEventStream struct {
+	writer *bufio.Writer
+}
+
+func (stream *serverSentEventStream) Send(event web.EventEnvelope) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(stream.writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data); err != nil {
+		return err
+	}
+	return stream.writer.Flush()
+}
+
+func (stream *serverSentEventStream) KeepAlive() error {
+	if _, err := stream.writer.WriteString(": keep-alive\n\n"); err != nil {
+		return err
+	}
+	return stream.writer.Flush()
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/gofiber/fiber/v2"
+	"log"
+	"time"
+)
+
+// exportFunc feeds every record of an export to write.
+type exportFunc func(ctx context.Context, write func(record interface{}) error) error
+
+// streamExport sends the records produced by export as a file download in the format
+// given by the format query parameter (csv by default). record is a zero value of the
+// records, which gives tabular formats their header even when there are none. The export
+// runs until its first record before the status is sent, so a failing query still gets an
+// error response. Later failures are logged and end the download with the writer's error
+// trailer.
+func streamExport(c *fiber.Ctx, name string, record interface{}, export exportFunc) error {
+	format := c.Query("format", helper.ExportFormatCSV)
+	if !helper.IsExportFormat(format) {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   fmt.Sprintf("unsupported export format %q, expected csv, jsonl or xlsx", format),
+		})
+	}
+
+	ctx, cancel := context.WithCancel(helper.DetachContext(c.Context()))
+	records := make(chan interface{})
+	result := make(chan error, 1)
+	go func() {
+		defer close(records)
+		result <- export(ctx, func(record interface{}) error {
+			select {
+			case records <- record:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	// ok is false when the export is already over, and result then holds its outcome.
+	first, ok := <-records
+	if !ok {
+		if err := <-result; err != nil {
+			cancel()
+			log.Printf("export %s: %v", name, err)
+			return errorResponse(c, err)
+		}
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102T150405Z"), format)
+	c.Set(fiber.HeaderContentType, helper.ExportContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// Stop the export and wait for it on every way out, so its goroutine never leaks.
+		defer func() {
+			cancel()
+			for range records {
+			}
+		}()
+
+		exportWriter, err := helper.NewExportWriter(format, w, record)
+		if err != nil {
+			log.Printf("export %s: %v", name, err)
+			return
+		}
+		if ok {
+			err = exportWriter.Write(first)
+			for record := range records {
+				if err != nil {
+					break
+				}
+				err = exportWriter.Write(record)
+			}
+		}
+		if err == nil && ok {
+			err = <-result
+		}
+		if err != nil {
+			log.Printf("export %s: %v", name, err)
+			_ = exportWriter.Fail(err)
+			return
+		}
+		if err := exportWriter.Close(); err != nil {
+			log.Printf("export %s: %v", name, err)
+		}
+	})
+	return nil
+}
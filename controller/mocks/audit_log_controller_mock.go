@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/audit_log_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/audit_log_controller.go -destination=controller/mocks/audit_log_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockAuditLogController is a mock of AuditLogController interface.
+type MockAuditLogController struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditLogControllerMockRecorder is the mock recorder for MockAuditLogController.
+type MockAuditLogControllerMockRecorder struct {
+	mock *MockAuditLogController
+}
+
+// NewMockAuditLogController creates a new mock instance.
+func NewMockAuditLogController(ctrl *gomock.Controller) *MockAuditLogController {
+	mock := &MockAuditLogController{ctrl: ctrl}
+	mock.recorder = &MockAuditLogControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogController) EXPECT() *MockAuditLogControllerMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockAuditLogController) FindAll(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAuditLogControllerMockRecorder) FindAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAuditLogController)(nil).FindAll), c)
+}
@@ -40,6 +40,20 @@ func (m *MockCategoryController) EXPECT() *MockCategoryControllerMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockCategoryController) Bulk(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockCategoryControllerMockRecorder) Bulk(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockCategoryController)(nil).Bulk), c)
+}
+
 // Create mocks base method.
 func (m *MockCategoryController) Create(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
@@ -68,6 +82,20 @@ func (mr *MockCategoryControllerMockRecorder) Delete(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryController)(nil).Delete), c)
 }
 
+// Export mocks base method.
+func (m *MockCategoryController) Export(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockCategoryControllerMockRecorder) Export(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCategoryController)(nil).Export), c)
+}
+
 // FindAll mocks base method.
 func (m *MockCategoryController) FindAll(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
@@ -96,6 +124,34 @@ func (mr *MockCategoryControllerMockRecorder) FindById(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCategoryController)(nil).FindById), c)
 }
 
+// FindTree mocks base method.
+func (m *MockCategoryController) FindTree(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTree", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindTree indicates an expected call of FindTree.
+func (mr *MockCategoryControllerMockRecorder) FindTree(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTree", reflect.TypeOf((*MockCategoryController)(nil).FindTree), c)
+}
+
+// Move mocks base method.
+func (m *MockCategoryController) Move(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockCategoryControllerMockRecorder) Move(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockCategoryController)(nil).Move), c)
+}
+
 // Update mocks base method.
 func (m *MockCategoryController) Update(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
@@ -40,6 +40,34 @@ func (m *MockCustomerController) EXPECT() *MockCustomerControllerMockRecorder {
 	return m.recorder
 }
 
+// Anonymise mocks base method.
+func (m *MockCustomerController) Anonymise(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymise", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymise indicates an expected call of Anonymise.
+func (mr *MockCustomerControllerMockRecorder) Anonymise(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymise", reflect.TypeOf((*MockCustomerController)(nil).Anonymise), c)
+}
+
+// Bulk mocks base method.
+func (m *MockCustomerController) Bulk(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockCustomerControllerMockRecorder) Bulk(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockCustomerController)(nil).Bulk), c)
+}
+
 // Create mocks base method.
 func (m *MockCustomerController) Create(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
@@ -54,6 +82,20 @@ func (mr *MockCustomerControllerMockRecorder) Create(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerController)(nil).Create), c)
 }
 
+// DataExport mocks base method.
+func (m *MockCustomerController) DataExport(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataExport", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DataExport indicates an expected call of DataExport.
+func (mr *MockCustomerControllerMockRecorder) DataExport(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataExport", reflect.TypeOf((*MockCustomerController)(nil).DataExport), c)
+}
+
 // Delete mocks base method.
 func (m *MockCustomerController) Delete(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
@@ -68,6 +110,20 @@ func (mr *MockCustomerControllerMockRecorder) Delete(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerController)(nil).Delete), c)
 }
 
+// Export mocks base method.
+func (m *MockCustomerController) Export(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockCustomerControllerMockRecorder) Export(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCustomerController)(nil).Export), c)
+}
+
 // FindAll mocks base method.
 func (m *MockCustomerController) FindAll(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
@@ -96,6 +152,62 @@ func (mr *MockCustomerControllerMockRecorder) FindById(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCustomerController)(nil).FindById), c)
 }
 
+// FindDuplicates mocks base method.
+func (m *MockCustomerController) FindDuplicates(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicates", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindDuplicates indicates an expected call of FindDuplicates.
+func (mr *MockCustomerControllerMockRecorder) FindDuplicates(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicates", reflect.TypeOf((*MockCustomerController)(nil).FindDuplicates), c)
+}
+
+// FindOrders mocks base method.
+func (m *MockCustomerController) FindOrders(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrders", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindOrders indicates an expected call of FindOrders.
+func (mr *MockCustomerControllerMockRecorder) FindOrders(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrders", reflect.TypeOf((*MockCustomerController)(nil).FindOrders), c)
+}
+
+// Merge mocks base method.
+func (m *MockCustomerController) Merge(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockCustomerControllerMockRecorder) Merge(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockCustomerController)(nil).Merge), c)
+}
+
+// Profile mocks base method.
+func (m *MockCustomerController) Profile(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *MockCustomerControllerMockRecorder) Profile(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockCustomerController)(nil).Profile), c)
+}
+
 // Update mocks base method.
 func (m *MockCustomerController) Update(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
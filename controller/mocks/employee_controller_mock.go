@@ -68,6 +68,20 @@ func (mr *MockEmployeeControllerMockRecorder) Delete(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEmployeeController)(nil).Delete), c)
 }
 
+// Export mocks base method.
+func (m *MockEmployeeController) Export(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockEmployeeControllerMockRecorder) Export(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockEmployeeController)(nil).Export), c)
+}
+
 // FindAll mocks base method.
 func (m *MockEmployeeController) FindAll(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
@@ -96,6 +110,20 @@ func (mr *MockEmployeeControllerMockRecorder) FindById(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockEmployeeController)(nil).FindById), c)
 }
 
+// IssueApiKey mocks base method.
+func (m *MockEmployeeController) IssueApiKey(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueApiKey", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IssueApiKey indicates an expected call of IssueApiKey.
+func (mr *MockEmployeeControllerMockRecorder) IssueApiKey(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueApiKey", reflect.TypeOf((*MockEmployeeController)(nil).IssueApiKey), c)
+}
+
 // Update mocks base method.
 func (m *MockEmployeeController) Update(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
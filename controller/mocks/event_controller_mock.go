@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/event_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/event_controller.go -destination=controller/mocks/event_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockEventController is a mock of EventController interface.
+type MockEventController struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockEventControllerMockRecorder is the mock recorder for MockEventController.
+type MockEventControllerMockRecorder struct {
+	mock *MockEventController
+}
+
+// NewMockEventController creates a new mock instance.
+func NewMockEventController(ctrl *gomock.Controller) *MockEventController {
+	mock := &MockEventController{ctrl: ctrl}
+	mock.recorder = &MockEventControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventController) EXPECT() *MockEventControllerMockRecorder {
+	return m.recorder
+}
+
+// Stream mocks base method.
+func (m *MockEventController) Stream(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockEventControllerMockRecorder) Stream(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockEventController)(nil).Stream), c)
+}
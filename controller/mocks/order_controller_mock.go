@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/order_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/order_controller.go -destination=controller/mocks/order_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockOrderController is a mock of OrderController interface.
+type MockOrderController struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderControllerMockRecorder is the mock recorder for MockOrderController.
+type MockOrderControllerMockRecorder struct {
+	mock *MockOrderController
+}
+
+// NewMockOrderController creates a new mock instance.
+func NewMockOrderController(ctrl *gomock.Controller) *MockOrderController {
+	mock := &MockOrderController{ctrl: ctrl}
+	mock.recorder = &MockOrderControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderController) EXPECT() *MockOrderControllerMockRecorder {
+	return m.recorder
+}
+
+// AddPayment mocks base method.
+func (m *MockOrderController) AddPayment(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPayment", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPayment indicates an expected call of AddPayment.
+func (mr *MockOrderControllerMockRecorder) AddPayment(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPayment", reflect.TypeOf((*MockOrderController)(nil).AddPayment), c)
+}
+
+// Create mocks base method.
+func (m *MockOrderController) Create(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderControllerMockRecorder) Create(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderController)(nil).Create), c)
+}
+
+// Export mocks base method.
+func (m *MockOrderController) Export(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockOrderControllerMockRecorder) Export(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockOrderController)(nil).Export), c)
+}
+
+// FindAll mocks base method.
+func (m *MockOrderController) FindAll(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockOrderControllerMockRecorder) FindAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderController)(nil).FindAll), c)
+}
+
+// FindById mocks base method.
+func (m *MockOrderController) FindById(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockOrderControllerMockRecorder) FindById(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderController)(nil).FindById), c)
+}
+
+// FindOffline mocks base method.
+func (m *MockOrderController) FindOffline(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOffline", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindOffline indicates an expected call of FindOffline.
+func (mr *MockOrderControllerMockRecorder) FindOffline(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOffline", reflect.TypeOf((*MockOrderController)(nil).FindOffline), c)
+}
+
+// UploadOffline mocks base method.
+func (m *MockOrderController) UploadOffline(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadOffline", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadOffline indicates an expected call of UploadOffline.
+func (mr *MockOrderControllerMockRecorder) UploadOffline(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadOffline", reflect.TypeOf((*MockOrderController)(nil).UploadOffline), c)
+}
@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/price_list_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/price_list_controller.go -destination=controller/mocks/price_list_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockPriceListController is a mock of PriceListController interface.
+type MockPriceListController struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceListControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceListControllerMockRecorder is the mock recorder for MockPriceListController.
+type MockPriceListControllerMockRecorder struct {
+	mock *MockPriceListController
+}
+
+// NewMockPriceListController creates a new mock instance.
+func NewMockPriceListController(ctrl *gomock.Controller) *MockPriceListController {
+	mock := &MockPriceListController{ctrl: ctrl}
+	mock.recorder = &MockPriceListControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceListController) EXPECT() *MockPriceListControllerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPriceListController) Create(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPriceListControllerMockRecorder) Create(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPriceListController)(nil).Create), c)
+}
+
+// Delete mocks base method.
+func (m *MockPriceListController) Delete(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPriceListControllerMockRecorder) Delete(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPriceListController)(nil).Delete), c)
+}
+
+// DeleteItem mocks base method.
+func (m *MockPriceListController) DeleteItem(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockPriceListControllerMockRecorder) DeleteItem(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockPriceListController)(nil).DeleteItem), c)
+}
+
+// FindAll mocks base method.
+func (m *MockPriceListController) FindAll(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPriceListControllerMockRecorder) FindAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPriceListController)(nil).FindAll), c)
+}
+
+// FindById mocks base method.
+func (m *MockPriceListController) FindById(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPriceListControllerMockRecorder) FindById(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPriceListController)(nil).FindById), c)
+}
+
+// FindItems mocks base method.
+func (m *MockPriceListController) FindItems(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindItems", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindItems indicates an expected call of FindItems.
+func (mr *MockPriceListControllerMockRecorder) FindItems(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindItems", reflect.TypeOf((*MockPriceListController)(nil).FindItems), c)
+}
+
+// FindPriceHistory mocks base method.
+func (m *MockPriceListController) FindPriceHistory(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPriceHistory", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindPriceHistory indicates an expected call of FindPriceHistory.
+func (mr *MockPriceListControllerMockRecorder) FindPriceHistory(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPriceHistory", reflect.TypeOf((*MockPriceListController)(nil).FindPriceHistory), c)
+}
+
+// ResolvePrice mocks base method.
+func (m *MockPriceListController) ResolvePrice(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvePrice", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolvePrice indicates an expected call of ResolvePrice.
+func (mr *MockPriceListControllerMockRecorder) ResolvePrice(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePrice", reflect.TypeOf((*MockPriceListController)(nil).ResolvePrice), c)
+}
+
+// SetItem mocks base method.
+func (m *MockPriceListController) SetItem(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItem", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItem indicates an expected call of SetItem.
+func (mr *MockPriceListControllerMockRecorder) SetItem(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItem", reflect.TypeOf((*MockPriceListController)(nil).SetItem), c)
+}
+
+// Update mocks base method.
+func (m *MockPriceListController) Update(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPriceListControllerMockRecorder) Update(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPriceListController)(nil).Update), c)
+}
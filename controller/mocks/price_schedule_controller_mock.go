@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/price_schedule_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/price_schedule_controller.go -destination=controller/mocks/price_schedule_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockPriceScheduleController is a mock of PriceScheduleController interface.
+type MockPriceScheduleController struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceScheduleControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceScheduleControllerMockRecorder is the mock recorder for MockPriceScheduleController.
+type MockPriceScheduleControllerMockRecorder struct {
+	mock *MockPriceScheduleController
+}
+
+// NewMockPriceScheduleController creates a new mock instance.
+func NewMockPriceScheduleController(ctrl *gomock.Controller) *MockPriceScheduleController {
+	mock := &MockPriceScheduleController{ctrl: ctrl}
+	mock.recorder = &MockPriceScheduleControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceScheduleController) EXPECT() *MockPriceScheduleControllerMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockPriceScheduleController) Cancel(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockPriceScheduleControllerMockRecorder) Cancel(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockPriceScheduleController)(nil).Cancel), c)
+}
+
+// Create mocks base method.
+func (m *MockPriceScheduleController) Create(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPriceScheduleControllerMockRecorder) Create(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPriceScheduleController)(nil).Create), c)
+}
+
+// FindAll mocks base method.
+func (m *MockPriceScheduleController) FindAll(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPriceScheduleControllerMockRecorder) FindAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPriceScheduleController)(nil).FindAll), c)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/product_barcode_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/product_barcode_controller.go -destination=controller/mocks/product_barcode_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockProductBarcodeController is a mock of ProductBarcodeController interface.
+type MockProductBarcodeController struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductBarcodeControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockProductBarcodeControllerMockRecorder is the mock recorder for MockProductBarcodeController.
+type MockProductBarcodeControllerMockRecorder struct {
+	mock *MockProductBarcodeController
+}
+
+// NewMockProductBarcodeController creates a new mock instance.
+func NewMockProductBarcodeController(ctrl *gomock.Controller) *MockProductBarcodeController {
+	mock := &MockProductBarcodeController{ctrl: ctrl}
+	mock.recorder = &MockProductBarcodeControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductBarcodeController) EXPECT() *MockProductBarcodeControllerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProductBarcodeController) Create(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProductBarcodeControllerMockRecorder) Create(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductBarcodeController)(nil).Create), c)
+}
+
+// Delete mocks base method.
+func (m *MockProductBarcodeController) Delete(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductBarcodeControllerMockRecorder) Delete(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductBarcodeController)(nil).Delete), c)
+}
+
+// FindAll mocks base method.
+func (m *MockProductBarcodeController) FindAll(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockProductBarcodeControllerMockRecorder) FindAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductBarcodeController)(nil).FindAll), c)
+}
+
+// GenerateMissing mocks base method.
+func (m *MockProductBarcodeController) GenerateMissing(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateMissing", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateMissing indicates an expected call of GenerateMissing.
+func (mr *MockProductBarcodeControllerMockRecorder) GenerateMissing(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMissing", reflect.TypeOf((*MockProductBarcodeController)(nil).GenerateMissing), c)
+}
+
+// Labels mocks base method.
+func (m *MockProductBarcodeController) Labels(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Labels", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Labels indicates an expected call of Labels.
+func (mr *MockProductBarcodeControllerMockRecorder) Labels(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Labels", reflect.TypeOf((*MockProductBarcodeController)(nil).Labels), c)
+}
+
+// Lookup mocks base method.
+func (m *MockProductBarcodeController) Lookup(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockProductBarcodeControllerMockRecorder) Lookup(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockProductBarcodeController)(nil).Lookup), c)
+}
@@ -40,6 +40,20 @@ func (m *MockProductController) EXPECT() *MockProductControllerMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockProductController) Bulk(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockProductControllerMockRecorder) Bulk(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockProductController)(nil).Bulk), c)
+}
+
 // Create mocks base method.
 func (m *MockProductController) Create(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
@@ -68,6 +82,20 @@ func (mr *MockProductControllerMockRecorder) Delete(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductController)(nil).Delete), c)
 }
 
+// Export mocks base method.
+func (m *MockProductController) Export(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockProductControllerMockRecorder) Export(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockProductController)(nil).Export), c)
+}
+
 // FindAll mocks base method.
 func (m *MockProductController) FindAll(c *v2.Ctx) error {
 	m.ctrl.T.Helper()
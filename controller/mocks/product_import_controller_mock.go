@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/product_import_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/product_import_controller.go -destination=controller/mocks/product_import_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockProductImportController is a mock of ProductImportController interface.
+type MockProductImportController struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductImportControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockProductImportControllerMockRecorder is the mock recorder for MockProductImportController.
+type MockProductImportControllerMockRecorder struct {
+	mock *MockProductImportController
+}
+
+// NewMockProductImportController creates a new mock instance.
+func NewMockProductImportController(ctrl *gomock.Controller) *MockProductImportController {
+	mock := &MockProductImportController{ctrl: ctrl}
+	mock.recorder = &MockProductImportControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductImportController) EXPECT() *MockProductImportControllerMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockProductImportController) Import(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockProductImportControllerMockRecorder) Import(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockProductImportController)(nil).Import), c)
+}
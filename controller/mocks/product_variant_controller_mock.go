@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/product_variant_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/product_variant_controller.go -destination=controller/mocks/product_variant_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockProductVariantController is a mock of ProductVariantController interface.
+type MockProductVariantController struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductVariantControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockProductVariantControllerMockRecorder is the mock recorder for MockProductVariantController.
+type MockProductVariantControllerMockRecorder struct {
+	mock *MockProductVariantController
+}
+
+// NewMockProductVariantController creates a new mock instance.
+func NewMockProductVariantController(ctrl *gomock.Controller) *MockProductVariantController {
+	mock := &MockProductVariantController{ctrl: ctrl}
+	mock.recorder = &MockProductVariantControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductVariantController) EXPECT() *MockProductVariantControllerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProductVariantController) Delete(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductVariantControllerMockRecorder) Delete(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductVariantController)(nil).Delete), c)
+}
+
+// FindAll mocks base method.
+func (m *MockProductVariantController) FindAll(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockProductVariantControllerMockRecorder) FindAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductVariantController)(nil).FindAll), c)
+}
+
+// GenerateVariants mocks base method.
+func (m *MockProductVariantController) GenerateVariants(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateVariants", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateVariants indicates an expected call of GenerateVariants.
+func (mr *MockProductVariantControllerMockRecorder) GenerateVariants(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateVariants", reflect.TypeOf((*MockProductVariantController)(nil).GenerateVariants), c)
+}
+
+// SetOptions mocks base method.
+func (m *MockProductVariantController) SetOptions(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOptions", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOptions indicates an expected call of SetOptions.
+func (mr *MockProductVariantControllerMockRecorder) SetOptions(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOptions", reflect.TypeOf((*MockProductVariantController)(nil).SetOptions), c)
+}
+
+// Update mocks base method.
+func (m *MockProductVariantController) Update(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProductVariantControllerMockRecorder) Update(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductVariantController)(nil).Update), c)
+}
@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/purchase_order_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/purchase_order_controller.go -destination=controller/mocks/purchase_order_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockPurchaseOrderController is a mock of PurchaseOrderController interface.
+type MockPurchaseOrderController struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseOrderControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockPurchaseOrderControllerMockRecorder is the mock recorder for MockPurchaseOrderController.
+type MockPurchaseOrderControllerMockRecorder struct {
+	mock *MockPurchaseOrderController
+}
+
+// NewMockPurchaseOrderController creates a new mock instance.
+func NewMockPurchaseOrderController(ctrl *gomock.Controller) *MockPurchaseOrderController {
+	mock := &MockPurchaseOrderController{ctrl: ctrl}
+	mock.recorder = &MockPurchaseOrderControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseOrderController) EXPECT() *MockPurchaseOrderControllerMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPurchaseOrderController) Close(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPurchaseOrderControllerMockRecorder) Close(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPurchaseOrderController)(nil).Close), c)
+}
+
+// Create mocks base method.
+func (m *MockPurchaseOrderController) Create(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPurchaseOrderControllerMockRecorder) Create(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPurchaseOrderController)(nil).Create), c)
+}
+
+// Delete mocks base method.
+func (m *MockPurchaseOrderController) Delete(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPurchaseOrderControllerMockRecorder) Delete(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPurchaseOrderController)(nil).Delete), c)
+}
+
+// FindAll mocks base method.
+func (m *MockPurchaseOrderController) FindAll(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPurchaseOrderControllerMockRecorder) FindAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPurchaseOrderController)(nil).FindAll), c)
+}
+
+// FindById mocks base method.
+func (m *MockPurchaseOrderController) FindById(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPurchaseOrderControllerMockRecorder) FindById(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPurchaseOrderController)(nil).FindById), c)
+}
+
+// Generate mocks base method.
+func (m *MockPurchaseOrderController) Generate(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockPurchaseOrderControllerMockRecorder) Generate(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockPurchaseOrderController)(nil).Generate), c)
+}
+
+// Receive mocks base method.
+func (m *MockPurchaseOrderController) Receive(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockPurchaseOrderControllerMockRecorder) Receive(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockPurchaseOrderController)(nil).Receive), c)
+}
+
+// Send mocks base method.
+func (m *MockPurchaseOrderController) Send(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockPurchaseOrderControllerMockRecorder) Send(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockPurchaseOrderController)(nil).Send), c)
+}
+
+// Update mocks base method.
+func (m *MockPurchaseOrderController) Update(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPurchaseOrderControllerMockRecorder) Update(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPurchaseOrderController)(nil).Update), c)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/report_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/report_controller.go -destination=controller/mocks/report_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockReportController is a mock of ReportController interface.
+type MockReportController struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockReportControllerMockRecorder is the mock recorder for MockReportController.
+type MockReportControllerMockRecorder struct {
+	mock *MockReportController
+}
+
+// NewMockReportController creates a new mock instance.
+func NewMockReportController(ctrl *gomock.Controller) *MockReportController {
+	mock := &MockReportController{ctrl: ctrl}
+	mock.recorder = &MockReportControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportController) EXPECT() *MockReportControllerMockRecorder {
+	return m.recorder
+}
+
+// Categories mocks base method.
+func (m *MockReportController) Categories(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categories", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Categories indicates an expected call of Categories.
+func (mr *MockReportControllerMockRecorder) Categories(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categories", reflect.TypeOf((*MockReportController)(nil).Categories), c)
+}
+
+// Discounts mocks base method.
+func (m *MockReportController) Discounts(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discounts", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Discounts indicates an expected call of Discounts.
+func (mr *MockReportControllerMockRecorder) Discounts(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discounts", reflect.TypeOf((*MockReportController)(nil).Discounts), c)
+}
+
+// InventoryValuation mocks base method.
+func (m *MockReportController) InventoryValuation(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InventoryValuation", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InventoryValuation indicates an expected call of InventoryValuation.
+func (mr *MockReportControllerMockRecorder) InventoryValuation(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InventoryValuation", reflect.TypeOf((*MockReportController)(nil).InventoryValuation), c)
+}
+
+// Margins mocks base method.
+func (m *MockReportController) Margins(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Margins", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Margins indicates an expected call of Margins.
+func (mr *MockReportControllerMockRecorder) Margins(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Margins", reflect.TypeOf((*MockReportController)(nil).Margins), c)
+}
+
+// PaymentTypes mocks base method.
+func (m *MockReportController) PaymentTypes(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaymentTypes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PaymentTypes indicates an expected call of PaymentTypes.
+func (mr *MockReportControllerMockRecorder) PaymentTypes(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentTypes", reflect.TypeOf((*MockReportController)(nil).PaymentTypes), c)
+}
+
+// Sales mocks base method.
+func (m *MockReportController) Sales(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sales", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Sales indicates an expected call of Sales.
+func (mr *MockReportControllerMockRecorder) Sales(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sales", reflect.TypeOf((*MockReportController)(nil).Sales), c)
+}
+
+// Taxes mocks base method.
+func (m *MockReportController) Taxes(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Taxes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Taxes indicates an expected call of Taxes.
+func (mr *MockReportControllerMockRecorder) Taxes(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Taxes", reflect.TypeOf((*MockReportController)(nil).Taxes), c)
+}
+
+// TopProducts mocks base method.
+func (m *MockReportController) TopProducts(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopProducts", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TopProducts indicates an expected call of TopProducts.
+func (mr *MockReportControllerMockRecorder) TopProducts(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopProducts", reflect.TypeOf((*MockReportController)(nil).TopProducts), c)
+}
@@ -0,0 +1,195 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/stock_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/stock_controller.go -destination=controller/mocks/stock_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockStockController is a mock of StockController interface.
+type MockStockController struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockStockControllerMockRecorder is the mock recorder for MockStockController.
+type MockStockControllerMockRecorder struct {
+	mock *MockStockController
+}
+
+// NewMockStockController creates a new mock instance.
+func NewMockStockController(ctrl *gomock.Controller) *MockStockController {
+	mock := &MockStockController{ctrl: ctrl}
+	mock.recorder = &MockStockControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockController) EXPECT() *MockStockControllerMockRecorder {
+	return m.recorder
+}
+
+// CancelTransfer mocks base method.
+func (m *MockStockController) CancelTransfer(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTransfer", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelTransfer indicates an expected call of CancelTransfer.
+func (mr *MockStockControllerMockRecorder) CancelTransfer(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTransfer", reflect.TypeOf((*MockStockController)(nil).CancelTransfer), c)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStockController) CreateTransfer(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStockControllerMockRecorder) CreateTransfer(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStockController)(nil).CreateTransfer), c)
+}
+
+// FindInventories mocks base method.
+func (m *MockStockController) FindInventories(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInventories", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInventories indicates an expected call of FindInventories.
+func (mr *MockStockControllerMockRecorder) FindInventories(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInventories", reflect.TypeOf((*MockStockController)(nil).FindInventories), c)
+}
+
+// FindLevels mocks base method.
+func (m *MockStockController) FindLevels(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLevels", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindLevels indicates an expected call of FindLevels.
+func (mr *MockStockControllerMockRecorder) FindLevels(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLevels", reflect.TypeOf((*MockStockController)(nil).FindLevels), c)
+}
+
+// FindMovements mocks base method.
+func (m *MockStockController) FindMovements(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMovements", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindMovements indicates an expected call of FindMovements.
+func (mr *MockStockControllerMockRecorder) FindMovements(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMovements", reflect.TypeOf((*MockStockController)(nil).FindMovements), c)
+}
+
+// FindTransferById mocks base method.
+func (m *MockStockController) FindTransferById(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransferById", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindTransferById indicates an expected call of FindTransferById.
+func (mr *MockStockControllerMockRecorder) FindTransferById(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransferById", reflect.TypeOf((*MockStockController)(nil).FindTransferById), c)
+}
+
+// FindTransfers mocks base method.
+func (m *MockStockController) FindTransfers(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransfers", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindTransfers indicates an expected call of FindTransfers.
+func (mr *MockStockControllerMockRecorder) FindTransfers(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransfers", reflect.TypeOf((*MockStockController)(nil).FindTransfers), c)
+}
+
+// ReceiveTransfer mocks base method.
+func (m *MockStockController) ReceiveTransfer(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveTransfer", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReceiveTransfer indicates an expected call of ReceiveTransfer.
+func (mr *MockStockControllerMockRecorder) ReceiveTransfer(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveTransfer", reflect.TypeOf((*MockStockController)(nil).ReceiveTransfer), c)
+}
+
+// Restock mocks base method.
+func (m *MockStockController) Restock(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restock", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restock indicates an expected call of Restock.
+func (mr *MockStockControllerMockRecorder) Restock(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restock", reflect.TypeOf((*MockStockController)(nil).Restock), c)
+}
+
+// SetLevel mocks base method.
+func (m *MockStockController) SetLevel(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLevel", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLevel indicates an expected call of SetLevel.
+func (mr *MockStockControllerMockRecorder) SetLevel(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevel", reflect.TypeOf((*MockStockController)(nil).SetLevel), c)
+}
+
+// SetRestockLevel mocks base method.
+func (m *MockStockController) SetRestockLevel(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRestockLevel", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRestockLevel indicates an expected call of SetRestockLevel.
+func (mr *MockStockControllerMockRecorder) SetRestockLevel(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRestockLevel", reflect.TypeOf((*MockStockController)(nil).SetRestockLevel), c)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/stocktake_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/stocktake_controller.go -destination=controller/mocks/stocktake_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockStocktakeController is a mock of StocktakeController interface.
+type MockStocktakeController struct {
+	ctrl     *gomock.Controller
+	recorder *MockStocktakeControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockStocktakeControllerMockRecorder is the mock recorder for MockStocktakeController.
+type MockStocktakeControllerMockRecorder struct {
+	mock *MockStocktakeController
+}
+
+// NewMockStocktakeController creates a new mock instance.
+func NewMockStocktakeController(ctrl *gomock.Controller) *MockStocktakeController {
+	mock := &MockStocktakeController{ctrl: ctrl}
+	mock.recorder = &MockStocktakeControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStocktakeController) EXPECT() *MockStocktakeControllerMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockStocktakeController) Approve(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockStocktakeControllerMockRecorder) Approve(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockStocktakeController)(nil).Approve), c)
+}
+
+// Cancel mocks base method.
+func (m *MockStocktakeController) Cancel(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockStocktakeControllerMockRecorder) Cancel(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockStocktakeController)(nil).Cancel), c)
+}
+
+// Count mocks base method.
+func (m *MockStocktakeController) Count(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockStocktakeControllerMockRecorder) Count(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockStocktakeController)(nil).Count), c)
+}
+
+// Create mocks base method.
+func (m *MockStocktakeController) Create(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStocktakeControllerMockRecorder) Create(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStocktakeController)(nil).Create), c)
+}
+
+// FindAll mocks base method.
+func (m *MockStocktakeController) FindAll(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStocktakeControllerMockRecorder) FindAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStocktakeController)(nil).FindAll), c)
+}
+
+// FindById mocks base method.
+func (m *MockStocktakeController) FindById(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockStocktakeControllerMockRecorder) FindById(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockStocktakeController)(nil).FindById), c)
+}
+
+// Upload mocks base method.
+func (m *MockStocktakeController) Upload(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockStocktakeControllerMockRecorder) Upload(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockStocktakeController)(nil).Upload), c)
+}
+
+// Variances mocks base method.
+func (m *MockStocktakeController) Variances(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Variances", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Variances indicates an expected call of Variances.
+func (mr *MockStocktakeControllerMockRecorder) Variances(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Variances", reflect.TypeOf((*MockStocktakeController)(nil).Variances), c)
+}
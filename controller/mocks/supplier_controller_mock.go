@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/supplier_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/supplier_controller.go -destination=controller/mocks/supplier_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockSupplierController is a mock of SupplierController interface.
+type MockSupplierController struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupplierControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockSupplierControllerMockRecorder is the mock recorder for MockSupplierController.
+type MockSupplierControllerMockRecorder struct {
+	mock *MockSupplierController
+}
+
+// NewMockSupplierController creates a new mock instance.
+func NewMockSupplierController(ctrl *gomock.Controller) *MockSupplierController {
+	mock := &MockSupplierController{ctrl: ctrl}
+	mock.recorder = &MockSupplierControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupplierController) EXPECT() *MockSupplierControllerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSupplierController) Create(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSupplierControllerMockRecorder) Create(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSupplierController)(nil).Create), c)
+}
+
+// Delete mocks base method.
+func (m *MockSupplierController) Delete(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSupplierControllerMockRecorder) Delete(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplierController)(nil).Delete), c)
+}
+
+// FindAll mocks base method.
+func (m *MockSupplierController) FindAll(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSupplierControllerMockRecorder) FindAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSupplierController)(nil).FindAll), c)
+}
+
+// FindById mocks base method.
+func (m *MockSupplierController) FindById(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockSupplierControllerMockRecorder) FindById(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockSupplierController)(nil).FindById), c)
+}
+
+// Update mocks base method.
+func (m *MockSupplierController) Update(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSupplierControllerMockRecorder) Update(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplierController)(nil).Update), c)
+}
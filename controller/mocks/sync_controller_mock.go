@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller/sync_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=controller/sync_controller.go -destination=controller/mocks/sync_controller_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	v2 "github.com/gofiber/fiber/v2"
+)
+
+// MockSyncController is a mock of SyncController interface.
+type MockSyncController struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockSyncControllerMockRecorder is the mock recorder for MockSyncController.
+type MockSyncControllerMockRecorder struct {
+	mock *MockSyncController
+}
+
+// NewMockSyncController creates a new mock instance.
+func NewMockSyncController(ctrl *gomock.Controller) *MockSyncController {
+	mock := &MockSyncController{ctrl: ctrl}
+	mock.recorder = &MockSyncControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncController) EXPECT() *MockSyncControllerMockRecorder {
+	return m.recorder
+}
+
+// Changes mocks base method.
+func (m *MockSyncController) Changes(c *v2.Ctx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Changes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Changes indicates an expected call of Changes.
+func (mr *MockSyncControllerMockRecorder) Changes(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Changes", reflect.TypeOf((*MockSyncController)(nil).Changes), c)
+}
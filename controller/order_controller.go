@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrderController interface {
+	Create(c *fiber.Ctx) error
+	UploadOffline(c *fiber.Ctx) error
+	AddPayment(c *fiber.Ctx) error
+	FindById(c *fiber.Ctx) error
+	FindAll(c *fiber.Ctx) error
+	FindOffline(c *fiber.Ctx) error
+	Export(c *fiber.Ctx) error
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrderControllerImpl struct {
+	OrderService service.OrderService
+}
+
+func NewOrderController(orderService service.OrderService) OrderController {
+	return &OrderControllerImpl{
+		OrderService: orderService,
+	}
+}
+
+// Create Order
+func (controller *OrderControllerImpl) Create(c *fiber.Ctx) error {
+	orderCreateRequest := new(web.OrderCreateRequest)
+	if err := c.BodyParser(orderCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	orderResponse, err := controller.OrderService.Create(c.Context(), *orderCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, orderResponse.Version)
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   orderResponse,
+	})
+}
+
+// Upload the Orders a terminal took while offline
+func (controller *OrderControllerImpl) UploadOffline(c *fiber.Ctx) error {
+	uploadRequest := new(web.OfflineOrderUploadRequest)
+	if err := c.BodyParser(uploadRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	uploadResponse, err := controller.OrderService.UploadOffline(c.Context(), *uploadRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   uploadResponse,
+	})
+}
+
+// Add a Payment to an Order
+func (controller *OrderControllerImpl) AddPayment(c *fiber.Ctx) error {
+	paymentCreateRequest := new(web.PaymentCreateRequest)
+	if err := c.BodyParser(paymentCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	paymentCreateRequest.OrderID = c.Params("orderId")
+
+	// If-Match names the version of the order the payment is for
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		paymentCreateRequest.Version = version
+	}
+
+	paymentResponse, err := controller.OrderService.AddPayment(c.Context(), *paymentCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, paymentResponse.Version)
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   paymentResponse,
+	})
+}
+
+// Find Order by ID
+func (controller *OrderControllerImpl) FindById(c *fiber.Ctx) error {
+	orderResponse, err := controller.OrderService.FindById(c.Context(), c.Params("orderId"))
+	if err != nil {
+		if _, ok := err.(exception.NotFoundError); ok {
+			return c.Status(fiber.StatusNotFound).JSON(web.WebResponse{
+				Code:   fiber.StatusNotFound,
+				Status: "Not Found",
+				Data:   err.Error(),
+			})
+		}
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, orderResponse.Version)
+	if c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   orderResponse,
+	})
+}
+
+// Find All Orders
+func (controller *OrderControllerImpl) FindAll(c *fiber.Ctx) error {
+	orderResponses, err := controller.OrderService.FindAll(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   orderResponses,
+	})
+}
+
+// Find the Orders taken offline, optionally by reconciliation status
+func (controller *OrderControllerImpl) FindOffline(c *fiber.Ctx) error {
+	orderResponses, err := controller.OrderService.FindOffline(c.Context(), c.Query("status"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   orderResponses,
+	})
+}
+
+// Export Orders as CSV, JSONL or XLSX
+func (controller *OrderControllerImpl) Export(c *fiber.Ctx) error {
+	return streamExport(c, "orders", web.OrderResponse{}, func(ctx context.Context, write func(record interface{}) error) error {
+		return controller.OrderService.Export(ctx, func(response web.OrderResponse) error {
+			return write(response)
+		})
+	})
+}
@@ -0,0 +1,246 @@
+package controller
+
+import (
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const orderExportHeader = "order_id,customer_id,store_id,employee_id,shift_id,order_date,total_amount,paid_amount,item_count,version," +
+	"item_product_id,item_variant_id,item_quantity,item_unit_price,item_total_price,item_discount_amount,item_tax_amount," +
+	"reconciliation_status,uploaded_at"
+
+func TestOrderController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOrderService(ctrl)
+	orderController := NewOrderController(mockService)
+	app := fiber.New()
+	app.Get("/api/orders/export", orderController.Export)
+	app.Get("/api/orders/:orderId", orderController.FindById)
+
+	orderDate := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	orders := []web.OrderResponse{
+		{OrderID: "o-1", CustomerID: "c-1", OrderDate: orderDate, TotalAmount: 25.5, ItemCount: 2, Version: 2,
+			OrderItems: []web.OrderItemResponse{
+				{ProductID: "p-1", Quantity: 1, UnitPrice: 15.5, TotalPrice: 15.5},
+				{ProductID: "p-2", Quantity: 2, UnitPrice: 5, TotalPrice: 10, DiscountAmount: 1},
+			}},
+		{OrderID: "o-2", CustomerID: "c-2", OrderDate: orderDate, TotalAmount: 10, ItemCount: 0, Version: 1},
+	}
+	exportOrders := func(ctx interface{}, fn func(response web.OrderResponse) error) error {
+		for _, order := range orders {
+			if err := fn(order); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		url         string
+		mock        func()
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			name: "find by id not found",
+			url:  "/api/orders/o-9",
+			mock: func() {
+				mockService.EXPECT().FindById(gomock.Any(), "o-9").Return(web.OrderResponse{}, exception.NewNotFoundError("Order not found"))
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:        "export csv by default",
+			url:         "/api/orders/export",
+			mock:        func() { mockService.EXPECT().Export(gomock.Any(), gomock.Any()).DoAndReturn(exportOrders) },
+			status:      http.StatusOK,
+			contentType: "text/csv; charset=utf-8",
+			body: orderExportHeader + "\n" +
+				"o-1,c-1,0,,,2024-03-01T09:30:00Z,25.5,0,2,2,p-1,,1,15.5,15.5,0,0,,\n" +
+				"o-1,c-1,0,,,2024-03-01T09:30:00Z,25.5,0,2,2,p-2,,2,5,10,1,0,,\n" +
+				"o-2,c-2,0,,,2024-03-01T09:30:00Z,10,0,0,1,,,,,,,,,\n",
+		},
+		{
+			name:        "export csv without orders still has a header",
+			url:         "/api/orders/export",
+			mock:        func() { mockService.EXPECT().Export(gomock.Any(), gomock.Any()).Return(nil) },
+			status:      http.StatusOK,
+			contentType: "text/csv; charset=utf-8",
+			body:        orderExportHeader + "\n",
+		},
+		{
+			name:        "export jsonl keeps items",
+			url:         "/api/orders/export?format=jsonl",
+			mock:        func() { mockService.EXPECT().Export(gomock.Any(), gomock.Any()).DoAndReturn(exportOrders) },
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			body: `{"order_id":"o-1","customer_id":"c-1","store_id":0,"employee_id":"","shift_id":null,"order_date":"2024-03-01T09:30:00Z","total_amount":25.5,"paid_amount":0,"item_count":2,"version":2,"order_items":[{"product_id":"p-1","quantity":1,"unit_price":15.5,"total_price":15.5,"discount_amount":0,"tax_amount":0},{"product_id":"p-2","quantity":2,"unit_price":5,"total_price":10,"discount_amount":1,"tax_amount":0}],"payments":null}` + "\n" +
+				`{"order_id":"o-2","customer_id":"c-2","store_id":0,"employee_id":"","shift_id":null,"order_date":"2024-03-01T09:30:00Z","total_amount":10,"paid_amount":0,"item_count":0,"version":1,"order_items":null,"payments":null}` + "\n",
+		},
+		{
+			name: "export failing before the first record",
+			url:  "/api/orders/export",
+			mock: func() {
+				mockService.EXPECT().Export(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name: "export failing mid-stream ends with a trailer",
+			url:  "/api/orders/export?format=jsonl",
+			mock: func() {
+				mockService.EXPECT().Export(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx interface{}, fn func(response web.OrderResponse) error) error {
+					if err := fn(orders[1]); err != nil {
+						return err
+					}
+					return errors.New("connection refused")
+				})
+			},
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			body: `{"order_id":"o-2","customer_id":"c-2","store_id":0,"employee_id":"","shift_id":null,"order_date":"2024-03-01T09:30:00Z","total_amount":10,"paid_amount":0,"item_count":0,"version":1,"order_items":null,"payments":null}` + "\n" +
+				`{"error":"# export failed: connection refused"}` + "\n",
+		},
+		{
+			name:   "export unsupported format",
+			url:    "/api/orders/export?format=pdf",
+			mock:   func() {},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			resp, err := app.Test(httptest.NewRequest("GET", tt.url, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.contentType != "" {
+				assert.Equal(t, tt.contentType, resp.Header.Get("Content-Type"))
+				assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment; filename=\"orders-")
+			}
+			if tt.body != "" {
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, tt.body, string(body))
+			}
+		})
+	}
+}
+
+func TestOrderControllerExportXlsx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOrderService(ctrl)
+	orderController := NewOrderController(mockService)
+	app := fiber.New()
+	app.Get("/api/orders/export", orderController.Export)
+
+	exportSheet := func(t *testing.T) [][]string {
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/orders/export?format=xlsx", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		file, err := excelize.OpenReader(resp.Body)
+		assert.NoError(t, err)
+		defer file.Close()
+		rows, err := file.GetRows(file.GetSheetName(0))
+		assert.NoError(t, err)
+		return rows
+	}
+	header := strings.Split(orderExportHeader, ",")
+
+	t.Run("one row per line item", func(t *testing.T) {
+		mockService.EXPECT().Export(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx interface{}, fn func(response web.OrderResponse) error) error {
+			return fn(web.OrderResponse{OrderID: "o-1", ItemCount: 2, OrderItems: []web.OrderItemResponse{
+				{ProductID: "p-1", Quantity: 1},
+				{ProductID: "p-2", Quantity: 3},
+			}})
+		})
+
+		rows := exportSheet(t)
+		assert.Len(t, rows, 3)
+		assert.Equal(t, header, rows[0])
+		assert.Equal(t, []string{"o-1", "p-1", "1"}, []string{rows[1][0], rows[1][10], rows[1][12]})
+		assert.Equal(t, []string{"o-1", "p-2", "3"}, []string{rows[2][0], rows[2][10], rows[2][12]})
+	})
+
+	t.Run("header without orders", func(t *testing.T) {
+		mockService.EXPECT().Export(gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.Equal(t, [][]string{header}, exportSheet(t))
+	})
+}
+
+func TestOrderControllerConditionalRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOrderService(ctrl)
+	orderController := NewOrderController(mockService)
+	app := fiber.New()
+	app.Get("/api/orders/:orderId", orderController.FindById)
+	app.Post("/api/orders/:orderId/payments", orderController.AddPayment)
+
+	t.Run("find by id exposes the version", func(t *testing.T) {
+		mockService.EXPECT().FindById(gomock.Any(), "o-1").Return(web.OrderResponse{OrderID: "o-1", Version: 2}, nil)
+
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/orders/o-1", nil))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+	})
+
+	t.Run("find by id not modified", func(t *testing.T) {
+		mockService.EXPECT().FindById(gomock.Any(), "o-1").Return(web.OrderResponse{OrderID: "o-1", Version: 2}, nil)
+
+		req := httptest.NewRequest("GET", "/api/orders/o-1", nil)
+		req.Header.Set("If-None-Match", `"2"`)
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	})
+
+	t.Run("add payment with If-Match", func(t *testing.T) {
+		mockService.EXPECT().AddPayment(gomock.Any(), web.PaymentCreateRequest{OrderID: "o-1", PaymentType: "Card", Amount: 40, Version: 2}).
+			Return(web.PaymentResponse{PaymentID: "pay-1", OrderID: "o-1", Amount: 40, Version: 1}, nil)
+
+		req := httptest.NewRequest("POST", "/api/orders/o-1/payments", strings.NewReader(`{"payment_type":"Card","amount":40}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"2"`)
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, `"1"`, resp.Header.Get("ETag"))
+	})
+
+	t.Run("add payment to a modified order", func(t *testing.T) {
+		mockService.EXPECT().AddPayment(gomock.Any(), gomock.Any()).Return(web.PaymentResponse{}, exception.NewConflictError("Order has been modified"))
+
+		req := httptest.NewRequest("POST", "/api/orders/o-1/payments", strings.NewReader(`{"payment_type":"Card","amount":40}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("add payment with an invalid If-Match", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/orders/o-1/payments", strings.NewReader(`{"payment_type":"Card","amount":40}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", "abc")
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
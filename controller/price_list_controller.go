@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type PriceListController interface {
+	Create(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	FindById(c *fiber.Ctx) error
+	FindAll(c *fiber.Ctx) error
+	FindItems(c *fiber.Ctx) error
+	SetItem(c *fiber.Ctx) error
+	DeleteItem(c *fiber.Ctx) error
+	FindPriceHistory(c *fiber.Ctx) error
+	ResolvePrice(c *fiber.Ctx) error
+}
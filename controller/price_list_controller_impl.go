@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type PriceListControllerImpl struct {
+	PriceListService service.PriceListService
+}
+
+func NewPriceListController(priceListService service.PriceListService) PriceListController {
+	return &PriceListControllerImpl{
+		PriceListService: priceListService,
+	}
+}
+
+// Create PriceList
+func (controller *PriceListControllerImpl) Create(c *fiber.Ctx) error {
+	priceListCreateRequest := new(web.PriceListCreateRequest)
+	if err := c.BodyParser(priceListCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	priceListResponse, err := controller.PriceListService.Create(c.Context(), *priceListCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, priceListResponse.Version)
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   priceListResponse,
+	})
+}
+
+// Update PriceList
+func (controller *PriceListControllerImpl) Update(c *fiber.Ctx) error {
+	priceListUpdateRequest := new(web.PriceListUpdateRequest)
+	if err := c.BodyParser(priceListUpdateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("priceListId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Price List ID",
+			Data:   err.Error(),
+		})
+	}
+	priceListUpdateRequest.Id = id
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		priceListUpdateRequest.Version = version
+	}
+
+	priceListResponse, err := controller.PriceListService.Update(c.Context(), *priceListUpdateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, priceListResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   priceListResponse,
+	})
+}
+
+// Delete PriceList
+func (controller *PriceListControllerImpl) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("priceListId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Price List ID",
+			Data:   err.Error(),
+		})
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	if err := controller.PriceListService.Delete(c.Context(), id, version); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "Deleted Successfully",
+	})
+}
+
+// Find PriceList by ID
+func (controller *PriceListControllerImpl) FindById(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("priceListId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Price List ID",
+			Data:   err.Error(),
+		})
+	}
+
+	priceListResponse, err := controller.PriceListService.FindById(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, priceListResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   priceListResponse,
+	})
+}
+
+// Find All PriceLists
+func (controller *PriceListControllerImpl) FindAll(c *fiber.Ctx) error {
+	priceListResponses, err := controller.PriceListService.FindAll(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   priceListResponses,
+	})
+}
+
+// Find the Product prices of a PriceList
+func (controller *PriceListControllerImpl) FindItems(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("priceListId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Price List ID",
+			Data:   err.Error(),
+		})
+	}
+
+	itemResponses, err := controller.PriceListService.FindItems(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   itemResponses,
+	})
+}
+
+// Set the price of a Product in a PriceList
+func (controller *PriceListControllerImpl) SetItem(c *fiber.Ctx) error {
+	itemRequest := new(web.PriceListItemRequest)
+	if err := c.BodyParser(itemRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("priceListId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Price List ID",
+			Data:   err.Error(),
+		})
+	}
+	itemRequest.PriceListId = id
+	itemRequest.ProductID = c.Params("productId")
+
+	itemResponse, err := controller.PriceListService.SetItem(c.Context(), *itemRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   itemResponse,
+	})
+}
+
+// Remove a Product from a PriceList
+func (controller *PriceListControllerImpl) DeleteItem(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("priceListId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Price List ID",
+			Data:   err.Error(),
+		})
+	}
+
+	if err := controller.PriceListService.DeleteItem(c.Context(), id, c.Params("productId")); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "Deleted Successfully",
+	})
+}
+
+// Find the price history of a Product
+func (controller *PriceListControllerImpl) FindPriceHistory(c *fiber.Ctx) error {
+	historyResponses, err := controller.PriceListService.FindPriceHistory(c.Context(), c.Params("productId"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   historyResponses,
+	})
+}
+
+// ResolvePrice returns the price a customer pays for a product, optionally for a
+// variant and at a given moment:
+// ?product_id=&customer_id=&variant_id=&at=2025-01-01T00:00:00Z
+func (controller *PriceListControllerImpl) ResolvePrice(c *fiber.Ctx) error {
+	priceQuery := web.PriceQuery{ProductID: c.Query("product_id")}
+	if priceQuery.ProductID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   "product_id is required",
+		})
+	}
+
+	if customerId := c.Query("customer_id"); customerId != "" {
+		id, err := strconv.ParseUint(customerId, 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Invalid Customer ID",
+				Data:   err.Error(),
+			})
+		}
+		priceQuery.CustomerID = id
+	}
+	if variantId := c.Query("variant_id"); variantId != "" {
+		id, err := strconv.ParseUint(variantId, 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Invalid Variant ID",
+				Data:   err.Error(),
+			})
+		}
+		priceQuery.VariantID = &id
+	}
+	at, err := parseTimeQuery(c, "at")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Date",
+			Data:   err.Error(),
+		})
+	}
+	if at != nil {
+		priceQuery.At = *at
+	}
+
+	resolvedPrice, err := controller.PriceListService.ResolvePrice(c.Context(), priceQuery)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   resolvedPrice,
+	})
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPriceListController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPriceListService(ctrl)
+	priceListController := NewPriceListController(mockService)
+	app := fiber.New()
+	app.Put("/api/price-lists/:priceListId/items/:productId", priceListController.SetItem)
+	app.Get("/api/prices/resolve", priceListController.ResolvePrice)
+
+	t.Run("set item", func(t *testing.T) {
+		mockService.EXPECT().SetItem(gomock.Any(), web.PriceListItemRequest{PriceListId: 2, ProductID: "p-1", Price: 85}).
+			Return(web.PriceListItemResponse{PriceListId: 2, ProductID: "p-1", Price: 85}, nil)
+
+		req := httptest.NewRequest("PUT", "/api/price-lists/2/items/p-1", strings.NewReader(`{"price":85}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("resolve price", func(t *testing.T) {
+		variantId := uint64(3)
+		at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		mockService.EXPECT().ResolvePrice(gomock.Any(), web.PriceQuery{CustomerID: 5, ProductID: "p-1", VariantID: &variantId, At: at}).
+			Return(web.ResolvedPrice{ProductID: "p-1", Price: 90, Source: "price_list"}, nil)
+
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/prices/resolve?product_id=p-1&customer_id=5&variant_id=3&at=2025-03-01T00:00:00Z", nil))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("resolve price without product", func(t *testing.T) {
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/prices/resolve?customer_id=5", nil))
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("resolve price of unknown product", func(t *testing.T) {
+		mockService.EXPECT().ResolvePrice(gomock.Any(), gomock.Any()).Return(web.ResolvedPrice{}, exception.NewNotFoundError("Product not found"))
+
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/prices/resolve?product_id=p-9", nil))
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type PriceScheduleController interface {
+	Create(c *fiber.Ctx) error
+	Cancel(c *fiber.Ctx) error
+	FindAll(c *fiber.Ctx) error
+}
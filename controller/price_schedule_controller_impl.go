@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type PriceScheduleControllerImpl struct {
+	PriceScheduleService service.PriceScheduleService
+}
+
+func NewPriceScheduleController(priceScheduleService service.PriceScheduleService) PriceScheduleController {
+	return &PriceScheduleControllerImpl{
+		PriceScheduleService: priceScheduleService,
+	}
+}
+
+// Create PriceSchedule
+func (controller *PriceScheduleControllerImpl) Create(c *fiber.Ctx) error {
+	scheduleCreateRequest := new(web.PriceScheduleCreateRequest)
+	if err := c.BodyParser(scheduleCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	scheduleResponse, err := controller.PriceScheduleService.Create(c.Context(), *scheduleCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   scheduleResponse,
+	})
+}
+
+// Cancel PriceSchedule
+func (controller *PriceScheduleControllerImpl) Cancel(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("scheduleId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Price Schedule ID",
+			Data:   err.Error(),
+		})
+	}
+
+	scheduleResponse, err := controller.PriceScheduleService.Cancel(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   scheduleResponse,
+	})
+}
+
+// Find All PriceSchedules, optionally filtered with ?product_id=&status=
+func (controller *PriceScheduleControllerImpl) FindAll(c *fiber.Ctx) error {
+	scheduleResponses, err := controller.PriceScheduleService.FindAll(c.Context(), web.PriceScheduleFilterRequest{
+		ProductID: c.Query("product_id"),
+		Status:    c.Query("status"),
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   scheduleResponses,
+	})
+}
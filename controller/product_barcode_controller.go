@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProductBarcodeController interface {
+	Lookup(c *fiber.Ctx) error
+	FindAll(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	GenerateMissing(c *fiber.Ctx) error
+	Labels(c *fiber.Ctx) error
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strings"
+)
+
+type ProductBarcodeControllerImpl struct {
+	ProductBarcodeService service.ProductBarcodeService
+}
+
+func NewProductBarcodeController(productBarcodeService service.ProductBarcodeService) ProductBarcodeController {
+	return &ProductBarcodeControllerImpl{
+		ProductBarcodeService: productBarcodeService,
+	}
+}
+
+// Lookup the Product of a scanned Barcode
+func (controller *ProductBarcodeControllerImpl) Lookup(c *fiber.Ctx) error {
+	lookupResponse, err := controller.ProductBarcodeService.Lookup(c.Context(), c.Params("code"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   lookupResponse,
+	})
+}
+
+// Find All Barcodes of a Product
+func (controller *ProductBarcodeControllerImpl) FindAll(c *fiber.Ctx) error {
+	barcodeResponses, err := controller.ProductBarcodeService.FindAll(c.Context(), c.Params("productId"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   barcodeResponses,
+	})
+}
+
+// Create Barcode
+func (controller *ProductBarcodeControllerImpl) Create(c *fiber.Ctx) error {
+	barcodeCreateRequest := new(web.ProductBarcodeCreateRequest)
+	if err := c.BodyParser(barcodeCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	barcodeCreateRequest.ProductID = c.Params("productId")
+
+	barcodeResponse, err := controller.ProductBarcodeService.Create(c.Context(), *barcodeCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   barcodeResponse,
+	})
+}
+
+// Delete Barcode
+func (controller *ProductBarcodeControllerImpl) Delete(c *fiber.Ctx) error {
+	if err := controller.ProductBarcodeService.Delete(c.Context(), c.Params("productId"), c.Params("code")); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "Deleted Successfully",
+	})
+}
+
+// Generate internal Barcodes for Products without one
+func (controller *ProductBarcodeControllerImpl) GenerateMissing(c *fiber.Ctx) error {
+	barcodeResponses, err := controller.ProductBarcodeService.GenerateMissing(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   barcodeResponses,
+	})
+}
+
+// Labels renders a printable sheet of shelf labels for the products listed in the
+// product_id query parameter (comma separated)
+func (controller *ProductBarcodeControllerImpl) Labels(c *fiber.Ctx) error {
+	labelRequest := web.BarcodeLabelRequest{
+		Format: c.Query("format", helper.LabelFormatPDF),
+		Copies: c.QueryInt("copies", 1),
+	}
+	for _, productId := range strings.Split(c.Query("product_id"), ",") {
+		if productId = strings.TrimSpace(productId); productId != "" {
+			labelRequest.ProductIDs = append(labelRequest.ProductIDs, productId)
+		}
+	}
+
+	var sheet bytes.Buffer
+	if err := controller.ProductBarcodeService.RenderLabels(c.Context(), labelRequest, &sheet); err != nil {
+		return errorResponse(c, err)
+	}
+
+	contentType := "application/pdf"
+	if labelRequest.Format == helper.LabelFormatPNG {
+		contentType = "image/png"
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="labels.%s"`, labelRequest.Format))
+	return c.Status(fiber.StatusOK).Send(sheet.Bytes())
+}
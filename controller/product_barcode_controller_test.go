@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProductBarcodeController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductBarcodeService(ctrl)
+	barcodeController := NewProductBarcodeController(mockService)
+	app := fiber.New()
+	app.Get("/api/products/barcode/:code", barcodeController.Lookup)
+	app.Get("/api/products/barcodes/labels", barcodeController.Labels)
+
+	t.Run("lookup unknown barcode", func(t *testing.T) {
+		mockService.EXPECT().Lookup(gomock.Any(), "96385074").Return(web.ProductBarcodeLookupResponse{}, exception.NewNotFoundError("Barcode not found"))
+
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/products/barcode/96385074", nil))
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("labels as pdf", func(t *testing.T) {
+		mockService.EXPECT().
+			RenderLabels(gomock.Any(), web.BarcodeLabelRequest{Format: "pdf", ProductIDs: []string{"1", "2"}, Copies: 3}, gomock.Any()).
+			DoAndReturn(func(ctx interface{}, request web.BarcodeLabelRequest, writer io.Writer) error {
+				_, err := writer.Write([]byte("%PDF-1.3"))
+				return err
+			})
+
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/products/barcodes/labels?product_id=1,2&copies=3", nil))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "%PDF-1.3", string(body))
+	})
+}
@@ -8,4 +8,6 @@ type ProductController interface {
 	Delete(c *fiber.Ctx) error
 	FindById(c *fiber.Ctx) error
 	FindAll(c *fiber.Ctx) error
+	Export(c *fiber.Ctx) error
+	Bulk(c *fiber.Ctx) error
 }
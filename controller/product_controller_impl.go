@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/model/web"
 	"github.com/aronipurwanto/go-restful-api/service"
@@ -31,13 +32,10 @@ func (controller *ProductControllerImpl) Create(c *fiber.Ctx) error {
 
 	productResponse, err := controller.ProductService.Create(c.Context(), *productCreateRequest)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
-			Code:   fiber.StatusInternalServerError,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		})
+		return errorResponse(c, err)
 	}
 
+	setVersionETag(c, productResponse.Version)
 	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
 		Code:   fiber.StatusCreated,
 		Status: "Created",
@@ -66,22 +64,24 @@ func (controller *ProductControllerImpl) Update(c *fiber.Ctx) error {
 	}
 	productUpdateRequest.ProductID = strconv.Itoa(id)
 
-	productResponse, err := controller.ProductService.Update(c.Context(), *productUpdateRequest)
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		if _, ok := err.(exception.NotFoundError); ok {
-			return c.Status(fiber.StatusNotFound).JSON(web.WebResponse{
-				Code:   fiber.StatusNotFound,
-				Status: "Not Found",
-				Data:   err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
-			Code:   fiber.StatusInternalServerError,
-			Status: "Internal Server Error",
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
 			Data:   err.Error(),
 		})
 	}
+	if version != 0 {
+		productUpdateRequest.Version = version
+	}
+
+	productResponse, err := controller.ProductService.Update(c.Context(), *productUpdateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
 
+	setVersionETag(c, productResponse.Version)
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
 		Code:   fiber.StatusOK,
 		Status: "OK",
@@ -100,7 +100,16 @@ func (controller *ProductControllerImpl) Delete(c *fiber.Ctx) error {
 		})
 	}
 
-	err = controller.ProductService.Delete(c.Context(), strconv.Itoa(id))
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	err = controller.ProductService.Delete(c.Context(), strconv.Itoa(id), version)
 	if err != nil {
 		if _, ok := err.(exception.NotFoundError); ok {
 			return c.Status(fiber.StatusNotFound).JSON(web.WebResponse{
@@ -109,6 +118,13 @@ func (controller *ProductControllerImpl) Delete(c *fiber.Ctx) error {
 				Data:   err.Error(),
 			})
 		}
+		if _, ok := err.(exception.ConflictError); ok {
+			return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+				Code:   fiber.StatusConflict,
+				Status: "Conflict",
+				Data:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   fiber.StatusInternalServerError,
 			Status: "Internal Server Error",
@@ -149,6 +165,11 @@ func (controller *ProductControllerImpl) FindById(c *fiber.Ctx) error {
 		})
 	}
 
+	setVersionETag(c, productResponse.Version)
+	if c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
 		Code:   fiber.StatusOK,
 		Status: "OK",
@@ -156,9 +177,24 @@ func (controller *ProductControllerImpl) FindById(c *fiber.Ctx) error {
 	})
 }
 
-// Find All Products
+// Find All Products, optionally of one category tree
 func (controller *ProductControllerImpl) FindAll(c *fiber.Ctx) error {
-	productResponses, err := controller.ProductService.FindAll(c.Context())
+	var productResponses []web.ProductResponse
+	var err error
+	if categoryId := c.Query("category_id"); categoryId != "" {
+		id, parseErr := strconv.ParseUint(categoryId, 10, 64)
+		if parseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Invalid Category ID",
+				Data:   parseErr.Error(),
+			})
+		}
+		// Products of descendant categories are included.
+		productResponses, err = controller.ProductService.FindAllByCategory(c.Context(), id)
+	} else {
+		productResponses, err = controller.ProductService.FindAll(c.Context())
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   fiber.StatusInternalServerError,
@@ -173,3 +209,46 @@ func (controller *ProductControllerImpl) FindAll(c *fiber.Ctx) error {
 		Data:   productResponses,
 	})
 }
+
+// Bulk Create, Update and Delete Products
+func (controller *ProductControllerImpl) Bulk(c *fiber.Ctx) error {
+	productBulkRequest := new(web.ProductBulkRequest)
+	if err := c.BodyParser(productBulkRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	bulkResponse, err := controller.ProductService.Bulk(c.Context(), *productBulkRequest)
+	if err != nil {
+		if _, ok := err.(exception.BadRequestError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Bad Request",
+				Data:   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   fiber.StatusInternalServerError,
+			Status: "Internal Server Error",
+			Data:   err.Error(),
+		})
+	}
+
+	return c.Status(bulkStatusCode(bulkResponse)).JSON(web.WebResponse{
+		Code:   bulkStatusCode(bulkResponse),
+		Status: "OK",
+		Data:   bulkResponse,
+	})
+}
+
+// Export Products as CSV, JSONL or XLSX
+func (controller *ProductControllerImpl) Export(c *fiber.Ctx) error {
+	return streamExport(c, "products", web.ProductResponse{}, func(ctx context.Context, write func(record interface{}) error) error {
+		return controller.ProductService.Export(ctx, func(response web.ProductResponse) error {
+			return write(response)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"bytes"
 	"encoding/json"
+	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/model/web"
 	"github.com/aronipurwanto/go-restful-api/service/mocks"
 	"github.com/gofiber/fiber/v2"
@@ -110,3 +111,148 @@ func TestProductController(t *testing.T) {
 		})
 	}
 }
+
+func TestProductControllerConditionalRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductService(ctrl)
+	app := setupProductTestApp(mockService)
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		headers        map[string]string
+		body           interface{}
+		setupMock      func()
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:   "Find product by ID - exposes ETag",
+			method: "GET",
+			url:    "/api/products/1",
+			setupMock: func() {
+				mockService.EXPECT().FindById(gomock.Any(), "1").Return(web.ProductResponse{ProductID: "1", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name:    "Find product by ID - not modified",
+			method:  "GET",
+			url:     "/api/products/1",
+			headers: map[string]string{"If-None-Match": `"4"`},
+			setupMock: func() {
+				mockService.EXPECT().FindById(gomock.Any(), "1").Return(web.ProductResponse{ProductID: "1", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"4"`,
+		},
+		{
+			name:    "Update product - If-Match is honoured",
+			method:  "PUT",
+			url:     "/api/products/1",
+			headers: map[string]string{"If-Match": `"3"`},
+			body:    web.ProductUpdateRequest{Name: "Laptop", Price: 1500.0},
+			setupMock: func() {
+				mockService.EXPECT().
+					Update(gomock.Any(), web.ProductUpdateRequest{ProductID: "1", Name: "Laptop", Price: 1500.0, Version: 3}).
+					Return(web.ProductResponse{}, exception.NewConflictError("Product has been modified"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Delete product - invalid If-Match",
+			method:         "DELETE",
+			url:            "/api/products/1",
+			headers:        map[string]string{"If-Match": "abc"},
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Delete product - version passed to service",
+			method:  "DELETE",
+			url:     "/api/products/1",
+			headers: map[string]string{"If-Match": `"7"`},
+			setupMock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), "1", uint64(7)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			var reqBody []byte
+			if tt.body != nil {
+				reqBody, _ = json.Marshal(tt.body)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			resp, _ := app.Test(req)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedETag != "" {
+				assert.Equal(t, tt.expectedETag, resp.Header.Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestProductControllerBulk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductService(ctrl)
+	app := fiber.New()
+	app.Post("/api/products/bulk", NewProductController(mockService).Bulk)
+
+	tests := []struct {
+		name           string
+		response       web.BulkResponse
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "all succeeded",
+			response:       web.BulkResponse{Mode: web.BulkModeAllOrNothing, Succeeded: 2},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "best effort partially failed",
+			response:       web.BulkResponse{Mode: web.BulkModeBestEffort, Succeeded: 1, Failed: 1},
+			expectedStatus: http.StatusMultiStatus,
+		},
+		{
+			name:           "all or nothing rolled back",
+			response:       web.BulkResponse{Mode: web.BulkModeAllOrNothing, Failed: 1},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "invalid request",
+			err:            exception.NewBadRequestError("bulk request has no items"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.EXPECT().Bulk(gomock.Any(), gomock.Any()).Return(tt.response, tt.err)
+
+			reqBody, _ := json.Marshal(web.ProductBulkRequest{})
+			req := httptest.NewRequest("POST", "/api/products/bulk", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, _ := app.Test(req)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package controller
+
+import "github.com/gofiber/fiber/v2"
+
+type ProductImportController interface {
+	Import(c *fiber.Ctx) error
+}
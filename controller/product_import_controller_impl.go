@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"encoding/json"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProductImportControllerImpl struct {
+	ProductImportService service.ProductImportService
+}
+
+func NewProductImportController(productImportService service.ProductImportService) ProductImportController {
+	return &ProductImportControllerImpl{
+		ProductImportService: productImportService,
+	}
+}
+
+// Import Products from a CSV or XLSX upload
+func (controller *ProductImportControllerImpl) Import(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	defer file.Close()
+
+	importRequest := web.ProductImportRequest{
+		DryRun: c.QueryBool("dry_run") || c.FormValue("dry_run") == "true",
+	}
+	if importRequest.Rows, err = helper.ReadSpreadsheet(fileHeader.Filename, file); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid File",
+			Data:   err.Error(),
+		})
+	}
+	if mapping := c.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &importRequest.Mapping); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Invalid Mapping",
+				Data:   err.Error(),
+			})
+		}
+	}
+
+	importResponse, err := controller.ProductImportService.Import(c.Context(), importRequest)
+	if err != nil {
+		if _, ok := err.(exception.BadRequestError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Bad Request",
+				Data:   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   fiber.StatusInternalServerError,
+			Status: "Internal Server Error",
+			Data:   err.Error(),
+		})
+	}
+
+	if len(importResponse.Errors) > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(web.WebResponse{
+			Code:   fiber.StatusUnprocessableEntity,
+			Status: "Unprocessable Entity",
+			Data:   importResponse,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   importResponse,
+	})
+}
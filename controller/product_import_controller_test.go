@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"bytes"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newImportRequest(t *testing.T, url string, filename string, content string, mapping string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	part.Write([]byte(content))
+	if mapping != "" {
+		writer.WriteField("mapping", mapping)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest("POST", url, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestProductImportController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductImportService(ctrl)
+	app := fiber.New()
+	app.Post("/api/products/import", NewProductImportController(mockService).Import)
+
+	csv := "Product Name,price,stock_qty,sku\nLaptop,1000,5,LPT-1\n"
+
+	t.Run("dry run csv upload", func(t *testing.T) {
+		mockService.EXPECT().
+			Import(gomock.Any(), web.ProductImportRequest{
+				DryRun:  true,
+				Mapping: map[string]string{"name": "Product Name"},
+				Rows:    [][]string{{"Product Name", "price", "stock_qty", "sku"}, {"Laptop", "1000", "5", "LPT-1"}},
+			}).
+			Return(web.ProductImportResponse{DryRun: true, TotalRows: 1, Inserts: 1}, nil)
+
+		req := newImportRequest(t, "/api/products/import?dry_run=true", "products.csv", csv, `{"name":"Product Name"}`)
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("row errors", func(t *testing.T) {
+		mockService.EXPECT().Import(gomock.Any(), gomock.Any()).
+			Return(web.ProductImportResponse{TotalRows: 1, Errors: []web.ProductImportRowError{{Row: 2, Message: "sku is required"}}}, nil)
+
+		req := newImportRequest(t, "/api/products/import", "products.csv", csv, "")
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("unsupported file type", func(t *testing.T) {
+		req := newImportRequest(t, "/api/products/import", "products.txt", csv, "")
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
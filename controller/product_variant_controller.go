@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProductVariantController interface {
+	SetOptions(c *fiber.Ctx) error
+	GenerateVariants(c *fiber.Ctx) error
+	FindAll(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type ProductVariantControllerImpl struct {
+	ProductVariantService service.ProductVariantService
+}
+
+func NewProductVariantController(productVariantService service.ProductVariantService) ProductVariantController {
+	return &ProductVariantControllerImpl{
+		ProductVariantService: productVariantService,
+	}
+}
+
+// Set the Options of a Product
+func (controller *ProductVariantControllerImpl) SetOptions(c *fiber.Ctx) error {
+	optionsRequest := new(web.ProductOptionsRequest)
+	if err := c.BodyParser(optionsRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	optionsRequest.ProductID = c.Params("productId")
+
+	optionResponses, err := controller.ProductVariantService.SetOptions(c.Context(), *optionsRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   optionResponses,
+	})
+}
+
+// Generate the missing Variants of a Product
+func (controller *ProductVariantControllerImpl) GenerateVariants(c *fiber.Ctx) error {
+	variantResponses, err := controller.ProductVariantService.GenerateVariants(c.Context(), c.Params("productId"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   variantResponses,
+	})
+}
+
+// Find All Variants of a Product
+func (controller *ProductVariantControllerImpl) FindAll(c *fiber.Ctx) error {
+	variantResponses, err := controller.ProductVariantService.FindAll(c.Context(), c.Params("productId"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   variantResponses,
+	})
+}
+
+// Update Variant
+func (controller *ProductVariantControllerImpl) Update(c *fiber.Ctx) error {
+	variantUpdateRequest := new(web.ProductVariantUpdateRequest)
+	if err := c.BodyParser(variantUpdateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("variantId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Variant ID",
+			Data:   err.Error(),
+		})
+	}
+	variantUpdateRequest.ProductID = c.Params("productId")
+	variantUpdateRequest.VariantID = id
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		variantUpdateRequest.Version = version
+	}
+
+	variantResponse, err := controller.ProductVariantService.Update(c.Context(), *variantUpdateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, variantResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   variantResponse,
+	})
+}
+
+// Delete Variant
+func (controller *ProductVariantControllerImpl) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("variantId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Variant ID",
+			Data:   err.Error(),
+		})
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	if err := controller.ProductVariantService.Delete(c.Context(), c.Params("productId"), id, version); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "Deleted Successfully",
+	})
+}
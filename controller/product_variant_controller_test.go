@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"bytes"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProductVariantController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductVariantService(ctrl)
+	variantController := NewProductVariantController(mockService)
+	app := fiber.New()
+	app.Put("/api/products/:productId/options", variantController.SetOptions)
+	app.Post("/api/products/:productId/variants/generate", variantController.GenerateVariants)
+	app.Put("/api/products/:productId/variants/:variantId", variantController.Update)
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		header map[string]string
+		mock   func()
+		status int
+	}{
+		{
+			name:   "set options",
+			method: "PUT",
+			url:    "/api/products/1/options",
+			body:   `{"options":[{"name":"Size","values":["S","M"]}]}`,
+			mock: func() {
+				mockService.EXPECT().
+					SetOptions(gomock.Any(), web.ProductOptionsRequest{ProductID: "1", Options: []web.ProductOptionRequest{{Name: "Size", Values: []string{"S", "M"}}}}).
+					Return([]web.ProductOptionResponse{{Name: "Size", Values: []string{"S", "M"}}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "generate without options",
+			method: "POST",
+			url:    "/api/products/1/variants/generate",
+			mock: func() {
+				mockService.EXPECT().GenerateVariants(gomock.Any(), "1").Return(nil, exception.NewBadRequestError("product has no options"))
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "update with if-match",
+			method: "PUT",
+			url:    "/api/products/1/variants/7",
+			body:   `{"sku":"TEE-S"}`,
+			header: map[string]string{"If-Match": `"3"`},
+			mock: func() {
+				mockService.EXPECT().
+					Update(gomock.Any(), web.ProductVariantUpdateRequest{ProductID: "1", VariantID: 7, SKU: "TEE-S", Version: 3}).
+					Return(web.ProductVariantResponse{}, exception.NewConflictError("Variant has been modified"))
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "update invalid variant id",
+			method: "PUT",
+			url:    "/api/products/1/variants/abc",
+			body:   `{}`,
+			mock:   func() {},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			resp, _ := app.Test(req)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
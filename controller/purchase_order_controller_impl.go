@@ -0,0 +1,262 @@
+package controller
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type PurchaseOrderControllerImpl struct {
+	PurchaseOrderService service.PurchaseOrderService
+}
+
+func NewPurchaseOrderController(purchaseOrderService service.PurchaseOrderService) PurchaseOrderController {
+	return &PurchaseOrderControllerImpl{
+		PurchaseOrderService: purchaseOrderService,
+	}
+}
+
+// Create a draft Purchase Order
+func (controller *PurchaseOrderControllerImpl) Create(c *fiber.Ctx) error {
+	purchaseOrderCreateRequest := new(web.PurchaseOrderCreateRequest)
+	if err := c.BodyParser(purchaseOrderCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	purchaseOrderResponse, err := controller.PurchaseOrderService.Create(c.Context(), *purchaseOrderCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, purchaseOrderResponse.Version)
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   purchaseOrderResponse,
+	})
+}
+
+// Update a draft Purchase Order
+func (controller *PurchaseOrderControllerImpl) Update(c *fiber.Ctx) error {
+	purchaseOrderUpdateRequest := new(web.PurchaseOrderUpdateRequest)
+	if err := c.BodyParser(purchaseOrderUpdateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("purchaseOrderId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Purchase Order ID",
+			Data:   err.Error(),
+		})
+	}
+	purchaseOrderUpdateRequest.Id = id
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		purchaseOrderUpdateRequest.Version = version
+	}
+
+	purchaseOrderResponse, err := controller.PurchaseOrderService.Update(c.Context(), *purchaseOrderUpdateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, purchaseOrderResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   purchaseOrderResponse,
+	})
+}
+
+// Delete a draft Purchase Order
+func (controller *PurchaseOrderControllerImpl) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("purchaseOrderId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Purchase Order ID",
+			Data:   err.Error(),
+		})
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	if err := controller.PurchaseOrderService.Delete(c.Context(), id, version); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "Deleted Successfully",
+	})
+}
+
+// Send a draft Purchase Order to its supplier
+func (controller *PurchaseOrderControllerImpl) Send(c *fiber.Ctx) error {
+	return controller.changeStatus(c, controller.PurchaseOrderService.Send)
+}
+
+// Receive goods delivered for a Purchase Order
+func (controller *PurchaseOrderControllerImpl) Receive(c *fiber.Ctx) error {
+	goodsReceiptRequest := new(web.GoodsReceiptRequest)
+	if err := c.BodyParser(goodsReceiptRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("purchaseOrderId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Purchase Order ID",
+			Data:   err.Error(),
+		})
+	}
+	goodsReceiptRequest.PurchaseOrderID = id
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		goodsReceiptRequest.Version = version
+	}
+
+	purchaseOrderResponse, err := controller.PurchaseOrderService.Receive(c.Context(), *goodsReceiptRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, purchaseOrderResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   purchaseOrderResponse,
+	})
+}
+
+// Close a Purchase Order nothing more is expected for
+func (controller *PurchaseOrderControllerImpl) Close(c *fiber.Ctx) error {
+	return controller.changeStatus(c, controller.PurchaseOrderService.Close)
+}
+
+// Find Purchase Order by ID
+func (controller *PurchaseOrderControllerImpl) FindById(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("purchaseOrderId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Purchase Order ID",
+			Data:   err.Error(),
+		})
+	}
+
+	purchaseOrderResponse, err := controller.PurchaseOrderService.FindById(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, purchaseOrderResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   purchaseOrderResponse,
+	})
+}
+
+// Find the Purchase Orders of the store
+func (controller *PurchaseOrderControllerImpl) FindAll(c *fiber.Ctx) error {
+	purchaseOrderResponses, err := controller.PurchaseOrderService.FindAll(c.Context(), web.PurchaseOrderFilterRequest{
+		Status:     c.Query("status"),
+		SupplierID: uint64(c.QueryInt("supplier_id")),
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   purchaseOrderResponses,
+	})
+}
+
+// Generate draft Purchase Orders for the products that run low at the store
+func (controller *PurchaseOrderControllerImpl) Generate(c *fiber.Ctx) error {
+	generateResponse, err := controller.PurchaseOrderService.Generate(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   generateResponse,
+	})
+}
+
+func (controller *PurchaseOrderControllerImpl) changeStatus(c *fiber.Ctx, action func(ctx context.Context, orderId uint64, version uint64) (web.PurchaseOrderResponse, error)) error {
+	id, err := strconv.ParseUint(c.Params("purchaseOrderId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Purchase Order ID",
+			Data:   err.Error(),
+		})
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	purchaseOrderResponse, err := action(c.Context(), id, version)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, purchaseOrderResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   purchaseOrderResponse,
+	})
+}
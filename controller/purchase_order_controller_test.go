@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPurchaseOrderController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPurchaseOrderService(ctrl)
+	purchaseOrderController := NewPurchaseOrderController(mockService)
+	app := fiber.New()
+	purchaseOrders := app.Group("/api/purchase-orders")
+	purchaseOrders.Get("/", purchaseOrderController.FindAll)
+	purchaseOrders.Get("/:purchaseOrderId", purchaseOrderController.FindById)
+	purchaseOrders.Post("/", purchaseOrderController.Create)
+	purchaseOrders.Post("/generate", purchaseOrderController.Generate)
+	purchaseOrders.Put("/:purchaseOrderId", purchaseOrderController.Update)
+	purchaseOrders.Delete("/:purchaseOrderId", purchaseOrderController.Delete)
+	purchaseOrders.Post("/:purchaseOrderId/send", purchaseOrderController.Send)
+	purchaseOrders.Post("/:purchaseOrderId/receive", purchaseOrderController.Receive)
+	purchaseOrders.Post("/:purchaseOrderId/close", purchaseOrderController.Close)
+
+	unitCost := 9.5
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		headers        map[string]string
+		body           string
+		setupMock      func()
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:   "Create purchase order",
+			method: "POST",
+			url:    "/api/purchase-orders",
+			body:   `{"supplier_id":3,"lines":[{"product_id":"p-1","quantity":10,"unit_cost":9}]}`,
+			setupMock: func() {
+				mockService.EXPECT().Create(gomock.Any(), web.PurchaseOrderCreateRequest{
+					SupplierID: 3,
+					Lines:      []web.PurchaseOrderLineRequest{{ProductID: "p-1", Quantity: 10, UnitCost: 9}},
+				}).Return(web.PurchaseOrderResponse{Id: 1, SupplierID: 3, Status: "draft", Version: 1}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:           "Create purchase order - malformed body",
+			method:         "POST",
+			url:            "/api/purchase-orders",
+			body:           `{"supplier_id":`,
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Update purchase order - If-Match is honoured",
+			method:  "PUT",
+			url:     "/api/purchase-orders/1",
+			headers: map[string]string{"If-Match": `"2"`},
+			body:    `{"supplier_id":3,"note":"urgent","lines":[{"product_id":"p-1","quantity":12,"unit_cost":9}]}`,
+			setupMock: func() {
+				mockService.EXPECT().Update(gomock.Any(), web.PurchaseOrderUpdateRequest{
+					Id:         1,
+					SupplierID: 3,
+					Note:       "urgent",
+					Lines:      []web.PurchaseOrderLineRequest{{ProductID: "p-1", Quantity: 12, UnitCost: 9}},
+					Version:    2,
+				}).Return(web.PurchaseOrderResponse{Id: 1, Status: "draft", Version: 3}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:   "Update purchase order - no longer a draft",
+			method: "PUT",
+			url:    "/api/purchase-orders/1",
+			body:   `{"supplier_id":3,"lines":[{"product_id":"p-1","quantity":12}]}`,
+			setupMock: func() {
+				mockService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(web.PurchaseOrderResponse{}, exception.NewConflictError("Purchase order is already sent"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:    "Delete purchase order - version passed to service",
+			method:  "DELETE",
+			url:     "/api/purchase-orders/1",
+			headers: map[string]string{"If-Match": `"2"`},
+			setupMock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), uint64(1), uint64(2)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Delete purchase order - invalid id",
+			method:         "DELETE",
+			url:            "/api/purchase-orders/abc",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Send purchase order",
+			method:  "POST",
+			url:     "/api/purchase-orders/1/send",
+			headers: map[string]string{"If-Match": `"3"`},
+			setupMock: func() {
+				mockService.EXPECT().Send(gomock.Any(), uint64(1), uint64(3)).Return(web.PurchaseOrderResponse{Id: 1, Status: "sent", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name:           "Send purchase order - invalid If-Match",
+			method:         "POST",
+			url:            "/api/purchase-orders/1/send",
+			headers:        map[string]string{"If-Match": "abc"},
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Receive goods",
+			method:  "POST",
+			url:     "/api/purchase-orders/1/receive",
+			headers: map[string]string{"If-Match": `"4"`},
+			body:    `{"reference":"DO-7","lines":[{"line_id":5,"quantity":4,"unit_cost":9.5}]}`,
+			setupMock: func() {
+				mockService.EXPECT().Receive(gomock.Any(), web.GoodsReceiptRequest{
+					PurchaseOrderID: 1,
+					Reference:       "DO-7",
+					Lines:           []web.GoodsReceiptLineRequest{{LineID: 5, Quantity: 4, UnitCost: &unitCost}},
+					Version:         4,
+				}).Return(web.PurchaseOrderResponse{Id: 1, Status: "partially_received", Version: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"5"`,
+		},
+		{
+			name:   "Receive goods - more than ordered",
+			method: "POST",
+			url:    "/api/purchase-orders/1/receive",
+			body:   `{"lines":[{"line_id":5,"quantity":40}]}`,
+			setupMock: func() {
+				mockService.EXPECT().Receive(gomock.Any(), gomock.Any()).Return(web.PurchaseOrderResponse{}, exception.NewBadRequestError("line 5 expects 6 more, not 40"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Close purchase order",
+			method: "POST",
+			url:    "/api/purchase-orders/1/close",
+			setupMock: func() {
+				mockService.EXPECT().Close(gomock.Any(), uint64(1), uint64(0)).Return(web.PurchaseOrderResponse{Id: 1, Status: "closed", Version: 6}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"6"`,
+		},
+		{
+			name:   "Find purchase order by ID - not found",
+			method: "GET",
+			url:    "/api/purchase-orders/9",
+			setupMock: func() {
+				mockService.EXPECT().FindById(gomock.Any(), uint64(9)).Return(web.PurchaseOrderResponse{}, exception.NewNotFoundError("Purchase order not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Find purchase orders - filters passed to service",
+			method: "GET",
+			url:    "/api/purchase-orders?status=sent&supplier_id=3",
+			setupMock: func() {
+				mockService.EXPECT().FindAll(gomock.Any(), web.PurchaseOrderFilterRequest{Status: "sent", SupplierID: 3}).
+					Return([]web.PurchaseOrderResponse{{Id: 1, Status: "sent"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Generate purchase orders",
+			method: "POST",
+			url:    "/api/purchase-orders/generate",
+			setupMock: func() {
+				mockService.EXPECT().Generate(gomock.Any()).Return(web.PurchaseOrderGenerateResponse{
+					PurchaseOrders:  []web.PurchaseOrderResponse{{Id: 2, SupplierID: 3, Status: "draft"}},
+					WithoutSupplier: []string{"p-9"},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			resp, _ := app.Test(req)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedETag != "" {
+				assert.Equal(t, tt.expectedETag, resp.Header.Get("ETag"))
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReportController interface {
+	Sales(c *fiber.Ctx) error
+	TopProducts(c *fiber.Ctx) error
+	Categories(c *fiber.Ctx) error
+	PaymentTypes(c *fiber.Ctx) error
+	Taxes(c *fiber.Ctx) error
+	Discounts(c *fiber.Ctx) error
+	Margins(c *fiber.Ctx) error
+	InventoryValuation(c *fiber.Ctx) error
+}
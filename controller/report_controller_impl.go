@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReportControllerImpl struct {
+	ReportService service.ReportService
+}
+
+func NewReportController(reportService service.ReportService) ReportController {
+	return &ReportControllerImpl{
+		ReportService: reportService,
+	}
+}
+
+// Sales report by day, hour, store or employee
+func (controller *ReportControllerImpl) Sales(c *fiber.Ctx) error {
+	return controller.report(c, controller.ReportService.Sales, web.SalesReportRow{})
+}
+
+// TopProducts report by revenue or quantity
+func (controller *ReportControllerImpl) TopProducts(c *fiber.Ctx) error {
+	return controller.report(c, controller.ReportService.TopProducts, web.ProductReportRow{})
+}
+
+// Categories report
+func (controller *ReportControllerImpl) Categories(c *fiber.Ctx) error {
+	return controller.report(c, controller.ReportService.Categories, web.CategoryReportRow{})
+}
+
+// PaymentTypes report
+func (controller *ReportControllerImpl) PaymentTypes(c *fiber.Ctx) error {
+	return controller.report(c, controller.ReportService.PaymentTypes, web.PaymentTypeReportRow{})
+}
+
+// Taxes report
+func (controller *ReportControllerImpl) Taxes(c *fiber.Ctx) error {
+	return controller.report(c, controller.ReportService.Taxes, web.TaxReportRow{})
+}
+
+// Discounts report
+func (controller *ReportControllerImpl) Discounts(c *fiber.Ctx) error {
+	return controller.report(c, controller.ReportService.Discounts, web.DiscountReportRow{})
+}
+
+// Margins report by product or category
+func (controller *ReportControllerImpl) Margins(c *fiber.Ctx) error {
+	return controller.report(c, controller.ReportService.Margins, web.MarginReportRow{})
+}
+
+// InventoryValuation report at a moment
+func (controller *ReportControllerImpl) InventoryValuation(c *fiber.Ctx) error {
+	return controller.report(c, controller.ReportService.InventoryValuation, web.InventoryValuationRow{})
+}
+
+// report runs a report for the query parameters. With a format query parameter the rows,
+// which are shaped like row, are sent as a file download, otherwise the report is sent as
+// JSON.
+func (controller *ReportControllerImpl) report(c *fiber.Ctx, run func(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error), row interface{}) error {
+	reportResponse, err := run(c.Context(), web.ReportRequest{
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+		At:       c.Query("at"),
+		TimeZone: c.Query("tz"),
+		GroupBy:  c.Query("group_by"),
+		By:       c.Query("by"),
+		Limit:    c.QueryInt("limit"),
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	if c.Query("format") != "" {
+		return streamExport(c, reportResponse.Report+"-report", row, func(ctx context.Context, write func(record interface{}) error) error {
+			for _, reportRow := range reportResponse.Rows {
+				if err := write(reportRow); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   reportResponse,
+	})
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReportController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReportService(ctrl)
+	reportController := NewReportController(mockService)
+	app := fiber.New()
+	app.Get("/api/reports/sales", reportController.Sales)
+	app.Get("/api/reports/top-products", reportController.TopProducts)
+
+	t.Run("sales as json", func(t *testing.T) {
+		mockService.EXPECT().Sales(gomock.Any(), web.ReportRequest{From: "2024-03-01", To: "2024-03-02", GroupBy: "store"}).
+			Return(web.ReportResponse{Report: "sales", GroupBy: "store", Rows: []interface{}{web.SalesReportRow{Key: "1", Label: "Main", OrderCount: 2, Revenue: 30, AverageOrder: 15}}}, nil)
+
+		req := httptest.NewRequest("GET", "/api/reports/sales?from=2024-03-01&to=2024-03-02&group_by=store", nil)
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), `"average_order":15`)
+	})
+
+	t.Run("top products as csv", func(t *testing.T) {
+		mockService.EXPECT().TopProducts(gomock.Any(), web.ReportRequest{By: "quantity", Limit: 5}).
+			Return(web.ReportResponse{Report: "top-products", Rows: []interface{}{web.ProductReportRow{ProductID: "p-1", ProductName: "Coffee", Quantity: 3, Revenue: 30}}}, nil)
+
+		req := httptest.NewRequest("GET", "/api/reports/top-products?by=quantity&limit=5&format=csv", nil)
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), "top-products-report-")
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "product_id,product_name,quantity,revenue\np-1,Coffee,3,30\n", string(body))
+	})
+
+	t.Run("unknown time zone", func(t *testing.T) {
+		mockService.EXPECT().Sales(gomock.Any(), web.ReportRequest{TimeZone: "Mars/Olympus"}).
+			Return(web.ReportResponse{}, exception.NewBadRequestError(`unknown time zone "Mars/Olympus"`))
+
+		req := httptest.NewRequest("GET", "/api/reports/sales?tz=Mars/Olympus", nil)
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type ShiftControllerImpl struct {
+	ShiftService service.ShiftService
+}
+
+func NewShiftController(shiftService service.ShiftService) ShiftController {
+	return &ShiftControllerImpl{
+		ShiftService: shiftService,
+	}
+}
+
+// Open a Shift for the cashier in X-Employee-Id
+func (controller *ShiftControllerImpl) Open(c *fiber.Ctx) error {
+	shiftOpenRequest := new(web.ShiftOpenRequest)
+	if err := c.BodyParser(shiftOpenRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	shiftResponse, err := controller.ShiftService.Open(c.Context(), *shiftOpenRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, shiftResponse.Version)
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   shiftResponse,
+	})
+}
+
+// Close a Shift with the counted cash
+func (controller *ShiftControllerImpl) Close(c *fiber.Ctx) error {
+	shiftCloseRequest := new(web.ShiftCloseRequest)
+	if err := c.BodyParser(shiftCloseRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("shiftId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Shift ID",
+			Data:   err.Error(),
+		})
+	}
+	shiftCloseRequest.ShiftID = id
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		shiftCloseRequest.Version = version
+	}
+
+	shiftResponse, err := controller.ShiftService.Close(c.Context(), *shiftCloseRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, shiftResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   shiftResponse,
+	})
+}
+
+// Record a cash-in or cash-out on a Shift
+func (controller *ShiftControllerImpl) RecordCashMovement(c *fiber.Ctx) error {
+	cashMovementRequest := new(web.CashMovementRequest)
+	if err := c.BodyParser(cashMovementRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("shiftId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Shift ID",
+			Data:   err.Error(),
+		})
+	}
+	cashMovementRequest.ShiftID = id
+
+	shiftResponse, err := controller.ShiftService.RecordCashMovement(c.Context(), *cashMovementRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   shiftResponse,
+	})
+}
+
+// Find the open Shift of the cashier in X-Employee-Id
+func (controller *ShiftControllerImpl) FindCurrent(c *fiber.Ctx) error {
+	shiftResponse, err := controller.ShiftService.FindCurrent(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, shiftResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   shiftResponse,
+	})
+}
+
+// Find Shift by ID
+func (controller *ShiftControllerImpl) FindById(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("shiftId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Shift ID",
+			Data:   err.Error(),
+		})
+	}
+
+	shiftResponse, err := controller.ShiftService.FindById(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, shiftResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   shiftResponse,
+	})
+}
+
+// Find All Shifts, optionally filtered with ?employee_id=&status=
+func (controller *ShiftControllerImpl) FindAll(c *fiber.Ctx) error {
+	shiftResponses, err := controller.ShiftService.FindAll(c.Context(), web.ShiftFilterRequest{
+		EmployeeID: c.Query("employee_id"),
+		Status:     c.Query("status"),
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   shiftResponses,
+	})
+}
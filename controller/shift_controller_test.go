@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestShiftController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockShiftService(ctrl)
+	shiftController := NewShiftController(mockService)
+	app := fiber.New()
+	app.Post("/api/shifts/open", shiftController.Open)
+	app.Post("/api/shifts/:shiftId/close", shiftController.Close)
+
+	t.Run("open without an employee", func(t *testing.T) {
+		mockService.EXPECT().Open(gomock.Any(), web.ShiftOpenRequest{OpeningFloat: 200}).Return(web.ShiftResponse{}, exception.NewForbiddenError("X-Employee-Id header is required"))
+
+		req := httptest.NewRequest("POST", "/api/shifts/open", strings.NewReader(`{"opening_float":200}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("close with If-Match", func(t *testing.T) {
+		variance := -5.5
+		mockService.EXPECT().Close(gomock.Any(), web.ShiftCloseRequest{ShiftID: 4, CountedCash: 455, Version: 3}).
+			Return(web.ShiftResponse{Id: 4, Status: "closed", Variance: &variance, Version: 4}, nil)
+
+		req := httptest.NewRequest("POST", "/api/shifts/4/close", strings.NewReader(`{"counted_cash":455}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"3"`)
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `"4"`, resp.Header.Get("ETag"))
+	})
+}
@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type StockController interface {
+	FindLevels(c *fiber.Ctx) error
+	SetLevel(c *fiber.Ctx) error
+	Restock(c *fiber.Ctx) error
+	FindMovements(c *fiber.Ctx) error
+	SetRestockLevel(c *fiber.Ctx) error
+	FindInventories(c *fiber.Ctx) error
+	CreateTransfer(c *fiber.Ctx) error
+	ReceiveTransfer(c *fiber.Ctx) error
+	CancelTransfer(c *fiber.Ctx) error
+	FindTransferById(c *fiber.Ctx) error
+	FindTransfers(c *fiber.Ctx) error
+}
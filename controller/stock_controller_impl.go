@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type StockControllerImpl struct {
+	StockService service.StockService
+}
+
+func NewStockController(stockService service.StockService) StockController {
+	return &StockControllerImpl{
+		StockService: stockService,
+	}
+}
+
+// Find the stock levels of the store
+func (controller *StockControllerImpl) FindLevels(c *fiber.Ctx) error {
+	stockResponses, err := controller.StockService.FindLevels(c.Context(), c.Query("product_id"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   stockResponses,
+	})
+}
+
+// Set the stock level of a Product at the store
+func (controller *StockControllerImpl) SetLevel(c *fiber.Ctx) error {
+	stockSetRequest := new(web.StoreStockSetRequest)
+	if err := c.BodyParser(stockSetRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	stockSetRequest.ProductID = c.Params("productId")
+
+	stockResponse, err := controller.StockService.SetLevel(c.Context(), *stockSetRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   stockResponse,
+	})
+}
+
+// Restock books a delivery of a product into the store at cost
+func (controller *StockControllerImpl) Restock(c *fiber.Ctx) error {
+	restockRequest := new(web.StockRestockRequest)
+	if err := c.BodyParser(restockRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	restockRequest.ProductID = c.Params("productId")
+
+	movementResponse, err := controller.StockService.Restock(c.Context(), *restockRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   movementResponse,
+	})
+}
+
+// FindMovements returns the inventory ledger of the store
+func (controller *StockControllerImpl) FindMovements(c *fiber.Ctx) error {
+	movementResponses, err := controller.StockService.FindMovements(c.Context(), web.InventoryMovementFilterRequest{
+		ProductID: c.Query("product_id"),
+		Limit:     c.QueryInt("limit"),
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   movementResponses,
+	})
+}
+
+// SetRestockLevel sets when a product runs low at the store and how much to reorder then
+func (controller *StockControllerImpl) SetRestockLevel(c *fiber.Ctx) error {
+	restockLevelRequest := new(web.RestockLevelRequest)
+	if err := c.BodyParser(restockLevelRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	restockLevelRequest.ProductID = c.Params("productId")
+
+	inventoryResponse, err := controller.StockService.SetRestockLevel(c.Context(), *restockLevelRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   inventoryResponse,
+	})
+}
+
+// FindInventories returns the restock levels of the store, only the low ones with ?low=true
+func (controller *StockControllerImpl) FindInventories(c *fiber.Ctx) error {
+	inventoryResponses, err := controller.StockService.FindInventories(c.Context(), c.QueryBool("low"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   inventoryResponses,
+	})
+}
+
+// Ship stock from the store to another store
+func (controller *StockControllerImpl) CreateTransfer(c *fiber.Ctx) error {
+	transferCreateRequest := new(web.StockTransferCreateRequest)
+	if err := c.BodyParser(transferCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	transferResponse, err := controller.StockService.CreateTransfer(c.Context(), *transferCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, transferResponse.Version)
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   transferResponse,
+	})
+}
+
+// Receive a stock transfer at the destination store
+func (controller *StockControllerImpl) ReceiveTransfer(c *fiber.Ctx) error {
+	return controller.closeTransfer(c, controller.StockService.ReceiveTransfer)
+}
+
+// Cancel a stock transfer that is still in transit
+func (controller *StockControllerImpl) CancelTransfer(c *fiber.Ctx) error {
+	return controller.closeTransfer(c, controller.StockService.CancelTransfer)
+}
+
+// Find a stock transfer by ID
+func (controller *StockControllerImpl) FindTransferById(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("transferId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Transfer ID",
+			Data:   err.Error(),
+		})
+	}
+
+	transferResponse, err := controller.StockService.FindTransferById(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, transferResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   transferResponse,
+	})
+}
+
+// Find the stock transfers from or to the store
+func (controller *StockControllerImpl) FindTransfers(c *fiber.Ctx) error {
+	transferResponses, err := controller.StockService.FindTransfers(c.Context(), web.StockTransferFilterRequest{
+		Status: c.Query("status"),
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   transferResponses,
+	})
+}
+
+func (controller *StockControllerImpl) closeTransfer(c *fiber.Ctx, action func(ctx context.Context, transferId uint64, version uint64) (web.StockTransferResponse, error)) error {
+	id, err := strconv.ParseUint(c.Params("transferId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Transfer ID",
+			Data:   err.Error(),
+		})
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	transferResponse, err := action(c.Context(), id, version)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, transferResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   transferResponse,
+	})
+}
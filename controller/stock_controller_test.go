@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStockController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStockService(ctrl)
+	stockController := NewStockController(mockService)
+	app := fiber.New()
+	app.Put("/api/stock/:productId", stockController.SetLevel)
+	app.Post("/api/stock-transfers/:transferId/receive", stockController.ReceiveTransfer)
+
+	t.Run("set level without a store", func(t *testing.T) {
+		mockService.EXPECT().SetLevel(gomock.Any(), web.StoreStockSetRequest{ProductID: "p-1", Quantity: 4}).Return(web.StoreStockResponse{}, repository.ErrStoreRequired)
+
+		req := httptest.NewRequest("PUT", "/api/stock/p-1", strings.NewReader(`{"quantity":4}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("receive with If-Match", func(t *testing.T) {
+		mockService.EXPECT().ReceiveTransfer(gomock.Any(), uint64(9), uint64(1)).Return(web.StockTransferResponse{Id: 9, Status: "received", Version: 2}, nil)
+
+		req := httptest.NewRequest("POST", "/api/stock-transfers/9/receive", nil)
+		req.Header.Set("If-Match", `"1"`)
+		resp, _ := app.Test(req)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+	})
+}
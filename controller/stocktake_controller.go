@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type StocktakeController interface {
+	Create(c *fiber.Ctx) error
+	Count(c *fiber.Ctx) error
+	Upload(c *fiber.Ctx) error
+	Approve(c *fiber.Ctx) error
+	Cancel(c *fiber.Ctx) error
+	FindById(c *fiber.Ctx) error
+	FindAll(c *fiber.Ctx) error
+	Variances(c *fiber.Ctx) error
+}
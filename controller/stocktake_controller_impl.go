@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type StocktakeControllerImpl struct {
+	StocktakeService service.StocktakeService
+}
+
+func NewStocktakeController(stocktakeService service.StocktakeService) StocktakeController {
+	return &StocktakeControllerImpl{
+		StocktakeService: stocktakeService,
+	}
+}
+
+// Start a Stocktake at the store
+func (controller *StocktakeControllerImpl) Create(c *fiber.Ctx) error {
+	stocktakeCreateRequest := new(web.StocktakeCreateRequest)
+	if err := c.BodyParser(stocktakeCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	stocktakeResponse, err := controller.StocktakeService.Create(c.Context(), *stocktakeCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, stocktakeResponse.Version)
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   stocktakeResponse,
+	})
+}
+
+// Record counted quantities on a Stocktake
+func (controller *StocktakeControllerImpl) Count(c *fiber.Ctx) error {
+	countRequest := new(web.StocktakeCountRequest)
+	if err := c.BodyParser(countRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, version, err := stocktakeIdAndVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	countRequest.StocktakeID = id
+	if version != 0 {
+		countRequest.Version = version
+	}
+
+	stocktakeResponse, err := controller.StocktakeService.Count(c.Context(), *countRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, stocktakeResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   stocktakeResponse,
+	})
+}
+
+// Record the scans of a scanner export, a CSV or XLSX upload, on a Stocktake
+func (controller *StocktakeControllerImpl) Upload(c *fiber.Ctx) error {
+	id, version, err := stocktakeIdAndVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	defer file.Close()
+
+	uploadRequest := web.StocktakeUploadRequest{StocktakeID: id, Version: version}
+	if uploadRequest.Rows, err = helper.ReadSpreadsheet(fileHeader.Filename, file); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid File",
+			Data:   err.Error(),
+		})
+	}
+
+	stocktakeResponse, err := controller.StocktakeService.Upload(c.Context(), uploadRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, stocktakeResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   stocktakeResponse,
+	})
+}
+
+// Approve a Stocktake and post its variances
+func (controller *StocktakeControllerImpl) Approve(c *fiber.Ctx) error {
+	approveRequest := new(web.StocktakeApproveRequest)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(approveRequest); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Bad Request",
+				Data:   err.Error(),
+			})
+		}
+	}
+
+	id, version, err := stocktakeIdAndVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+	approveRequest.StocktakeID = id
+	if version != 0 {
+		approveRequest.Version = version
+	}
+
+	stocktakeResponse, err := controller.StocktakeService.Approve(c.Context(), *approveRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, stocktakeResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   stocktakeResponse,
+	})
+}
+
+// Cancel a Stocktake that is still being counted
+func (controller *StocktakeControllerImpl) Cancel(c *fiber.Ctx) error {
+	id, version, err := stocktakeIdAndVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	stocktakeResponse, err := controller.StocktakeService.Cancel(c.Context(), id, version)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, stocktakeResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   stocktakeResponse,
+	})
+}
+
+// Find Stocktake by ID
+func (controller *StocktakeControllerImpl) FindById(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("stocktakeId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Stocktake ID",
+			Data:   err.Error(),
+		})
+	}
+
+	stocktakeResponse, err := controller.StocktakeService.FindById(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, stocktakeResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   stocktakeResponse,
+	})
+}
+
+// Find the Stocktakes of the store
+func (controller *StocktakeControllerImpl) FindAll(c *fiber.Ctx) error {
+	stocktakeResponses, err := controller.StocktakeService.FindAll(c.Context(), web.StocktakeFilterRequest{
+		Status: c.Query("status"),
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   stocktakeResponses,
+	})
+}
+
+// Variances reports what was counted differently than expected on a Stocktake, as a file
+// download when a format is given
+func (controller *StocktakeControllerImpl) Variances(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("stocktakeId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Stocktake ID",
+			Data:   err.Error(),
+		})
+	}
+
+	varianceResponse, err := controller.StocktakeService.Variances(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	if c.Query("format") != "" {
+		return streamExport(c, "stocktake-"+strconv.FormatUint(id, 10)+"-variances", web.StocktakeVarianceRow{}, func(ctx context.Context, write func(record interface{}) error) error {
+			for _, row := range varianceResponse.Rows {
+				if err := write(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   varianceResponse,
+	})
+}
+
+// stocktakeIdAndVersion reads the stocktake ID from the path and the version it was read
+// at from the If-Match header, 0 when there is none.
+func stocktakeIdAndVersion(c *fiber.Ctx) (uint64, uint64, error) {
+	id, err := strconv.ParseUint(c.Params("stocktakeId"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	return id, version, nil
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStoreController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStoreService(ctrl)
+	storeController := NewStoreController(mockService)
+	app := fiber.New()
+	stores := app.Group("/api/stores")
+	stores.Get("/", storeController.FindAll)
+	stores.Get("/:storeId", storeController.FindById)
+	stores.Post("/", storeController.Create)
+	stores.Put("/:storeId", storeController.Update)
+	stores.Delete("/:storeId", storeController.Delete)
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		headers        map[string]string
+		body           string
+		setupMock      func()
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:   "Create store",
+			method: "POST",
+			url:    "/api/stores",
+			body:   `{"code":"JKT","name":"Jakarta"}`,
+			setupMock: func() {
+				mockService.EXPECT().Create(gomock.Any(), web.StoreCreateRequest{Code: "JKT", Name: "Jakarta"}).
+					Return(web.StoreResponse{Id: 1, Code: "JKT", Name: "Jakarta", Version: 1}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:           "Create store - malformed body",
+			method:         "POST",
+			url:            "/api/stores",
+			body:           `{"code":`,
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Update store - If-Match is honoured",
+			method:  "PUT",
+			url:     "/api/stores/1",
+			headers: map[string]string{"If-Match": `"2"`},
+			body:    `{"code":"JKT","name":"Jakarta Pusat"}`,
+			setupMock: func() {
+				mockService.EXPECT().Update(gomock.Any(), web.StoreUpdateRequest{Id: 1, Code: "JKT", Name: "Jakarta Pusat", Version: 2}).
+					Return(web.StoreResponse{Id: 1, Code: "JKT", Name: "Jakarta Pusat", Version: 3}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:           "Update store - invalid id",
+			method:         "PUT",
+			url:            "/api/stores/abc",
+			body:           `{"code":"JKT","name":"Jakarta"}`,
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Update store - modified meanwhile",
+			method:  "PUT",
+			url:     "/api/stores/1",
+			headers: map[string]string{"If-Match": `"1"`},
+			body:    `{"code":"JKT","name":"Jakarta Pusat"}`,
+			setupMock: func() {
+				mockService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(web.StoreResponse{}, exception.NewConflictError("Store has been modified"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:    "Delete store - version passed to service",
+			method:  "DELETE",
+			url:     "/api/stores/1",
+			headers: map[string]string{"If-Match": `"4"`},
+			setupMock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), uint64(1), uint64(4)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Delete store - still in use",
+			method: "DELETE",
+			url:    "/api/stores/1",
+			setupMock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), uint64(1), uint64(0)).
+					Return(exception.NewConflictError("Store still has employees, shifts, orders, stock or transfers in transit"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Delete store - invalid If-Match",
+			method:         "DELETE",
+			url:            "/api/stores/1",
+			headers:        map[string]string{"If-Match": "abc"},
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Find store by ID - exposes ETag",
+			method: "GET",
+			url:    "/api/stores/1",
+			setupMock: func() {
+				mockService.EXPECT().FindById(gomock.Any(), uint64(1)).Return(web.StoreResponse{Id: 1, Code: "JKT", Version: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"5"`,
+		},
+		{
+			name:   "Find store by ID - not found",
+			method: "GET",
+			url:    "/api/stores/9",
+			setupMock: func() {
+				mockService.EXPECT().FindById(gomock.Any(), uint64(9)).Return(web.StoreResponse{}, exception.NewNotFoundError("Store not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Find all stores",
+			method: "GET",
+			url:    "/api/stores",
+			setupMock: func() {
+				mockService.EXPECT().FindAll(gomock.Any()).Return([]web.StoreResponse{{Id: 1, Code: "JKT"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			resp, _ := app.Test(req)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedETag != "" {
+				assert.Equal(t, tt.expectedETag, resp.Header.Get("ETag"))
+			}
+		})
+	}
+}
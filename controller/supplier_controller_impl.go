@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type SupplierControllerImpl struct {
+	SupplierService service.SupplierService
+}
+
+func NewSupplierController(supplierService service.SupplierService) SupplierController {
+	return &SupplierControllerImpl{
+		SupplierService: supplierService,
+	}
+}
+
+// Create Supplier
+func (controller *SupplierControllerImpl) Create(c *fiber.Ctx) error {
+	supplierCreateRequest := new(web.SupplierCreateRequest)
+	if err := c.BodyParser(supplierCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	supplierResponse, err := controller.SupplierService.Create(c.Context(), *supplierCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, supplierResponse.Version)
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   supplierResponse,
+	})
+}
+
+// Update Supplier
+func (controller *SupplierControllerImpl) Update(c *fiber.Ctx) error {
+	supplierUpdateRequest := new(web.SupplierUpdateRequest)
+	if err := c.BodyParser(supplierUpdateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("supplierId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Supplier ID",
+			Data:   err.Error(),
+		})
+	}
+	supplierUpdateRequest.Id = id
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		supplierUpdateRequest.Version = version
+	}
+
+	supplierResponse, err := controller.SupplierService.Update(c.Context(), *supplierUpdateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, supplierResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   supplierResponse,
+	})
+}
+
+// Delete Supplier
+func (controller *SupplierControllerImpl) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("supplierId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Supplier ID",
+			Data:   err.Error(),
+		})
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	if err := controller.SupplierService.Delete(c.Context(), id, version); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "Deleted Successfully",
+	})
+}
+
+// Find Supplier by ID
+func (controller *SupplierControllerImpl) FindById(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("supplierId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Supplier ID",
+			Data:   err.Error(),
+		})
+	}
+
+	supplierResponse, err := controller.SupplierService.FindById(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, supplierResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   supplierResponse,
+	})
+}
+
+// Find All Suppliers
+func (controller *SupplierControllerImpl) FindAll(c *fiber.Ctx) error {
+	supplierResponses, err := controller.SupplierService.FindAll(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   supplierResponses,
+	})
+}
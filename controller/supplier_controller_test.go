@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSupplierController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSupplierService(ctrl)
+	supplierController := NewSupplierController(mockService)
+	app := fiber.New()
+	suppliers := app.Group("/api/suppliers")
+	suppliers.Get("/", supplierController.FindAll)
+	suppliers.Get("/:supplierId", supplierController.FindById)
+	suppliers.Post("/", supplierController.Create)
+	suppliers.Put("/:supplierId", supplierController.Update)
+	suppliers.Delete("/:supplierId", supplierController.Delete)
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		headers        map[string]string
+		body           string
+		setupMock      func()
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:   "Create supplier",
+			method: "POST",
+			url:    "/api/suppliers",
+			body:   `{"code":"ACME","name":"Acme","email":"siti@acme.id"}`,
+			setupMock: func() {
+				mockService.EXPECT().Create(gomock.Any(), web.SupplierCreateRequest{Code: "ACME", Name: "Acme", Email: "siti@acme.id"}).
+					Return(web.SupplierResponse{Id: 1, Code: "ACME", Name: "Acme", Email: "siti@acme.id", Version: 1}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:           "Create supplier - malformed body",
+			method:         "POST",
+			url:            "/api/suppliers",
+			body:           `{"code":`,
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Update supplier - If-Match is honoured",
+			method:  "PUT",
+			url:     "/api/suppliers/1",
+			headers: map[string]string{"If-Match": `"2"`},
+			body:    `{"code":"ACME","name":"Acme Jaya"}`,
+			setupMock: func() {
+				mockService.EXPECT().Update(gomock.Any(), web.SupplierUpdateRequest{Id: 1, Code: "ACME", Name: "Acme Jaya", Version: 2}).
+					Return(web.SupplierResponse{Id: 1, Code: "ACME", Name: "Acme Jaya", Version: 3}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:           "Update supplier - invalid id",
+			method:         "PUT",
+			url:            "/api/suppliers/abc",
+			body:           `{"code":"ACME","name":"Acme"}`,
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Update supplier - modified meanwhile",
+			method:  "PUT",
+			url:     "/api/suppliers/1",
+			headers: map[string]string{"If-Match": `"1"`},
+			body:    `{"code":"ACME","name":"Acme Jaya"}`,
+			setupMock: func() {
+				mockService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(web.SupplierResponse{}, exception.NewConflictError("Supplier has been modified"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:    "Delete supplier - version passed to service",
+			method:  "DELETE",
+			url:     "/api/suppliers/1",
+			headers: map[string]string{"If-Match": `"4"`},
+			setupMock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), uint64(1), uint64(4)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Delete supplier - still in use",
+			method: "DELETE",
+			url:    "/api/suppliers/1",
+			setupMock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), uint64(1), uint64(0)).
+					Return(exception.NewConflictError("Supplier is still the preferred supplier of products or has purchase orders"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "Find supplier by ID - exposes ETag",
+			method: "GET",
+			url:    "/api/suppliers/1",
+			setupMock: func() {
+				mockService.EXPECT().FindById(gomock.Any(), uint64(1)).Return(web.SupplierResponse{Id: 1, Code: "ACME", Version: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"5"`,
+		},
+		{
+			name:   "Find supplier by ID - not found",
+			method: "GET",
+			url:    "/api/suppliers/9",
+			setupMock: func() {
+				mockService.EXPECT().FindById(gomock.Any(), uint64(9)).Return(web.SupplierResponse{}, exception.NewNotFoundError("Supplier not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Find all suppliers",
+			method: "GET",
+			url:    "/api/suppliers",
+			setupMock: func() {
+				mockService.EXPECT().FindAll(gomock.Any()).Return([]web.SupplierResponse{{Id: 1, Code: "ACME"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			resp, _ := app.Test(req)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedETag != "" {
+				assert.Equal(t, tt.expectedETag, resp.Header.Get("ETag"))
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type SyncControllerImpl struct {
+	SyncService service.SyncService
+}
+
+func NewSyncController(syncService service.SyncService) SyncController {
+	return &SyncControllerImpl{
+		SyncService: syncService,
+	}
+}
+
+// Changes returns the catalogue changes after the cursor in since, from the start when
+// it is left out
+func (controller *SyncControllerImpl) Changes(c *fiber.Ctx) error {
+	var since uint64
+	if value := c.Query("since"); value != "" {
+		var err error
+		if since, err = strconv.ParseUint(value, 10, 64); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Invalid Cursor",
+				Data:   err.Error(),
+			})
+		}
+	}
+
+	changeFeedResponse, err := controller.SyncService.Changes(c.Context(), web.ChangeFeedRequest{
+		Since: since,
+		Limit: c.QueryInt("limit"),
+	})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   changeFeedResponse,
+	})
+}
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/service"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type WebhookControllerImpl struct {
+	WebhookService service.WebhookService
+}
+
+func NewWebhookController(webhookService service.WebhookService) WebhookController {
+	return &WebhookControllerImpl{
+		WebhookService: webhookService,
+	}
+}
+
+// Create Webhook Subscription
+func (controller *WebhookControllerImpl) Create(c *fiber.Ctx) error {
+	subscriptionCreateRequest := new(web.WebhookSubscriptionCreateRequest)
+	if err := c.BodyParser(subscriptionCreateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	subscriptionResponse, err := controller.WebhookService.Create(c.Context(), *subscriptionCreateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, subscriptionResponse.Version)
+	return c.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   subscriptionResponse,
+	})
+}
+
+// Update Webhook Subscription
+func (controller *WebhookControllerImpl) Update(c *fiber.Ctx) error {
+	subscriptionUpdateRequest := new(web.WebhookSubscriptionUpdateRequest)
+	if err := c.BodyParser(subscriptionUpdateRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   err.Error(),
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Params("webhookId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Webhook ID",
+			Data:   err.Error(),
+		})
+	}
+	subscriptionUpdateRequest.Id = id
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+	if version != 0 {
+		subscriptionUpdateRequest.Version = version
+	}
+
+	subscriptionResponse, err := controller.WebhookService.Update(c.Context(), *subscriptionUpdateRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, subscriptionResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   subscriptionResponse,
+	})
+}
+
+// Delete Webhook Subscription
+func (controller *WebhookControllerImpl) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("webhookId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Webhook ID",
+			Data:   err.Error(),
+		})
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid If-Match Header",
+			Data:   err.Error(),
+		})
+	}
+
+	if err := controller.WebhookService.Delete(c.Context(), id, version); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "Deleted Successfully",
+	})
+}
+
+// Find Webhook Subscription by ID
+func (controller *WebhookControllerImpl) FindById(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("webhookId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Webhook ID",
+			Data:   err.Error(),
+		})
+	}
+
+	subscriptionResponse, err := controller.WebhookService.FindById(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setVersionETag(c, subscriptionResponse.Version)
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   subscriptionResponse,
+	})
+}
+
+// Find All Webhook Subscriptions
+func (controller *WebhookControllerImpl) FindAll(c *fiber.Ctx) error {
+	subscriptionResponses, err := controller.WebhookService.FindAll(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   subscriptionResponses,
+	})
+}
+
+// Find Webhook Deliveries, the log of what was sent to the subscriptions, filtered by
+// subscription and status; status dead lists the dead letters
+func (controller *WebhookControllerImpl) FindDeliveries(c *fiber.Ctx) error {
+	filterRequest := web.WebhookDeliveryFilterRequest{
+		SubscriptionID: uint64(c.QueryInt("subscription_id")),
+		Status:         c.Query("status"),
+		Limit:          c.QueryInt("limit"),
+	}
+
+	deliveryResponses, err := controller.WebhookService.FindDeliveries(c.Context(), filterRequest)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   deliveryResponses,
+	})
+}
+
+// Redeliver a Webhook Delivery right away, e.g. one from the dead letters
+func (controller *WebhookControllerImpl) Redeliver(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("deliveryId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Invalid Delivery ID",
+			Data:   err.Error(),
+		})
+	}
+
+	deliveryResponse, err := controller.WebhookService.Redeliver(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   deliveryResponse,
+	})
+}
@@ -0,0 +1,21 @@
+package exception
+
+// ConflictError is a request that conflicts with the current state. Field is set when the
+// conflict is a value that must be unique, such as an email address already in use.
+type ConflictError struct {
+	Message string
+	Field   string
+}
+
+func (e ConflictError) Error() string {
+	return e.Message
+}
+
+func NewConflictError(message string) error {
+	return ConflictError{Message: message}
+}
+
+// NewFieldConflictError is a value of field that is already used by another record.
+func NewFieldConflictError(field string, message string) error {
+	return ConflictError{Message: message, Field: field}
+}
@@ -0,0 +1,77 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	BarcodeTypeEAN13 = "ean13"
+	BarcodeTypeEAN8  = "ean8"
+
+	// Internal codes use the GS1 prefix 20, which is reserved for numbers assigned
+	// within a store and never clashes with a manufacturer's code.
+	internalBarcodePrefix = "20"
+)
+
+// NormalizeBarcode validates an EAN-13, UPC-A or EAN-8 code and its check digit. UPC-A
+// codes are returned as their 13 digit EAN form, so a product scanned either way is
+// found under one code.
+func NormalizeBarcode(code string) (string, string, error) {
+	code = strings.TrimSpace(code)
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", "", fmt.Errorf("barcode %q must contain only digits", code)
+		}
+	}
+
+	var barcodeType string
+	switch len(code) {
+	case 8:
+		barcodeType = BarcodeTypeEAN8
+	case 12:
+		code = "0" + code
+		barcodeType = BarcodeTypeEAN13
+	case 13:
+		barcodeType = BarcodeTypeEAN13
+	default:
+		return "", "", fmt.Errorf("barcode %q must have 8, 12 or 13 digits", code)
+	}
+
+	if GTINCheckDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return "", "", fmt.Errorf("barcode %q has an invalid check digit", code)
+	}
+	return code, barcodeType, nil
+}
+
+// GTINCheckDigit computes the GS1 mod 10 check digit of the digits before it.
+func GTINCheckDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// InternalEAN13 returns the in-store EAN-13 code with the given sequence number.
+func InternalEAN13(sequence uint64) string {
+	digits := fmt.Sprintf("%s%010d", internalBarcodePrefix, sequence)
+	return digits + string(GTINCheckDigit(digits))
+}
+
+// InternalEAN13Sequence returns the sequence number of an in-store EAN-13 code, or
+// false when the code is not one.
+func InternalEAN13Sequence(code string) (uint64, bool) {
+	if len(code) != 13 || !strings.HasPrefix(code, internalBarcodePrefix) {
+		return 0, false
+	}
+	var sequence uint64
+	if _, err := fmt.Sscanf(code[2:12], "%d", &sequence); err != nil {
+		return 0, false
+	}
+	return sequence, true
+}
@@ -0,0 +1,164 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/ean"
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+const (
+	LabelFormatPNG = "png"
+	LabelFormatPDF = "pdf"
+
+	labelColumns = 3
+
+	// PNG sheets are drawn in pixels.
+	labelWidthPx    = 300
+	labelHeightPx   = 150
+	labelBarcodePx  = 70
+	labelPaddingPx  = 12
+	labelLineHeight = 16
+
+	// PDF sheets use the 3 x 8 layout of A4 label paper, in millimetres.
+	labelWidthMm   = 63.5
+	labelHeightMm  = 33.9
+	labelMarginMm  = 7.2
+	labelTopMm     = 12.9
+	labelRowsPerA4 = 8
+)
+
+// BarcodeLabel is one shelf label.
+type BarcodeLabel struct {
+	Code  string
+	Name  string
+	Price float64
+}
+
+// RenderBarcodeLabels writes a sheet of labels as a PNG image or an A4 PDF.
+func RenderBarcodeLabels(format string, labels []BarcodeLabel, writer io.Writer) error {
+	switch format {
+	case LabelFormatPNG:
+		return renderBarcodeLabelsPNG(labels, writer)
+	case LabelFormatPDF:
+		return renderBarcodeLabelsPDF(labels, writer)
+	default:
+		return fmt.Errorf("unsupported label format %q, expected png or pdf", format)
+	}
+}
+
+// barcodeImage draws code as an 8-bit grayscale image, which both image/png and the
+// PDF writer can embed.
+func barcodeImage(code string, width, height int) (image.Image, error) {
+	encoded, err := ean.Encode(code)
+	if err != nil {
+		return nil, err
+	}
+	scaled, err := barcode.Scale(encoded, width, height)
+	if err != nil {
+		return nil, err
+	}
+	gray := image.NewGray(scaled.Bounds())
+	draw.Draw(gray, gray.Bounds(), scaled, scaled.Bounds().Min, draw.Src)
+	return gray, nil
+}
+
+func renderBarcodeLabelsPNG(labels []BarcodeLabel, writer io.Writer) error {
+	rows := (len(labels) + labelColumns - 1) / labelColumns
+	sheet := image.NewRGBA(image.Rect(0, 0, labelColumns*labelWidthPx, rows*labelHeightPx))
+	draw.Draw(sheet, sheet.Bounds(), image.White, image.Point{}, draw.Src)
+
+	face := basicfont.Face7x13
+	drawText := func(text string, x, y int) {
+		drawer := font.Drawer{Dst: sheet, Src: image.Black, Face: face, Dot: fixed.P(x, y)}
+		drawer.DrawString(text)
+	}
+
+	for i, label := range labels {
+		left := (i % labelColumns) * labelWidthPx
+		top := (i / labelColumns) * labelHeightPx
+		width := labelWidthPx - 2*labelPaddingPx
+
+		drawText(truncateLabelText(label.Name, width/face.Advance), left+labelPaddingPx, top+labelPaddingPx+labelLineHeight)
+
+		code, err := barcodeImage(label.Code, width, labelBarcodePx)
+		if err != nil {
+			return err
+		}
+		barcodeTop := top + labelPaddingPx + labelLineHeight + 6
+		draw.Draw(sheet, image.Rect(left+labelPaddingPx, barcodeTop, left+labelPaddingPx+width, barcodeTop+labelBarcodePx), code, image.Point{}, draw.Src)
+
+		textTop := barcodeTop + labelBarcodePx + labelLineHeight
+		drawText(label.Code, left+labelPaddingPx, textTop)
+		drawText(fmt.Sprintf("%.2f", label.Price), left+labelPaddingPx, textTop+labelLineHeight)
+
+		border := color.Gray{Y: 220}
+		for x := left; x < left+labelWidthPx; x++ {
+			sheet.Set(x, top+labelHeightPx-1, border)
+		}
+		for y := top; y < top+labelHeightPx; y++ {
+			sheet.Set(left+labelWidthPx-1, y, border)
+		}
+	}
+
+	return png.Encode(writer, sheet)
+}
+
+func renderBarcodeLabelsPDF(labels []BarcodeLabel, writer io.Writer) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetFont("Helvetica", "", 8)
+
+	images := map[string]bool{}
+	for i, label := range labels {
+		position := i % (labelColumns * labelRowsPerA4)
+		if position == 0 {
+			pdf.AddPage()
+		}
+		left := labelMarginMm + float64(position%labelColumns)*labelWidthMm
+		top := labelTopMm + float64(position/labelColumns)*labelHeightMm
+
+		if !images[label.Code] {
+			code, err := barcodeImage(label.Code, 380, 120)
+			if err != nil {
+				return err
+			}
+			var buffer bytes.Buffer
+			if err := png.Encode(&buffer, code); err != nil {
+				return err
+			}
+			pdf.RegisterImageOptionsReader(label.Code, gofpdf.ImageOptions{ImageType: "PNG"}, &buffer)
+			images[label.Code] = true
+		}
+
+		pdf.SetXY(left+3, top+2)
+		pdf.CellFormat(labelWidthMm-6, 4, truncateLabelText(label.Name, 40), "", 0, "L", false, 0, "")
+		pdf.ImageOptions(label.Code, left+3, top+7, labelWidthMm-6, 15, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+		pdf.SetXY(left+3, top+23)
+		pdf.CellFormat(labelWidthMm-6, 4, label.Code, "", 0, "C", false, 0, "")
+		pdf.SetXY(left+3, top+27)
+		pdf.CellFormat(labelWidthMm-6, 4, fmt.Sprintf("%.2f", label.Price), "", 0, "R", false, 0, "")
+	}
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+
+	return pdf.Output(writer)
+}
+
+func truncateLabelText(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-3]) + "..."
+}
@@ -0,0 +1,121 @@
+package helper
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultPhoneCountryCode is the calling code of numbers written without one, in the
+// national format that starts with a trunk 0 (Indonesia).
+const DefaultPhoneCountryCode = "62"
+
+// NormalizePhone returns a phone number in E.164 form, "+" and the digits with the country
+// code, so that "0812-3456-789", "+62 812 3456 789" and "62812345678 9" are one number.
+// Numbers without a country code are taken to be national ones of DefaultPhoneCountryCode.
+// It returns "" for a value that has too few or too many digits to be a phone number.
+func NormalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	international := strings.HasPrefix(phone, "+")
+
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	number := digits.String()
+
+	switch {
+	case international:
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	case strings.HasPrefix(number, "0"):
+		number = DefaultPhoneCountryCode + number[1:]
+	case !strings.HasPrefix(number, DefaultPhoneCountryCode):
+		number = DefaultPhoneCountryCode + number
+	}
+	if len(number) < 8 || len(number) > 15 {
+		return ""
+	}
+	return "+" + number
+}
+
+// NormalizeEmail returns an email address trimmed and in lower case.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizeName returns a name in lower case with only its letters and digits, the words
+// separated by single spaces.
+func NormalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// NameSimilarity compares two names from 0 (nothing alike) to 1 (the same once normalised)
+// with the Jaro-Winkler similarity, which forgives typos and favours a common start. The
+// words are compared in either order, so "Budi Santoso" matches "Santoso, Budi".
+func NameSimilarity(a, b string) float64 {
+	a, b = NormalizeName(a), NormalizeName(b)
+	return max(jaroWinkler(a, b), jaroWinkler(sortWords(a), sortWords(b)))
+}
+
+func sortWords(name string) string {
+	words := strings.Fields(name)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+func jaroWinkler(a, b string) float64 {
+	s, t := []rune(a), []rune(b)
+	if len(s) == 0 || len(t) == 0 {
+		if len(s) == len(t) {
+			return 1
+		}
+		return 0
+	}
+
+	window := max(len(s), len(t))/2 - 1
+	window = max(window, 0)
+	sMatched := make([]bool, len(s))
+	tMatched := make([]bool, len(t))
+	matches := 0
+	for i := range s {
+		for j := max(0, i-window); j < min(len(t), i+window+1); j++ {
+			if !tMatched[j] && s[i] == t[j] {
+				sMatched[i], tMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := range s {
+		if !sMatched[i] {
+			continue
+		}
+		for !tMatched[j] {
+			j++
+		}
+		if s[i] != t[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(s)) + m/float64(len(t)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(s), len(t)) && s[prefix] == t[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,65 @@
+package helper
+
+import (
+	"context"
+	"strconv"
+)
+
+type contextKey string
+
+const (
+	ContextKeyActor     contextKey = "actor"
+	ContextKeyRequestId contextKey = "request_id"
+	ContextKeyClientIP  contextKey = "client_ip"
+	ContextKeyEmployee  contextKey = "employee_id"
+	ContextKeyStore     contextKey = "store_id"
+)
+
+func ActorFromContext(ctx context.Context) string {
+	return stringFromContext(ctx, ContextKeyActor)
+}
+
+// EmployeeIdFromContext returns the employee making the request, or "" when the request
+// was not made on behalf of an employee.
+func EmployeeIdFromContext(ctx context.Context) string {
+	return stringFromContext(ctx, ContextKeyEmployee)
+}
+
+// StoreIdFromContext returns the store the request is made for, and false when the
+// request has no store.
+func StoreIdFromContext(ctx context.Context) (uint64, bool) {
+	storeId, err := strconv.ParseUint(stringFromContext(ctx, ContextKeyStore), 10, 64)
+	if err != nil || storeId == 0 {
+		return 0, false
+	}
+	return storeId, true
+}
+
+func RequestIdFromContext(ctx context.Context) string {
+	return stringFromContext(ctx, ContextKeyRequestId)
+}
+
+func ClientIPFromContext(ctx context.Context) string {
+	return stringFromContext(ctx, ContextKeyClientIP)
+}
+
+// DetachContext copies the request values of ctx into a new background context. It is
+// used for work that outlives the request handler, such as streaming a response body,
+// where the fasthttp request context must no longer be touched.
+func DetachContext(ctx context.Context) context.Context {
+	detached := context.Background()
+	for _, key := range []contextKey{ContextKeyActor, ContextKeyRequestId, ContextKeyClientIP, ContextKeyEmployee, ContextKeyStore} {
+		if value := stringFromContext(ctx, key); value != "" {
+			detached = context.WithValue(detached, key, value)
+		}
+	}
+	return detached
+}
+
+func stringFromContext(ctx context.Context, key contextKey) string {
+	if ctx == nil {
+		return ""
+	}
+	value, _ := ctx.Value(key).(string)
+	return value
+}
@@ -0,0 +1,285 @@
+package helper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	ExportFormatCSV   = "csv"
+	ExportFormatJSONL = "jsonl"
+	ExportFormatXLSX  = "xlsx"
+)
+
+// ExportFailedPrefix starts the trailer written by ExportWriter.Fail.
+const ExportFailedPrefix = "# export failed: "
+
+// ExportWriter writes records one at a time in an export format. Records are web response
+// structs; tabular formats use their json tags as column names and skip nested slices,
+// except a slice of structs tagged export:"<prefix>", which is written as one row per
+// element with its fields in columns named <prefix>_<json name>. An export ends with Close
+// when it is complete, or with Fail when it was cut short, which writes a trailer that
+// tells the truncated file apart from a complete one.
+type ExportWriter interface {
+	Write(record interface{}) error
+	Close() error
+	Fail(cause error) error
+}
+
+// NewExportWriter starts an export of records shaped like record, a zero value of their
+// type. Tabular formats write the header for it straight away, so an export without any
+// records still names its columns.
+func NewExportWriter(format string, writer io.Writer, record interface{}) (ExportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return newCsvExportWriter(writer, record)
+	case ExportFormatJSONL:
+		return &jsonlExportWriter{encoder: json.NewEncoder(writer)}, nil
+	case ExportFormatXLSX:
+		return newXlsxExportWriter(writer, record)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q, expected csv, jsonl or xlsx", format)
+	}
+}
+
+// IsExportFormat reports whether format is one of the supported export formats.
+func IsExportFormat(format string) bool {
+	return format == ExportFormatCSV || format == ExportFormatJSONL || format == ExportFormatXLSX
+}
+
+// ExportContentType returns the Content-Type of an export format.
+func ExportContentType(format string) string {
+	switch format {
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case ExportFormatJSONL:
+		return "application/x-ndjson"
+	case ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func newCsvExportWriter(writer io.Writer, record interface{}) (ExportWriter, error) {
+	exportWriter := &csvExportWriter{writer: csv.NewWriter(writer)}
+	columns, _ := exportRows(record)
+	if err := exportWriter.writer.Write(columns); err != nil {
+		return nil, err
+	}
+	return exportWriter, nil
+}
+
+func (exportWriter *csvExportWriter) Write(record interface{}) error {
+	_, rows := exportRows(record)
+	for _, values := range rows {
+		row := make([]string, len(values))
+		for i, value := range values {
+			row[i] = exportString(value)
+		}
+		if err := exportWriter.writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (exportWriter *csvExportWriter) Close() error {
+	exportWriter.writer.Flush()
+	return exportWriter.writer.Error()
+}
+
+func (exportWriter *csvExportWriter) Fail(cause error) error {
+	if err := exportWriter.writer.Write([]string{ExportFailedPrefix + cause.Error()}); err != nil {
+		return err
+	}
+	return exportWriter.Close()
+}
+
+type jsonlExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (exportWriter *jsonlExportWriter) Write(record interface{}) error {
+	return exportWriter.encoder.Encode(record)
+}
+
+func (exportWriter *jsonlExportWriter) Close() error {
+	return nil
+}
+
+func (exportWriter *jsonlExportWriter) Fail(cause error) error {
+	return exportWriter.encoder.Encode(map[string]string{"error": ExportFailedPrefix + cause.Error()})
+}
+
+// xlsxExportWriter uses excelize's stream writer, which keeps only a small window of
+// rows in memory and spills the rest to a temporary file.
+type xlsxExportWriter struct {
+	writer       io.Writer
+	file         *excelize.File
+	streamWriter *excelize.StreamWriter
+	row          int
+}
+
+func newXlsxExportWriter(writer io.Writer, record interface{}) (ExportWriter, error) {
+	file := excelize.NewFile()
+	streamWriter, err := file.NewStreamWriter(file.GetSheetName(0))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	exportWriter := &xlsxExportWriter{writer: writer, file: file, streamWriter: streamWriter, row: 1}
+	columns, _ := exportRows(record)
+	if err := exportWriter.writeRow(toInterfaces(columns)); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return exportWriter, nil
+}
+
+func (exportWriter *xlsxExportWriter) Write(record interface{}) error {
+	_, rows := exportRows(record)
+	for _, values := range rows {
+		for i, value := range values {
+			if _, ok := value.(time.Time); ok {
+				values[i] = exportString(value)
+			}
+		}
+		if err := exportWriter.writeRow(values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (exportWriter *xlsxExportWriter) writeRow(values []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, exportWriter.row)
+	if err != nil {
+		return err
+	}
+	exportWriter.row++
+	return exportWriter.streamWriter.SetRow(cell, values)
+}
+
+func (exportWriter *xlsxExportWriter) Close() error {
+	defer exportWriter.file.Close()
+	if err := exportWriter.streamWriter.Flush(); err != nil {
+		return err
+	}
+	return exportWriter.file.Write(exportWriter.writer)
+}
+
+// Fail ends the sheet with a row holding the error, as the workbook is only written on close.
+func (exportWriter *xlsxExportWriter) Fail(cause error) error {
+	if err := exportWriter.writeRow([]interface{}{ExportFailedPrefix + cause.Error()}); err != nil {
+		return err
+	}
+	return exportWriter.Close()
+}
+
+// exportRows returns the columns of a record and the rows it is written as: one row, or
+// one row per element of its slice tagged export:"<prefix>", with the columns of the
+// elements in place of the slice. A record whose tagged slice is empty is still written,
+// with the element columns left blank.
+func exportRows(record interface{}) ([]string, [][]interface{}) {
+	value := reflect.Indirect(reflect.ValueOf(record))
+	columns, values, lines := exportColumns(value, "")
+	if lines == nil {
+		return columns, [][]interface{}{values}
+	}
+
+	lineColumns, blank, _ := exportColumns(reflect.New(lines.elements.Type().Elem()).Elem(), lines.prefix+"_")
+	columns = append(append(append([]string{}, columns[:lines.at]...), lineColumns...), columns[lines.at:]...)
+	row := func(lineValues []interface{}) []interface{} {
+		return append(append(append([]interface{}{}, values[:lines.at]...), lineValues...), values[lines.at:]...)
+	}
+
+	if lines.elements.Len() == 0 {
+		for i := range blank {
+			blank[i] = ""
+		}
+		return columns, [][]interface{}{row(blank)}
+	}
+	rows := make([][]interface{}, 0, lines.elements.Len())
+	for i := 0; i < lines.elements.Len(); i++ {
+		_, lineValues, _ := exportColumns(lines.elements.Index(i), lines.prefix+"_")
+		rows = append(rows, row(lineValues))
+	}
+	return columns, rows
+}
+
+// exportLines is the slice of a record that is flattened into rows, and the column its
+// element columns go in at.
+type exportLines struct {
+	at       int
+	prefix   string
+	elements reflect.Value
+}
+
+// exportColumns returns the json names, prefixed with prefix, and values of the scalar
+// fields of a struct, and the slice tagged to be flattened if it has one.
+func exportColumns(value reflect.Value, prefix string) ([]string, []interface{}, *exportLines) {
+	var columns []string
+	var values []interface{}
+	var lines *exportLines
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		kind := fieldType.Kind()
+		if kind == reflect.Slice && fieldType.Elem().Kind() == reflect.Struct && field.Tag.Get("export") != "" && lines == nil {
+			lines = &exportLines{at: len(columns), prefix: field.Tag.Get("export"), elements: value.Field(i)}
+			continue
+		}
+		if kind == reflect.Slice || kind == reflect.Map || (kind == reflect.Struct && fieldType != reflect.TypeOf(time.Time{})) {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				columns = append(columns, prefix+name)
+				values = append(values, "")
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		columns = append(columns, prefix+name)
+		values = append(values, fieldValue.Interface())
+	}
+	return columns, values, lines
+}
+
+func exportString(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
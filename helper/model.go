@@ -1,14 +1,20 @@
 package helper
 
 import (
+	"encoding/json"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"github.com/aronipurwanto/go-restful-api/model/web"
+	"strings"
 )
 
 func ToCategoryResponse(category domain.Category) web.CategoryResponse {
 	return web.CategoryResponse{
-		Id:   category.Id,
-		Name: category.Name,
+		Id:        category.Id,
+		Name:      category.Name,
+		ParentId:  category.ParentId,
+		Version:   category.Version,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
 	}
 }
 
@@ -22,12 +28,17 @@ func ToCategoryResponses(categories []domain.Category) []web.CategoryResponse {
 
 func ToCustomerResponse(customer domain.Customer) web.CustomerResponse {
 	return web.CustomerResponse{
-		CustomerID: customer.CustomerID,
-		Name:       customer.Name,
-		Email:      customer.Email,
-		Phone:      customer.Phone,
-		Address:    customer.Address,
-		LoyaltyPts: customer.LoyaltyPts,
+		CustomerID:   customer.CustomerID,
+		Name:         customer.Name,
+		Email:        customer.Email,
+		Phone:        customer.Phone,
+		Address:      customer.Address,
+		LoyaltyPts:   customer.LoyaltyPts,
+		PriceListId:  customer.PriceListId,
+		Version:      customer.Version,
+		CreatedAt:    customer.CreatedAt,
+		UpdatedAt:    customer.UpdatedAt,
+		AnonymisedAt: customer.AnonymisedAt,
 	}
 }
 
@@ -47,6 +58,10 @@ func ToEmployeeResponse(employee domain.Employee) web.EmployeeResponse {
 		Email:      employee.Email,
 		Phone:      employee.Phone,
 		DateHired:  employee.DateHired,
+		StoreID:    employee.StoreID,
+		Version:    employee.Version,
+		CreatedAt:  employee.CreatedAt,
+		UpdatedAt:  employee.UpdatedAt,
 	}
 }
 
@@ -68,6 +83,16 @@ func ToProductResponse(product domain.Product) web.ProductResponse {
 		CategoryID:  product.CategoryId,
 		SKU:         product.SKU,
 		TaxRate:     product.TaxRate,
+		TaxType:     product.TaxType,
+		CostPrice:   product.CostPrice,
+		CostMethod:  product.CostMethod,
+		SupplierID:  product.SupplierID,
+		Version:     product.Version,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+		Options:     ToProductOptionResponses(product.Options),
+		Variants:    ToProductVariantResponses(product, product.Variants),
+		Barcodes:    ToProductBarcodeResponses(product.Barcodes),
 	}
 }
 
@@ -78,3 +103,597 @@ func ToProductResponses(products []domain.Product) []web.ProductResponse {
 	}
 	return productResponses
 }
+
+func ToProductOptionResponse(option domain.ProductOption) web.ProductOptionResponse {
+	var values []string
+	_ = json.Unmarshal([]byte(option.Values), &values)
+	return web.ProductOptionResponse{
+		Name:   option.Name,
+		Values: values,
+	}
+}
+
+func ToProductOptionResponses(options []domain.ProductOption) []web.ProductOptionResponse {
+	var optionResponses []web.ProductOptionResponse
+	for _, option := range options {
+		optionResponses = append(optionResponses, ToProductOptionResponse(option))
+	}
+	return optionResponses
+}
+
+// ToProductVariantResponse needs the parent product to resolve the variant's price.
+func ToProductVariantResponse(product domain.Product, variant domain.ProductVariant) web.ProductVariantResponse {
+	var options map[string]string
+	_ = json.Unmarshal([]byte(variant.Options), &options)
+
+	price := product.Price
+	if variant.Price != nil {
+		price = *variant.Price
+	}
+
+	return web.ProductVariantResponse{
+		VariantID:     variant.Id,
+		ProductID:     variant.ProductID,
+		SKU:           variant.SKU,
+		Barcode:       variantBarcode(product, variant),
+		Options:       options,
+		Price:         price,
+		PriceOverride: variant.Price,
+		StockQty:      variant.StockQty,
+		Version:       variant.Version,
+		CreatedAt:     variant.CreatedAt,
+		UpdatedAt:     variant.UpdatedAt,
+	}
+}
+
+// variantBarcode returns the first barcode of a variant among the product's barcodes.
+func variantBarcode(product domain.Product, variant domain.ProductVariant) string {
+	for _, barcode := range product.Barcodes {
+		if barcode.VariantID != nil && *barcode.VariantID == variant.Id {
+			return barcode.Code
+		}
+	}
+	return ""
+}
+
+func ToProductVariantResponses(product domain.Product, variants []domain.ProductVariant) []web.ProductVariantResponse {
+	var variantResponses []web.ProductVariantResponse
+	for _, variant := range variants {
+		variantResponses = append(variantResponses, ToProductVariantResponse(product, variant))
+	}
+	return variantResponses
+}
+
+func ToProductBarcodeResponse(barcode domain.ProductBarcode) web.ProductBarcodeResponse {
+	return web.ProductBarcodeResponse{
+		ProductID: barcode.ProductID,
+		Code:      barcode.Code,
+		Type:      barcode.Type,
+		VariantID: barcode.VariantID,
+		Internal:  barcode.Internal,
+	}
+}
+
+func ToProductBarcodeResponses(barcodes []domain.ProductBarcode) []web.ProductBarcodeResponse {
+	var barcodeResponses []web.ProductBarcodeResponse
+	for _, barcode := range barcodes {
+		barcodeResponses = append(barcodeResponses, ToProductBarcodeResponse(barcode))
+	}
+	return barcodeResponses
+}
+
+func ToPriceListResponse(priceList domain.PriceList) web.PriceListResponse {
+	return web.PriceListResponse{
+		Id:        priceList.Id,
+		Code:      priceList.Code,
+		Name:      priceList.Name,
+		Version:   priceList.Version,
+		CreatedAt: priceList.CreatedAt,
+		UpdatedAt: priceList.UpdatedAt,
+	}
+}
+
+func ToPriceListResponses(priceLists []domain.PriceList) []web.PriceListResponse {
+	var priceListResponses []web.PriceListResponse
+	for _, priceList := range priceLists {
+		priceListResponses = append(priceListResponses, ToPriceListResponse(priceList))
+	}
+	return priceListResponses
+}
+
+func ToPriceListItemResponse(item domain.PriceListItem) web.PriceListItemResponse {
+	return web.PriceListItemResponse{
+		PriceListId: item.PriceListId,
+		ProductID:   item.ProductID,
+		Price:       item.Price,
+	}
+}
+
+func ToPriceListItemResponses(items []domain.PriceListItem) []web.PriceListItemResponse {
+	var itemResponses []web.PriceListItemResponse
+	for _, item := range items {
+		itemResponses = append(itemResponses, ToPriceListItemResponse(item))
+	}
+	return itemResponses
+}
+
+func ToPriceScheduleResponse(schedule domain.PriceSchedule) web.PriceScheduleResponse {
+	return web.PriceScheduleResponse{
+		Id:            schedule.Id,
+		PriceListId:   schedule.PriceListId,
+		ProductID:     schedule.ProductID,
+		Price:         schedule.Price,
+		EffectiveFrom: schedule.EffectiveFrom,
+		EffectiveTo:   schedule.EffectiveTo,
+		Status:        schedule.Status,
+		PreviousPrice: schedule.PreviousPrice,
+		AppliedAt:     schedule.AppliedAt,
+		EndedAt:       schedule.EndedAt,
+	}
+}
+
+func ToPriceScheduleResponses(schedules []domain.PriceSchedule) []web.PriceScheduleResponse {
+	var scheduleResponses []web.PriceScheduleResponse
+	for _, schedule := range schedules {
+		scheduleResponses = append(scheduleResponses, ToPriceScheduleResponse(schedule))
+	}
+	return scheduleResponses
+}
+
+func ToPriceHistoryResponses(histories []domain.PriceHistory) []web.PriceHistoryResponse {
+	var historyResponses []web.PriceHistoryResponse
+	for _, history := range histories {
+		historyResponses = append(historyResponses, web.PriceHistoryResponse{
+			ProductID:   history.ProductID,
+			PriceListId: history.PriceListId,
+			OldPrice:    history.OldPrice,
+			NewPrice:    history.NewPrice,
+			Source:      history.Source,
+			ScheduleId:  history.ScheduleId,
+			Actor:       history.Actor,
+			ChangedAt:   history.ChangedAt,
+		})
+	}
+	return historyResponses
+}
+
+// ToShiftResponse describes a shift. The expected cash of an open shift is computed from
+// the running totals; a closed shift reports what was stored when it was closed.
+func ToShiftResponse(shift domain.Shift, totals domain.ShiftCashTotals, movements []domain.CashMovement) web.ShiftResponse {
+	expectedCash := RoundMoney(shift.OpeningFloat + totals.CashSales + totals.CashIn - totals.CashOut)
+	if shift.ExpectedCash != nil {
+		expectedCash = *shift.ExpectedCash
+	}
+
+	var movementResponses []web.CashMovementResponse
+	for _, movement := range movements {
+		movementResponses = append(movementResponses, web.CashMovementResponse{
+			Id:        movement.Id,
+			Type:      movement.Type,
+			Amount:    movement.Amount,
+			Reason:    movement.Reason,
+			Actor:     movement.Actor,
+			CreatedAt: movement.CreatedAt,
+		})
+	}
+
+	return web.ShiftResponse{
+		Id:            shift.Id,
+		StoreID:       shift.StoreID,
+		EmployeeID:    shift.EmployeeID,
+		Status:        shift.Status,
+		OpeningFloat:  shift.OpeningFloat,
+		OpenedAt:      shift.OpenedAt,
+		ClosedAt:      shift.ClosedAt,
+		CashSales:     totals.CashSales,
+		CashIn:        totals.CashIn,
+		CashOut:       totals.CashOut,
+		ExpectedCash:  expectedCash,
+		CountedCash:   shift.CountedCash,
+		Variance:      shift.Variance,
+		Note:          shift.Note,
+		Version:       shift.Version,
+		CashMovements: movementResponses,
+	}
+}
+
+func ToAuditLogResponse(auditLog domain.AuditLog) web.AuditLogResponse {
+	return web.AuditLogResponse{
+		Id:         auditLog.Id,
+		EntityType: auditLog.EntityType,
+		EntityId:   auditLog.EntityId,
+		Action:     auditLog.Action,
+		Actor:      auditLog.Actor,
+		Changes:    json.RawMessage(auditLog.Changes),
+		ClientIP:   auditLog.ClientIP,
+		RequestId:  auditLog.RequestId,
+		CreatedAt:  auditLog.CreatedAt,
+	}
+}
+
+func ToAuditLogResponses(auditLogs []domain.AuditLog) []web.AuditLogResponse {
+	var auditLogResponses []web.AuditLogResponse
+	for _, auditLog := range auditLogs {
+		auditLogResponses = append(auditLogResponses, ToAuditLogResponse(auditLog))
+	}
+	return auditLogResponses
+}
+
+func ToOrderResponse(order domain.Order) web.OrderResponse {
+	orderItems := make([]web.OrderItemResponse, 0, len(order.OrderItems))
+	for _, orderItem := range order.OrderItems {
+		orderItems = append(orderItems, web.OrderItemResponse{
+			ProductID:      orderItem.ProductID,
+			VariantID:      orderItem.VariantID,
+			Quantity:       orderItem.Quantity,
+			UnitPrice:      orderItem.UnitPrice,
+			TotalPrice:     orderItem.TotalPrice,
+			DiscountAmount: orderItem.DiscountAmount,
+			TaxAmount:      orderItem.TaxAmount,
+		})
+	}
+
+	payments := make([]web.PaymentResponse, 0, len(order.Payments))
+	var paidAmount float64
+	for _, payment := range order.Payments {
+		payments = append(payments, ToPaymentResponse(payment))
+		if payment.Status == domain.PaymentStatusCompleted {
+			paidAmount += payment.Amount
+		}
+	}
+
+	var issues []web.ReconciliationIssue
+	if order.ReconciliationIssues != "" {
+		_ = json.Unmarshal([]byte(order.ReconciliationIssues), &issues)
+	}
+
+	return web.OrderResponse{
+		OrderID:              order.OrderID,
+		CustomerID:           order.CustomerID,
+		StoreID:              order.StoreID,
+		EmployeeID:           order.EmployeeID,
+		ShiftID:              order.ShiftID,
+		OrderDate:            order.OrderDate,
+		TotalAmount:          order.TotalAmount,
+		PaidAmount:           paidAmount,
+		ItemCount:            len(order.OrderItems),
+		Version:              order.Version,
+		OrderItems:           orderItems,
+		Payments:             payments,
+		ReconciliationStatus: order.ReconciliationStatus,
+		ReconciliationIssues: issues,
+		UploadedAt:           order.UploadedAt,
+	}
+}
+
+func ToPaymentResponse(payment domain.Payment) web.PaymentResponse {
+	return web.PaymentResponse{
+		PaymentID:   payment.PaymentID,
+		OrderID:     payment.OrderID,
+		ShiftID:     payment.ShiftID,
+		Amount:      payment.Amount,
+		PaymentType: payment.PaymentType,
+		PaymentDate: payment.PaymentDate,
+		Status:      payment.Status,
+		Version:     payment.Version,
+	}
+}
+
+func ToOrderResponses(orders []domain.Order) []web.OrderResponse {
+	var orderResponses []web.OrderResponse
+	for _, order := range orders {
+		orderResponses = append(orderResponses, ToOrderResponse(order))
+	}
+	return orderResponses
+}
+
+func ToStoreResponse(store domain.Store) web.StoreResponse {
+	return web.StoreResponse{
+		Id:        store.Id,
+		Code:      store.Code,
+		Name:      store.Name,
+		Address:   store.Address,
+		Version:   store.Version,
+		CreatedAt: store.CreatedAt,
+		UpdatedAt: store.UpdatedAt,
+	}
+}
+
+func ToStoreResponses(stores []domain.Store) []web.StoreResponse {
+	var storeResponses []web.StoreResponse
+	for _, store := range stores {
+		storeResponses = append(storeResponses, ToStoreResponse(store))
+	}
+	return storeResponses
+}
+
+func ToTaxResponse(tax domain.Tax) web.TaxResponse {
+	return web.TaxResponse{
+		TaxID:       tax.TaxID,
+		TaxRate:     tax.TaxRate,
+		TaxType:     tax.TaxType,
+		Description: tax.Description,
+		Version:     tax.Version,
+		CreatedAt:   tax.CreatedAt,
+		UpdatedAt:   tax.UpdatedAt,
+	}
+}
+
+func ToDiscountResponse(discount domain.Discount) web.DiscountResponse {
+	return web.DiscountResponse{
+		DiscountID:  discount.DiscountID,
+		Description: discount.Description,
+		DiscountPct: discount.DiscountPct,
+		ValidFrom:   discount.ValidFrom,
+		ValidUntil:  discount.ValidUntil,
+		Version:     discount.Version,
+		CreatedAt:   discount.CreatedAt,
+		UpdatedAt:   discount.UpdatedAt,
+	}
+}
+
+func ToStoreStockResponses(stocks []domain.StoreStock) []web.StoreStockResponse {
+	stockResponses := make([]web.StoreStockResponse, 0, len(stocks))
+	for _, stock := range stocks {
+		stockResponses = append(stockResponses, web.StoreStockResponse{
+			StoreID:   stock.StoreID,
+			ProductID: stock.ProductID,
+			VariantID: variantIdPtr(stock.VariantID),
+			Quantity:  stock.Quantity,
+		})
+	}
+	return stockResponses
+}
+
+func ToInventoryMovementResponses(movements []domain.InventoryMovement) []web.InventoryMovementResponse {
+	movementResponses := make([]web.InventoryMovementResponse, 0, len(movements))
+	for _, movement := range movements {
+		movementResponses = append(movementResponses, ToInventoryMovementResponse(movement))
+	}
+	return movementResponses
+}
+
+func ToInventoryMovementResponse(movement domain.InventoryMovement) web.InventoryMovementResponse {
+	return web.InventoryMovementResponse{
+		Id:        movement.Id,
+		StoreID:   movement.StoreID,
+		ProductID: movement.ProductID,
+		VariantID: variantIdPtr(movement.VariantID),
+		Type:      movement.Type,
+		Quantity:  movement.Quantity,
+		UnitCost:  movement.UnitCost,
+		TotalCost: movement.TotalCost,
+		Reference: movement.Reference,
+		Reason:    movement.Reason,
+		CreatedBy: movement.CreatedBy,
+		CreatedAt: movement.CreatedAt,
+	}
+}
+
+func ToInventoryResponses(inventories []domain.Inventory) []web.InventoryResponse {
+	inventoryResponses := make([]web.InventoryResponse, 0, len(inventories))
+	for _, inventory := range inventories {
+		inventoryResponses = append(inventoryResponses, ToInventoryResponse(inventory))
+	}
+	return inventoryResponses
+}
+
+func ToInventoryResponse(inventory domain.Inventory) web.InventoryResponse {
+	return web.InventoryResponse{
+		ProductID:       inventory.ProductID,
+		VariantID:       variantIdPtr(inventory.VariantID),
+		StockQty:        inventory.StockQty,
+		RestockLevel:    inventory.RestockLevel,
+		ReorderQuantity: inventory.ReorderQuantity,
+		LastRestock:     inventory.LastRestock,
+	}
+}
+
+func ToSupplierResponse(supplier domain.Supplier) web.SupplierResponse {
+	return web.SupplierResponse{
+		Id:          supplier.Id,
+		Code:        supplier.Code,
+		Name:        supplier.Name,
+		ContactName: supplier.ContactName,
+		Email:       supplier.Email,
+		Phone:       supplier.Phone,
+		Address:     supplier.Address,
+		Version:     supplier.Version,
+		CreatedAt:   supplier.CreatedAt,
+		UpdatedAt:   supplier.UpdatedAt,
+	}
+}
+
+func ToSupplierResponses(suppliers []domain.Supplier) []web.SupplierResponse {
+	var supplierResponses []web.SupplierResponse
+	for _, supplier := range suppliers {
+		supplierResponses = append(supplierResponses, ToSupplierResponse(supplier))
+	}
+	return supplierResponses
+}
+
+func ToPurchaseOrderResponse(order domain.PurchaseOrder) web.PurchaseOrderResponse {
+	lines := make([]web.PurchaseOrderLineResponse, 0, len(order.Lines))
+	var total float64
+	for _, line := range order.Lines {
+		lines = append(lines, web.PurchaseOrderLineResponse{
+			Id:               line.Id,
+			ProductID:        line.ProductID,
+			VariantID:        variantIdPtr(line.VariantID),
+			Quantity:         line.Quantity,
+			ReceivedQuantity: line.ReceivedQuantity,
+			UnitCost:         line.UnitCost,
+		})
+		total += line.UnitCost * float64(line.Quantity)
+	}
+	return web.PurchaseOrderResponse{
+		Id:         order.Id,
+		StoreID:    order.StoreID,
+		SupplierID: order.SupplierID,
+		Status:     order.Status,
+		Note:       order.Note,
+		Total:      RoundMoney(total),
+		CreatedBy:  order.CreatedBy,
+		CreatedAt:  order.CreatedAt,
+		SentAt:     order.SentAt,
+		ClosedAt:   order.ClosedAt,
+		Version:    order.Version,
+		Lines:      lines,
+	}
+}
+
+func ToPurchaseOrderResponses(orders []domain.PurchaseOrder) []web.PurchaseOrderResponse {
+	orderResponses := make([]web.PurchaseOrderResponse, 0, len(orders))
+	for _, order := range orders {
+		orderResponses = append(orderResponses, ToPurchaseOrderResponse(order))
+	}
+	return orderResponses
+}
+
+func ToStocktakeResponse(stocktake domain.Stocktake) web.StocktakeResponse {
+	lines := make([]web.StocktakeLineResponse, 0, len(stocktake.Lines))
+	for _, line := range stocktake.Lines {
+		lines = append(lines, web.StocktakeLineResponse{
+			Id:               line.Id,
+			ProductID:        line.ProductID,
+			VariantID:        variantIdPtr(line.VariantID),
+			ExpectedQuantity: line.ExpectedQuantity,
+			CountedQuantity:  line.CountedQuantity,
+			Variance:         StocktakeVariance(line),
+			Reason:           line.Reason,
+			AdjustmentCost:   line.AdjustmentCost,
+		})
+	}
+	return web.StocktakeResponse{
+		Id:         stocktake.Id,
+		StoreID:    stocktake.StoreID,
+		Status:     stocktake.Status,
+		Note:       stocktake.Note,
+		CreatedBy:  stocktake.CreatedBy,
+		CreatedAt:  stocktake.CreatedAt,
+		ApprovedBy: stocktake.ApprovedBy,
+		ClosedAt:   stocktake.ClosedAt,
+		Version:    stocktake.Version,
+		Lines:      lines,
+	}
+}
+
+func ToStocktakeResponses(stocktakes []domain.Stocktake) []web.StocktakeResponse {
+	stocktakeResponses := make([]web.StocktakeResponse, 0, len(stocktakes))
+	for _, stocktake := range stocktakes {
+		stocktakeResponses = append(stocktakeResponses, ToStocktakeResponse(stocktake))
+	}
+	return stocktakeResponses
+}
+
+// StocktakeVariance is how many more units of a line were counted than expected, negative
+// for shrinkage and 0 while the line is not counted.
+func StocktakeVariance(line domain.StocktakeLine) int {
+	if line.CountedQuantity == nil {
+		return 0
+	}
+	return *line.CountedQuantity - line.ExpectedQuantity
+}
+
+func ToStockTransferResponse(transfer domain.StockTransfer) web.StockTransferResponse {
+	items := make([]web.StockTransferItemResponse, 0, len(transfer.Items))
+	for _, item := range transfer.Items {
+		items = append(items, web.StockTransferItemResponse{
+			ProductID: item.ProductID,
+			VariantID: variantIdPtr(item.VariantID),
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return web.StockTransferResponse{
+		Id:          transfer.Id,
+		FromStoreID: transfer.FromStoreID,
+		ToStoreID:   transfer.ToStoreID,
+		Status:      transfer.Status,
+		Note:        transfer.Note,
+		CreatedBy:   transfer.CreatedBy,
+		ReceivedBy:  transfer.ReceivedBy,
+		ShippedAt:   transfer.ShippedAt,
+		ClosedAt:    transfer.ClosedAt,
+		Version:     transfer.Version,
+		Items:       items,
+	}
+}
+
+func ToStockTransferResponses(transfers []domain.StockTransfer) []web.StockTransferResponse {
+	transferResponses := make([]web.StockTransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		transferResponses = append(transferResponses, ToStockTransferResponse(transfer))
+	}
+	return transferResponses
+}
+
+// variantIdPtr turns the 0 that stock rows use for "no variant" back into nil.
+func variantIdPtr(variantId uint64) *uint64 {
+	if variantId == 0 {
+		return nil
+	}
+	return &variantId
+}
+
+func ToEventEnvelope(event domain.OutboxEvent) web.EventEnvelope {
+	return web.EventEnvelope{
+		Id:            event.Id,
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		StoreID:       event.OriginStoreID,
+		Actor:         event.Actor,
+		RequestId:     event.RequestId,
+		OccurredAt:    event.OccurredAt,
+		Data:          json.RawMessage(event.Payload),
+	}
+}
+
+func ToWebhookSubscriptionResponse(subscription domain.WebhookSubscription) web.WebhookSubscriptionResponse {
+	return web.WebhookSubscriptionResponse{
+		Id:         subscription.Id,
+		URL:        subscription.URL,
+		EventTypes: strings.Split(subscription.EventTypes, ","),
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		Version:    subscription.Version,
+	}
+}
+
+func ToWebhookSubscriptionResponses(subscriptions []domain.WebhookSubscription) []web.WebhookSubscriptionResponse {
+	var subscriptionResponses []web.WebhookSubscriptionResponse
+	for _, subscription := range subscriptions {
+		subscriptionResponses = append(subscriptionResponses, ToWebhookSubscriptionResponse(subscription))
+	}
+	return subscriptionResponses
+}
+
+func ToWebhookDeliveryResponse(delivery domain.WebhookDelivery) web.WebhookDeliveryResponse {
+	response := web.WebhookDeliveryResponse{
+		Id:             delivery.Id,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	return response
+}
+
+func ToWebhookDeliveryResponses(deliveries []domain.WebhookDelivery) []web.WebhookDeliveryResponse {
+	var deliveryResponses []web.WebhookDeliveryResponse
+	for _, delivery := range deliveries {
+		deliveryResponses = append(deliveryResponses, ToWebhookDeliveryResponse(delivery))
+	}
+	return deliveryResponses
+}
@@ -0,0 +1,9 @@
+package helper
+
+import "math"
+
+// RoundMoney rounds an amount to whole cents, removing the float noise that sums of
+// prices pick up.
+func RoundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
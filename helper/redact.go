@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// RedactedValue replaces personal data that has been redacted.
+const RedactedValue = "[redacted]"
+
+// RedactJSON replaces the non-empty strings found under any of keys, at any depth of a
+// JSON document, with RedactedValue. It reports whether anything was replaced; the
+// document is returned as it was when nothing was.
+func RedactJSON(document string, keys ...string) (string, bool, error) {
+	if document == "" {
+		return document, false, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", false, err
+	}
+
+	redactKeys := map[string]bool{}
+	for _, key := range keys {
+		redactKeys[key] = true
+	}
+	value, redacted := redactValue(value, redactKeys, false)
+	if !redacted {
+		return document, false, nil
+	}
+
+	redactedDocument, err := json.Marshal(value)
+	if err != nil {
+		return "", false, err
+	}
+	return string(redactedDocument), true, nil
+}
+
+// redactValue walks value, replacing its strings when redact is set, and the strings of
+// the members named in keys.
+func redactValue(value interface{}, keys map[string]bool, redact bool) (interface{}, bool) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		redacted := false
+		for key, member := range value {
+			var memberRedacted bool
+			value[key], memberRedacted = redactValue(member, keys, redact || keys[key])
+			redacted = redacted || memberRedacted
+		}
+		return value, redacted
+	case []interface{}:
+		redacted := false
+		for i, element := range value {
+			var elementRedacted bool
+			value[i], elementRedacted = redactValue(element, keys, redact)
+			redacted = redacted || elementRedacted
+		}
+		return value, redacted
+	case string:
+		if redact && value != "" && value != RedactedValue {
+			return RedactedValue, true
+		}
+	}
+	return value, false
+}
+
+// WriteJSONZip writes a ZIP archive with a JSON file for every entry of files, by name.
+func WriteJSONZip(writer io.Writer, files map[string]interface{}) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	archive := zip.NewWriter(writer)
+	for _, name := range names {
+		file, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(files[name]); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
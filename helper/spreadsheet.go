@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"encoding/csv"
+	"errors"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ReadSpreadsheet returns the rows of a CSV file or of the first sheet of an XLSX file,
+// picking the format from the file name.
+func ReadSpreadsheet(filename string, reader io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		csvReader := csv.NewReader(reader)
+		csvReader.FieldsPerRecord = -1
+		csvReader.TrimLeadingSpace = true
+		return csvReader.ReadAll()
+	case ".xlsx":
+		file, err := excelize.OpenReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return file.GetRows(file.GetSheetName(0))
+	default:
+		return nil, errors.New("unsupported file type, expected .csv or .xlsx")
+	}
+}
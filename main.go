@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"github.com/aronipurwanto/go-restful-api/app"
 	"github.com/aronipurwanto/go-restful-api/controller"
 	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/middleware"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"github.com/aronipurwanto/go-restful-api/repository"
 	"github.com/aronipurwanto/go-restful-api/service"
@@ -11,6 +13,11 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gofiber/fiber/v2"
 	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func main() {
@@ -20,30 +27,218 @@ func main() {
 	// Initialize Database
 	db := app.NewDB()
 
+	// Clear values that would break the unique indexes the migration creates, and stop on
+	// values used more than once
+	err := repository.PrepareUniqueIndexes(db)
+	helper.PanicIfError(err)
+
 	// Run Auto Migration (Opsional, bisa dihapus jika tidak diperlukan)
-	err := db.AutoMigrate(&domain.Category{})
+	err = db.AutoMigrate(&domain.Store{}, &domain.StoreStock{}, &domain.StockTransfer{}, &domain.StockTransferItem{}, &domain.InventoryMovement{}, &domain.CostLayer{}, &domain.Inventory{}, &domain.Supplier{}, &domain.PurchaseOrder{}, &domain.PurchaseOrderLine{}, &domain.Stocktake{}, &domain.StocktakeLine{}, &domain.Category{}, &domain.Tax{}, &domain.Discount{}, &domain.Customer{}, &domain.Employee{}, &domain.Product{}, &domain.ProductOption{}, &domain.ProductVariant{}, &domain.ProductBarcode{}, &domain.PriceList{}, &domain.PriceListItem{}, &domain.PriceSchedule{}, &domain.PriceHistory{}, &domain.Shift{}, &domain.CashMovement{}, &domain.Order{}, &domain.OrderItem{}, &domain.Payment{}, &domain.AuditLog{}, &domain.OutboxEvent{}, &domain.EntityChange{}, &domain.WebhookSubscription{}, &domain.WebhookDelivery{}, &domain.IdempotencyKey{})
+	helper.PanicIfError(err)
+
+	// Move the barcodes variants used to keep themselves into the product barcodes
+	err = repository.MigrateVariantBarcodes(db)
+	helper.PanicIfError(err)
+
+	// Confine employees, shifts, orders and stock levels to the store of the request
+	err = repository.RegisterStoreScopeCallbacks(db)
+	helper.PanicIfError(err)
+
+	// Report a value already used in a unique column as a conflict over that field
+	err = repository.RegisterUniqueConstraintCallbacks(db)
+	helper.PanicIfError(err)
+
+	// Record every create, update and delete in the audit log, except of the models below:
+	// ledgers and histories that are records of changes themselves, and delivery bookkeeping
+	err = repository.RegisterAuditCallbacks(db, &domain.InventoryMovement{}, &domain.CostLayer{}, &domain.PriceHistory{}, &domain.EntityChange{}, &domain.OutboxEvent{}, &domain.WebhookDelivery{}, &domain.IdempotencyKey{})
+	helper.PanicIfError(err)
+
+	// Record every change of the catalogue for the change feed of the POS terminals
+	changeFeedTables := []repository.ChangeFeedTable{
+		{Model: &domain.Product{}, EntityType: domain.ChangeEntityProduct},
+		{Model: &domain.ProductOption{}, EntityType: domain.ChangeEntityProduct, ParentColumn: "product_id"},
+		{Model: &domain.ProductVariant{}, EntityType: domain.ChangeEntityProduct, ParentColumn: "product_id"},
+		{Model: &domain.ProductBarcode{}, EntityType: domain.ChangeEntityProduct, ParentColumn: "product_id"},
+		{Model: &domain.Category{}, EntityType: domain.ChangeEntityCategory},
+		{Model: &domain.Tax{}, EntityType: domain.ChangeEntityTax},
+		{Model: &domain.Discount{}, EntityType: domain.ChangeEntityDiscount},
+	}
+	err = repository.RegisterChangeFeedCallbacks(db, changeFeedTables...)
+	helper.PanicIfError(err)
+	err = repository.BackfillChangeFeed(db, changeFeedTables...)
 	helper.PanicIfError(err)
 
 	// Initialize Validator
 	validate := validator.New()
 
 	// Initialize Repository, Service, and Controller
+	transactionManager := repository.NewTransactionManager(db)
+	outboxRepository := repository.NewOutboxRepository(db)
+
+	storeRepository := repository.NewStoreRepository(db)
+	storeService := service.NewStoreService(storeRepository, validate)
+	storeController := controller.NewStoreController(storeService)
+
 	categoryRepository := repository.NewCategoryRepository(db)
-	categoryService := service.NewCategoryService(categoryRepository, validate)
+	categoryService := service.NewCategoryService(categoryRepository, transactionManager, validate)
 	categoryController := controller.NewCategoryController(categoryService)
 
 	customerRepository := repository.NewCustomerRepository(db)
-	CustomerService := service.NewCustomerService(customerRepository, validate)
+	orderRepository := repository.NewOrderRepository(db)
+	auditLogRepository := repository.NewAuditLogRepository(db)
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository(db)
+	CustomerService := service.NewCustomerService(customerRepository, orderRepository, auditLogRepository, outboxRepository, webhookDeliveryRepository, transactionManager, validate)
 	customerController := controller.NewCustomerController(CustomerService)
 
 	employeeRepository := repository.NewEmployeeRepository(db)
 	employeeService := service.NewEmployeeService(employeeRepository, validate)
 	employeeController := controller.NewEmployeeController(employeeService)
 
+	priceHistoryRepository := repository.NewPriceHistoryRepository(db)
+
+	productRepository := repository.NewProductRepository(db)
+	productService := service.NewProductService(productRepository, priceHistoryRepository, outboxRepository, transactionManager, validate)
+	productController := controller.NewProductController(productService)
+
+	productImportService := service.NewProductImportService(productRepository, categoryRepository, priceHistoryRepository, outboxRepository, transactionManager, validate)
+	productImportController := controller.NewProductImportController(productImportService)
+
+	productVariantRepository := repository.NewProductVariantRepository(db)
+	productVariantService := service.NewProductVariantService(productRepository, productVariantRepository, validate)
+	productVariantController := controller.NewProductVariantController(productVariantService)
+
+	productBarcodeRepository := repository.NewProductBarcodeRepository(db)
+	productBarcodeService := service.NewProductBarcodeService(productRepository, productVariantRepository, productBarcodeRepository, transactionManager, validate)
+	productBarcodeController := controller.NewProductBarcodeController(productBarcodeService)
+
+	priceListRepository := repository.NewPriceListRepository(db)
+	priceScheduleRepository := repository.NewPriceScheduleRepository(db)
+	priceListService := service.NewPriceListService(priceListRepository, priceScheduleRepository, priceHistoryRepository, productRepository, productVariantRepository, customerRepository, outboxRepository, transactionManager, validate)
+	priceListController := controller.NewPriceListController(priceListService)
+
+	priceScheduleService := service.NewPriceScheduleService(priceScheduleRepository, priceListRepository, priceHistoryRepository, productRepository, outboxRepository, transactionManager, validate)
+	priceScheduleController := controller.NewPriceScheduleController(priceScheduleService)
+
+	storeStockRepository := repository.NewStoreStockRepository(db)
+	inventoryRepository := repository.NewInventoryRepository(db)
+	stockTransferRepository := repository.NewStockTransferRepository(db)
+	stockService := service.NewStockService(storeStockRepository, inventoryRepository, stockTransferRepository, storeRepository, productRepository, productVariantRepository, outboxRepository, transactionManager, validate)
+	stockController := controller.NewStockController(stockService)
+
+	supplierRepository := repository.NewSupplierRepository(db)
+	supplierService := service.NewSupplierService(supplierRepository, validate)
+	supplierController := controller.NewSupplierController(supplierService)
+
+	purchaseOrderRepository := repository.NewPurchaseOrderRepository(db)
+	purchaseOrderService := service.NewPurchaseOrderService(purchaseOrderRepository, supplierRepository, inventoryRepository, storeStockRepository, productRepository, productVariantRepository, outboxRepository, transactionManager, validate)
+	purchaseOrderController := controller.NewPurchaseOrderController(purchaseOrderService)
+
+	stocktakeRepository := repository.NewStocktakeRepository(db)
+	stocktakeService := service.NewStocktakeService(stocktakeRepository, storeStockRepository, inventoryRepository, productRepository, productVariantRepository, employeeRepository, productBarcodeService, outboxRepository, transactionManager, validate)
+	stocktakeController := controller.NewStocktakeController(stocktakeService)
+
+	shiftRepository := repository.NewShiftRepository(db)
+	shiftService := service.NewShiftService(shiftRepository, employeeRepository, transactionManager, validate)
+	shiftController := controller.NewShiftController(shiftService)
+
+	paymentRepository := repository.NewPaymentRepository(db)
+	orderService := service.NewOrderService(orderRepository, productRepository, productVariantRepository, storeStockRepository, inventoryRepository, paymentRepository, employeeRepository, shiftRepository, priceListService, outboxRepository, transactionManager, validate)
+	orderController := controller.NewOrderController(orderService)
+
+	reportRepository := repository.NewReportRepository(db)
+	reportService := service.NewReportService(reportRepository, validate)
+	reportController := controller.NewReportController(reportService)
+
+	webhookSubscriptionRepository := repository.NewWebhookSubscriptionRepository(db)
+	webhookService := service.NewWebhookService(webhookSubscriptionRepository, webhookDeliveryRepository, &http.Client{Timeout: 10 * time.Second}, validate)
+	webhookController := controller.NewWebhookController(webhookService)
+
+	syncService := service.NewSyncService(repository.NewEntityChangeRepository(db), productRepository, categoryRepository, repository.NewTaxRepository(db), repository.NewDiscountRepository(db), validate)
+	syncController := controller.NewSyncController(syncService)
+
+	// Fan the events out to the clients of the live event stream
+	eventHub := service.NewEventHub()
+	eventStreamService := service.NewEventStreamService(outboxRepository, eventHub, validate)
+	eventController := controller.NewEventController(eventStreamService)
+
+	auditLogService := service.NewAuditLogService(auditLogRepository)
+	auditLogController := controller.NewAuditLogController(auditLogService)
+
+	// Accept the system API key, or the API key of an employee who then makes the request
+	systemApiKey := os.Getenv("API_KEY")
+	if systemApiKey == "" {
+		log.Println("API_KEY is not set, using the default system API key")
+		systemApiKey = "RAHASIA"
+	}
+	authMiddleware := middleware.NewAuthMiddleware(systemApiKey, employeeRepository)
+
+	// Resolve the store of every request from the employee's store, or X-Store-Id for admins
+	storeMiddleware := middleware.NewStoreMiddleware(storeRepository)
+
+	// Replay responses of retried POST requests for IDEMPOTENCY_TTL, 24 hours by default
+	idempotencyTtl := 24 * time.Hour
+	if value := os.Getenv("IDEMPOTENCY_TTL"); value == "" {
+		log.Println("IDEMPOTENCY_TTL is not set, keeping idempotency keys for 24h")
+	} else {
+		idempotencyTtl, err = time.ParseDuration(value)
+		helper.PanicIfError(err)
+	}
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(repository.NewIdempotencyKeyRepository(db), idempotencyTtl)
+
+	// Apply and end scheduled price changes every minute
+	app.StartJob(context.Background(), "price-schedules", time.Minute, func(ctx context.Context) error {
+		_, err := priceScheduleService.ApplyDue(ctx, time.Now())
+		return err
+	})
+
+	// Anonymise the customers that have been inactive for CUSTOMER_RETENTION_DAYS, once an
+	// hour; customers are kept as they are when it is not set
+	if value := os.Getenv("CUSTOMER_RETENTION_DAYS"); value != "" {
+		retentionDays, err := strconv.Atoi(value)
+		if err != nil || retentionDays < 1 {
+			log.Fatalf("CUSTOMER_RETENTION_DAYS must be a number of days, got %q", value)
+		}
+		retentionCtx := context.WithValue(context.Background(), helper.ContextKeyActor, "customer-retention")
+		app.StartJob(retentionCtx, "customer-retention", time.Hour, func(ctx context.Context) error {
+			_, err := CustomerService.AnonymiseInactive(ctx, time.Now().AddDate(0, 0, -retentionDays))
+			return err
+		})
+	}
+
+	// Deliver the domain events of the outbox to in-process subscribers, to the webhooks
+	// in OUTBOX_WEBHOOK_URLS (comma separated) and, for testing, to the file in OUTBOX_FILE
+	// ("-" for stdout)
+	eventBus := service.NewEventBus()
+	eventBus.Subscribe(service.AllEvents, webhookService.Enqueue)
+	eventBus.Subscribe(service.AllEvents, eventHub.Publish)
+	eventSinks := []service.EventSink{eventBus}
+	for _, url := range strings.Split(os.Getenv("OUTBOX_WEBHOOK_URLS"), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			eventSinks = append(eventSinks, service.NewWebhookSink(url))
+		}
+	}
+	if path := os.Getenv("OUTBOX_FILE"); path == "-" {
+		eventSinks = append(eventSinks, service.NewWriterSink("stdout", os.Stdout))
+	} else if path != "" {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		helper.PanicIfError(err)
+		defer file.Close()
+		eventSinks = append(eventSinks, service.NewWriterSink(path, file))
+	}
+	outboxDispatcher := service.NewOutboxDispatcher(outboxRepository, eventSinks...)
+	app.StartJob(context.Background(), "outbox", 5*time.Second, func(ctx context.Context) error {
+		_, err := outboxDispatcher.Dispatch(ctx, time.Now())
+		return err
+	})
+
+	// Send the deliveries of the webhook subscriptions, retrying failed ones with backoff
+	app.StartJob(context.Background(), "webhooks", 5*time.Second, func(ctx context.Context) error {
+		_, err := webhookService.Dispatch(ctx, time.Now())
+		return err
+	})
+
 	// Setup Routes
-	app.NewRouter(server, categoryController)
-	app.NewRouter(server, customerController)
-	app.NewRouter(server, employeeController)
+	app.NewRouter(server, authMiddleware, storeMiddleware, idempotencyMiddleware, storeController, categoryController, customerController, employeeController, productController, productImportController, productVariantController, productBarcodeController, orderController, priceListController, priceScheduleController, shiftController, stockController, supplierController, purchaseOrderController, stocktakeController, webhookController, syncController, eventController, reportController, auditLogController)
 
 	// Start Server
 	log.Println("Server running on port 8080")
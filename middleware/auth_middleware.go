@@ -1,21 +1,72 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/helper"
 	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
-type AuthMiddleware struct{}
+const (
+	HeaderApiKey     = "X-API-Key"
+	HeaderEmployeeId = "X-Employee-Id"
 
-func NewAuthMiddleware() fiber.Handler {
+	// localsEmployee holds the domain.Employee the API key of the request belongs to.
+	localsEmployee = "employee"
+)
+
+// NewAuthMiddleware accepts requests made with the system API key, used by integrations
+// and the back office, or with the API key of an employee. The employee is taken from
+// their key; an X-Employee-Id header naming anyone else is refused, and so is one sent
+// with the system key, which does not act for an employee.
+func NewAuthMiddleware(systemApiKey string, employeeRepository repository.EmployeeRepository) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		if c.Get("X-API-Key") == "RAHASIA" {
+		apiKey := c.Get(HeaderApiKey)
+		if apiKey == "" {
+			return authError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", nil)
+		}
+		employeeId := c.Get(HeaderEmployeeId)
+
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(systemApiKey)) == 1 {
+			if employeeId != "" {
+				return authError(c, fiber.StatusForbidden, "Forbidden", "X-Employee-Id needs the API key of that employee")
+			}
+			c.Locals(helper.ContextKeyActor, maskedApiKey(apiKey))
 			return c.Next()
 		}
 
-		return c.Status(fiber.StatusUnauthorized).JSON(web.WebResponse{
-			Code:   fiber.StatusUnauthorized,
-			Status: "UNAUTHORIZED",
-		})
+		employee, err := employeeRepository.FindByApiKeyHash(repository.WithoutStoreScope(c.Context()), helper.HashApiKey(apiKey))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return authError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", nil)
+		} else if err != nil {
+			return err
+		}
+		if employeeId != "" && employeeId != employee.EmployeeID {
+			return authError(c, fiber.StatusForbidden, "Forbidden", "The API key does not belong to employee "+employeeId)
+		}
+
+		c.Locals(localsEmployee, employee)
+		c.Locals(helper.ContextKeyEmployee, employee.EmployeeID)
+		c.Locals(helper.ContextKeyActor, "employee:"+employee.EmployeeID)
+		return c.Next()
+	}
+}
+
+// maskedApiKey identifies a request made with the system API key without logging the key.
+func maskedApiKey(apiKey string) string {
+	if len(apiKey) > 4 {
+		apiKey = apiKey[:4] + "****"
 	}
+	return "api_key:" + apiKey
+}
+
+func authError(c *fiber.Ctx, code int, status string, data interface{}) error {
+	return c.Status(code).JSON(web.WebResponse{
+		Code:   code,
+		Status: status,
+		Data:   data,
+	})
 }
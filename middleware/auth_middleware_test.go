@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	employeeRepo := mocks.NewMockEmployeeRepository(ctrl)
+	app := fiber.New()
+	app.Use(NewAuthMiddleware("system-key", employeeRepo))
+	app.Get("/api/orders", func(c *fiber.Ctx) error {
+		return c.SendString(helper.ActorFromContext(c.Context()) + "|" + helper.EmployeeIdFromContext(c.Context()))
+	})
+
+	get := func(headers map[string]string) (int, string) {
+		req := httptest.NewRequest("GET", "/api/orders", nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("system key", func(t *testing.T) {
+		status, body := get(map[string]string{HeaderApiKey: "system-key"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "api_key:syst****|", body)
+	})
+
+	t.Run("system key cannot name an employee", func(t *testing.T) {
+		status, _ := get(map[string]string{HeaderApiKey: "system-key", HeaderEmployeeId: "e-1"})
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("employee key", func(t *testing.T) {
+		employeeRepo.EXPECT().FindByApiKeyHash(gomock.Any(), helper.HashApiKey("cashier-key")).
+			Return(domain.Employee{EmployeeID: "e-1", Role: domain.EmployeeRoleCashier}, nil)
+		status, body := get(map[string]string{HeaderApiKey: "cashier-key", HeaderEmployeeId: "e-1"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "employee:e-1|e-1", body)
+	})
+
+	t.Run("employee key naming another employee", func(t *testing.T) {
+		employeeRepo.EXPECT().FindByApiKeyHash(gomock.Any(), helper.HashApiKey("cashier-key")).
+			Return(domain.Employee{EmployeeID: "e-1", Role: domain.EmployeeRoleCashier}, nil)
+		status, _ := get(map[string]string{HeaderApiKey: "cashier-key", HeaderEmployeeId: "e-2"})
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		employeeRepo.EXPECT().FindByApiKeyHash(gomock.Any(), helper.HashApiKey("guess")).Return(domain.Employee{}, gorm.ErrRecordNotFound)
+		status, _ := get(map[string]string{HeaderApiKey: "guess"})
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		status, _ := get(nil)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+}
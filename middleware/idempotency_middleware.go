@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	idempotencyMaxKeyLength  = 255
+	idempotencyInFlightWait  = 10 * time.Second
+	idempotencyInFlightPoll  = 100 * time.Millisecond
+	// idempotencyLease is how long a request holds its key before a retry may take it
+	// over, which only happens when the request never stored a response.
+	idempotencyLease = 5 * time.Minute
+)
+
+// NewIdempotencyMiddleware makes POST requests carrying an Idempotency-Key header safe to
+// retry. The first request with a key is executed and its response is stored for ttl;
+// identical retries get the stored response back, while reusing the key with a different
+// payload is rejected with 409. A retry that arrives while the first request is still
+// running waits for it to finish; one arriving after the first request crashed, once its
+// lease has run out, is executed. Keys are kept per caller and store, so the same key
+// sent by another API key, employee or store is another key.
+func NewIdempotencyMiddleware(idempotencyKeyRepository repository.IdempotencyKeyRepository, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if c.Method() != fiber.MethodPost || key == "" {
+			return c.Next()
+		}
+		if len(key) > idempotencyMaxKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(web.WebResponse{
+				Code:   fiber.StatusBadRequest,
+				Status: "Bad Request",
+				Data:   "Idempotency-Key must be at most 255 characters",
+			})
+		}
+
+		now := time.Now()
+		lockedUntil := now.Add(idempotencyLease)
+		stored, reserved, err := idempotencyKeyRepository.Reserve(c.Context(), domain.IdempotencyKey{
+			Key:         scopedIdempotencyKey(c, key),
+			Fingerprint: requestFingerprint(c),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+			LockedUntil: &lockedUntil,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !reserved {
+			return replayIdempotentResponse(c, idempotencyKeyRepository, stored)
+		}
+
+		if err := c.Next(); err != nil {
+			_ = idempotencyKeyRepository.Delete(c.Context(), stored.Key)
+			return err
+		}
+
+		// Server errors are not remembered so that the client can retry them.
+		statusCode := c.Response().StatusCode()
+		if statusCode >= fiber.StatusInternalServerError {
+			return idempotencyKeyRepository.Delete(c.Context(), stored.Key)
+		}
+
+		stored.StatusCode = statusCode
+		stored.ContentType = string(c.Response().Header.ContentType())
+		stored.ResponseBody = append([]byte(nil), c.Response().Body()...)
+		return idempotencyKeyRepository.Complete(c.Context(), stored)
+	}
+}
+
+func replayIdempotentResponse(c *fiber.Ctx, idempotencyKeyRepository repository.IdempotencyKeyRepository, stored domain.IdempotencyKey) error {
+	if stored.Fingerprint != requestFingerprint(c) {
+		return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+			Code:   fiber.StatusConflict,
+			Status: "Conflict",
+			Data:   "Idempotency-Key has already been used with a different request",
+		})
+	}
+
+	deadline := time.Now().Add(idempotencyInFlightWait)
+	for !stored.Completed {
+		if stored.LeaseExpired(time.Now()) {
+			return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+				Code:   fiber.StatusConflict,
+				Status: "Conflict",
+				Data:   "The original request with this Idempotency-Key did not complete, please retry",
+			})
+		}
+		if time.Now().After(deadline) {
+			return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+				Code:   fiber.StatusConflict,
+				Status: "Conflict",
+				Data:   "A request with this Idempotency-Key is still being processed",
+			})
+		}
+		time.Sleep(idempotencyInFlightPoll)
+
+		var err error
+		stored, err = idempotencyKeyRepository.FindByKey(c.Context(), stored.Key)
+		if err != nil {
+			// The original request failed and released the key; let the client retry.
+			return c.Status(fiber.StatusConflict).JSON(web.WebResponse{
+				Code:   fiber.StatusConflict,
+				Status: "Conflict",
+				Data:   "The original request with this Idempotency-Key did not complete, please retry",
+			})
+		}
+	}
+
+	c.Set(HeaderIdempotentReplayed, "true")
+	c.Set(fiber.HeaderContentType, stored.ContentType)
+	return c.Status(stored.StatusCode).Send(stored.ResponseBody)
+}
+
+// scopedIdempotencyKey is the key as stored: a hash of the key sent with the caller and
+// the store of the request, so that callers never share stored responses.
+func scopedIdempotencyKey(c *fiber.Ctx, key string) string {
+	actor, _ := c.Locals(helper.ContextKeyActor).(string)
+	storeId, _ := c.Locals(helper.ContextKeyStore).(string)
+	hash := sha256.New()
+	hash.Write([]byte(actor))
+	hash.Write([]byte{0})
+	hash.Write([]byte(storeId))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// requestFingerprint identifies the request payload, so a reused key can be told apart
+// from a genuine retry.
+func requestFingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.Path()))
+	hash.Write([]byte{0})
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
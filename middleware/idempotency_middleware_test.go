@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setupIdempotencyTestApp(calls *int32, delay time.Duration) *fiber.App {
+	return setupIdempotencyTestAppWith(repository.NewIdempotencyKeyRepositoryMemory(), calls, delay)
+}
+
+// setupIdempotencyTestAppWith runs the middleware on a repository, for the caller and
+// store the test-only headers X-Test-Actor and X-Test-Store name.
+func setupIdempotencyTestAppWith(idempotencyKeyRepository repository.IdempotencyKeyRepository, calls *int32, delay time.Duration) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(helper.ContextKeyActor, c.Get("X-Test-Actor", "employee:e-1"))
+		c.Locals(helper.ContextKeyStore, c.Get("X-Test-Store", "1"))
+		return c.Next()
+	})
+	app.Use(NewIdempotencyMiddleware(idempotencyKeyRepository, time.Hour))
+	app.Post("/api/orders", func(c *fiber.Ctx) error {
+		n := atomic.AddInt32(calls, 1)
+		time.Sleep(delay)
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"order": n})
+	})
+	return app
+}
+
+func postOrder(t *testing.T, app *fiber.App, key string, body string, headers ...string) (*http.Response, string) {
+	req := httptest.NewRequest("POST", "/api/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	resp, err := app.Test(req, -1)
+	assert.NoError(t, err)
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp, string(respBody)
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Run("retry replays the stored response", func(t *testing.T) {
+		var calls int32
+		app := setupIdempotencyTestApp(&calls, 0)
+
+		first, firstBody := postOrder(t, app, "key-1", `{"total":10}`)
+		second, secondBody := postOrder(t, app, "key-1", `{"total":10}`)
+
+		assert.Equal(t, http.StatusCreated, first.StatusCode)
+		assert.Equal(t, http.StatusCreated, second.StatusCode)
+		assert.Equal(t, firstBody, secondBody)
+		assert.Equal(t, "true", second.Header.Get(HeaderIdempotentReplayed))
+		assert.Equal(t, int32(1), calls)
+	})
+
+	t.Run("same key with a different payload is rejected", func(t *testing.T) {
+		var calls int32
+		app := setupIdempotencyTestApp(&calls, 0)
+
+		postOrder(t, app, "key-2", `{"total":10}`)
+		resp, _ := postOrder(t, app, "key-2", `{"total":99}`)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, int32(1), calls)
+	})
+
+	t.Run("requests without a key are not deduplicated", func(t *testing.T) {
+		var calls int32
+		app := setupIdempotencyTestApp(&calls, 0)
+
+		postOrder(t, app, "", `{"total":10}`)
+		postOrder(t, app, "", `{"total":10}`)
+
+		assert.Equal(t, int32(2), calls)
+	})
+
+	t.Run("concurrent duplicates run the handler once", func(t *testing.T) {
+		var calls int32
+		app := setupIdempotencyTestApp(&calls, 200*time.Millisecond)
+
+		var wg sync.WaitGroup
+		bodies := make([]string, 3)
+		for i := range bodies {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, bodies[i] = postOrder(t, app, "key-3", `{"total":10}`)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls)
+		assert.Equal(t, bodies[0], bodies[1])
+		assert.Equal(t, bodies[0], bodies[2])
+	})
+
+	t.Run("keys are kept per caller and store", func(t *testing.T) {
+		var calls int32
+		app := setupIdempotencyTestApp(&calls, 0)
+
+		_, firstBody := postOrder(t, app, "key-4", `{"total":10}`)
+		_, otherActorBody := postOrder(t, app, "key-4", `{"total":10}`, "X-Test-Actor", "employee:e-2")
+		_, otherStoreBody := postOrder(t, app, "key-4", `{"total":10}`, "X-Test-Store", "2")
+
+		assert.Equal(t, int32(3), calls)
+		assert.NotEqual(t, firstBody, otherActorBody)
+		assert.NotEqual(t, firstBody, otherStoreBody)
+	})
+
+	t.Run("a key left in progress is taken over once its lease ran out", func(t *testing.T) {
+		var calls int32
+		idempotencyKeyRepository := repository.NewIdempotencyKeyRepositoryMemory()
+		app := setupIdempotencyTestAppWith(idempotencyKeyRepository, &calls, 0)
+
+		// The first request stored no response, as if it crashed, and its lease has run out
+		app.Post("/setup", func(c *fiber.Ctx) error {
+			fingerprint := sha256.Sum256([]byte("POST\x00/api/orders\x00" + `{"total":10}`))
+			now := time.Now()
+			lockedUntil := now.Add(-time.Second)
+			_, _, err := idempotencyKeyRepository.Reserve(c.Context(), domain.IdempotencyKey{
+				Key:         scopedIdempotencyKey(c, "key-5"),
+				Fingerprint: hex.EncodeToString(fingerprint[:]),
+				CreatedAt:   now.Add(-time.Minute),
+				ExpiresAt:   now.Add(time.Hour),
+				LockedUntil: &lockedUntil,
+			})
+			return err
+		})
+		_, err := app.Test(httptest.NewRequest("POST", "/setup", nil), -1)
+		assert.NoError(t, err)
+
+		resp, _ := postOrder(t, app, "key-5", `{"total":10}`)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, int32(1), calls)
+	})
+}
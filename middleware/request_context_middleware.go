@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// NewRequestContextMiddleware stores the request id and client IP on the request
+// so that services and repositories can read them back from the context.
+func NewRequestContextMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestId := c.Get(fiber.HeaderXRequestID)
+		if requestId == "" {
+			requestId = uuid.NewString()
+		}
+		c.Set(fiber.HeaderXRequestID, requestId)
+
+		c.Locals(helper.ContextKeyRequestId, requestId)
+		c.Locals(helper.ContextKeyClientIP, c.IP())
+		return c.Next()
+	}
+}
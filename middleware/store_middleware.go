@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+)
+
+const HeaderStoreId = "X-Store-Id"
+
+// NewStoreMiddleware resolves the store a request is made for, which confines the
+// repositories to that store's rows. It runs after NewAuthMiddleware. An employee acts
+// for the store they work at; only admins, and the system API key, may pick a store with
+// the X-Store-Id header.
+func NewStoreMiddleware(storeRepository repository.StoreRepository) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		employee, isEmployee := c.Locals(localsEmployee).(domain.Employee)
+		canSwitchStores := !isEmployee || strings.EqualFold(employee.Role, domain.EmployeeRoleAdmin)
+
+		var storeId uint64
+		if isEmployee {
+			storeId = employee.StoreID
+		}
+
+		if header := c.Get(HeaderStoreId); header != "" {
+			id, err := strconv.ParseUint(header, 10, 64)
+			if err != nil || id == 0 {
+				return authError(c, fiber.StatusBadRequest, "Bad Request", "X-Store-Id must be a store id")
+			}
+			if !canSwitchStores && id != storeId {
+				return authError(c, fiber.StatusForbidden, "Forbidden", "Employee "+employee.EmployeeID+" does not work at store "+header)
+			}
+			if _, err := storeRepository.FindById(c.Context(), id); errors.Is(err, gorm.ErrRecordNotFound) {
+				return authError(c, fiber.StatusBadRequest, "Bad Request", "Store "+header+" does not exist")
+			} else if err != nil {
+				return err
+			}
+			storeId = id
+		}
+
+		if storeId == 0 && !canSwitchStores {
+			return authError(c, fiber.StatusForbidden, "Forbidden", "Employee "+employee.EmployeeID+" is not assigned to a store")
+		}
+		if storeId != 0 {
+			c.Locals(helper.ContextKeyStore, strconv.FormatUint(storeId, 10))
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStoreMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	employees := map[string]domain.Employee{
+		"cashier": {EmployeeID: "e-1", Role: domain.EmployeeRoleCashier, StoreID: 3},
+		"admin":   {EmployeeID: "e-2", Role: domain.EmployeeRoleAdmin, StoreID: 1},
+		"nowhere": {EmployeeID: "e-3", Role: domain.EmployeeRoleCashier},
+	}
+	app := fiber.New()
+	// Stands in for NewAuthMiddleware, which sets the employee of the API key.
+	app.Use(func(c *fiber.Ctx) error {
+		if employee, ok := employees[c.Get(HeaderApiKey)]; ok {
+			c.Locals(localsEmployee, employee)
+		}
+		return c.Next()
+	})
+	app.Use(NewStoreMiddleware(storeRepo))
+	app.Get("/api/orders", func(c *fiber.Ctx) error {
+		storeId, _ := helper.StoreIdFromContext(c.Context())
+		return c.JSON(storeId)
+	})
+
+	get := func(headers map[string]string) (int, string) {
+		req := httptest.NewRequest("GET", "/api/orders", nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("store from the header with the system key", func(t *testing.T) {
+		storeRepo.EXPECT().FindById(gomock.Any(), uint64(2)).Return(domain.Store{Id: 2}, nil)
+		status, body := get(map[string]string{HeaderStoreId: "2"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "2", body)
+	})
+
+	t.Run("store of the employee", func(t *testing.T) {
+		status, body := get(map[string]string{HeaderApiKey: "cashier"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "3", body)
+	})
+
+	t.Run("employee naming their own store", func(t *testing.T) {
+		storeRepo.EXPECT().FindById(gomock.Any(), uint64(3)).Return(domain.Store{Id: 3}, nil)
+		status, body := get(map[string]string{HeaderApiKey: "cashier", HeaderStoreId: "3"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "3", body)
+	})
+
+	t.Run("employee acting for another store", func(t *testing.T) {
+		status, _ := get(map[string]string{HeaderApiKey: "cashier", HeaderStoreId: "2"})
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("admin switching stores", func(t *testing.T) {
+		storeRepo.EXPECT().FindById(gomock.Any(), uint64(2)).Return(domain.Store{Id: 2}, nil)
+		status, body := get(map[string]string{HeaderApiKey: "admin", HeaderStoreId: "2"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "2", body)
+	})
+
+	t.Run("employee without a store", func(t *testing.T) {
+		status, _ := get(map[string]string{HeaderApiKey: "nowhere"})
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("unknown store", func(t *testing.T) {
+		storeRepo.EXPECT().FindById(gomock.Any(), uint64(9)).Return(domain.Store{}, gorm.ErrRecordNotFound)
+		status, _ := get(map[string]string{HeaderStoreId: "9"})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		status, _ := get(map[string]string{HeaderStoreId: "abc"})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("no store", func(t *testing.T) {
+		status, body := get(nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "0", body)
+	})
+}
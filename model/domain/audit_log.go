@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// AuditActionMerge is the action of the audit log entry of a customer merged into another,
+// whose changes name the survivor under merged_into.
+const AuditActionMerge = "merge"
+
+type AuditLog struct {
+	Id         uint64    `gorm:"primary_key;autoIncrement;column:id"`
+	EntityType string    `gorm:"column:entity_type; type:varchar(50); index"`
+	EntityId   string    `gorm:"column:entity_id; type:varchar(100); index"`
+	Action     string    `gorm:"column:action; type:varchar(20)"` // create, update, delete, merge
+	Actor      string    `gorm:"column:actor; type:varchar(100); index"`
+	Changes    string    `gorm:"column:changes; type:text"` // JSON object of column -> {before, after}
+	ClientIP   string    `gorm:"column:client_ip; type:varchar(45)"`
+	RequestId  string    `gorm:"column:request_id; type:varchar(64)"`
+	CreatedAt  time.Time `gorm:"column:created_at; index"`
+}
+
+type AuditLogFilter struct {
+	EntityType string
+	EntityId   string
+	Actor      string
+	Action     string
+	From       *time.Time
+	To         *time.Time
+}
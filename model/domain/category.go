@@ -1,7 +1,13 @@
 package domain
 
+import "time"
+
 type Category struct {
-	Id      uint64    `gorm:"primary_key;autoIncrement;column:id"`
-	Name    string    `gorm:"column:name"`
-	Product []Product `gorm:"foreignkey:CategoryId;references:Id"`
+	Id        uint64    `gorm:"primary_key;autoIncrement;column:id"`
+	Name      string    `gorm:"column:name"`
+	ParentId  *uint64   `gorm:"column:parent_id; index"`
+	Version   uint64    `gorm:"column:version; not null; default:1"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+	Product   []Product `gorm:"foreignkey:CategoryId;references:Id"`
 }
@@ -1,10 +1,41 @@
 package domain
 
+import "time"
+
+// AnonymisedCustomerName replaces the name of an anonymised customer.
+const AnonymisedCustomerName = "Anonymised"
+
+// Customer is a customer of any store. Email and Phone are stored as NULL when empty, as
+// they are on anonymised customers, which the unique indexes allow any number of.
 type Customer struct {
-	CustomerID uint64 `gorm:"primary_key;column:id;autoIncrement"`
-	Name       string `gorm:"column:customer_name; type:varchar(100);"`
-	Email      string `gorm:"column:customer_email; type:varchar(255);"`
-	Phone      string `gorm:"column:customer_phone; type:varchar(20);"`
-	Address    string `gorm:"column:customer_address; type:varchar(255);"`
-	LoyaltyPts int    `gorm:"column:loyalty_pts; type:int(11);"`
+	CustomerID  uint64    `gorm:"primary_key;column:id;autoIncrement"`
+	Name        string    `gorm:"column:customer_name; type:varchar(100);"`
+	Email       string    `gorm:"column:customer_email; type:varchar(255); uniqueIndex:uq_customer_email; serializer:emptynull"`
+	Phone       string    `gorm:"column:customer_phone; type:varchar(20); uniqueIndex:uq_customer_phone; serializer:emptynull"`
+	Address     string    `gorm:"column:customer_address; type:varchar(255);"`
+	LoyaltyPts  int       `gorm:"column:loyalty_pts; type:int(11);"`
+	PriceListId *uint64   `gorm:"column:price_list_id; index"`
+	Version     uint64    `gorm:"column:version; not null; default:1"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+	// Set when the personal data of the customer has been scrubbed
+	AnonymisedAt *time.Time `gorm:"column:anonymised_at; index"`
+}
+
+// CustomerOrderSummary sums up the orders of one customer.
+type CustomerOrderSummary struct {
+	OrderCount     int64
+	TotalSpent     float64
+	FirstOrderDate *time.Time
+	LastOrderDate  *time.Time
+}
+
+// CustomerProductPurchase sums up what one customer bought of one product.
+type CustomerProductPurchase struct {
+	ProductID     string
+	ProductName   string
+	Quantity      int64
+	OrderCount    int64
+	Revenue       float64
+	LastOrderDate time.Time
 }
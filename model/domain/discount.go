@@ -1,9 +1,15 @@
 package domain
 
+import "time"
+
+// Discount is a percentage off, valid from ValidFrom until ValidUntil when they are set.
 type Discount struct {
-	DiscountID  string  `json:"discount_id"`
-	Description string  `json:"description"`
-	DiscountPct float64 `json:"discount_pct"` // e.g., 10 for 10%
-	ValidFrom   string  `json:"valid_from"`
-	ValidUntil  string  `json:"valid_until"`
+	DiscountID  string     `gorm:"primaryKey;column:id; type:varchar(50)"`
+	Description string     `gorm:"column:description; type:varchar(255)"`
+	DiscountPct float64    `gorm:"column:discount_pct"` // e.g., 10 for 10%
+	ValidFrom   *time.Time `gorm:"column:valid_from"`
+	ValidUntil  *time.Time `gorm:"column:valid_until"`
+	Version     uint64     `gorm:"column:version; not null; default:1"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
 }
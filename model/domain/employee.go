@@ -1,10 +1,20 @@
 package domain
 
+import "time"
+
+// Employee works at the store StoreID. Requests on behalf of an employee are made with
+// their own API key, of which only the SHA-256 hash is kept. Email and Phone are stored
+// as NULL when empty, so the unique indexes allow any number of employees without them.
 type Employee struct {
-	EmployeeID string `gorm:"column:id;primary_key"`
-	Name       string `gorm:"column:name"`
-	Role       string `gorm:"column:role"` // e.g., Cashier, Manager
-	Email      string `gorm:"column:email"`
-	Phone      string `gorm:"column:phone"`
-	DateHired  string `gorm:"column:date_hired"`
+	EmployeeID string    `gorm:"column:id;primary_key"`
+	Name       string    `gorm:"column:name"`
+	Role       string    `gorm:"column:role"` // e.g., Cashier, Manager
+	Email      string    `gorm:"column:email; type:varchar(255); uniqueIndex:uq_employee_email; serializer:emptynull"`
+	Phone      string    `gorm:"column:phone; type:varchar(50); uniqueIndex:uq_employee_phone; serializer:emptynull"`
+	DateHired  string    `gorm:"column:date_hired"`
+	StoreID    uint64    `gorm:"column:store_id; index"`
+	ApiKeyHash string    `gorm:"column:api_key_hash; type:varchar(64); uniqueIndex:uq_employee_api_key; serializer:emptynull" audit:"-"`
+	Version    uint64    `gorm:"column:version; not null; default:1"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
 }
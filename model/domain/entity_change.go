@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+const (
+	ChangeUpsert = "upsert"
+	ChangeDelete = "delete"
+
+	ChangeEntityProduct  = "product"
+	ChangeEntityCategory = "category"
+	ChangeEntityTax      = "tax"
+	ChangeEntityDiscount = "discount"
+)
+
+// EntityChange records that an entity was created or updated (upsert) or deleted. Seq is
+// the cursor of the change feed and does not depend on any clock. The database assigns it
+// when the row is inserted, not when the transaction commits, so a change can become
+// visible after changes with a higher Seq. The feed stops at such a gap until the gap is
+// older than the gap timeout of the sync service, and then skips it as a rollback: a
+// change whose transaction takes longer than that to commit is missed by terminals that
+// already moved past it.
+type EntityChange struct {
+	Seq        uint64    `gorm:"primaryKey;autoIncrement;column:seq"`
+	EntityType string    `gorm:"column:entity_type; type:varchar(50); index"`
+	EntityId   string    `gorm:"column:entity_id; type:varchar(191)"`
+	Action     string    `gorm:"column:action; type:varchar(10)"`
+	ChangedAt  time.Time `gorm:"column:changed_at"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// IdempotencyKey is the response stored for an Idempotency-Key header. Key is scoped to
+// the caller and store that sent it. Until the response is stored, the request holds the
+// key up to LockedUntil; after that, or without a LockedUntil, a retry takes it over.
+type IdempotencyKey struct {
+	Key          string     `gorm:"primary_key;column:id; type:varchar(255)"`
+	Fingerprint  string     `gorm:"column:fingerprint; type:char(64)"` // sha256 of method, path and body
+	Completed    bool       `gorm:"column:completed"`
+	StatusCode   int        `gorm:"column:status_code"`
+	ContentType  string     `gorm:"column:content_type; type:varchar(100)"`
+	ResponseBody []byte     `gorm:"column:response_body; type:mediumblob"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	ExpiresAt    time.Time  `gorm:"column:expires_at; index"`
+	LockedUntil  *time.Time `gorm:"column:locked_until"`
+}
+
+// LeaseExpired reports whether the request holding the key has stopped holding it at now
+// without storing a response, e.g. because it crashed.
+func (idempotencyKey IdempotencyKey) LeaseExpired(now time.Time) bool {
+	return !idempotencyKey.Completed && (idempotencyKey.LockedUntil == nil || idempotencyKey.LockedUntil.Before(now))
+}
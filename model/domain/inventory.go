@@ -1,8 +1,17 @@
 package domain
 
+import "time"
+
+// Inventory holds when the stock of a product, or of one of its variants, at a store
+// runs low and when it was last restocked. Stock at or below RestockLevel is reordered,
+// ReorderQuantity at a time or, without one, up to twice the restock level.
 type Inventory struct {
-	ProductID    string `json:"product_id"`
-	StockQty     int    `json:"stock_qty"`
-	RestockLevel int    `json:"restock_level"`
-	LastRestock  string `json:"last_restock"`
+	Id              uint64     `gorm:"primaryKey;autoIncrement;column:id" json:"-"`
+	StoreID         uint64     `gorm:"column:store_id; uniqueIndex:idx_inventory" json:"store_id"`
+	ProductID       string     `gorm:"column:product_id; type:varchar(191); uniqueIndex:idx_inventory" json:"product_id"`
+	VariantID       uint64     `gorm:"column:variant_id; uniqueIndex:idx_inventory" json:"variant_id"`
+	StockQty        int        `gorm:"->;-:migration;column:stock_qty" json:"stock_qty"` // on hand, read with the store stock
+	RestockLevel    int        `gorm:"column:restock_level" json:"restock_level"`
+	ReorderQuantity int        `gorm:"column:reorder_quantity" json:"reorder_quantity"`
+	LastRestock     *time.Time `gorm:"column:last_restock" json:"last_restock"`
 }
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+const (
+	CostMethodAverage = "average"
+	CostMethodFIFO    = "fifo"
+
+	MovementTypeRestock     = "restock"
+	MovementTypeSale        = "sale"
+	MovementTypeAdjustment  = "adjustment"
+	MovementTypeTransferOut = "transfer_out"
+	MovementTypeTransferIn  = "transfer_in"
+)
+
+// InventoryMovement is an entry of the inventory ledger: stock coming into a store
+// (positive Quantity) or going out of it (negative Quantity), valued at cost. The sum of
+// the movements up to a moment is the stock and its value at that moment.
+type InventoryMovement struct {
+	Id        uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	StoreID   uint64    `gorm:"column:store_id; index"`
+	ProductID string    `gorm:"column:product_id; type:varchar(191); index"`
+	VariantID uint64    `gorm:"column:variant_id"` // 0 for the product itself
+	Type      string    `gorm:"column:movement_type; type:varchar(20)"`
+	Quantity  int       `gorm:"column:quantity"`
+	UnitCost  float64   `gorm:"column:unit_cost"`
+	TotalCost float64   `gorm:"column:total_cost"`
+	Reference string    `gorm:"column:reference; type:varchar(191)"` // order, transfer or delivery note
+	Reason    string    `gorm:"column:reason; type:varchar(20)"`     // why an adjustment was made, e.g. damaged
+	CreatedBy string    `gorm:"column:created_by; type:varchar(100)"`
+	CreatedAt time.Time `gorm:"column:created_at; index"`
+}
+
+// CostLayer is stock on hand at a store that came in at one unit cost. FIFO products keep
+// a layer per receipt and sell from the oldest; weighted average products keep a single
+// layer at the average cost.
+type CostLayer struct {
+	Id         uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	StoreID    uint64    `gorm:"column:store_id; index:idx_cost_layer"`
+	ProductID  string    `gorm:"column:product_id; type:varchar(191); index:idx_cost_layer"`
+	VariantID  uint64    `gorm:"column:variant_id; index:idx_cost_layer"`
+	Quantity   int       `gorm:"column:quantity"`
+	UnitCost   float64   `gorm:"column:unit_cost"`
+	ReceivedAt time.Time `gorm:"column:received_at"`
+}
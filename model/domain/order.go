@@ -1,16 +1,53 @@
 package domain
 
+import "time"
+
+// Reconciliation statuses of an order taken offline, from good to bad.
+const (
+	ReconciliationAccepted = "accepted" // applied as taken
+	ReconciliationAdjusted = "adjusted" // applied with a correction that needs no follow-up
+	ReconciliationFlagged  = "flagged"  // applied, but needs a review
+)
+
+// Issues found when reconciling an order taken offline.
+const (
+	IssuePriceBelowCurrent = "price_below_current" // charged less than the current price; booked as discount
+	IssuePriceAboveCurrent = "price_above_current" // charged more than the current price
+	IssueNegativeStock     = "negative_stock"      // sold more than the store had in stock
+	IssueOverpaid          = "overpaid"            // paid more than the order total
+	IssueFutureOrderDate   = "future_order_date"   // dated after the upload, the terminal clock is off
+)
+
 type Order struct {
-	OrderID     string      `json:"order_id"`
-	CustomerID  string      `json:"customer_id"`
-	OrderDate   string      `json:"order_date"`
-	TotalAmount float64     `json:"total_amount"`
-	OrderItems  []OrderItem `json:"order_items"`
+	OrderID     string    `gorm:"primaryKey;column:id; type:varchar(36)" json:"order_id"`
+	CustomerID  string    `gorm:"column:customer_id; type:varchar(36); index" json:"customer_id"`
+	StoreID     uint64    `gorm:"column:store_id; index" json:"store_id"`
+	EmployeeID  string    `gorm:"column:employee_id; type:varchar(191); index" json:"employee_id"`
+	ShiftID     *uint64   `gorm:"column:shift_id; index" json:"shift_id"`
+	OrderDate   time.Time `gorm:"column:order_date; index" json:"order_date"`
+	TotalAmount float64   `gorm:"column:total_amount" json:"total_amount"`
+	Version     uint64    `gorm:"column:version; not null; default:1" json:"version"` // bumped whenever a payment is added
+	// Set on orders taken offline: how the upload reconciled them, with the issues as JSON
+	ReconciliationStatus string      `gorm:"column:reconciliation_status; type:varchar(20); not null; default:''; index" json:"reconciliation_status"`
+	ReconciliationIssues string      `gorm:"column:reconciliation_issues; type:text" json:"reconciliation_issues"`
+	UploadedAt           *time.Time  `gorm:"column:uploaded_at" json:"uploaded_at"`
+	OrderItems           []OrderItem `gorm:"foreignKey:OrderID;references:OrderID" json:"order_items"`
+	Payments             []Payment   `gorm:"foreignKey:OrderID;references:OrderID" json:"payments"`
 }
 
 type OrderItem struct {
-	ProductID  string  `json:"product_id"`
-	Quantity   int     `json:"quantity"`
-	UnitPrice  float64 `json:"unit_price"`
-	TotalPrice float64 `json:"total_price"`
+	Id         uint64  `gorm:"primaryKey;autoIncrement;column:id" json:"-"`
+	OrderID    string  `gorm:"column:order_id; type:varchar(36); index" json:"order_id"`
+	ProductID  string  `gorm:"column:product_id; type:varchar(191); index" json:"product_id"`
+	VariantID  *uint64 `gorm:"column:variant_id; index" json:"variant_id"`
+	Quantity   int     `gorm:"column:quantity" json:"quantity"`
+	UnitPrice  float64 `gorm:"column:unit_price" json:"unit_price"`
+	TotalPrice float64 `gorm:"column:total_price" json:"total_price"`
+	// Regular price, tax and discount as they were when the order was placed.
+	RegularPrice   float64 `gorm:"column:regular_price" json:"regular_price"`
+	DiscountAmount float64 `gorm:"column:discount_amount" json:"discount_amount"`
+	TaxType        string  `gorm:"column:tax_type; type:varchar(50)" json:"tax_type"`
+	TaxRate        float64 `gorm:"column:tax_rate" json:"tax_rate"`
+	TaxAmount      float64 `gorm:"column:tax_amount" json:"tax_amount"`
+	CostAmount     float64 `gorm:"column:cost_amount" json:"cost_amount"` // cost of goods sold
 }
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+const (
+	EventProductCreated     = "ProductCreated"
+	EventPriceChanged       = "PriceChanged"
+	EventStockAdjusted      = "StockAdjusted"
+	EventOrderPaid          = "OrderPaid"
+	EventCustomerUpdated    = "CustomerUpdated"
+	EventCustomerMerged     = "CustomerMerged"
+	EventCustomerAnonymised = "CustomerAnonymised"
+
+	AggregateProduct  = "product"
+	AggregateOrder    = "order"
+	AggregateCustomer = "customer"
+)
+
+// OutboxEvent is a domain event waiting to be delivered to the event sinks. It is written
+// in the same transaction as the change it describes, so it is published if and only if
+// the change is committed. Payload is the JSON of the event data. OriginStoreID is the
+// store of the request that raised the event, 0 for none; it is not named store_id, as
+// the outbox is shared by all stores and must not be confined to one.
+type OutboxEvent struct {
+	Id            uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	Type          string     `gorm:"column:event_type; type:varchar(50); index"`
+	AggregateType string     `gorm:"column:aggregate_type; type:varchar(50)"`
+	AggregateID   string     `gorm:"column:aggregate_id; type:varchar(100); index"`
+	Payload       string     `gorm:"column:payload; type:text"`
+	OriginStoreID uint64     `gorm:"column:origin_store_id; index"`
+	Actor         string     `gorm:"column:actor; type:varchar(100)"`
+	RequestId     string     `gorm:"column:request_id; type:varchar(64)"`
+	OccurredAt    time.Time  `gorm:"column:occurred_at"`
+	Attempts      int        `gorm:"column:attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at; index"`
+	DeliveredAt   *time.Time `gorm:"column:delivered_at; index"`
+	LastError     string     `gorm:"column:last_error; type:varchar(1000)"`
+}
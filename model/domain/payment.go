@@ -1,10 +1,23 @@
 package domain
 
+import "time"
+
+const (
+	PaymentTypeCash   = "Cash"
+	PaymentTypeCard   = "Card"
+	PaymentTypeOnline = "Online"
+
+	PaymentStatusCompleted = "Completed"
+	PaymentStatusPending   = "Pending"
+)
+
 type Payment struct {
-	PaymentID   string  `json:"payment_id"`
-	OrderID     string  `json:"order_id"`
-	Amount      float64 `json:"amount"`
-	PaymentType string  `json:"payment_type"` // e.g., Cash, Card, Online
-	PaymentDate string  `json:"payment_date"`
-	Status      string  `json:"status"` // e.g., Completed, Pending
+	PaymentID   string    `gorm:"primaryKey;column:id; type:varchar(36)" json:"payment_id"`
+	OrderID     string    `gorm:"column:order_id; type:varchar(36); index" json:"order_id"`
+	ShiftID     *uint64   `gorm:"column:shift_id; index" json:"shift_id"` // set for cash payments
+	Amount      float64   `gorm:"column:amount" json:"amount"`
+	PaymentType string    `gorm:"column:payment_type; type:varchar(20)" json:"payment_type"` // e.g., Cash, Card, Online
+	PaymentDate time.Time `gorm:"column:payment_date" json:"payment_date"`
+	Status      string    `gorm:"column:status; type:varchar(20)" json:"status"` // e.g., Completed, Pending
+	Version     uint64    `gorm:"column:version; not null; default:1" json:"version"`
 }
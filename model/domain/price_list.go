@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+const (
+	PriceScheduleStatusPending   = "pending"
+	PriceScheduleStatusActive    = "active"
+	PriceScheduleStatusEnded     = "ended"
+	PriceScheduleStatusCancelled = "cancelled"
+
+	PriceSourceManual   = "manual"
+	PriceSourceSchedule = "schedule"
+	PriceSourceImport   = "import"
+)
+
+// PriceList is a named set of product prices, e.g. wholesale or member prices, that
+// customers can be assigned to. Products without an item in the list keep their own
+// price.
+type PriceList struct {
+	Id        uint64          `gorm:"primaryKey;autoIncrement;column:id"`
+	Code      string          `gorm:"column:code; type:varchar(50); uniqueIndex"`
+	Name      string          `gorm:"column:name; type:varchar(100)"`
+	Version   uint64          `gorm:"column:version; not null; default:1"`
+	CreatedAt time.Time       `gorm:"column:created_at"`
+	UpdatedAt time.Time       `gorm:"column:updated_at"`
+	Items     []PriceListItem `gorm:"foreignKey:PriceListId;references:Id"`
+}
+
+type PriceListItem struct {
+	Id          uint64  `gorm:"primaryKey;autoIncrement;column:id"`
+	PriceListId uint64  `gorm:"column:price_list_id; uniqueIndex:idx_price_list_product"`
+	ProductID   string  `gorm:"column:product_id; type:varchar(191); uniqueIndex:idx_price_list_product"`
+	Price       float64 `gorm:"column:price"`
+}
+
+// PriceSchedule changes the price of a product, in a price list or its own price when
+// PriceListId is nil, from EffectiveFrom until EffectiveTo. When the schedule ends the
+// price it replaced is restored; without an EffectiveTo the change is permanent.
+type PriceSchedule struct {
+	Id            uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	PriceListId   *uint64    `gorm:"column:price_list_id; index"`
+	ProductID     string     `gorm:"column:product_id; type:varchar(191); index"`
+	Price         float64    `gorm:"column:price"`
+	EffectiveFrom time.Time  `gorm:"column:effective_from; index"`
+	EffectiveTo   *time.Time `gorm:"column:effective_to; index"`
+	Status        string     `gorm:"column:status; type:varchar(20); index"`
+	PreviousPrice *float64   `gorm:"column:previous_price"`
+	AppliedAt     *time.Time `gorm:"column:applied_at"`
+	EndedAt       *time.Time `gorm:"column:ended_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+}
+
+// PriceHistory records one change of a product's price, or of its price in a price list.
+type PriceHistory struct {
+	Id          uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	ProductID   string    `gorm:"column:product_id; type:varchar(191); index"`
+	PriceListId *uint64   `gorm:"column:price_list_id"`
+	OldPrice    *float64  `gorm:"column:old_price"`
+	NewPrice    *float64  `gorm:"column:new_price"` // nil when a price list item was removed
+	Source      string    `gorm:"column:source; type:varchar(20)"`
+	ScheduleId  *uint64   `gorm:"column:schedule_id"`
+	Actor       string    `gorm:"column:actor; type:varchar(100)"`
+	ChangedAt   time.Time `gorm:"column:changed_at; index"`
+}
@@ -1,15 +1,27 @@
 package domain
 
+import "time"
+
 type Product struct {
-	ProductID   string   `gorm:"primaryKey;column:id"`
-	Name        string   `gorm:"column:product_name; length:255"`
-	Description string   `gorm:"column:product_description; length:255"`
-	Price       float64  `gorm:"column:product_price"`
-	StockQty    int      `gorm:"column:stock_qty"`
-	CategoryId  int      `gorm:"column:category_id"`
-	SKU         string   `gorm:"column:product_sku"`
-	TaxRate     float64  `gorm:"column:tax_rate"`
-	Category    Category `gorm:"foreignKey:CategoryId;references:Id"`
+	ProductID   string           `gorm:"primaryKey;column:id"`
+	Name        string           `gorm:"column:product_name; length:255"`
+	Description string           `gorm:"column:product_description; length:255"`
+	Price       float64          `gorm:"column:product_price"`
+	StockQty    int              `gorm:"column:stock_qty"`
+	CategoryId  int              `gorm:"column:category_id"`
+	SKU         string           `gorm:"column:product_sku; type:varchar(191); uniqueIndex:uq_product_sku; serializer:emptynull"` // optional, unique when set
+	TaxRate     float64          `gorm:"column:tax_rate"`                                                                         // percentage, included in the price
+	TaxType     string           `gorm:"column:tax_type; type:varchar(50)"`
+	CostPrice   float64          `gorm:"column:cost_price"`                    // values stock received without a cost
+	CostMethod  string           `gorm:"column:cost_method; type:varchar(20)"` // fifo or average, empty is average
+	SupplierID  *uint64          `gorm:"column:preferred_supplier_id; index"`  // preferred supplier, reordered from
+	Version     uint64           `gorm:"column:version; not null; default:1"`
+	CreatedAt   time.Time        `gorm:"column:created_at"`
+	UpdatedAt   time.Time        `gorm:"column:updated_at"`
+	Category    Category         `gorm:"foreignKey:CategoryId;references:Id"`
+	Options     []ProductOption  `gorm:"foreignKey:ProductID;references:ProductID"`
+	Variants    []ProductVariant `gorm:"foreignKey:ProductID;references:ProductID"`
+	Barcodes    []ProductBarcode `gorm:"foreignKey:ProductID;references:ProductID"`
 }
 
 type ProductError struct {
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// ProductBarcode is a scannable code of a product, or of one of its variants when
+// VariantID is set. Internal codes were generated by us rather than printed by the
+// manufacturer.
+type ProductBarcode struct {
+	Id        uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	ProductID string    `gorm:"column:product_id; type:varchar(191); index"`
+	VariantID *uint64   `gorm:"column:variant_id; index"`
+	Code      string    `gorm:"column:code; type:varchar(14); uniqueIndex"`
+	Type      string    `gorm:"column:type; type:varchar(10)"`
+	Internal  bool      `gorm:"column:internal; index"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// ProductOption is an option a product is sold in, e.g. Size with the values S, M and L.
+type ProductOption struct {
+	Id        uint64 `gorm:"primaryKey;autoIncrement;column:id"`
+	ProductID string `gorm:"column:product_id; type:varchar(191); index"`
+	Name      string `gorm:"column:name; length:100"`
+	Values    string `gorm:"column:option_values; type:text"` // JSON array of values
+	Position  int    `gorm:"column:position"`
+}
+
+// ProductVariant is one combination of option values of a product. A nil Price means
+// the variant is sold at the product's price. Its barcodes are product barcodes with
+// its VariantID.
+type ProductVariant struct {
+	Id        uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	ProductID string    `gorm:"column:product_id; type:varchar(191); index"`
+	SKU       string    `gorm:"column:sku; type:varchar(191); index"`
+	Options   string    `gorm:"column:options; type:varchar(512)"` // JSON object of option name to value
+	Price     *float64  `gorm:"column:price"`
+	StockQty  int       `gorm:"column:stock_qty"`
+	Version   uint64    `gorm:"column:version; not null; default:1"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusSent              = "sent"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusClosed            = "closed"
+)
+
+type Supplier struct {
+	Id          uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	Code        string    `gorm:"column:code; type:varchar(50); uniqueIndex"`
+	Name        string    `gorm:"column:name; type:varchar(100)"`
+	ContactName string    `gorm:"column:contact_name; type:varchar(100)"`
+	Email       string    `gorm:"column:email; type:varchar(255)"`
+	Phone       string    `gorm:"column:phone; type:varchar(50)"`
+	Address     string    `gorm:"column:address; type:varchar(255)"`
+	Version     uint64    `gorm:"column:version; not null; default:1"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+// PurchaseOrder is stock a store orders from a supplier. It moves from draft to sent,
+// then to partially received and received as goods arrive, and is closed when nothing
+// more is expected.
+type PurchaseOrder struct {
+	Id         uint64              `gorm:"primaryKey;autoIncrement;column:id"`
+	StoreID    uint64              `gorm:"column:store_id; index"`
+	SupplierID uint64              `gorm:"column:supplier_id; index"`
+	Status     string              `gorm:"column:status; type:varchar(20); index"`
+	Note       string              `gorm:"column:note; type:varchar(255)"`
+	CreatedBy  string              `gorm:"column:created_by; type:varchar(100)"`
+	CreatedAt  time.Time           `gorm:"column:created_at"`
+	SentAt     *time.Time          `gorm:"column:sent_at"`
+	ClosedAt   *time.Time          `gorm:"column:closed_at"`
+	Version    uint64              `gorm:"column:version; not null; default:1"`
+	Lines      []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderID;references:Id"`
+}
+
+type PurchaseOrderLine struct {
+	Id               uint64  `gorm:"primaryKey;autoIncrement;column:id"`
+	PurchaseOrderID  uint64  `gorm:"column:purchase_order_id; index"`
+	ProductID        string  `gorm:"column:product_id; type:varchar(191)"`
+	VariantID        uint64  `gorm:"column:variant_id; not null; default:0"`
+	Quantity         int     `gorm:"column:quantity"`
+	ReceivedQuantity int     `gorm:"column:received_quantity"`
+	UnitCost         float64 `gorm:"column:unit_cost"`
+}
+
+// OutstandingQuantity is what is still expected of a product or variant on the open
+// purchase orders of a store.
+type OutstandingQuantity struct {
+	ProductID string
+	VariantID uint64
+	Quantity  int
+}
@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+// OrderTotal is the date and amount of one order, which reports bucket by local day or hour.
+type OrderTotal struct {
+	OrderDate   time.Time
+	TotalAmount float64
+}
+
+// SalesTotal sums up the orders of one store or employee.
+type SalesTotal struct {
+	Key        string
+	Label      string
+	OrderCount int64
+	Revenue    float64
+}
+
+// ProductSales sums up what was sold of one product.
+type ProductSales struct {
+	ProductID      string
+	ProductName    string
+	Quantity       int64
+	Revenue        float64
+	RegularRevenue float64
+	DiscountAmount float64
+}
+
+// CategorySales sums up what was sold of the products of one category.
+type CategorySales struct {
+	CategoryID   uint64
+	CategoryName string
+	OrderCount   int64
+	Quantity     int64
+	Revenue      float64
+}
+
+// PaymentTypeTotal sums up the completed payments of one payment type.
+type PaymentTypeTotal struct {
+	PaymentType  string
+	PaymentCount int64
+	Amount       float64
+}
+
+// TaxTotal sums up the tax included in the sales of one tax type and rate.
+type TaxTotal struct {
+	TaxType   string
+	TaxRate   float64
+	Revenue   float64
+	TaxAmount float64
+}
+
+// MarginTotal sums up the sales of one product or category, net of tax, and their cost.
+type MarginTotal struct {
+	Key      string
+	Label    string
+	Quantity int64
+	Revenue  float64
+	Cost     float64
+}
+
+// InventoryValue is the stock of a product or variant and its value at cost at a moment.
+type InventoryValue struct {
+	ProductID   string
+	ProductName string
+	VariantID   uint64
+	Quantity    int64
+	Value       float64
+}
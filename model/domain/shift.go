@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+const (
+	ShiftStatusOpen   = "open"
+	ShiftStatusClosed = "closed"
+
+	CashMovementIn  = "cash_in"
+	CashMovementOut = "cash_out"
+
+	EmployeeRoleCashier = "Cashier"
+	EmployeeRoleManager = "Manager"
+	EmployeeRoleAdmin   = "Admin" // head office staff, who may act for any store
+)
+
+// Shift is a cashier's session at a till, from opening it with a float of cash to
+// counting the cash when closing it.
+type Shift struct {
+	Id           uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	StoreID      uint64     `gorm:"column:store_id; index"`
+	EmployeeID   string     `gorm:"column:employee_id; type:varchar(191); index"`
+	Status       string     `gorm:"column:status; type:varchar(20); index"`
+	OpeningFloat float64    `gorm:"column:opening_float"`
+	OpenedAt     time.Time  `gorm:"column:opened_at"`
+	ClosedAt     *time.Time `gorm:"column:closed_at"`
+	ExpectedCash *float64   `gorm:"column:expected_cash"`
+	CountedCash  *float64   `gorm:"column:counted_cash"`
+	Variance     *float64   `gorm:"column:variance"`
+	Note         string     `gorm:"column:note; type:varchar(255)"`
+	// OpenKey holds the employee id while the shift is open and is NULL once it is
+	// closed, so the unique index allows at most one open shift per employee.
+	OpenKey *string `gorm:"column:open_key; type:varchar(191); uniqueIndex"`
+	Version uint64  `gorm:"column:version; not null; default:1"`
+}
+
+// CashMovement is cash put into or taken out of the till during a shift for anything
+// other than a sale, e.g. a change top-up or a cash drop to the safe.
+type CashMovement struct {
+	Id        uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	ShiftID   uint64    `gorm:"column:shift_id; index"`
+	Type      string    `gorm:"column:type; type:varchar(20)"`
+	Amount    float64   `gorm:"column:amount"`
+	Reason    string    `gorm:"column:reason; type:varchar(255)"`
+	Actor     string    `gorm:"column:actor; type:varchar(100)"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// ShiftCashTotals sums up the cash that went through the till of a shift.
+type ShiftCashTotals struct {
+	CashSales float64
+	CashIn    float64
+	CashOut   float64
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+const (
+	StocktakeStatusCounting  = "counting"
+	StocktakeStatusApproved  = "approved"
+	StocktakeStatusCancelled = "cancelled"
+
+	ShrinkageReasonDamaged    = "damaged"
+	ShrinkageReasonExpired    = "expired"
+	ShrinkageReasonTheft      = "theft"
+	ShrinkageReasonCountError = "count_error"
+	ShrinkageReasonUnknown    = "unknown"
+)
+
+// Stocktake is a count of the stock of a store, of all of it or of some products (a cycle
+// count). The quantities expected are taken when it is started; once a manager approves
+// it the differences with what was counted are posted to the inventory ledger.
+type Stocktake struct {
+	Id         uint64          `gorm:"primaryKey;autoIncrement;column:id"`
+	StoreID    uint64          `gorm:"column:store_id; index"`
+	Status     string          `gorm:"column:status; type:varchar(20); index"`
+	Note       string          `gorm:"column:note; type:varchar(255)"`
+	CreatedBy  string          `gorm:"column:created_by; type:varchar(100)"`
+	CreatedAt  time.Time       `gorm:"column:created_at"`
+	ApprovedBy string          `gorm:"column:approved_by; type:varchar(100)"`
+	ClosedAt   *time.Time      `gorm:"column:closed_at"`
+	Version    uint64          `gorm:"column:version; not null; default:1"`
+	Lines      []StocktakeLine `gorm:"foreignKey:StocktakeID;references:Id"`
+}
+
+// StocktakeLine is a product, or one of its variants, on a stocktake. CountedQuantity is
+// nil until it is counted. AdjustmentCost is what the adjustment posted on approval was
+// valued at.
+type StocktakeLine struct {
+	Id               uint64  `gorm:"primaryKey;autoIncrement;column:id"`
+	StocktakeID      uint64  `gorm:"column:stocktake_id; index"`
+	ProductID        string  `gorm:"column:product_id; type:varchar(191)"`
+	VariantID        uint64  `gorm:"column:variant_id; not null; default:0"`
+	ExpectedQuantity int     `gorm:"column:expected_quantity"`
+	CountedQuantity  *int    `gorm:"column:counted_quantity"`
+	Reason           string  `gorm:"column:reason; type:varchar(20)"`
+	AdjustmentCost   float64 `gorm:"column:adjustment_cost"`
+}
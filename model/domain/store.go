@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+const (
+	StockTransferStatusInTransit = "in_transit"
+	StockTransferStatusReceived  = "received"
+	StockTransferStatusCancelled = "cancelled"
+)
+
+// Store is one branch of the business. Employees, shifts, orders and stock levels belong
+// to a store, and repositories only ever see the rows of the store a request is made for.
+type Store struct {
+	Id        uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	Code      string    `gorm:"column:code; type:varchar(50); uniqueIndex"`
+	Name      string    `gorm:"column:name; type:varchar(100)"`
+	Address   string    `gorm:"column:address; type:varchar(255)"`
+	Version   uint64    `gorm:"column:version; not null; default:1"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// StoreStock is the quantity of a product, or of one of its variants, on hand at a store.
+// VariantID is 0 for the product itself. Product.StockQty and ProductVariant.StockQty
+// hold the stock across all stores.
+type StoreStock struct {
+	Id        uint64 `gorm:"primaryKey;autoIncrement;column:id"`
+	StoreID   uint64 `gorm:"column:store_id; uniqueIndex:idx_store_stock"`
+	ProductID string `gorm:"column:product_id; type:varchar(191); uniqueIndex:idx_store_stock"`
+	VariantID uint64 `gorm:"column:variant_id; not null; default:0; uniqueIndex:idx_store_stock"`
+	Quantity  int    `gorm:"column:quantity"`
+}
+
+// StockTransfer moves stock from one store to another. The stock leaves the source store
+// when the transfer is created and is in transit until the destination store receives it.
+type StockTransfer struct {
+	Id          uint64              `gorm:"primaryKey;autoIncrement;column:id"`
+	FromStoreID uint64              `gorm:"column:from_store_id; index"`
+	ToStoreID   uint64              `gorm:"column:to_store_id; index"`
+	Status      string              `gorm:"column:status; type:varchar(20); index"`
+	Note        string              `gorm:"column:note; type:varchar(255)"`
+	CreatedBy   string              `gorm:"column:created_by; type:varchar(100)"`
+	ReceivedBy  string              `gorm:"column:received_by; type:varchar(100)"`
+	ShippedAt   time.Time           `gorm:"column:shipped_at"`
+	ClosedAt    *time.Time          `gorm:"column:closed_at"`
+	Version     uint64              `gorm:"column:version; not null; default:1"`
+	Items       []StockTransferItem `gorm:"foreignKey:TransferID;references:Id"`
+}
+
+type StockTransferItem struct {
+	Id         uint64  `gorm:"primaryKey;autoIncrement;column:id"`
+	TransferID uint64  `gorm:"column:transfer_id; index"`
+	ProductID  string  `gorm:"column:product_id; type:varchar(191)"`
+	VariantID  uint64  `gorm:"column:variant_id; not null; default:0"`
+	Quantity   int     `gorm:"column:quantity"`
+	UnitCost   float64 `gorm:"column:unit_cost"` // cost the stock left the source store at
+}
@@ -1,8 +1,17 @@
 package domain
 
+import "time"
+
+// DefaultTaxType is the tax type of products that do not name one.
+const DefaultTaxType = "Sales Tax"
+
+// Tax is a tax rate; products are taxed at the rate of the tax whose TaxType they name.
 type Tax struct {
-	TaxID       string  `json:"tax_id"`
-	TaxRate     float64 `json:"tax_rate"` // Percentage value of the tax rate
-	TaxType     string  `json:"tax_type"` // e.g., Sales Tax, VAT
-	Description string  `json:"description"`
+	TaxID       string    `gorm:"primaryKey;column:id; type:varchar(50)"`
+	TaxRate     float64   `gorm:"column:tax_rate"`                   // Percentage value of the tax rate
+	TaxType     string    `gorm:"column:tax_type; type:varchar(50)"` // e.g., Sales Tax, VAT
+	Description string    `gorm:"column:description; type:varchar(255)"`
+	Version     uint64    `gorm:"column:version; not null; default:1"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
 }
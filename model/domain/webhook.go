@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+// WebhookSubscription is a partner URL that is sent the domain events of the listed types.
+// EventTypes is a comma separated list, where "*" stands for every type. Deliveries are
+// signed with Secret, which is kept out of the audit log.
+type WebhookSubscription struct {
+	Id         uint64    `gorm:"primaryKey;autoIncrement;column:id"`
+	URL        string    `gorm:"column:url; type:varchar(500)"`
+	EventTypes string    `gorm:"column:event_types; type:varchar(500)"`
+	Secret     string    `gorm:"column:secret; type:varchar(100)" audit:"-"`
+	Active     bool      `gorm:"column:active; not null; default:true"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+	Version    uint64    `gorm:"column:version; not null; default:1"`
+}
+
+// WebhookDelivery is one event to be sent to one subscription, and the log of the attempts
+// to send it. It is retried until it is delivered or runs out of attempts, after which it
+// stays dead until it is redelivered by hand.
+type WebhookDelivery struct {
+	Id             uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	SubscriptionID uint64     `gorm:"column:subscription_id; uniqueIndex:idx_webhook_delivery_event"`
+	EventID        uint64     `gorm:"column:event_id; uniqueIndex:idx_webhook_delivery_event"`
+	EventType      string     `gorm:"column:event_type; type:varchar(50)"`
+	Payload        string     `gorm:"column:payload; type:text"`
+	Status         string     `gorm:"column:status; type:varchar(20); index"`
+	Attempts       int        `gorm:"column:attempts"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at; index"`
+	LastStatusCode int        `gorm:"column:last_status_code"`
+	LastError      string     `gorm:"column:last_error; type:varchar(1000)"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+}
@@ -0,0 +1,26 @@
+package web
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogFilterRequest struct {
+	EntityType string
+	EntityId   string
+	Actor      string
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditLogResponse struct {
+	Id         uint64          `json:"id"`
+	EntityType string          `json:"entity_type"`
+	EntityId   string          `json:"entity_id"`
+	Action     string          `json:"action"`
+	Actor      string          `json:"actor"`
+	Changes    json.RawMessage `json:"changes"`
+	ClientIP   string          `json:"client_ip"`
+	RequestId  string          `json:"request_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
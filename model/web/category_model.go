@@ -1,15 +1,55 @@
 package web
 
+import "time"
+
 type CategoryCreateRequest struct {
-	Name string `validate:"required,min=1,max=100" json:"name"`
+	Name     string  `validate:"required,min=1,max=100" json:"name"`
+	ParentId *uint64 `json:"parent_id"`
 }
 
 type CategoryUpdateRequest struct {
-	Id   uint64 `validate:"required"`
-	Name string `validate:"required,max=200,min=1" json:"name"`
+	Id      uint64 `validate:"required"`
+	Name    string `validate:"required,max=200,min=1" json:"name"`
+	Version uint64 `json:"version"`
+}
+
+// CategoryMoveRequest re-parents a category; a nil ParentId makes it a root category.
+type CategoryMoveRequest struct {
+	Id       uint64  `validate:"required"`
+	ParentId *uint64 `json:"parent_id"`
+	Version  uint64  `json:"version"`
 }
 
 type CategoryResponse struct {
-	Id   uint64 `json:"id"`
-	Name string `json:"name"`
+	Id           uint64    `json:"id"`
+	Name         string    `json:"name"`
+	ParentId     *uint64   `json:"parent_id"`
+	Version      uint64    `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	ProductCount *int      `json:"product_count,omitempty"`
+}
+
+// CategoryTreeResponse is a category with its children. ProductCount counts the
+// products of the category itself, TotalProductCount those of its whole subtree.
+type CategoryTreeResponse struct {
+	Id                uint64                 `json:"id"`
+	Name              string                 `json:"name"`
+	ParentId          *uint64                `json:"parent_id"`
+	Version           uint64                 `json:"version"`
+	ProductCount      *int                   `json:"product_count,omitempty"`
+	TotalProductCount *int                   `json:"total_product_count,omitempty"`
+	Children          []CategoryTreeResponse `json:"children"`
+}
+
+type CategoryDeleteRequest struct {
+	Id      uint64 `json:"id"`
+	Version uint64 `json:"version"`
+}
+
+type CategoryBulkRequest struct {
+	Mode   string                  `json:"mode"`
+	Create []CategoryCreateRequest `json:"create"`
+	Update []CategoryUpdateRequest `json:"update"`
+	Delete []CategoryDeleteRequest `json:"delete"`
 }
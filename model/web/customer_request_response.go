@@ -1,27 +1,158 @@
 package web
 
+import "time"
+
 type CustomerCreateRequest struct {
-	Name       string `validate:"required,min=1,max=100" json:"name"`
-	Email      string `validate:"required,email" json:"email"`
-	Phone      string `validate:"required" json:"phone"`
-	Address    string `json:"address"`
-	LoyaltyPts int    `json:"loyalty_points"`
+	Name        string  `validate:"required,min=1,max=100" json:"name"`
+	Email       string  `validate:"required,email" json:"email"`
+	Phone       string  `validate:"required" json:"phone"`
+	Address     string  `json:"address"`
+	LoyaltyPts  int     `json:"loyalty_points"`
+	PriceListId *uint64 `json:"price_list_id"`
 }
 
 type CustomerResponse struct {
-	CustomerID uint64 `json:"customer_id"`
-	Name       string `json:"name"`
-	Email      string `json:"email"`
-	Phone      string `json:"phone"`
-	Address    string `json:"address"`
-	LoyaltyPts int    `json:"loyalty_points"`
+	CustomerID  uint64    `json:"customer_id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Phone       string    `json:"phone"`
+	Address     string    `json:"address"`
+	LoyaltyPts  int       `json:"loyalty_points"`
+	PriceListId *uint64   `json:"price_list_id"`
+	Version     uint64    `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// Only on anonymised customers
+	AnonymisedAt *time.Time `json:"anonymised_at,omitempty"`
 }
 
 type CustomerUpdateRequest struct {
+	CustomerID  uint64  `validate:"required" json:"customer_id"`
+	Name        string  `validate:"required,max=100,min=1" json:"name"`
+	Email       string  `validate:"required,email" json:"email"`
+	Phone       string  `validate:"required" json:"phone"`
+	Address     string  `json:"address"`
+	LoyaltyPts  int     `json:"loyalty_points"`
+	PriceListId *uint64 `json:"price_list_id"`
+	Version     uint64  `json:"version"`
+}
+
+type CustomerDeleteRequest struct {
+	CustomerID uint64 `json:"customer_id"`
+	Version    uint64 `json:"version"`
+}
+
+type CustomerBulkRequest struct {
+	Mode   string                  `json:"mode"`
+	Create []CustomerCreateRequest `json:"create"`
+	Update []CustomerUpdateRequest `json:"update"`
+	Delete []CustomerDeleteRequest `json:"delete"`
+}
+
+// Confidence of a likely duplicate customer, from the similarity of the names.
+const (
+	DuplicateConfidenceHigh   = "high"
+	DuplicateConfidenceMedium = "medium"
+	DuplicateConfidenceLow    = "low"
+)
+
+// CustomerDuplicateResponse is a pair of customers that are likely the same person: they
+// share the phone number or email address in MatchedOn. Duplicate is the newer of the two.
+type CustomerDuplicateResponse struct {
+	Customer       CustomerResponse `json:"customer"`
+	Duplicate      CustomerResponse `json:"duplicate"`
+	MatchedOn      []string         `json:"matched_on"`
+	NameSimilarity float64          `json:"name_similarity"`
+	Confidence     string           `json:"confidence"`
+}
+
+type CustomerMergeRequest struct {
+	SurvivorID   uint64   `validate:"required" json:"survivor_id"`
+	DuplicateIDs []uint64 `validate:"required,min=1,max=20,dive,required" json:"duplicate_ids"`
+}
+
+type CustomerMergeResponse struct {
+	Customer          CustomerResponse `json:"customer"`
+	MergedCustomerIDs []uint64         `json:"merged_customer_ids"`
+	OrdersMoved       int64            `json:"orders_moved"`
+	LoyaltyPtsMoved   int              `json:"loyalty_points_moved"`
+}
+
+type CustomerOrdersRequest struct {
 	CustomerID uint64 `validate:"required" json:"customer_id"`
-	Name       string `validate:"required,max=100,min=1" json:"name"`
-	Email      string `validate:"required,email" json:"email"`
-	Phone      string `validate:"required" json:"phone"`
-	Address    string `json:"address"`
-	LoyaltyPts int    `json:"loyalty_points"`
+	Page       int    `validate:"omitempty,min=1" json:"page"`
+	Size       int    `validate:"omitempty,min=1,max=100" json:"size"`
+}
+
+// CustomerOrdersResponse is a page of the orders of a customer, newest first.
+type CustomerOrdersResponse struct {
+	Orders     []OrderResponse `json:"orders"`
+	Page       int             `json:"page"`
+	Size       int             `json:"size"`
+	TotalCount int64           `json:"total_count"`
+	HasMore    bool            `json:"has_more"`
+}
+
+// CustomerProfileResponse is what the counter needs to know of a customer: the lifetime
+// value, the products they buy most often and their loyalty points.
+type CustomerProfileResponse struct {
+	Customer          CustomerResponse                   `json:"customer"`
+	Summary           CustomerSummaryResponse            `json:"summary"`
+	FavouriteProducts []CustomerFavouriteProductResponse `json:"favourite_products"`
+	Loyalty           CustomerLoyaltyResponse            `json:"loyalty"`
+}
+
+// CustomerSummaryResponse is the lifetime value of a customer. Every order counts as a
+// visit.
+type CustomerSummaryResponse struct {
+	TotalSpent    float64    `json:"total_spent"`
+	VisitCount    int64      `json:"visit_count"`
+	AverageBasket float64    `json:"average_basket"`
+	FirstVisit    *time.Time `json:"first_visit"`
+	LastVisit     *time.Time `json:"last_visit"`
+}
+
+type CustomerFavouriteProductResponse struct {
+	ProductID     string    `json:"product_id"`
+	ProductName   string    `json:"product_name"`
+	Quantity      int64     `json:"quantity"`
+	OrderCount    int64     `json:"order_count"`
+	TotalSpent    float64   `json:"total_spent"`
+	LastPurchased time.Time `json:"last_purchased"`
+}
+
+type CustomerLoyaltyResponse struct {
+	Balance int                    `json:"balance"`
+	History []LoyaltyEntryResponse `json:"history"`
+}
+
+// LoyaltyEntryResponse is one change of the loyalty points of a customer, from the audit
+// log: Action is the audited action, e.g. create or update, and Balance the points after it.
+type LoyaltyEntryResponse struct {
+	Change    int       `json:"change"`
+	Balance   int       `json:"balance"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	RequestId string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CustomerDataExportResponse is everything held on a customer, as handed to them on a
+// data subject access request.
+type CustomerDataExportResponse struct {
+	ExportedAt     time.Time              `json:"exported_at"`
+	Customer       CustomerResponse       `json:"customer"`
+	Orders         []OrderResponse        `json:"orders"`
+	LoyaltyHistory []LoyaltyEntryResponse `json:"loyalty_history"`
+	AuditLog       []AuditLogResponse     `json:"audit_log"`
+	Events         []EventEnvelope        `json:"events"`
+}
+
+// CustomerAnonymiseResponse is an anonymised customer, with the number of audit log
+// entries, events and webhook deliveries their personal data was redacted from.
+type CustomerAnonymiseResponse struct {
+	Customer                  CustomerResponse `json:"customer"`
+	AuditLogsRedacted         int              `json:"audit_logs_redacted"`
+	EventsRedacted            int              `json:"events_redacted"`
+	WebhookDeliveriesRedacted int              `json:"webhook_deliveries_redacted"`
 }
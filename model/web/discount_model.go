@@ -0,0 +1,14 @@
+package web
+
+import "time"
+
+type DiscountResponse struct {
+	DiscountID  string     `json:"discount_id"`
+	Description string     `json:"description"`
+	DiscountPct float64    `json:"discount_pct"`
+	ValidFrom   *time.Time `json:"valid_from"`
+	ValidUntil  *time.Time `json:"valid_until"`
+	Version     uint64     `json:"version"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -1,5 +1,7 @@
 package web
 
+import "time"
+
 type EmployeeCreateRequest struct {
 	Name      string `validate:"required,min=1,max=100" json:"name"`
 	Role      string `validate:"required" json:"role"`
@@ -9,12 +11,22 @@ type EmployeeCreateRequest struct {
 }
 
 type EmployeeResponse struct {
+	EmployeeID string    `json:"employee_id"`
+	Name       string    `json:"name"`
+	Role       string    `json:"role"`
+	Email      string    `json:"email"`
+	Phone      string    `json:"phone"`
+	DateHired  string    `json:"date_hired"`
+	StoreID    uint64    `json:"store_id"`
+	Version    uint64    `json:"version"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// EmployeeApiKeyResponse holds a newly issued API key, which is shown only once.
+type EmployeeApiKeyResponse struct {
 	EmployeeID string `json:"employee_id"`
-	Name       string `json:"name"`
-	Role       string `json:"role"`
-	Email      string `json:"email"`
-	Phone      string `json:"phone"`
-	DateHired  string `json:"date_hired"`
+	ApiKey     string `json:"api_key"`
 }
 
 type EmployeeUpdateRequest struct {
@@ -24,4 +36,5 @@ type EmployeeUpdateRequest struct {
 	Email      string `validate:"required,email" json:"email"`
 	Phone      string `validate:"required" json:"phone"`
 	DateHired  string `json:"date_hired"`
+	Version    uint64 `json:"version"`
 }
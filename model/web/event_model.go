@@ -0,0 +1,49 @@
+package web
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventEnvelope is a domain event as it is delivered to subscribers. Id is unique per
+// event; as events are delivered at least once, subscribers use it to skip duplicates.
+type EventEnvelope struct {
+	Id            uint64          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	StoreID       uint64          `json:"store_id,omitempty"`
+	Actor         string          `json:"actor,omitempty"`
+	RequestId     string          `json:"request_id,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// PriceChangedEvent is the data of a PriceChanged event: the regular price of a product
+// when PriceListID is nil, otherwise its price on a price list.
+type PriceChangedEvent struct {
+	ProductID   string   `json:"product_id"`
+	PriceListID *uint64  `json:"price_list_id"`
+	OldPrice    *float64 `json:"old_price"`
+	NewPrice    *float64 `json:"new_price"`
+	Source      string   `json:"source"`
+	ScheduleID  *uint64  `json:"schedule_id"`
+}
+
+// EventStreamRequest selects the events a client of the live event stream receives: only
+// those of the types and aggregate types listed, all when a list is empty, and with a
+// store, only those raised at the store or at none. LastEventId resumes a stream after
+// the last event the client has seen.
+type EventStreamRequest struct {
+	Types          []string `validate:"dive,oneof=ProductCreated PriceChanged StockAdjusted OrderPaid CustomerUpdated CustomerMerged CustomerAnonymised" json:"types"`
+	AggregateTypes []string `validate:"dive,oneof=product order customer" json:"aggregate_types"`
+	StoreID        uint64   `json:"store_id"`
+	LastEventId    uint64   `json:"last_event_id"`
+}
+
+// CustomerMergedEvent is the data of a CustomerMerged event: the customers that were
+// merged into a surviving one and deleted.
+type CustomerMergedEvent struct {
+	Customer          CustomerResponse `json:"customer"`
+	MergedCustomerIDs []uint64         `json:"merged_customer_ids"`
+}
@@ -0,0 +1,115 @@
+package web
+
+import "time"
+
+type OrderItemResponse struct {
+	ProductID      string  `json:"product_id"`
+	VariantID      *uint64 `json:"variant_id,omitempty"`
+	Quantity       int     `json:"quantity"`
+	UnitPrice      float64 `json:"unit_price"`
+	TotalPrice     float64 `json:"total_price"`
+	DiscountAmount float64 `json:"discount_amount"`
+	TaxAmount      float64 `json:"tax_amount"`
+}
+
+type PaymentResponse struct {
+	PaymentID   string    `json:"payment_id"`
+	OrderID     string    `json:"order_id"`
+	ShiftID     *uint64   `json:"shift_id,omitempty"`
+	Amount      float64   `json:"amount"`
+	PaymentType string    `json:"payment_type"`
+	PaymentDate time.Time `json:"payment_date"`
+	Status      string    `json:"status"`
+	Version     uint64    `json:"version"`
+}
+
+type OrderResponse struct {
+	OrderID     string              `json:"order_id"`
+	CustomerID  string              `json:"customer_id"`
+	StoreID     uint64              `json:"store_id"`
+	EmployeeID  string              `json:"employee_id"`
+	ShiftID     *uint64             `json:"shift_id"`
+	OrderDate   time.Time           `json:"order_date"`
+	TotalAmount float64             `json:"total_amount"`
+	PaidAmount  float64             `json:"paid_amount"`
+	ItemCount   int                 `json:"item_count"`
+	Version     uint64              `json:"version"`
+	OrderItems  []OrderItemResponse `json:"order_items" export:"item"`
+	Payments    []PaymentResponse   `json:"payments"`
+	// Only on orders taken offline
+	ReconciliationStatus string                `json:"reconciliation_status,omitempty"`
+	ReconciliationIssues []ReconciliationIssue `json:"reconciliation_issues,omitempty"`
+	UploadedAt           *time.Time            `json:"uploaded_at,omitempty"`
+}
+
+type OrderItemCreateRequest struct {
+	ProductID string  `validate:"required" json:"product_id"`
+	VariantID *uint64 `json:"variant_id"`
+	Quantity  int     `validate:"required,gt=0" json:"quantity"`
+}
+
+type PaymentCreateRequest struct {
+	OrderID     string  `json:"order_id"`
+	PaymentType string  `validate:"required,oneof=Cash Card Online" json:"payment_type"`
+	Amount      float64 `validate:"required,gt=0" json:"amount"`
+	Version     uint64  `json:"version"` // version of the order the payment is expected for, 0 to not check it
+}
+
+// OrderCreateRequest places an order. Unit prices are not taken from the client but
+// resolved for the customer at the time the server receives the order.
+type OrderCreateRequest struct {
+	CustomerID uint64                   `json:"customer_id"` // 0 for walk-in customers
+	Items      []OrderItemCreateRequest `validate:"required,min=1,dive" json:"items"`
+	Payments   []PaymentCreateRequest   `validate:"dive" json:"payments"`
+}
+
+// OfflineOrderItemRequest is an item as the terminal sold it. UnitPrice is what it charged;
+// without it the item is charged at the current price.
+type OfflineOrderItemRequest struct {
+	ProductID string   `validate:"required" json:"product_id"`
+	VariantID *uint64  `json:"variant_id"`
+	Quantity  int      `validate:"required,gt=0" json:"quantity"`
+	UnitPrice *float64 `validate:"omitempty,gte=0" json:"unit_price"`
+}
+
+// OfflineOrderRequest is an order a terminal took while it was offline, with the UUID it
+// generated for it and the time it was taken.
+type OfflineOrderRequest struct {
+	OrderID    string                    `validate:"required,uuid" json:"order_id"`
+	CustomerID uint64                    `json:"customer_id"`
+	OrderDate  time.Time                 `validate:"required" json:"order_date"`
+	Items      []OfflineOrderItemRequest `validate:"required,min=1,dive" json:"items"`
+	Payments   []PaymentCreateRequest    `validate:"dive" json:"payments"`
+}
+
+type OfflineOrderUploadRequest struct {
+	Orders []OfflineOrderRequest `validate:"required,min=1,max=200,dive" json:"orders"`
+}
+
+type ReconciliationIssue struct {
+	Type      string  `json:"type"`
+	ProductID string  `json:"product_id,omitempty"`
+	VariantID *uint64 `json:"variant_id,omitempty"`
+	Expected  float64 `json:"expected"`
+	Actual    float64 `json:"actual"`
+}
+
+// OfflineOrderResult is how one uploaded order was reconciled: accepted, adjusted or
+// flagged like the order itself, or rejected with an error when it could not be applied.
+// Duplicate is set for an order that an earlier upload applied already.
+type OfflineOrderResult struct {
+	OrderID   string                `json:"order_id"`
+	Status    string                `json:"status"`
+	Duplicate bool                  `json:"duplicate"`
+	Issues    []ReconciliationIssue `json:"issues"`
+	Error     string                `json:"error,omitempty"`
+	Order     *OrderResponse        `json:"order,omitempty"`
+}
+
+type OfflineOrderUploadResponse struct {
+	Results  []OfflineOrderResult `json:"results"`
+	Accepted int                  `json:"accepted"`
+	Adjusted int                  `json:"adjusted"`
+	Flagged  int                  `json:"flagged"`
+	Rejected int                  `json:"rejected"`
+}
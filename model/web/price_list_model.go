@@ -0,0 +1,102 @@
+package web
+
+import "time"
+
+type PriceListCreateRequest struct {
+	Code string `validate:"required,max=50" json:"code"`
+	Name string `validate:"required,max=100" json:"name"`
+}
+
+type PriceListUpdateRequest struct {
+	Id      uint64 `validate:"required" json:"id"`
+	Code    string `validate:"required,max=50" json:"code"`
+	Name    string `validate:"required,max=100" json:"name"`
+	Version uint64 `json:"version"`
+}
+
+type PriceListResponse struct {
+	Id        uint64    `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Version   uint64    `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PriceListItemRequest struct {
+	PriceListId uint64  `validate:"required" json:"price_list_id"`
+	ProductID   string  `validate:"required" json:"product_id"`
+	Price       float64 `validate:"required,gt=0" json:"price"`
+}
+
+type PriceListItemResponse struct {
+	PriceListId uint64  `json:"price_list_id"`
+	ProductID   string  `json:"product_id"`
+	Price       float64 `json:"price"`
+}
+
+type PriceScheduleCreateRequest struct {
+	PriceListId   *uint64    `json:"price_list_id"` // nil changes the product's own price
+	ProductID     string     `validate:"required" json:"product_id"`
+	Price         float64    `validate:"required,gt=0" json:"price"`
+	EffectiveFrom time.Time  `validate:"required" json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+}
+
+type PriceScheduleFilterRequest struct {
+	ProductID string
+	Status    string
+}
+
+type PriceScheduleResponse struct {
+	Id            uint64     `json:"id"`
+	PriceListId   *uint64    `json:"price_list_id"`
+	ProductID     string     `json:"product_id"`
+	Price         float64    `json:"price"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+	Status        string     `json:"status"`
+	PreviousPrice *float64   `json:"previous_price"`
+	AppliedAt     *time.Time `json:"applied_at"`
+	EndedAt       *time.Time `json:"ended_at"`
+}
+
+// PriceScheduleRunResponse reports what one run of the price scheduler did.
+type PriceScheduleRunResponse struct {
+	Applied int `json:"applied"`
+	Ended   int `json:"ended"`
+	Skipped int `json:"skipped"`
+}
+
+type PriceHistoryResponse struct {
+	ProductID   string    `json:"product_id"`
+	PriceListId *uint64   `json:"price_list_id"`
+	OldPrice    *float64  `json:"old_price"`
+	NewPrice    *float64  `json:"new_price"`
+	Source      string    `json:"source"`
+	ScheduleId  *uint64   `json:"schedule_id,omitempty"`
+	Actor       string    `json:"actor"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+type PriceQuery struct {
+	CustomerID uint64 // 0 for walk-in customers
+	ProductID  string
+	VariantID  *uint64
+	At         time.Time
+}
+
+// ResolvedPrice is the unit price of a product for a customer at a moment, and where
+// it came from: price_list_schedule, price_list, variant, schedule or product.
+type ResolvedPrice struct {
+	ProductID   string  `json:"product_id"`
+	VariantID   *uint64 `json:"variant_id,omitempty"`
+	PriceListId *uint64 `json:"price_list_id,omitempty"`
+	Price       float64 `json:"price"`
+	Source      string  `json:"source"`
+	// RegularPrice is the product's or variant's own price, before price lists and
+	// scheduled changes.
+	RegularPrice float64 `json:"regular_price"`
+	TaxRate      float64 `json:"tax_rate"`
+	TaxType      string  `json:"tax_type"`
+}
@@ -0,0 +1,27 @@
+package web
+
+type ProductBarcodeCreateRequest struct {
+	ProductID string  `validate:"required" json:"product_id"`
+	VariantID *uint64 `json:"variant_id"`
+	Code      string  `validate:"required" json:"code"`
+}
+
+type ProductBarcodeResponse struct {
+	ProductID string  `json:"product_id"`
+	Code      string  `json:"code"`
+	Type      string  `json:"type"`
+	VariantID *uint64 `json:"variant_id,omitempty"`
+	Internal  bool    `json:"internal"`
+}
+
+type ProductBarcodeLookupResponse struct {
+	Barcode ProductBarcodeResponse  `json:"barcode"`
+	Product ProductResponse         `json:"product"`
+	Variant *ProductVariantResponse `json:"variant,omitempty"`
+}
+
+type BarcodeLabelRequest struct {
+	Format     string   // png or pdf
+	ProductIDs []string // one label per product, using its first barcode
+	Copies     int
+}
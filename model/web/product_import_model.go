@@ -0,0 +1,22 @@
+package web
+
+type ProductImportRequest struct {
+	Rows    [][]string        // first row is the header
+	Mapping map[string]string // product field -> column header, defaults to the field name
+	DryRun  bool
+}
+
+type ProductImportRowError struct {
+	Row     int    `json:"row"` // row number in the file, the header being row 1
+	SKU     string `json:"sku"`
+	Message string `json:"message"`
+}
+
+type ProductImportResponse struct {
+	DryRun    bool                    `json:"dry_run"`
+	Committed bool                    `json:"committed"`
+	TotalRows int                     `json:"total_rows"`
+	Inserts   int                     `json:"inserts"`
+	Updates   int                     `json:"updates"`
+	Errors    []ProductImportRowError `json:"errors"`
+}
@@ -1,33 +1,67 @@
 package web
 
+import "time"
+
+// ProductCreateRequest creates a product without stock. Stock is only booked through the
+// stock endpoints, which keep the inventory ledger and the stock of every store.
 type ProductCreateRequest struct {
 	Name        string  `validate:"required,min=1,max=100" json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `validate:"required,gt=0" json:"price"`
-	StockQty    int     `validate:"required,gte=0" json:"stock_qty"`
 	CategoryID  int     `json:"category"`
 	SKU         string  `json:"sku"`
 	TaxRate     float64 `json:"tax_rate"`
+	TaxType     string  `validate:"max=50" json:"tax_type"`
+	CostPrice   float64 `validate:"gte=0" json:"cost_price"`
+	CostMethod  string  `validate:"omitempty,oneof=fifo average" json:"cost_method"`
+	SupplierID  *uint64 `json:"preferred_supplier_id"`
 }
 
 type ProductResponse struct {
-	ProductID   string  `json:"product_id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	StockQty    int     `json:"stock_qty"`
-	CategoryID  int     `json:"category"`
-	SKU         string  `json:"sku"`
-	TaxRate     float64 `json:"tax_rate"`
+	ProductID   string                   `json:"product_id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Price       float64                  `json:"price"`
+	StockQty    int                      `json:"stock_qty"`
+	CategoryID  int                      `json:"category"`
+	SKU         string                   `json:"sku"`
+	TaxRate     float64                  `json:"tax_rate"`
+	TaxType     string                   `json:"tax_type"`
+	CostPrice   float64                  `json:"cost_price"`
+	CostMethod  string                   `json:"cost_method"`
+	SupplierID  *uint64                  `json:"preferred_supplier_id"`
+	Version     uint64                   `json:"version"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	Options     []ProductOptionResponse  `json:"options,omitempty"`
+	Variants    []ProductVariantResponse `json:"variants,omitempty"`
+	Barcodes    []ProductBarcodeResponse `json:"barcodes,omitempty"`
 }
 
+// ProductUpdateRequest updates everything of a product but its stock.
 type ProductUpdateRequest struct {
 	ProductID   string  `validate:"required" json:"product_id"`
 	Name        string  `validate:"required,max=100,min=1" json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `validate:"required,gt=0" json:"price"`
-	StockQty    int     `validate:"required,gte=0" json:"stock_qty"`
 	CategoryID  int     `json:"category"`
 	SKU         string  `json:"sku"`
 	TaxRate     float64 `json:"tax_rate"`
+	TaxType     string  `validate:"max=50" json:"tax_type"`
+	CostPrice   float64 `validate:"gte=0" json:"cost_price"`
+	CostMethod  string  `validate:"omitempty,oneof=fifo average" json:"cost_method"`
+	SupplierID  *uint64 `json:"preferred_supplier_id"`
+	Version     uint64  `json:"version"`
+}
+
+type ProductDeleteRequest struct {
+	ProductID string `json:"product_id"`
+	Version   uint64 `json:"version"`
+}
+
+type ProductBulkRequest struct {
+	Mode   string                 `json:"mode"`
+	Create []ProductCreateRequest `json:"create"`
+	Update []ProductUpdateRequest `json:"update"`
+	Delete []ProductDeleteRequest `json:"delete"`
 }
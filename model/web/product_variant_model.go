@@ -0,0 +1,40 @@
+package web
+
+import "time"
+
+type ProductOptionRequest struct {
+	Name   string   `validate:"required,max=100" json:"name"`
+	Values []string `validate:"required,min=1,dive,required,max=100" json:"values"`
+}
+
+type ProductOptionsRequest struct {
+	ProductID string                 `validate:"required" json:"product_id"`
+	Options   []ProductOptionRequest `validate:"dive" json:"options"`
+}
+
+type ProductOptionResponse struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type ProductVariantResponse struct {
+	VariantID     uint64            `json:"variant_id"`
+	ProductID     string            `json:"product_id"`
+	SKU           string            `json:"sku"`
+	Barcode       string            `json:"barcode"` // first of the variant's product barcodes
+	Options       map[string]string `json:"options"`
+	Price         float64           `json:"price"` // price override, or the product price
+	PriceOverride *float64          `json:"price_override"`
+	StockQty      int               `json:"stock_qty"`
+	Version       uint64            `json:"version"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+type ProductVariantUpdateRequest struct {
+	ProductID     string   `validate:"required" json:"product_id"`
+	VariantID     uint64   `validate:"required" json:"variant_id"`
+	SKU           string   `validate:"required,max=191" json:"sku"`
+	PriceOverride *float64 `validate:"omitempty,gt=0" json:"price_override"`
+	Version       uint64   `json:"version"`
+}
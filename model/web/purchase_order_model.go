@@ -0,0 +1,76 @@
+package web
+
+import "time"
+
+type PurchaseOrderLineRequest struct {
+	ProductID string  `validate:"required" json:"product_id"`
+	VariantID *uint64 `json:"variant_id"`
+	Quantity  int     `validate:"required,gt=0" json:"quantity"`
+	UnitCost  float64 `validate:"gte=0" json:"unit_cost"`
+}
+
+type PurchaseOrderCreateRequest struct {
+	SupplierID uint64                     `validate:"required" json:"supplier_id"`
+	Note       string                     `validate:"max=255" json:"note"`
+	Lines      []PurchaseOrderLineRequest `validate:"required,min=1,dive" json:"lines"`
+}
+
+// PurchaseOrderUpdateRequest replaces the supplier, note and lines of a draft purchase order.
+type PurchaseOrderUpdateRequest struct {
+	Id         uint64                     `validate:"required" json:"id"`
+	SupplierID uint64                     `validate:"required" json:"supplier_id"`
+	Note       string                     `validate:"max=255" json:"note"`
+	Lines      []PurchaseOrderLineRequest `validate:"required,min=1,dive" json:"lines"`
+	Version    uint64                     `json:"version"`
+}
+
+type PurchaseOrderFilterRequest struct {
+	Status     string `validate:"omitempty,oneof=draft sent partially_received received closed" json:"status"`
+	SupplierID uint64 `json:"supplier_id"`
+}
+
+// GoodsReceiptLineRequest books goods delivered for one line of a purchase order. A unit
+// cost overrides the one ordered at, e.g. when the invoice differs.
+type GoodsReceiptLineRequest struct {
+	LineID   uint64   `validate:"required" json:"line_id"`
+	Quantity int      `validate:"required,gt=0" json:"quantity"`
+	UnitCost *float64 `validate:"omitempty,gte=0" json:"unit_cost"`
+}
+
+type GoodsReceiptRequest struct {
+	PurchaseOrderID uint64                    `validate:"required" json:"purchase_order_id"`
+	Reference       string                    `validate:"max=191" json:"reference"`
+	Lines           []GoodsReceiptLineRequest `validate:"required,min=1,dive" json:"lines"`
+	Version         uint64                    `json:"version"`
+}
+
+type PurchaseOrderLineResponse struct {
+	Id               uint64  `json:"id"`
+	ProductID        string  `json:"product_id"`
+	VariantID        *uint64 `json:"variant_id"`
+	Quantity         int     `json:"quantity"`
+	ReceivedQuantity int     `json:"received_quantity"`
+	UnitCost         float64 `json:"unit_cost"`
+}
+
+type PurchaseOrderResponse struct {
+	Id         uint64                      `json:"id"`
+	StoreID    uint64                      `json:"store_id"`
+	SupplierID uint64                      `json:"supplier_id"`
+	Status     string                      `json:"status"`
+	Note       string                      `json:"note"`
+	Total      float64                     `json:"total"`
+	CreatedBy  string                      `json:"created_by"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	SentAt     *time.Time                  `json:"sent_at"`
+	ClosedAt   *time.Time                  `json:"closed_at"`
+	Version    uint64                      `json:"version"`
+	Lines      []PurchaseOrderLineResponse `json:"lines"`
+}
+
+// PurchaseOrderGenerateResponse lists the draft purchase orders raised for the products
+// that run low, and the low products that have no preferred supplier to order from.
+type PurchaseOrderGenerateResponse struct {
+	PurchaseOrders  []PurchaseOrderResponse `json:"purchase_orders"`
+	WithoutSupplier []string                `json:"without_supplier"`
+}
@@ -0,0 +1,93 @@
+package web
+
+import "time"
+
+// ReportRequest selects the orders a report covers. From and To are dates (YYYY-MM-DD,
+// both included) in TimeZone or RFC3339 timestamps (To excluded). TimeZone is an IANA
+// name and defaults to UTC. At is the moment of a point in time report, a date (the end of
+// that day) or an RFC3339 timestamp, and defaults to now.
+type ReportRequest struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	At       string `json:"at"`
+	TimeZone string `json:"tz"`
+	GroupBy  string `validate:"omitempty,oneof=day hour store employee product category" json:"group_by"`
+	By       string `validate:"omitempty,oneof=revenue quantity" json:"by"`
+	Limit    int    `validate:"omitempty,min=1,max=100" json:"limit"`
+}
+
+type ReportResponse struct {
+	Report   string        `json:"report"`
+	From     *time.Time    `json:"from,omitempty"`
+	To       *time.Time    `json:"to,omitempty"`
+	At       *time.Time    `json:"at,omitempty"`
+	TimeZone string        `json:"tz"`
+	GroupBy  string        `json:"group_by,omitempty"`
+	Rows     []interface{} `json:"rows"`
+}
+
+type SalesReportRow struct {
+	Key          string  `json:"key"`
+	Label        string  `json:"label"`
+	OrderCount   int64   `json:"order_count"`
+	Revenue      float64 `json:"revenue"`
+	AverageOrder float64 `json:"average_order"`
+}
+
+type ProductReportRow struct {
+	ProductID   string  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	Quantity    int64   `json:"quantity"`
+	Revenue     float64 `json:"revenue"`
+}
+
+type CategoryReportRow struct {
+	CategoryID   uint64  `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	OrderCount   int64   `json:"order_count"`
+	Quantity     int64   `json:"quantity"`
+	Revenue      float64 `json:"revenue"`
+}
+
+type PaymentTypeReportRow struct {
+	PaymentType  string  `json:"payment_type"`
+	PaymentCount int64   `json:"payment_count"`
+	Amount       float64 `json:"amount"`
+	Share        float64 `json:"share"` // percentage of all completed payments
+}
+
+type TaxReportRow struct {
+	TaxType    string  `json:"tax_type"`
+	TaxRate    float64 `json:"tax_rate"`
+	GrossSales float64 `json:"gross_sales"`
+	NetSales   float64 `json:"net_sales"`
+	TaxAmount  float64 `json:"tax_amount"`
+}
+
+type DiscountReportRow struct {
+	ProductID      string  `json:"product_id"`
+	ProductName    string  `json:"product_name"`
+	Quantity       int64   `json:"quantity"`
+	RegularRevenue float64 `json:"regular_revenue"`
+	Revenue        float64 `json:"revenue"`
+	DiscountAmount float64 `json:"discount_amount"`
+}
+
+type MarginReportRow struct {
+	Key           string  `json:"key"`
+	Label         string  `json:"label"`
+	Quantity      int64   `json:"quantity"`
+	NetSales      float64 `json:"net_sales"` // revenue without the tax included in the prices
+	Cost          float64 `json:"cost"`
+	GrossMargin   float64 `json:"gross_margin"`
+	MarginPercent float64 `json:"margin_percent"`
+}
+
+type InventoryValuationRow struct {
+	ProductID   string  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	VariantID   *uint64 `json:"variant_id"`
+	Quantity    int64   `json:"quantity"`
+	UnitCost    float64 `json:"unit_cost"`
+	Value       float64 `json:"value"`
+}
@@ -0,0 +1,57 @@
+package web
+
+import "time"
+
+type ShiftOpenRequest struct {
+	OpeningFloat float64 `validate:"gte=0" json:"opening_float"`
+}
+
+type ShiftCloseRequest struct {
+	ShiftID     uint64  `validate:"required" json:"shift_id"`
+	CountedCash float64 `validate:"gte=0" json:"counted_cash"`
+	Note        string  `validate:"max=255" json:"note"`
+	Version     uint64  `json:"version"`
+}
+
+type CashMovementRequest struct {
+	ShiftID uint64  `validate:"required" json:"shift_id"`
+	Type    string  `validate:"required,oneof=cash_in cash_out" json:"type"`
+	Amount  float64 `validate:"required,gt=0" json:"amount"`
+	Reason  string  `validate:"required,max=255" json:"reason"`
+}
+
+type ShiftFilterRequest struct {
+	EmployeeID string
+	Status     string
+}
+
+type CashMovementResponse struct {
+	Id        uint64    `json:"id"`
+	Type      string    `json:"type"`
+	Amount    float64   `json:"amount"`
+	Reason    string    `json:"reason"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ShiftResponse describes a shift and the cash that should be in its till. While the
+// shift is open ExpectedCash is the running total; CountedCash and Variance (counted
+// minus expected, negative when cash is missing) are set when it is closed.
+type ShiftResponse struct {
+	Id            uint64                 `json:"id"`
+	StoreID       uint64                 `json:"store_id"`
+	EmployeeID    string                 `json:"employee_id"`
+	Status        string                 `json:"status"`
+	OpeningFloat  float64                `json:"opening_float"`
+	OpenedAt      time.Time              `json:"opened_at"`
+	ClosedAt      *time.Time             `json:"closed_at"`
+	CashSales     float64                `json:"cash_sales"`
+	CashIn        float64                `json:"cash_in"`
+	CashOut       float64                `json:"cash_out"`
+	ExpectedCash  float64                `json:"expected_cash"`
+	CountedCash   *float64               `json:"counted_cash"`
+	Variance      *float64               `json:"variance"`
+	Note          string                 `json:"note"`
+	Version       uint64                 `json:"version"`
+	CashMovements []CashMovementResponse `json:"cash_movements,omitempty"`
+}
@@ -0,0 +1,101 @@
+package web
+
+import "time"
+
+// StocktakeCreateRequest starts a count of the stock of the store of the request, of the
+// products listed or of everything the store has stock of.
+type StocktakeCreateRequest struct {
+	Note       string   `validate:"max=255" json:"note"`
+	ProductIDs []string `validate:"dive,required" json:"product_ids"`
+}
+
+// StocktakeCountLineRequest is what was counted of a product or variant, given by its ID
+// or by a barcode.
+type StocktakeCountLineRequest struct {
+	ProductID string  `validate:"required_without=Barcode" json:"product_id"`
+	VariantID *uint64 `json:"variant_id"`
+	Barcode   string  `validate:"required_without=ProductID" json:"barcode"`
+	Quantity  int     `validate:"gte=0" json:"quantity"`
+	Reason    string  `validate:"omitempty,oneof=damaged expired theft count_error unknown" json:"reason"`
+}
+
+// StocktakeCountRequest records counted quantities. With Add they are added to what was
+// counted before, e.g. for the same shelf item found in two places, otherwise they
+// replace it.
+type StocktakeCountRequest struct {
+	StocktakeID uint64                      `validate:"required" json:"stocktake_id"`
+	Add         bool                        `json:"add"`
+	Lines       []StocktakeCountLineRequest `validate:"required,min=1,dive" json:"lines"`
+	Version     uint64                      `json:"version"`
+}
+
+// StocktakeUploadRequest records the rows of a scanner export: a barcode and optionally a
+// quantity (1 when left out) per row, added to what was counted before.
+type StocktakeUploadRequest struct {
+	StocktakeID uint64     `validate:"required" json:"stocktake_id"`
+	Rows        [][]string `validate:"required,min=1" json:"rows"`
+	Version     uint64     `json:"version"`
+}
+
+// StocktakeApproveRequest posts the variances of a stocktake. Reason is the shrinkage
+// reason of lines counted without one.
+type StocktakeApproveRequest struct {
+	StocktakeID uint64 `validate:"required" json:"stocktake_id"`
+	Reason      string `validate:"omitempty,oneof=damaged expired theft count_error unknown" json:"reason"`
+	Version     uint64 `json:"version"`
+}
+
+type StocktakeFilterRequest struct {
+	Status string `validate:"omitempty,oneof=counting approved cancelled" json:"status"`
+}
+
+type StocktakeLineResponse struct {
+	Id               uint64  `json:"id"`
+	ProductID        string  `json:"product_id"`
+	VariantID        *uint64 `json:"variant_id"`
+	ExpectedQuantity int     `json:"expected_quantity"`
+	CountedQuantity  *int    `json:"counted_quantity"`
+	Variance         int     `json:"variance"`
+	Reason           string  `json:"reason"`
+	AdjustmentCost   float64 `json:"adjustment_cost"`
+}
+
+type StocktakeResponse struct {
+	Id         uint64                  `json:"id"`
+	StoreID    uint64                  `json:"store_id"`
+	Status     string                  `json:"status"`
+	Note       string                  `json:"note"`
+	CreatedBy  string                  `json:"created_by"`
+	CreatedAt  time.Time               `json:"created_at"`
+	ApprovedBy string                  `json:"approved_by"`
+	ClosedAt   *time.Time              `json:"closed_at"`
+	Version    uint64                  `json:"version"`
+	Lines      []StocktakeLineResponse `json:"lines,omitempty"`
+}
+
+// StocktakeVarianceRow is a counted product or variant that differs from what was expected,
+// valued at what the adjustment was posted at or, before approval, at its cost price.
+type StocktakeVarianceRow struct {
+	ProductID     string  `json:"product_id"`
+	ProductName   string  `json:"product_name"`
+	VariantID     *uint64 `json:"variant_id"`
+	Expected      int     `json:"expected"`
+	Counted       int     `json:"counted"`
+	Variance      int     `json:"variance"`
+	UnitCost      float64 `json:"unit_cost"`
+	VarianceValue float64 `json:"variance_value"`
+	Reason        string  `json:"reason"`
+}
+
+type StocktakeVarianceResponse struct {
+	StocktakeID    uint64                 `json:"stocktake_id"`
+	Status         string                 `json:"status"`
+	LinesCounted   int                    `json:"lines_counted"`
+	LinesUncounted int                    `json:"lines_uncounted"`
+	ShrinkageQty   int                    `json:"shrinkage_qty"`
+	ShrinkageValue float64                `json:"shrinkage_value"`
+	OverageQty     int                    `json:"overage_qty"`
+	OverageValue   float64                `json:"overage_value"`
+	NetValue       float64                `json:"net_value"`
+	Rows           []StocktakeVarianceRow `json:"rows"`
+}
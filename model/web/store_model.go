@@ -0,0 +1,127 @@
+package web
+
+import "time"
+
+type StoreCreateRequest struct {
+	Code    string `validate:"required,max=50" json:"code"`
+	Name    string `validate:"required,max=100" json:"name"`
+	Address string `validate:"max=255" json:"address"`
+}
+
+type StoreUpdateRequest struct {
+	Id      uint64 `validate:"required" json:"id"`
+	Code    string `validate:"required,max=50" json:"code"`
+	Name    string `validate:"required,max=100" json:"name"`
+	Address string `validate:"max=255" json:"address"`
+	Version uint64 `json:"version"`
+}
+
+type StoreResponse struct {
+	Id        uint64    `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	Version   uint64    `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StoreStockSetRequest sets the quantity of a product, or of one of its variants, on
+// hand at the store of the request.
+type StoreStockSetRequest struct {
+	ProductID string  `validate:"required" json:"product_id"`
+	VariantID *uint64 `json:"variant_id"`
+	Quantity  int     `validate:"gte=0" json:"quantity"`
+}
+
+type StoreStockResponse struct {
+	StoreID   uint64  `json:"store_id"`
+	ProductID string  `json:"product_id"`
+	VariantID *uint64 `json:"variant_id"`
+	Quantity  int     `json:"quantity"`
+}
+
+// StockRestockRequest books a delivery of a product, or of one of its variants, into the
+// store of the request at the price it was bought at.
+type StockRestockRequest struct {
+	ProductID string   `validate:"required" json:"product_id"`
+	VariantID *uint64  `json:"variant_id"`
+	Quantity  int      `validate:"required,gt=0" json:"quantity"`
+	UnitCost  *float64 `validate:"required,gte=0" json:"unit_cost"`
+	Reference string   `validate:"max=191" json:"reference"`
+}
+
+// RestockLevelRequest sets when the stock of a product, or of one of its variants, at the
+// store of the request runs low and how much to reorder then.
+type RestockLevelRequest struct {
+	ProductID       string  `validate:"required" json:"product_id"`
+	VariantID       *uint64 `json:"variant_id"`
+	RestockLevel    int     `validate:"gte=0" json:"restock_level"`
+	ReorderQuantity int     `validate:"gte=0" json:"reorder_quantity"`
+}
+
+type InventoryResponse struct {
+	ProductID       string     `json:"product_id"`
+	VariantID       *uint64    `json:"variant_id"`
+	StockQty        int        `json:"stock_qty"`
+	RestockLevel    int        `json:"restock_level"`
+	ReorderQuantity int        `json:"reorder_quantity"`
+	LastRestock     *time.Time `json:"last_restock"`
+}
+
+type InventoryMovementFilterRequest struct {
+	ProductID string `json:"product_id"`
+	Limit     int    `validate:"omitempty,min=1,max=1000" json:"limit"`
+}
+
+type InventoryMovementResponse struct {
+	Id        uint64    `json:"id"`
+	StoreID   uint64    `json:"store_id"`
+	ProductID string    `json:"product_id"`
+	VariantID *uint64   `json:"variant_id"`
+	Type      string    `json:"type"`
+	Quantity  int       `json:"quantity"`
+	UnitCost  float64   `json:"unit_cost"`
+	TotalCost float64   `json:"total_cost"`
+	Reference string    `json:"reference"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type StockTransferItemRequest struct {
+	ProductID string  `validate:"required" json:"product_id"`
+	VariantID *uint64 `json:"variant_id"`
+	Quantity  int     `validate:"required,gt=0" json:"quantity"`
+}
+
+// StockTransferCreateRequest ships stock from the store of the request to another store.
+type StockTransferCreateRequest struct {
+	ToStoreID uint64                     `validate:"required" json:"to_store_id"`
+	Note      string                     `validate:"max=255" json:"note"`
+	Items     []StockTransferItemRequest `validate:"required,min=1,dive" json:"items"`
+}
+
+type StockTransferFilterRequest struct {
+	Status string `validate:"omitempty,oneof=in_transit received cancelled" json:"status"`
+}
+
+type StockTransferItemResponse struct {
+	ProductID string  `json:"product_id"`
+	VariantID *uint64 `json:"variant_id"`
+	Quantity  int     `json:"quantity"`
+}
+
+type StockTransferResponse struct {
+	Id          uint64                      `json:"id"`
+	FromStoreID uint64                      `json:"from_store_id"`
+	ToStoreID   uint64                      `json:"to_store_id"`
+	Status      string                      `json:"status"`
+	Note        string                      `json:"note"`
+	CreatedBy   string                      `json:"created_by"`
+	ReceivedBy  string                      `json:"received_by"`
+	ShippedAt   time.Time                   `json:"shipped_at"`
+	ClosedAt    *time.Time                  `json:"closed_at"`
+	Version     uint64                      `json:"version"`
+	Items       []StockTransferItemResponse `json:"items"`
+}
@@ -0,0 +1,36 @@
+package web
+
+import "time"
+
+type SupplierCreateRequest struct {
+	Code        string `validate:"required,max=50" json:"code"`
+	Name        string `validate:"required,max=100" json:"name"`
+	ContactName string `validate:"max=100" json:"contact_name"`
+	Email       string `validate:"omitempty,email,max=255" json:"email"`
+	Phone       string `validate:"max=50" json:"phone"`
+	Address     string `validate:"max=255" json:"address"`
+}
+
+type SupplierUpdateRequest struct {
+	Id          uint64 `validate:"required" json:"id"`
+	Code        string `validate:"required,max=50" json:"code"`
+	Name        string `validate:"required,max=100" json:"name"`
+	ContactName string `validate:"max=100" json:"contact_name"`
+	Email       string `validate:"omitempty,email,max=255" json:"email"`
+	Phone       string `validate:"max=50" json:"phone"`
+	Address     string `validate:"max=255" json:"address"`
+	Version     uint64 `json:"version"`
+}
+
+type SupplierResponse struct {
+	Id          uint64    `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	ContactName string    `json:"contact_name"`
+	Email       string    `json:"email"`
+	Phone       string    `json:"phone"`
+	Address     string    `json:"address"`
+	Version     uint64    `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
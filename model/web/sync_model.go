@@ -0,0 +1,24 @@
+package web
+
+type ChangeFeedRequest struct {
+	Since uint64 `json:"since"`
+	Limit int    `validate:"omitempty,min=1,max=1000" json:"limit"`
+}
+
+// ChangeResponse is an upsert of an entity, with its current state as Data, or the
+// tombstone of a deleted one.
+type ChangeResponse struct {
+	Seq        uint64      `json:"seq"`
+	EntityType string      `json:"entity_type"`
+	EntityId   string      `json:"entity_id"`
+	Action     string      `json:"action"`
+	Data       interface{} `json:"data,omitempty"`
+}
+
+// ChangeFeedResponse is a page of the change feed. Cursor is passed as since to get the
+// next page; it is unchanged when there is nothing new.
+type ChangeFeedResponse struct {
+	Changes []ChangeResponse `json:"changes"`
+	Cursor  uint64           `json:"cursor"`
+	HasMore bool             `json:"has_more"`
+}
@@ -0,0 +1,13 @@
+package web
+
+import "time"
+
+type TaxResponse struct {
+	TaxID       string    `json:"tax_id"`
+	TaxRate     float64   `json:"tax_rate"`
+	TaxType     string    `json:"tax_type"`
+	Description string    `json:"description"`
+	Version     uint64    `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
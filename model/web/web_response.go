@@ -5,3 +5,9 @@ type WebResponse struct {
 	Status string      `json:"status"`
 	Data   interface{} `json:"data"`
 }
+
+// FieldErrorResponse is the data of an error about one field of the request.
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
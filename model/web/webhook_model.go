@@ -0,0 +1,54 @@
+package web
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookSubscriptionCreateRequest struct {
+	URL        string   `validate:"required,url,max=500" json:"url"`
+	EventTypes []string `validate:"required,min=1,dive,oneof=* ProductCreated PriceChanged StockAdjusted OrderPaid CustomerUpdated CustomerMerged CustomerAnonymised" json:"event_types"`
+	Secret     string   `validate:"omitempty,min=16,max=100" json:"secret"`
+}
+
+type WebhookSubscriptionUpdateRequest struct {
+	Id         uint64   `validate:"required" json:"id"`
+	URL        string   `validate:"required,url,max=500" json:"url"`
+	EventTypes []string `validate:"required,min=1,dive,oneof=* ProductCreated PriceChanged StockAdjusted OrderPaid CustomerUpdated CustomerMerged CustomerAnonymised" json:"event_types"`
+	Secret     string   `validate:"omitempty,min=16,max=100" json:"secret"`
+	Active     bool     `json:"active"`
+	Version    uint64   `json:"version"`
+}
+
+type WebhookDeliveryFilterRequest struct {
+	SubscriptionID uint64 `json:"subscription_id"`
+	Status         string `validate:"omitempty,oneof=pending delivered dead" json:"status"`
+	Limit          int    `validate:"omitempty,min=1,max=500" json:"limit"`
+}
+
+// WebhookSubscriptionResponse only carries the secret when the subscription is created,
+// so that it cannot be read back later.
+type WebhookSubscriptionResponse struct {
+	Id         uint64    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"secret,omitempty"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	Version    uint64    `json:"version"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id             uint64          `json:"id"`
+	SubscriptionID uint64          `json:"subscription_id"`
+	EventID        uint64          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	Payload        json.RawMessage `json:"payload"`
+}
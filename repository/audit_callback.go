@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"reflect"
+	"time"
+)
+
+const auditBeforeKey = "audit:before"
+
+type auditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// RegisterAuditCallbacks hooks into GORM so that every create, update and delete of any
+// model writes an AuditLog row, except for the excluded models and the audit log itself.
+// The row is inserted with the same connection as the change itself, so it is committed
+// or rolled back together with it. Fields tagged audit:"-", such as secrets, are left out
+// of the changes.
+func RegisterAuditCallbacks(db *gorm.DB, excluded ...interface{}) error {
+	excludedTables := map[string]bool{}
+	for _, model := range append(excluded, &domain.AuditLog{}) {
+		statement := &gorm.Statement{DB: db}
+		if err := statement.Parse(model); err != nil {
+			return err
+		}
+		excludedTables[statement.Schema.Table] = true
+	}
+	callback := db.Callback()
+	if err := callback.Create().After("gorm:create").Register("audit:after_create", auditAfterCreate(excludedTables)); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("audit:before_update", auditCaptureBefore(excludedTables)); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register("audit:after_update", auditAfterUpdate(excludedTables)); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("audit:before_delete", auditCaptureBefore(excludedTables)); err != nil {
+		return err
+	}
+	return callback.Delete().After("gorm:delete").Register("audit:after_delete", auditAfterDelete(excludedTables))
+}
+
+func auditAfterCreate(excludedTables map[string]bool) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if !isAudited(db, excludedTables) || db.Statement.RowsAffected == 0 {
+			return
+		}
+
+		value := reflect.Indirect(db.Statement.ReflectValue)
+		switch value.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < value.Len(); i++ {
+				row := reflect.Indirect(value.Index(i))
+				writeAuditLog(db, "create", auditEntityId(db, row), nil, auditSnapshot(db, row))
+			}
+		case reflect.Struct:
+			writeAuditLog(db, "create", auditEntityId(db, value), nil, auditSnapshot(db, value))
+		}
+	}
+}
+
+func auditCaptureBefore(excludedTables map[string]bool) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if !isAudited(db, excludedTables) {
+			return
+		}
+
+		before, found := findAuditedRow(db)
+		if found {
+			db.InstanceSet(auditBeforeKey, before)
+		}
+	}
+}
+
+func auditAfterUpdate(excludedTables map[string]bool) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if !isAudited(db, excludedTables) || db.Statement.RowsAffected == 0 {
+			return
+		}
+
+		before, ok := db.InstanceGet(auditBeforeKey)
+		if !ok {
+			return
+		}
+		after, found := findAuditedRow(db)
+		if !found {
+			return
+		}
+
+		value := reflect.Indirect(db.Statement.ReflectValue)
+		writeAuditLog(db, "update", auditEntityId(db, value), before.(map[string]interface{}), after)
+	}
+}
+
+func auditAfterDelete(excludedTables map[string]bool) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if !isAudited(db, excludedTables) || db.Statement.RowsAffected == 0 {
+			return
+		}
+
+		before, ok := db.InstanceGet(auditBeforeKey)
+		if !ok {
+			return
+		}
+
+		value := reflect.Indirect(db.Statement.ReflectValue)
+		writeAuditLog(db, "delete", auditEntityId(db, value), before.(map[string]interface{}), nil)
+	}
+}
+
+func isAudited(db *gorm.DB, excludedTables map[string]bool) bool {
+	return db.Error == nil && db.Statement.Schema != nil && !excludedTables[db.Statement.Schema.Table]
+}
+
+// findAuditedRow reloads the row addressed by the statement's model, so that the
+// snapshot reflects what is actually stored rather than what the caller passed in.
+func findAuditedRow(db *gorm.DB) (map[string]interface{}, bool) {
+	value := reflect.Indirect(db.Statement.ReflectValue)
+	if value.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	primaryField := db.Statement.Schema.PrioritizedPrimaryField
+	if primaryField == nil {
+		return nil, false
+	}
+	id, isZero := primaryField.ValueOf(db.Statement.Context, value)
+	if isZero {
+		return nil, false
+	}
+
+	row := reflect.New(db.Statement.Schema.ModelType)
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Table(db.Statement.Schema.Table).
+		Where(primaryField.DBName+" = ?", id).
+		Take(row.Interface()).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false
+	} else if err != nil {
+		_ = db.AddError(err)
+		return nil, false
+	}
+
+	return auditSnapshot(db, row.Elem()), true
+}
+
+func auditSnapshot(db *gorm.DB, value reflect.Value) map[string]interface{} {
+	snapshot := map[string]interface{}{}
+	for _, field := range db.Statement.Schema.Fields {
+		if field.DBName == "" || field.Tag.Get("audit") == "-" {
+			continue
+		}
+		if field.Serializer != nil {
+			// ValueOf wraps a serialized field for binding; the snapshot wants its value
+			snapshot[field.DBName] = field.ReflectValueOf(db.Statement.Context, value).Interface()
+			continue
+		}
+		fieldValue, _ := field.ValueOf(db.Statement.Context, value)
+		snapshot[field.DBName] = fieldValue
+	}
+	return snapshot
+}
+
+func auditEntityId(db *gorm.DB, value reflect.Value) string {
+	primaryField := db.Statement.Schema.PrioritizedPrimaryField
+	if primaryField == nil || value.Kind() != reflect.Struct {
+		return ""
+	}
+	id, _ := primaryField.ValueOf(db.Statement.Context, value)
+	return fmt.Sprint(id)
+}
+
+func writeAuditLog(db *gorm.DB, action string, entityId string, before, after map[string]interface{}) {
+	changes := diffSnapshots(before, after)
+	if len(changes) == 0 {
+		return
+	}
+
+	changesJson, err := json.Marshal(changes)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	ctx := db.Statement.Context
+	auditLog := domain.AuditLog{
+		EntityType: db.Statement.Schema.Table,
+		EntityId:   entityId,
+		Action:     action,
+		Actor:      helper.ActorFromContext(ctx),
+		Changes:    string(changesJson),
+		ClientIP:   helper.ClientIPFromContext(ctx),
+		RequestId:  helper.RequestIdFromContext(ctx),
+		CreatedAt:  time.Now(),
+	}
+
+	err = db.Session(&gorm.Session{NewDB: true, SkipDefaultTransaction: true}).Create(&auditLog).Error
+	if err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// diffSnapshots returns the columns whose value differs between before and after.
+// A nil before (create) or nil after (delete) reports every column.
+func diffSnapshots(before, after map[string]interface{}) map[string]auditChange {
+	changes := map[string]auditChange{}
+	for column, beforeValue := range before {
+		afterValue, ok := after[column]
+		if ok && sameJson(beforeValue, afterValue) {
+			continue
+		}
+		changes[column] = auditChange{Before: beforeValue, After: afterValue}
+	}
+	for column, afterValue := range after {
+		if _, ok := before[column]; !ok {
+			changes[column] = auditChange{Before: nil, After: afterValue}
+		}
+	}
+	return changes
+}
+
+func sameJson(a, b interface{}) bool {
+	aJson, errA := json.Marshal(a)
+	bJson, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aJson) == string(bJson)
+}
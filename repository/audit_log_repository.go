@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type AuditLogRepository interface {
+	Save(ctx context.Context, auditLog domain.AuditLog) (domain.AuditLog, error)
+	FindAll(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error)
+	UpdateChanges(ctx context.Context, auditLog domain.AuditLog) error
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &AuditLogRepositoryImpl{db: db}
+}
+
+// Save an audit log entry of a change the audit callbacks do not see, such as a merge
+func (repository *AuditLogRepositoryImpl) Save(ctx context.Context, auditLog domain.AuditLog) (domain.AuditLog, error) {
+	if err := dbFromContext(ctx, repository.db).Create(&auditLog).Error; err != nil {
+		return domain.AuditLog{}, err
+	}
+	return auditLog, nil
+}
+
+func (repository *AuditLogRepositoryImpl) FindAll(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	query := dbFromContext(ctx, repository.db)
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityId != "" {
+		query = query.Where("entity_id = ?", filter.EntityId)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var auditLogs []domain.AuditLog
+	err := query.Order("created_at DESC, id DESC").Find(&auditLogs).Error
+	return auditLogs, err
+}
+
+// UpdateChanges - Rewrite the changes of an audit log entry, to redact personal data
+func (repository *AuditLogRepositoryImpl) UpdateChanges(ctx context.Context, auditLog domain.AuditLog) error {
+	return dbFromContext(ctx, repository.db).Model(&auditLog).Update("changes", auditLog.Changes).Error
+}
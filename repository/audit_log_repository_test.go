@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"reflect"
+	"testing"
+)
+
+func TestAuditLogRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAuditLogRepository(ctrl)
+	ctx := context.Background()
+
+	filter := domain.AuditLogFilter{EntityType: "customers"}
+	repo.EXPECT().FindAll(ctx, filter).Return([]domain.AuditLog{{Id: 1, EntityType: "customers", Action: "delete"}}, nil)
+
+	result, err := repo.FindAll(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AuditLog{{Id: 1, EntityType: "customers", Action: "delete"}}, result)
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	tests := []struct {
+		name   string
+		before map[string]interface{}
+		after  map[string]interface{}
+		expect map[string]auditChange
+	}{
+		{
+			name:   "create reports every column",
+			before: nil,
+			after:  map[string]interface{}{"id": 1, "name": "Food"},
+			expect: map[string]auditChange{"id": {After: 1}, "name": {After: "Food"}},
+		},
+		{
+			name:   "update reports changed columns only",
+			before: map[string]interface{}{"id": "1", "product_price": 10.0, "stock_qty": 5},
+			after:  map[string]interface{}{"id": "1", "product_price": 12.5, "stock_qty": 5},
+			expect: map[string]auditChange{"product_price": {Before: 10.0, After: 12.5}},
+		},
+		{
+			name:   "delete reports every column",
+			before: map[string]interface{}{"id": 3},
+			after:  nil,
+			expect: map[string]auditChange{"id": {Before: 3}},
+		},
+		{
+			name:   "no changes",
+			before: map[string]interface{}{"id": 3},
+			after:  map[string]interface{}{"id": 3},
+			expect: map[string]auditChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, diffSnapshots(tt.before, tt.after))
+		})
+	}
+}
+
+func TestAuditSnapshotOfSerializedField(t *testing.T) {
+	db := newDryRunDB(t)
+	product := domain.Product{ProductID: "p-1", SKU: "CF-1"}
+	statement := db.Model(&product).Statement
+	assert.NoError(t, statement.Parse(&product))
+
+	snapshot := auditSnapshot(statement.DB, reflect.ValueOf(product))
+	assert.Equal(t, "CF-1", snapshot["product_sku"])
+	_, err := json.Marshal(snapshot)
+	assert.NoError(t, err)
+}
+
+func TestAuditedModels(t *testing.T) {
+	db := newDryRunDB(t)
+	excludedTables := map[string]bool{"outbox_events": true, "audit_logs": true}
+	statementOf := func(model interface{}) *gorm.DB {
+		tx := db.Model(model)
+		assert.NoError(t, tx.Statement.Parse(model))
+		return tx
+	}
+
+	assert.True(t, isAudited(statementOf(&domain.Order{}), excludedTables))
+	assert.True(t, isAudited(statementOf(&domain.WebhookSubscription{}), excludedTables))
+	assert.False(t, isAudited(statementOf(&domain.OutboxEvent{}), excludedTables))
+	assert.False(t, isAudited(statementOf(&domain.AuditLog{}), excludedTables))
+
+	subscription := domain.WebhookSubscription{Id: 1, URL: "https://example.com", Secret: "s3cret"}
+	tx := statementOf(&subscription)
+	snapshot := auditSnapshot(tx, reflect.ValueOf(subscription))
+	assert.Equal(t, "https://example.com", snapshot["url"])
+	assert.NotContains(t, snapshot, "secret")
+}
@@ -11,4 +11,9 @@ type CategoryRepository interface {
 	Delete(ctx context.Context, category domain.Category) error
 	FindById(ctx context.Context, categoryId uint64) (domain.Category, error)
 	FindAll(ctx context.Context) ([]domain.Category, error)
+	FindAllForUpdate(ctx context.Context) ([]domain.Category, error)
+	FindAllById(ctx context.Context, categoryIds []uint64) ([]domain.Category, error)
+	CountChildren(ctx context.Context, categoryId uint64) (int64, error)
+	CountProducts(ctx context.Context) (map[uint64]int, error)
+	FindInBatches(ctx context.Context, batchSize int, fn func(categories []domain.Category) error) error
 }
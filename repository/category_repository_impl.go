@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CategoryRepositoryImpl struct {
@@ -17,7 +18,8 @@ func NewCategoryRepository(db *gorm.DB) CategoryRepository {
 
 // Save category
 func (repository *CategoryRepositoryImpl) Save(ctx context.Context, category domain.Category) (domain.Category, error) {
-	if err := repository.db.WithContext(ctx).Create(&category).Error; err != nil {
+	category.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&category).Error; err != nil {
 		return domain.Category{}, err
 	}
 	return category, nil
@@ -25,16 +27,32 @@ func (repository *CategoryRepositoryImpl) Save(ctx context.Context, category dom
 
 // Update category
 func (repository *CategoryRepositoryImpl) Update(ctx context.Context, category domain.Category) (domain.Category, error) {
-	if err := repository.db.WithContext(ctx).Save(&category).Error; err != nil {
-		return domain.Category{}, err
+	expectedVersion := category.Version
+	category.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&category).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(&category)
+	if result.Error != nil {
+		return domain.Category{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.Category{}, ErrVersionConflict
 	}
 	return category, nil
 }
 
 // Delete category
 func (repository *CategoryRepositoryImpl) Delete(ctx context.Context, category domain.Category) error {
-	if err := repository.db.WithContext(ctx).Delete(&category).Error; err != nil {
-		return err
+	result := dbFromContext(ctx, repository.db).Where("version = ?", category.Version).Delete(&category)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
 	}
 	return nil
 }
@@ -42,7 +60,7 @@ func (repository *CategoryRepositoryImpl) Delete(ctx context.Context, category d
 // FindById - Get category by ID
 func (repository *CategoryRepositoryImpl) FindById(ctx context.Context, categoryId uint64) (domain.Category, error) {
 	var category domain.Category
-	err := repository.db.WithContext(ctx).First(&category, categoryId).Error
+	err := dbFromContext(ctx, repository.db).First(&category, categoryId).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return category, errors.New("category is not found")
 	}
@@ -52,6 +70,63 @@ func (repository *CategoryRepositoryImpl) FindById(ctx context.Context, category
 // FindAll - Get all categories
 func (repository *CategoryRepositoryImpl) FindAll(ctx context.Context) ([]domain.Category, error) {
 	var categories []domain.Category
-	err := repository.db.WithContext(ctx).Find(&categories).Error
+	err := dbFromContext(ctx, repository.db).Find(&categories).Error
+	return categories, err
+}
+
+// FindAllForUpdate - Get all categories in id order, locking them for the rest of the
+// transaction
+func (repository *CategoryRepositoryImpl) FindAllForUpdate(ctx context.Context) ([]domain.Category, error) {
+	var categories []domain.Category
+	err := dbFromContext(ctx, repository.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Order("id").
+		Find(&categories).Error
 	return categories, err
 }
+
+// FindAllById - Get the categories with the given ids
+func (repository *CategoryRepositoryImpl) FindAllById(ctx context.Context, categoryIds []uint64) ([]domain.Category, error) {
+	var categories []domain.Category
+	if len(categoryIds) == 0 {
+		return categories, nil
+	}
+	return categories, dbFromContext(ctx, repository.db).Where("id IN ?", categoryIds).Find(&categories).Error
+}
+
+// CountChildren - Count the direct children of a category
+func (repository *CategoryRepositoryImpl) CountChildren(ctx context.Context, categoryId uint64) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, repository.db).Model(&domain.Category{}).Where("parent_id = ?", categoryId).Count(&count).Error
+	return count, err
+}
+
+// CountProducts - Count the products of every category that has any
+func (repository *CategoryRepositoryImpl) CountProducts(ctx context.Context) (map[uint64]int, error) {
+	var rows []struct {
+		CategoryId uint64
+		Count      int
+	}
+	err := dbFromContext(ctx, repository.db).
+		Model(&domain.Product{}).
+		Select("category_id, COUNT(*) AS count").
+		Group("category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint64]int, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryId] = row.Count
+	}
+	return counts, nil
+}
+
+// FindInBatches - Walk all categories batchSize rows at a time
+func (repository *CategoryRepositoryImpl) FindInBatches(ctx context.Context, batchSize int, fn func(categories []domain.Category) error) error {
+	var categories []domain.Category
+	return dbFromContext(ctx, repository.db).FindInBatches(&categories, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(categories)
+	}).Error
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"reflect"
+	"time"
+)
+
+// ChangeFeedTable puts the changes of a model into the change feed as changes of an
+// entity type: changes of the row itself, or, with ParentColumn, upserts of the entity
+// the row belongs to, e.g. the product of a variant.
+type ChangeFeedTable struct {
+	Model        interface{}
+	EntityType   string
+	ParentColumn string
+}
+
+// changedEntitiesKey is the statement setting holding the entities an update or delete
+// selects by its conditions only.
+const changedEntitiesKey = "changes:entity_ids"
+
+// RegisterChangeFeedCallbacks hooks into GORM so that every create, update and delete of
+// the given tables writes an EntityChange row. Like the audit log, the row is inserted
+// with the same connection as the change, so it is committed or rolled back with it.
+// Updates and deletes whose model does not carry the changed entities, like the stock
+// adjustments of AdjustStock, first look up the entities of the rows they select.
+func RegisterChangeFeedCallbacks(db *gorm.DB, tables ...ChangeFeedTable) error {
+	tablesByName := map[string]ChangeFeedTable{}
+	for _, table := range tables {
+		statement := &gorm.Statement{DB: db}
+		if err := statement.Parse(table.Model); err != nil {
+			return err
+		}
+		tablesByName[statement.Schema.Table] = table
+	}
+
+	callback := db.Callback()
+	if err := callback.Update().Before("gorm:update").Register("changes:before_update", captureChangedEntities(tablesByName)); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("changes:before_delete", captureChangedEntities(tablesByName)); err != nil {
+		return err
+	}
+	if err := callback.Create().After("gorm:create").Register("changes:after_create", recordChanges(tablesByName, domain.ChangeUpsert)); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register("changes:after_update", recordChanges(tablesByName, domain.ChangeUpsert)); err != nil {
+		return err
+	}
+	return callback.Delete().After("gorm:delete").Register("changes:after_delete", recordChanges(tablesByName, domain.ChangeDelete))
+}
+
+// BackfillChangeFeed records an upsert of every existing row of the tables whose entity
+// type has no changes yet, so that a terminal syncing from the start gets rows that were
+// written before the change feed existed.
+func BackfillChangeFeed(db *gorm.DB, tables ...ChangeFeedTable) error {
+	for _, table := range tables {
+		if table.ParentColumn != "" {
+			continue
+		}
+		statement := &gorm.Statement{DB: db}
+		if err := statement.Parse(table.Model); err != nil {
+			return err
+		}
+
+		err := db.Exec(`INSERT INTO entity_changes (entity_type, entity_id, action, changed_at)
+			SELECT ?, `+statement.Schema.PrioritizedPrimaryField.DBName+`, ?, ? FROM `+statement.Schema.Table+`
+			WHERE NOT EXISTS (SELECT 1 FROM entity_changes WHERE entity_type = ?)`,
+			table.EntityType, domain.ChangeUpsert, time.Now(), table.EntityType).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordChanges(tables map[string]ChangeFeedTable, action string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.Statement.Schema == nil || db.Statement.RowsAffected == 0 {
+			return
+		}
+		table, ok := tables[db.Statement.Schema.Table]
+		if !ok {
+			return
+		}
+		// A deleted child only changes the entity it belongs to
+		rowAction := action
+		if table.ParentColumn != "" {
+			rowAction = domain.ChangeUpsert
+		}
+
+		entityIds := modelEntityIds(db, table)
+		if captured, ok := db.Statement.Settings.Load(changedEntitiesKey); ok && len(entityIds) == 0 {
+			entityIds = captured.([]string)
+		}
+
+		now := time.Now()
+		for _, entityId := range entityIds {
+			err := db.Session(&gorm.Session{NewDB: true, SkipDefaultTransaction: true}).Create(&domain.EntityChange{
+				EntityType: table.EntityType,
+				EntityId:   entityId,
+				Action:     rowAction,
+				ChangedAt:  now,
+			}).Error
+			if err != nil {
+				_ = db.AddError(err)
+				return
+			}
+		}
+	}
+}
+
+// captureChangedEntities looks up, before an update or delete whose model does not carry
+// the changed entities, the entities of the rows its conditions select.
+func captureChangedEntities(tables map[string]ChangeFeedTable) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.Statement.Schema == nil {
+			return
+		}
+		table, ok := tables[db.Statement.Schema.Table]
+		if !ok || len(modelEntityIds(db, table)) > 0 {
+			return
+		}
+		where, ok := db.Statement.Clauses["WHERE"]
+		if !ok {
+			return
+		}
+
+		column := db.Statement.Schema.PrioritizedPrimaryField.DBName
+		if table.ParentColumn != "" {
+			column = table.ParentColumn
+		}
+		var entityIds []string
+		err := db.Session(&gorm.Session{NewDB: true, SkipDefaultTransaction: true}).
+			Table(db.Statement.Schema.Table).
+			Clauses(where.Expression).
+			Distinct().
+			Pluck(column, &entityIds).Error
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		db.Statement.Settings.Store(changedEntitiesKey, entityIds)
+	}
+}
+
+// modelEntityIds are the ids of the entities the rows of the statement's model are, or
+// belong to.
+func modelEntityIds(db *gorm.DB, table ChangeFeedTable) []string {
+	var entityIds []string
+	seen := map[string]bool{}
+	addRow := func(row reflect.Value) {
+		entityId := changedEntityId(db, table, row)
+		if entityId != "" && !seen[entityId] {
+			seen[entityId] = true
+			entityIds = append(entityIds, entityId)
+		}
+	}
+	value := reflect.Indirect(db.Statement.ReflectValue)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			addRow(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		addRow(value)
+	}
+	return entityIds
+}
+
+// changedEntityId is the id of the entity a changed row is, or belongs to; empty when the
+// statement did not carry it.
+func changedEntityId(db *gorm.DB, table ChangeFeedTable, row reflect.Value) string {
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if table.ParentColumn != "" {
+		field = db.Statement.Schema.LookUpField(table.ParentColumn)
+	}
+	if field == nil || row.Kind() != reflect.Struct {
+		return ""
+	}
+	id, isZero := field.ValueOf(db.Statement.Context, row)
+	if isZero {
+		return ""
+	}
+	return fmt.Sprint(id)
+}
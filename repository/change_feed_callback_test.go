@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestChangeFeedCapturesEntitiesSelectedByConditions(t *testing.T) {
+	db := newDryRunDB(t)
+	require.NoError(t, RegisterChangeFeedCallbacks(db,
+		ChangeFeedTable{Model: &domain.Product{}, EntityType: domain.ChangeEntityProduct},
+		ChangeFeedTable{Model: &domain.ProductBarcode{}, EntityType: domain.ChangeEntityProduct, ParentColumn: "product_id"},
+	))
+	var lookups []string
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:lookups", func(db *gorm.DB) {
+		lookups = append(lookups, db.Statement.SQL.String())
+	}))
+
+	t.Run("stock adjustments look up the products they update", func(t *testing.T) {
+		lookups = nil
+		_ = NewProductRepository(db).AdjustStock(context.Background(), "p-1", -2)
+		require.Len(t, lookups, 1)
+		assert.Equal(t, "SELECT DISTINCT `id` FROM `products` WHERE id = ? AND stock_qty + ? >= 0", lookups[0])
+	})
+
+	t.Run("deleted children look up the entity they belong to", func(t *testing.T) {
+		lookups = nil
+		db.Where("variant_id = ?", 4).Delete(&domain.ProductBarcode{})
+		require.Len(t, lookups, 1)
+		assert.Equal(t, "SELECT DISTINCT `product_id` FROM `product_barcodes` WHERE variant_id = ?", lookups[0])
+	})
+
+	t.Run("a model carrying the entity needs no lookup", func(t *testing.T) {
+		lookups = nil
+		product := domain.Product{ProductID: "p-1"}
+		db.Model(&product).Update("name", "Tea")
+		assert.Empty(t, lookups)
+	})
+}
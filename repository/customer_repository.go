@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"time"
 )
 
 type CustomerRepository interface {
@@ -11,4 +12,7 @@ type CustomerRepository interface {
 	Delete(ctx context.Context, customer domain.Customer) error
 	FindById(ctx context.Context, customerId string) (domain.Customer, error)
 	FindAll(ctx context.Context) ([]domain.Customer, error)
+	FindAllByEmailOrPhone(ctx context.Context, email string, phone string) ([]domain.Customer, error)
+	FindInactive(ctx context.Context, before time.Time, afterId uint64, limit int) ([]domain.Customer, error)
+	FindInBatches(ctx context.Context, batchSize int, fn func(customers []domain.Customer) error) error
 }
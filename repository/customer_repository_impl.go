@@ -2,9 +2,10 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
 type CustomerRepositoryImpl struct {
@@ -16,33 +17,79 @@ func NewCustomerRepository(db *gorm.DB) CustomerRepository {
 }
 
 func (repository *CustomerRepositoryImpl) Save(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
-	if err := repository.db.WithContext(ctx).Create(&customer).Error; err != nil {
+	customer.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&customer).Error; err != nil {
 		return domain.Customer{}, err
 	}
 	return customer, nil
 }
 
 func (repository *CustomerRepositoryImpl) Update(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
-	if err := repository.db.WithContext(ctx).Save(&customer).Error; err != nil {
-		return domain.Customer{}, err
+	expectedVersion := customer.Version
+	customer.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&customer).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(&customer)
+	if result.Error != nil {
+		return domain.Customer{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.Customer{}, ErrVersionConflict
 	}
 	return customer, nil
 }
 
 func (repository *CustomerRepositoryImpl) Delete(ctx context.Context, customer domain.Customer) error {
-	return repository.db.WithContext(ctx).Delete(&customer).Error
+	result := dbFromContext(ctx, repository.db).Where("version = ?", customer.Version).Delete(&customer)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
 }
 
 func (repository *CustomerRepositoryImpl) FindById(ctx context.Context, customerId string) (domain.Customer, error) {
 	var customer domain.Customer
-	err := repository.db.WithContext(ctx).First(&customer, "customer_id = ?", customerId).Error
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return customer, errors.New("customer not found")
-	}
+	err := dbFromContext(ctx, repository.db).First(&customer, "id = ?", customerId).Error
 	return customer, err
 }
 
 func (repository *CustomerRepositoryImpl) FindAll(ctx context.Context) ([]domain.Customer, error) {
 	var customers []domain.Customer
-	return customers, repository.db.WithContext(ctx).Find(&customers).Error
+	return customers, dbFromContext(ctx, repository.db).Find(&customers).Error
+}
+
+// FindAllByEmailOrPhone - Get the customers with the email address or the phone number
+func (repository *CustomerRepositoryImpl) FindAllByEmailOrPhone(ctx context.Context, email string, phone string) ([]domain.Customer, error) {
+	var customers []domain.Customer
+	err := dbFromContext(ctx, repository.db).Where("customer_email = ? OR customer_phone = ?", email, phone).Find(&customers).Error
+	return customers, err
+}
+
+// FindInactive - Get limit customers after an id, by id, that are not anonymised and have neither changed nor placed an order in any store since a time
+func (repository *CustomerRepositoryImpl) FindInactive(ctx context.Context, before time.Time, afterId uint64, limit int) ([]domain.Customer, error) {
+	var customers []domain.Customer
+	// Customers saved before the timestamps existed have none, and count as changed long ago
+	err := dbFromContext(ctx, repository.db).
+		Where("id > ? AND anonymised_at IS NULL", afterId).
+		Where("(COALESCE(updated_at, created_at) IS NULL OR COALESCE(updated_at, created_at) < ?)", before).
+		Where("NOT EXISTS (SELECT 1 FROM orders WHERE orders.customer_id = CAST(customers.id AS CHAR) AND orders.order_date >= ?)", before).
+		Order("id").
+		Limit(limit).
+		Find(&customers).Error
+	return customers, err
+}
+
+// FindInBatches - Walk all customers batchSize rows at a time
+func (repository *CustomerRepositoryImpl) FindInBatches(ctx context.Context, batchSize int, fn func(customers []domain.Customer) error) error {
+	var customers []domain.Customer
+	return dbFromContext(ctx, repository.db).FindInBatches(&customers, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(customers)
+	}).Error
 }
@@ -7,7 +7,9 @@ import (
 	"github.com/aronipurwanto/go-restful-api/repository/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 	"testing"
+	"time"
 )
 
 func TestCustomerRepository(t *testing.T) {
@@ -107,3 +109,17 @@ func TestCustomerRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestFindInactiveCustomersWithoutTimestamps(t *testing.T) {
+	db := newDryRunDB(t)
+	var statements []string
+	assert.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+
+	_, err := NewCustomerRepository(db).FindInactive(context.Background(), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 0, 100)
+	assert.NoError(t, err)
+	assert.Len(t, statements, 1)
+	assert.Contains(t, statements[0], "(COALESCE(updated_at, created_at) IS NULL OR COALESCE(updated_at, created_at) < ?)")
+	assert.NotContains(t, statements[0], "AND updated_at < ?")
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type DiscountRepository interface {
+	FindAllById(ctx context.Context, discountIds []string) ([]domain.Discount, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type DiscountRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewDiscountRepository(db *gorm.DB) DiscountRepository {
+	return &DiscountRepositoryImpl{db: db}
+}
+
+// FindAllById - Get the discounts with the given ids
+func (repository *DiscountRepositoryImpl) FindAllById(ctx context.Context, discountIds []string) ([]domain.Discount, error) {
+	var discounts []domain.Discount
+	if len(discountIds) == 0 {
+		return discounts, nil
+	}
+	return discounts, dbFromContext(ctx, repository.db).Where("id IN ?", discountIds).Find(&discounts).Error
+}
@@ -11,4 +11,7 @@ type EmployeeRepository interface {
 	Delete(ctx context.Context, employee domain.Employee) error
 	FindById(ctx context.Context, employeeId string) (domain.Employee, error)
 	FindAll(ctx context.Context) ([]domain.Employee, error)
+	FindByApiKeyHash(ctx context.Context, apiKeyHash string) (domain.Employee, error)
+	FindAllByEmailOrPhone(ctx context.Context, email string, phone string) ([]domain.Employee, error)
+	FindInBatches(ctx context.Context, batchSize int, fn func(employees []domain.Employee) error) error
 }
@@ -2,9 +2,9 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type EmployeeRepositoryImpl struct {
@@ -16,33 +16,72 @@ func NewEmployeeRepository(db *gorm.DB) EmployeeRepository {
 }
 
 func (repository *EmployeeRepositoryImpl) Save(ctx context.Context, employee domain.Employee) (domain.Employee, error) {
-	if err := repository.db.WithContext(ctx).Create(&employee).Error; err != nil {
+	employee.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&employee).Error; err != nil {
 		return domain.Employee{}, err
 	}
 	return employee, nil
 }
 
 func (repository *EmployeeRepositoryImpl) Update(ctx context.Context, employee domain.Employee) (domain.Employee, error) {
-	if err := repository.db.WithContext(ctx).Save(&employee).Error; err != nil {
-		return domain.Employee{}, err
+	expectedVersion := employee.Version
+	employee.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&employee).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(&employee)
+	if result.Error != nil {
+		return domain.Employee{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.Employee{}, ErrVersionConflict
 	}
 	return employee, nil
 }
 
 func (repository *EmployeeRepositoryImpl) Delete(ctx context.Context, employee domain.Employee) error {
-	return repository.db.WithContext(ctx).Delete(&employee).Error
+	result := dbFromContext(ctx, repository.db).Where("version = ?", employee.Version).Delete(&employee)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
 }
 
 func (repository *EmployeeRepositoryImpl) FindById(ctx context.Context, employeeId string) (domain.Employee, error) {
 	var employee domain.Employee
-	err := repository.db.WithContext(ctx).First(&employee, "employee_id = ?", employeeId).Error
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return employee, errors.New("employee not found")
-	}
+	err := dbFromContext(ctx, repository.db).First(&employee, "id = ?", employeeId).Error
 	return employee, err
 }
 
 func (repository *EmployeeRepositoryImpl) FindAll(ctx context.Context) ([]domain.Employee, error) {
 	var employees []domain.Employee
-	return employees, repository.db.WithContext(ctx).Find(&employees).Error
+	return employees, dbFromContext(ctx, repository.db).Find(&employees).Error
+}
+
+// FindByApiKeyHash - Get the employee holding the API key with the given hash
+func (repository *EmployeeRepositoryImpl) FindByApiKeyHash(ctx context.Context, apiKeyHash string) (domain.Employee, error) {
+	var employee domain.Employee
+	err := dbFromContext(ctx, repository.db).Take(&employee, "api_key_hash = ?", apiKeyHash).Error
+	return employee, err
+}
+
+// FindAllByEmailOrPhone - Get the employees with the email address or the phone number
+func (repository *EmployeeRepositoryImpl) FindAllByEmailOrPhone(ctx context.Context, email string, phone string) ([]domain.Employee, error) {
+	var employees []domain.Employee
+	err := dbFromContext(ctx, repository.db).Where("email = ? OR phone = ?", email, phone).Find(&employees).Error
+	return employees, err
+}
+
+// FindInBatches - Walk all employees batchSize rows at a time
+func (repository *EmployeeRepositoryImpl) FindInBatches(ctx context.Context, batchSize int, fn func(employees []domain.Employee) error) error {
+	var employees []domain.Employee
+	return dbFromContext(ctx, repository.db).FindInBatches(&employees, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(employees)
+	}).Error
 }
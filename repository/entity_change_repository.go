@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type EntityChangeRepository interface {
+	FindAfter(ctx context.Context, seq uint64, limit int) ([]domain.EntityChange, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type EntityChangeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEntityChangeRepository(db *gorm.DB) EntityChangeRepository {
+	return &EntityChangeRepositoryImpl{db: db}
+}
+
+// FindAfter - Get the changes after a sequence number, in order
+func (repository *EntityChangeRepositoryImpl) FindAfter(ctx context.Context, seq uint64, limit int) ([]domain.EntityChange, error) {
+	var changes []domain.EntityChange
+	return changes, dbFromContext(ctx, repository.db).Where("seq > ?", seq).Order("seq").Limit(limit).Find(&changes).Error
+}
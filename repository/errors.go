@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+)
+
+// ErrVersionConflict is returned by Update and Delete when the row no longer has the
+// version the caller read, i.e. somebody else changed it in the meantime.
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrInsufficientStock is returned by AdjustStock when taking the quantity would leave
+// the stock below zero.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// ErrOrderExists is returned by OrderRepository.Save when an order with the same id has
+// been saved already, e.g. by a concurrent upload of the same offline order.
+var ErrOrderExists = errors.New("order already exists")
+
+// ErrStoreRequired is returned when a store-scoped model is read or written without a
+// store on the context.
+var ErrStoreRequired = exception.NewBadRequestError("a store is required, send the X-Store-Id header")
+
+// ErrStoreMismatch is returned when a store-scoped row is created for another store than
+// the one the request is made for.
+var ErrStoreMismatch = exception.NewForbiddenError("the record belongs to another store")
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type IdempotencyKeyRepository interface {
+	// Reserve stores the key if it is new, its previous use has expired or the lease of the
+	// request holding it has run out, and reports true. Otherwise it returns the record
+	// already stored under that key and false.
+	Reserve(ctx context.Context, idempotencyKey domain.IdempotencyKey) (domain.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, idempotencyKey domain.IdempotencyKey) error
+	Delete(ctx context.Context, key string) error
+	FindByKey(ctx context.Context, key string) (domain.IdempotencyKey, error)
+}
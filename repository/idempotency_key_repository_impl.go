@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) IdempotencyKeyRepository {
+	return &IdempotencyKeyRepositoryImpl{db: db}
+}
+
+func (repository *IdempotencyKeyRepositoryImpl) Reserve(ctx context.Context, idempotencyKey domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	var existing domain.IdempotencyKey
+	reserved := false
+
+	err := dbFromContext(ctx, repository.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ? AND expires_at < ?", idempotencyKey.Key, idempotencyKey.CreatedAt).
+			Delete(&domain.IdempotencyKey{}).Error
+		if err != nil {
+			return err
+		}
+
+		// The primary key makes the insert the lock: only one request can create the row.
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&idempotencyKey)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			reserved = true
+			return nil
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing, "id = ?", idempotencyKey.Key).Error
+		if err != nil || !existing.LeaseExpired(idempotencyKey.CreatedAt) {
+			return err
+		}
+
+		// The request holding the key never finished: take the key over
+		reserved = true
+		return tx.Model(&existing).Updates(map[string]interface{}{
+			"fingerprint":  idempotencyKey.Fingerprint,
+			"created_at":   idempotencyKey.CreatedAt,
+			"expires_at":   idempotencyKey.ExpiresAt,
+			"locked_until": idempotencyKey.LockedUntil,
+		}).Error
+	})
+	if err != nil {
+		return domain.IdempotencyKey{}, false, err
+	}
+	if reserved {
+		return idempotencyKey, true, nil
+	}
+	return existing, false, nil
+}
+
+func (repository *IdempotencyKeyRepositoryImpl) Complete(ctx context.Context, idempotencyKey domain.IdempotencyKey) error {
+	idempotencyKey.Completed = true
+	return dbFromContext(ctx, repository.db).
+		Model(&domain.IdempotencyKey{}).
+		Where("id = ?", idempotencyKey.Key).
+		Updates(map[string]interface{}{
+			"completed":     true,
+			"status_code":   idempotencyKey.StatusCode,
+			"content_type":  idempotencyKey.ContentType,
+			"response_body": idempotencyKey.ResponseBody,
+		}).Error
+}
+
+func (repository *IdempotencyKeyRepositoryImpl) Delete(ctx context.Context, key string) error {
+	return dbFromContext(ctx, repository.db).Where("id = ?", key).Delete(&domain.IdempotencyKey{}).Error
+}
+
+func (repository *IdempotencyKeyRepositoryImpl) FindByKey(ctx context.Context, key string) (domain.IdempotencyKey, error) {
+	var idempotencyKey domain.IdempotencyKey
+	err := dbFromContext(ctx, repository.db).First(&idempotencyKey, "id = ?", key).Error
+	return idempotencyKey, err
+}
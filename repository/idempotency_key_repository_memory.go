@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"sync"
+)
+
+// IdempotencyKeyRepositoryMemory keeps idempotency keys in process memory. It is meant
+// for single-instance deployments and tests; use the GORM repository otherwise.
+type IdempotencyKeyRepositoryMemory struct {
+	mutex sync.Mutex
+	keys  map[string]domain.IdempotencyKey
+}
+
+func NewIdempotencyKeyRepositoryMemory() IdempotencyKeyRepository {
+	return &IdempotencyKeyRepositoryMemory{keys: map[string]domain.IdempotencyKey{}}
+}
+
+func (repository *IdempotencyKeyRepositoryMemory) Reserve(ctx context.Context, idempotencyKey domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	for key, stored := range repository.keys {
+		if stored.ExpiresAt.Before(idempotencyKey.CreatedAt) {
+			delete(repository.keys, key)
+		}
+	}
+
+	if existing, ok := repository.keys[idempotencyKey.Key]; ok && !existing.LeaseExpired(idempotencyKey.CreatedAt) {
+		return existing, false, nil
+	}
+	repository.keys[idempotencyKey.Key] = idempotencyKey
+	return idempotencyKey, true, nil
+}
+
+func (repository *IdempotencyKeyRepositoryMemory) Complete(ctx context.Context, idempotencyKey domain.IdempotencyKey) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	idempotencyKey.Completed = true
+	repository.keys[idempotencyKey.Key] = idempotencyKey
+	return nil
+}
+
+func (repository *IdempotencyKeyRepositoryMemory) Delete(ctx context.Context, key string) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	delete(repository.keys, key)
+	return nil
+}
+
+func (repository *IdempotencyKeyRepositoryMemory) FindByKey(ctx context.Context, key string) (domain.IdempotencyKey, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	idempotencyKey, ok := repository.keys[key]
+	if !ok {
+		return domain.IdempotencyKey{}, gorm.ErrRecordNotFound
+	}
+	return idempotencyKey, nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"time"
+)
+
+// InventoryRepository keeps the inventory ledger, the cost layers of the stock on hand and
+// the restock settings of the store of the request.
+type InventoryRepository interface {
+	SaveMovement(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error)
+	FindMovements(ctx context.Context, productId string, limit int) ([]domain.InventoryMovement, error)
+	FindCostLayers(ctx context.Context, productId string, variantId uint64) ([]domain.CostLayer, error)
+	SaveCostLayer(ctx context.Context, layer domain.CostLayer) (domain.CostLayer, error)
+	DeleteCostLayer(ctx context.Context, layer domain.CostLayer) error
+	SaveInventory(ctx context.Context, inventory domain.Inventory) (domain.Inventory, error)
+	MarkRestocked(ctx context.Context, productId string, variantId uint64, at time.Time) error
+	FindInventories(ctx context.Context, lowOnly bool) ([]domain.Inventory, error)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type InventoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewInventoryRepository(db *gorm.DB) InventoryRepository {
+	return &InventoryRepositoryImpl{db: db}
+}
+
+// SaveMovement - Append a movement to the inventory ledger
+func (repository *InventoryRepositoryImpl) SaveMovement(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+	if err := dbFromContext(ctx, repository.db).Create(&movement).Error; err != nil {
+		return domain.InventoryMovement{}, err
+	}
+	return movement, nil
+}
+
+// FindMovements - Get the newest movements of the ledger, optionally only those of a product
+func (repository *InventoryRepositoryImpl) FindMovements(ctx context.Context, productId string, limit int) ([]domain.InventoryMovement, error) {
+	query := dbFromContext(ctx, repository.db).Order("created_at DESC, id DESC").Limit(limit)
+	if productId != "" {
+		query = query.Where("product_id = ?", productId)
+	}
+	var movements []domain.InventoryMovement
+	return movements, query.Find(&movements).Error
+}
+
+// FindCostLayers - Get the cost layers of a product or variant, oldest first, locking them
+// for the rest of the transaction
+func (repository *InventoryRepositoryImpl) FindCostLayers(ctx context.Context, productId string, variantId uint64) ([]domain.CostLayer, error) {
+	var layers []domain.CostLayer
+	return layers, dbFromContext(ctx, repository.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND variant_id = ?", productId, variantId).
+		Order("received_at, id").
+		Find(&layers).Error
+}
+
+// SaveCostLayer - Create a cost layer, or update the quantity and cost of an existing one
+func (repository *InventoryRepositoryImpl) SaveCostLayer(ctx context.Context, layer domain.CostLayer) (domain.CostLayer, error) {
+	if err := dbFromContext(ctx, repository.db).Save(&layer).Error; err != nil {
+		return domain.CostLayer{}, err
+	}
+	return layer, nil
+}
+
+// DeleteCostLayer - Delete a cost layer that has been sold out
+func (repository *InventoryRepositoryImpl) DeleteCostLayer(ctx context.Context, layer domain.CostLayer) error {
+	return dbFromContext(ctx, repository.db).Delete(&layer).Error
+}
+
+// SaveInventory - Set the restock level and reorder quantity of a product or variant
+func (repository *InventoryRepositoryImpl) SaveInventory(ctx context.Context, inventory domain.Inventory) (domain.Inventory, error) {
+	err := dbFromContext(ctx, repository.db).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"restock_level", "reorder_quantity"}),
+	}).Create(&inventory).Error
+	return inventory, err
+}
+
+// MarkRestocked - Record when a product or variant was last restocked
+func (repository *InventoryRepositoryImpl) MarkRestocked(ctx context.Context, productId string, variantId uint64, at time.Time) error {
+	inventory := domain.Inventory{ProductID: productId, VariantID: variantId, LastRestock: &at}
+	return dbFromContext(ctx, repository.db).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"last_restock"}),
+	}).Create(&inventory).Error
+}
+
+// FindInventories - Get the restock settings with the stock on hand, optionally only of the
+// products and variants at or below their restock level
+func (repository *InventoryRepositoryImpl) FindInventories(ctx context.Context, lowOnly bool) ([]domain.Inventory, error) {
+	query := dbFromContext(ctx, repository.db).
+		Model(&domain.Inventory{}).
+		Select("inventories.*, COALESCE(store_stocks.quantity, 0) AS stock_qty").
+		Joins("LEFT JOIN store_stocks ON store_stocks.store_id = inventories.store_id AND store_stocks.product_id = inventories.product_id AND store_stocks.variant_id = inventories.variant_id").
+		Order("inventories.product_id, inventories.variant_id")
+	if lowOnly {
+		query = query.Where("inventories.restock_level > 0 AND COALESCE(store_stocks.quantity, 0) <= inventories.restock_level")
+	}
+	var inventories []domain.Inventory
+	return inventories, query.Find(&inventories).Error
+}
@@ -41,6 +41,36 @@ func (m *MockCategoryRepository) EXPECT() *MockCategoryRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountChildren mocks base method.
+func (m *MockCategoryRepository) CountChildren(ctx context.Context, categoryId uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChildren", ctx, categoryId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChildren indicates an expected call of CountChildren.
+func (mr *MockCategoryRepositoryMockRecorder) CountChildren(ctx, categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildren", reflect.TypeOf((*MockCategoryRepository)(nil).CountChildren), ctx, categoryId)
+}
+
+// CountProducts mocks base method.
+func (m *MockCategoryRepository) CountProducts(ctx context.Context) (map[uint64]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProducts", ctx)
+	ret0, _ := ret[0].(map[uint64]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProducts indicates an expected call of CountProducts.
+func (mr *MockCategoryRepositoryMockRecorder) CountProducts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProducts", reflect.TypeOf((*MockCategoryRepository)(nil).CountProducts), ctx)
+}
+
 // Delete mocks base method.
 func (m *MockCategoryRepository) Delete(ctx context.Context, category domain.Category) error {
 	m.ctrl.T.Helper()
@@ -70,6 +100,36 @@ func (mr *MockCategoryRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryRepository)(nil).FindAll), ctx)
 }
 
+// FindAllById mocks base method.
+func (m *MockCategoryRepository) FindAllById(ctx context.Context, categoryIds []uint64) ([]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllById", ctx, categoryIds)
+	ret0, _ := ret[0].([]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllById indicates an expected call of FindAllById.
+func (mr *MockCategoryRepositoryMockRecorder) FindAllById(ctx, categoryIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllById", reflect.TypeOf((*MockCategoryRepository)(nil).FindAllById), ctx, categoryIds)
+}
+
+// FindAllForUpdate mocks base method.
+func (m *MockCategoryRepository) FindAllForUpdate(ctx context.Context) ([]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllForUpdate", ctx)
+	ret0, _ := ret[0].([]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllForUpdate indicates an expected call of FindAllForUpdate.
+func (mr *MockCategoryRepositoryMockRecorder) FindAllForUpdate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllForUpdate", reflect.TypeOf((*MockCategoryRepository)(nil).FindAllForUpdate), ctx)
+}
+
 // FindById mocks base method.
 func (m *MockCategoryRepository) FindById(ctx context.Context, categoryId uint64) (domain.Category, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +145,20 @@ func (mr *MockCategoryRepositoryMockRecorder) FindById(ctx, categoryId any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCategoryRepository)(nil).FindById), ctx, categoryId)
 }
 
+// FindInBatches mocks base method.
+func (m *MockCategoryRepository) FindInBatches(ctx context.Context, batchSize int, fn func([]domain.Category) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBatches", ctx, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockCategoryRepositoryMockRecorder) FindInBatches(ctx, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockCategoryRepository)(nil).FindInBatches), ctx, batchSize, fn)
+}
+
 // Save mocks base method.
 func (m *MockCategoryRepository) Save(ctx context.Context, category domain.Category) (domain.Category, error) {
 	m.ctrl.T.Helper()
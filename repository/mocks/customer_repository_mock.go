@@ -13,6 +13,7 @@ import (
 	context "context"
 	"github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/aronipurwanto/go-restful-api/model/domain"
 )
@@ -70,6 +71,21 @@ func (mr *MockCustomerRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCustomerRepository)(nil).FindAll), ctx)
 }
 
+// FindAllByEmailOrPhone mocks base method.
+func (m *MockCustomerRepository) FindAllByEmailOrPhone(ctx context.Context, email, phone string) ([]domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByEmailOrPhone", ctx, email, phone)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByEmailOrPhone indicates an expected call of FindAllByEmailOrPhone.
+func (mr *MockCustomerRepositoryMockRecorder) FindAllByEmailOrPhone(ctx, email, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByEmailOrPhone", reflect.TypeOf((*MockCustomerRepository)(nil).FindAllByEmailOrPhone), ctx, email, phone)
+}
+
 // FindById mocks base method.
 func (m *MockCustomerRepository) FindById(ctx context.Context, customerId string) (domain.Customer, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +101,35 @@ func (mr *MockCustomerRepositoryMockRecorder) FindById(ctx, customerId any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCustomerRepository)(nil).FindById), ctx, customerId)
 }
 
+// FindInBatches mocks base method.
+func (m *MockCustomerRepository) FindInBatches(ctx context.Context, batchSize int, fn func([]domain.Customer) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBatches", ctx, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockCustomerRepositoryMockRecorder) FindInBatches(ctx, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockCustomerRepository)(nil).FindInBatches), ctx, batchSize, fn)
+}
+
+// FindInactive mocks base method.
+func (m *MockCustomerRepository) FindInactive(ctx context.Context, before time.Time, afterId uint64, limit int) ([]domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInactive", ctx, before, afterId, limit)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInactive indicates an expected call of FindInactive.
+func (mr *MockCustomerRepositoryMockRecorder) FindInactive(ctx, before, afterId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInactive", reflect.TypeOf((*MockCustomerRepository)(nil).FindInactive), ctx, before, afterId, limit)
+}
+
 // Save mocks base method.
 func (m *MockCustomerRepository) Save(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
 	m.ctrl.T.Helper()
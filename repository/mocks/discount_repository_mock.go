@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/discount_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/discount_repository.go -destination=repository/mocks/discount_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockDiscountRepository is a mock of DiscountRepository interface.
+type MockDiscountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiscountRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDiscountRepositoryMockRecorder is the mock recorder for MockDiscountRepository.
+type MockDiscountRepositoryMockRecorder struct {
+	mock *MockDiscountRepository
+}
+
+// NewMockDiscountRepository creates a new mock instance.
+func NewMockDiscountRepository(ctrl *gomock.Controller) *MockDiscountRepository {
+	mock := &MockDiscountRepository{ctrl: ctrl}
+	mock.recorder = &MockDiscountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiscountRepository) EXPECT() *MockDiscountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAllById mocks base method.
+func (m *MockDiscountRepository) FindAllById(ctx context.Context, discountIds []string) ([]domain.Discount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllById", ctx, discountIds)
+	ret0, _ := ret[0].([]domain.Discount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllById indicates an expected call of FindAllById.
+func (mr *MockDiscountRepositoryMockRecorder) FindAllById(ctx, discountIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllById", reflect.TypeOf((*MockDiscountRepository)(nil).FindAllById), ctx, discountIds)
+}
@@ -70,6 +70,36 @@ func (mr *MockEmployeeRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockEmployeeRepository)(nil).FindAll), ctx)
 }
 
+// FindAllByEmailOrPhone mocks base method.
+func (m *MockEmployeeRepository) FindAllByEmailOrPhone(ctx context.Context, email, phone string) ([]domain.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByEmailOrPhone", ctx, email, phone)
+	ret0, _ := ret[0].([]domain.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByEmailOrPhone indicates an expected call of FindAllByEmailOrPhone.
+func (mr *MockEmployeeRepositoryMockRecorder) FindAllByEmailOrPhone(ctx, email, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByEmailOrPhone", reflect.TypeOf((*MockEmployeeRepository)(nil).FindAllByEmailOrPhone), ctx, email, phone)
+}
+
+// FindByApiKeyHash mocks base method.
+func (m *MockEmployeeRepository) FindByApiKeyHash(ctx context.Context, apiKeyHash string) (domain.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByApiKeyHash", ctx, apiKeyHash)
+	ret0, _ := ret[0].(domain.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByApiKeyHash indicates an expected call of FindByApiKeyHash.
+func (mr *MockEmployeeRepositoryMockRecorder) FindByApiKeyHash(ctx, apiKeyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByApiKeyHash", reflect.TypeOf((*MockEmployeeRepository)(nil).FindByApiKeyHash), ctx, apiKeyHash)
+}
+
 // FindById mocks base method.
 func (m *MockEmployeeRepository) FindById(ctx context.Context, employeeId string) (domain.Employee, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +115,20 @@ func (mr *MockEmployeeRepositoryMockRecorder) FindById(ctx, employeeId any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockEmployeeRepository)(nil).FindById), ctx, employeeId)
 }
 
+// FindInBatches mocks base method.
+func (m *MockEmployeeRepository) FindInBatches(ctx context.Context, batchSize int, fn func([]domain.Employee) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBatches", ctx, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockEmployeeRepositoryMockRecorder) FindInBatches(ctx, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockEmployeeRepository)(nil).FindInBatches), ctx, batchSize, fn)
+}
+
 // Save mocks base method.
 func (m *MockEmployeeRepository) Save(ctx context.Context, employee domain.Employee) (domain.Employee, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/entity_change_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/entity_change_repository.go -destination=repository/mocks/entity_change_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockEntityChangeRepository is a mock of EntityChangeRepository interface.
+type MockEntityChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEntityChangeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEntityChangeRepositoryMockRecorder is the mock recorder for MockEntityChangeRepository.
+type MockEntityChangeRepositoryMockRecorder struct {
+	mock *MockEntityChangeRepository
+}
+
+// NewMockEntityChangeRepository creates a new mock instance.
+func NewMockEntityChangeRepository(ctrl *gomock.Controller) *MockEntityChangeRepository {
+	mock := &MockEntityChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockEntityChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEntityChangeRepository) EXPECT() *MockEntityChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAfter mocks base method.
+func (m *MockEntityChangeRepository) FindAfter(ctx context.Context, seq uint64, limit int) ([]domain.EntityChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAfter", ctx, seq, limit)
+	ret0, _ := ret[0].([]domain.EntityChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAfter indicates an expected call of FindAfter.
+func (mr *MockEntityChangeRepositoryMockRecorder) FindAfter(ctx, seq, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAfter", reflect.TypeOf((*MockEntityChangeRepository)(nil).FindAfter), ctx, seq, limit)
+}
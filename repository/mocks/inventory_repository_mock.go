@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/inventory_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/inventory_repository.go -destination=repository/mocks/inventory_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockInventoryRepository is a mock of InventoryRepository interface.
+type MockInventoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryRepositoryMockRecorder is the mock recorder for MockInventoryRepository.
+type MockInventoryRepositoryMockRecorder struct {
+	mock *MockInventoryRepository
+}
+
+// NewMockInventoryRepository creates a new mock instance.
+func NewMockInventoryRepository(ctrl *gomock.Controller) *MockInventoryRepository {
+	mock := &MockInventoryRepository{ctrl: ctrl}
+	mock.recorder = &MockInventoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryRepository) EXPECT() *MockInventoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteCostLayer mocks base method.
+func (m *MockInventoryRepository) DeleteCostLayer(ctx context.Context, layer domain.CostLayer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCostLayer", ctx, layer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCostLayer indicates an expected call of DeleteCostLayer.
+func (mr *MockInventoryRepositoryMockRecorder) DeleteCostLayer(ctx, layer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCostLayer", reflect.TypeOf((*MockInventoryRepository)(nil).DeleteCostLayer), ctx, layer)
+}
+
+// FindCostLayers mocks base method.
+func (m *MockInventoryRepository) FindCostLayers(ctx context.Context, productId string, variantId uint64) ([]domain.CostLayer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCostLayers", ctx, productId, variantId)
+	ret0, _ := ret[0].([]domain.CostLayer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCostLayers indicates an expected call of FindCostLayers.
+func (mr *MockInventoryRepositoryMockRecorder) FindCostLayers(ctx, productId, variantId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCostLayers", reflect.TypeOf((*MockInventoryRepository)(nil).FindCostLayers), ctx, productId, variantId)
+}
+
+// FindInventories mocks base method.
+func (m *MockInventoryRepository) FindInventories(ctx context.Context, lowOnly bool) ([]domain.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInventories", ctx, lowOnly)
+	ret0, _ := ret[0].([]domain.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInventories indicates an expected call of FindInventories.
+func (mr *MockInventoryRepositoryMockRecorder) FindInventories(ctx, lowOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInventories", reflect.TypeOf((*MockInventoryRepository)(nil).FindInventories), ctx, lowOnly)
+}
+
+// FindMovements mocks base method.
+func (m *MockInventoryRepository) FindMovements(ctx context.Context, productId string, limit int) ([]domain.InventoryMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMovements", ctx, productId, limit)
+	ret0, _ := ret[0].([]domain.InventoryMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMovements indicates an expected call of FindMovements.
+func (mr *MockInventoryRepositoryMockRecorder) FindMovements(ctx, productId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMovements", reflect.TypeOf((*MockInventoryRepository)(nil).FindMovements), ctx, productId, limit)
+}
+
+// MarkRestocked mocks base method.
+func (m *MockInventoryRepository) MarkRestocked(ctx context.Context, productId string, variantId uint64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRestocked", ctx, productId, variantId, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRestocked indicates an expected call of MarkRestocked.
+func (mr *MockInventoryRepositoryMockRecorder) MarkRestocked(ctx, productId, variantId, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRestocked", reflect.TypeOf((*MockInventoryRepository)(nil).MarkRestocked), ctx, productId, variantId, at)
+}
+
+// SaveCostLayer mocks base method.
+func (m *MockInventoryRepository) SaveCostLayer(ctx context.Context, layer domain.CostLayer) (domain.CostLayer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCostLayer", ctx, layer)
+	ret0, _ := ret[0].(domain.CostLayer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCostLayer indicates an expected call of SaveCostLayer.
+func (mr *MockInventoryRepositoryMockRecorder) SaveCostLayer(ctx, layer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCostLayer", reflect.TypeOf((*MockInventoryRepository)(nil).SaveCostLayer), ctx, layer)
+}
+
+// SaveInventory mocks base method.
+func (m *MockInventoryRepository) SaveInventory(ctx context.Context, inventory domain.Inventory) (domain.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInventory", ctx, inventory)
+	ret0, _ := ret[0].(domain.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveInventory indicates an expected call of SaveInventory.
+func (mr *MockInventoryRepositoryMockRecorder) SaveInventory(ctx, inventory any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInventory", reflect.TypeOf((*MockInventoryRepository)(nil).SaveInventory), ctx, inventory)
+}
+
+// SaveMovement mocks base method.
+func (m *MockInventoryRepository) SaveMovement(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMovement", ctx, movement)
+	ret0, _ := ret[0].(domain.InventoryMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveMovement indicates an expected call of SaveMovement.
+func (mr *MockInventoryRepositoryMockRecorder) SaveMovement(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMovement", reflect.TypeOf((*MockInventoryRepository)(nil).SaveMovement), ctx, movement)
+}
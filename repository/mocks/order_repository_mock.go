@@ -0,0 +1,222 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/order_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/order_repository.go -destination=repository/mocks/order_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockOrderRepository) FindAll(ctx context.Context) ([]domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockOrderRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderRepository)(nil).FindAll), ctx)
+}
+
+// FindAllByCustomer mocks base method.
+func (m *MockOrderRepository) FindAllByCustomer(ctx context.Context, customerId string) ([]domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByCustomer", ctx, customerId)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByCustomer indicates an expected call of FindAllByCustomer.
+func (mr *MockOrderRepositoryMockRecorder) FindAllByCustomer(ctx, customerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCustomer", reflect.TypeOf((*MockOrderRepository)(nil).FindAllByCustomer), ctx, customerId)
+}
+
+// FindAllByReconciliationStatus mocks base method.
+func (m *MockOrderRepository) FindAllByReconciliationStatus(ctx context.Context, status string) ([]domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByReconciliationStatus", ctx, status)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByReconciliationStatus indicates an expected call of FindAllByReconciliationStatus.
+func (mr *MockOrderRepositoryMockRecorder) FindAllByReconciliationStatus(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByReconciliationStatus", reflect.TypeOf((*MockOrderRepository)(nil).FindAllByReconciliationStatus), ctx, status)
+}
+
+// FindById mocks base method.
+func (m *MockOrderRepository) FindById(ctx context.Context, orderId string) (domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, orderId)
+	ret0, _ := ret[0].(domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockOrderRepositoryMockRecorder) FindById(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderRepository)(nil).FindById), ctx, orderId)
+}
+
+// FindByIdForUpdate mocks base method.
+func (m *MockOrderRepository) FindByIdForUpdate(ctx context.Context, orderId string) (domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdForUpdate", ctx, orderId)
+	ret0, _ := ret[0].(domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdForUpdate indicates an expected call of FindByIdForUpdate.
+func (mr *MockOrderRepositoryMockRecorder) FindByIdForUpdate(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdForUpdate", reflect.TypeOf((*MockOrderRepository)(nil).FindByIdForUpdate), ctx, orderId)
+}
+
+// FindInBatches mocks base method.
+func (m *MockOrderRepository) FindInBatches(ctx context.Context, batchSize int, fn func([]domain.Order) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBatches", ctx, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockOrderRepositoryMockRecorder) FindInBatches(ctx, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockOrderRepository)(nil).FindInBatches), ctx, batchSize, fn)
+}
+
+// FindPageByCustomer mocks base method.
+func (m *MockOrderRepository) FindPageByCustomer(ctx context.Context, customerId string, offset, limit int) ([]domain.Order, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPageByCustomer", ctx, customerId, offset, limit)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPageByCustomer indicates an expected call of FindPageByCustomer.
+func (mr *MockOrderRepositoryMockRecorder) FindPageByCustomer(ctx, customerId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPageByCustomer", reflect.TypeOf((*MockOrderRepository)(nil).FindPageByCustomer), ctx, customerId, offset, limit)
+}
+
+// ProductPurchasesByCustomer mocks base method.
+func (m *MockOrderRepository) ProductPurchasesByCustomer(ctx context.Context, customerId string, limit int) ([]domain.CustomerProductPurchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductPurchasesByCustomer", ctx, customerId, limit)
+	ret0, _ := ret[0].([]domain.CustomerProductPurchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductPurchasesByCustomer indicates an expected call of ProductPurchasesByCustomer.
+func (mr *MockOrderRepositoryMockRecorder) ProductPurchasesByCustomer(ctx, customerId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductPurchasesByCustomer", reflect.TypeOf((*MockOrderRepository)(nil).ProductPurchasesByCustomer), ctx, customerId, limit)
+}
+
+// ReassignCustomer mocks base method.
+func (m *MockOrderRepository) ReassignCustomer(ctx context.Context, fromCustomerId, toCustomerId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignCustomer", ctx, fromCustomerId, toCustomerId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignCustomer indicates an expected call of ReassignCustomer.
+func (mr *MockOrderRepositoryMockRecorder) ReassignCustomer(ctx, fromCustomerId, toCustomerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignCustomer", reflect.TypeOf((*MockOrderRepository)(nil).ReassignCustomer), ctx, fromCustomerId, toCustomerId)
+}
+
+// Save mocks base method.
+func (m *MockOrderRepository) Save(ctx context.Context, order domain.Order) (domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, order)
+	ret0, _ := ret[0].(domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOrderRepositoryMockRecorder) Save(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOrderRepository)(nil).Save), ctx, order)
+}
+
+// SummaryByCustomer mocks base method.
+func (m *MockOrderRepository) SummaryByCustomer(ctx context.Context, customerId string) (domain.CustomerOrderSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummaryByCustomer", ctx, customerId)
+	ret0, _ := ret[0].(domain.CustomerOrderSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummaryByCustomer indicates an expected call of SummaryByCustomer.
+func (mr *MockOrderRepositoryMockRecorder) SummaryByCustomer(ctx, customerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummaryByCustomer", reflect.TypeOf((*MockOrderRepository)(nil).SummaryByCustomer), ctx, customerId)
+}
+
+// Update mocks base method.
+func (m *MockOrderRepository) Update(ctx context.Context, order domain.Order) (domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, order)
+	ret0, _ := ret[0].(domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOrderRepositoryMockRecorder) Update(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderRepository)(nil).Update), ctx, order)
+}
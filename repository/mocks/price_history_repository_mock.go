@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/price_history_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/price_history_repository.go -destination=repository/mocks/price_history_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockPriceHistoryRepository is a mock of PriceHistoryRepository interface.
+type MockPriceHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceHistoryRepositoryMockRecorder is the mock recorder for MockPriceHistoryRepository.
+type MockPriceHistoryRepositoryMockRecorder struct {
+	mock *MockPriceHistoryRepository
+}
+
+// NewMockPriceHistoryRepository creates a new mock instance.
+func NewMockPriceHistoryRepository(ctrl *gomock.Controller) *MockPriceHistoryRepository {
+	mock := &MockPriceHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockPriceHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceHistoryRepository) EXPECT() *MockPriceHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAllByProductId mocks base method.
+func (m *MockPriceHistoryRepository) FindAllByProductId(ctx context.Context, productId string) ([]domain.PriceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByProductId", ctx, productId)
+	ret0, _ := ret[0].([]domain.PriceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByProductId indicates an expected call of FindAllByProductId.
+func (mr *MockPriceHistoryRepositoryMockRecorder) FindAllByProductId(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByProductId", reflect.TypeOf((*MockPriceHistoryRepository)(nil).FindAllByProductId), ctx, productId)
+}
+
+// Save mocks base method.
+func (m *MockPriceHistoryRepository) Save(ctx context.Context, history domain.PriceHistory) (domain.PriceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, history)
+	ret0, _ := ret[0].(domain.PriceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPriceHistoryRepositoryMockRecorder) Save(ctx, history any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPriceHistoryRepository)(nil).Save), ctx, history)
+}
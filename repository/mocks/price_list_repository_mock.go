@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/price_list_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/price_list_repository.go -destination=repository/mocks/price_list_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockPriceListRepository is a mock of PriceListRepository interface.
+type MockPriceListRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceListRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceListRepositoryMockRecorder is the mock recorder for MockPriceListRepository.
+type MockPriceListRepositoryMockRecorder struct {
+	mock *MockPriceListRepository
+}
+
+// NewMockPriceListRepository creates a new mock instance.
+func NewMockPriceListRepository(ctrl *gomock.Controller) *MockPriceListRepository {
+	mock := &MockPriceListRepository{ctrl: ctrl}
+	mock.recorder = &MockPriceListRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceListRepository) EXPECT() *MockPriceListRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPriceListRepository) Delete(ctx context.Context, priceList domain.PriceList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, priceList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPriceListRepositoryMockRecorder) Delete(ctx, priceList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPriceListRepository)(nil).Delete), ctx, priceList)
+}
+
+// DeleteItem mocks base method.
+func (m *MockPriceListRepository) DeleteItem(ctx context.Context, item domain.PriceListItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockPriceListRepositoryMockRecorder) DeleteItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockPriceListRepository)(nil).DeleteItem), ctx, item)
+}
+
+// FindAll mocks base method.
+func (m *MockPriceListRepository) FindAll(ctx context.Context) ([]domain.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]domain.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPriceListRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPriceListRepository)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockPriceListRepository) FindById(ctx context.Context, priceListId uint64) (domain.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, priceListId)
+	ret0, _ := ret[0].(domain.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPriceListRepositoryMockRecorder) FindById(ctx, priceListId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPriceListRepository)(nil).FindById), ctx, priceListId)
+}
+
+// FindItem mocks base method.
+func (m *MockPriceListRepository) FindItem(ctx context.Context, priceListId uint64, productId string) (domain.PriceListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindItem", ctx, priceListId, productId)
+	ret0, _ := ret[0].(domain.PriceListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindItem indicates an expected call of FindItem.
+func (mr *MockPriceListRepositoryMockRecorder) FindItem(ctx, priceListId, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindItem", reflect.TypeOf((*MockPriceListRepository)(nil).FindItem), ctx, priceListId, productId)
+}
+
+// FindItems mocks base method.
+func (m *MockPriceListRepository) FindItems(ctx context.Context, priceListId uint64) ([]domain.PriceListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindItems", ctx, priceListId)
+	ret0, _ := ret[0].([]domain.PriceListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindItems indicates an expected call of FindItems.
+func (mr *MockPriceListRepositoryMockRecorder) FindItems(ctx, priceListId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindItems", reflect.TypeOf((*MockPriceListRepository)(nil).FindItems), ctx, priceListId)
+}
+
+// Save mocks base method.
+func (m *MockPriceListRepository) Save(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, priceList)
+	ret0, _ := ret[0].(domain.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPriceListRepositoryMockRecorder) Save(ctx, priceList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPriceListRepository)(nil).Save), ctx, priceList)
+}
+
+// SaveItem mocks base method.
+func (m *MockPriceListRepository) SaveItem(ctx context.Context, item domain.PriceListItem) (domain.PriceListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveItem", ctx, item)
+	ret0, _ := ret[0].(domain.PriceListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveItem indicates an expected call of SaveItem.
+func (mr *MockPriceListRepositoryMockRecorder) SaveItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveItem", reflect.TypeOf((*MockPriceListRepository)(nil).SaveItem), ctx, item)
+}
+
+// Update mocks base method.
+func (m *MockPriceListRepository) Update(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, priceList)
+	ret0, _ := ret[0].(domain.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPriceListRepositoryMockRecorder) Update(ctx, priceList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPriceListRepository)(nil).Update), ctx, priceList)
+}
@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/price_schedule_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/price_schedule_repository.go -destination=repository/mocks/price_schedule_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockPriceScheduleRepository is a mock of PriceScheduleRepository interface.
+type MockPriceScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceScheduleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceScheduleRepositoryMockRecorder is the mock recorder for MockPriceScheduleRepository.
+type MockPriceScheduleRepositoryMockRecorder struct {
+	mock *MockPriceScheduleRepository
+}
+
+// NewMockPriceScheduleRepository creates a new mock instance.
+func NewMockPriceScheduleRepository(ctrl *gomock.Controller) *MockPriceScheduleRepository {
+	mock := &MockPriceScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockPriceScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceScheduleRepository) EXPECT() *MockPriceScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountOverlapping mocks base method.
+func (m *MockPriceScheduleRepository) CountOverlapping(ctx context.Context, schedule domain.PriceSchedule) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverlapping", ctx, schedule)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverlapping indicates an expected call of CountOverlapping.
+func (mr *MockPriceScheduleRepositoryMockRecorder) CountOverlapping(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverlapping", reflect.TypeOf((*MockPriceScheduleRepository)(nil).CountOverlapping), ctx, schedule)
+}
+
+// FindAll mocks base method.
+func (m *MockPriceScheduleRepository) FindAll(ctx context.Context, productId, status string) ([]domain.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, productId, status)
+	ret0, _ := ret[0].([]domain.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPriceScheduleRepositoryMockRecorder) FindAll(ctx, productId, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPriceScheduleRepository)(nil).FindAll), ctx, productId, status)
+}
+
+// FindById mocks base method.
+func (m *MockPriceScheduleRepository) FindById(ctx context.Context, scheduleId uint64) (domain.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, scheduleId)
+	ret0, _ := ret[0].(domain.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPriceScheduleRepositoryMockRecorder) FindById(ctx, scheduleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPriceScheduleRepository)(nil).FindById), ctx, scheduleId)
+}
+
+// FindDue mocks base method.
+func (m *MockPriceScheduleRepository) FindDue(ctx context.Context, now time.Time) ([]domain.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", ctx, now)
+	ret0, _ := ret[0].([]domain.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockPriceScheduleRepositoryMockRecorder) FindDue(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockPriceScheduleRepository)(nil).FindDue), ctx, now)
+}
+
+// FindEffective mocks base method.
+func (m *MockPriceScheduleRepository) FindEffective(ctx context.Context, priceListId *uint64, productId string, at time.Time) (domain.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEffective", ctx, priceListId, productId, at)
+	ret0, _ := ret[0].(domain.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEffective indicates an expected call of FindEffective.
+func (mr *MockPriceScheduleRepositoryMockRecorder) FindEffective(ctx, priceListId, productId, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEffective", reflect.TypeOf((*MockPriceScheduleRepository)(nil).FindEffective), ctx, priceListId, productId, at)
+}
+
+// Save mocks base method.
+func (m *MockPriceScheduleRepository) Save(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, schedule)
+	ret0, _ := ret[0].(domain.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPriceScheduleRepositoryMockRecorder) Save(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPriceScheduleRepository)(nil).Save), ctx, schedule)
+}
+
+// Update mocks base method.
+func (m *MockPriceScheduleRepository) Update(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, schedule)
+	ret0, _ := ret[0].(domain.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPriceScheduleRepositoryMockRecorder) Update(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPriceScheduleRepository)(nil).Update), ctx, schedule)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/product_barcode_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/product_barcode_repository.go -destination=repository/mocks/product_barcode_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockProductBarcodeRepository is a mock of ProductBarcodeRepository interface.
+type MockProductBarcodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductBarcodeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProductBarcodeRepositoryMockRecorder is the mock recorder for MockProductBarcodeRepository.
+type MockProductBarcodeRepositoryMockRecorder struct {
+	mock *MockProductBarcodeRepository
+}
+
+// NewMockProductBarcodeRepository creates a new mock instance.
+func NewMockProductBarcodeRepository(ctrl *gomock.Controller) *MockProductBarcodeRepository {
+	mock := &MockProductBarcodeRepository{ctrl: ctrl}
+	mock.recorder = &MockProductBarcodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductBarcodeRepository) EXPECT() *MockProductBarcodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProductBarcodeRepository) Delete(ctx context.Context, barcode domain.ProductBarcode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, barcode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductBarcodeRepositoryMockRecorder) Delete(ctx, barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductBarcodeRepository)(nil).Delete), ctx, barcode)
+}
+
+// FindAllByProductId mocks base method.
+func (m *MockProductBarcodeRepository) FindAllByProductId(ctx context.Context, productId string) ([]domain.ProductBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByProductId", ctx, productId)
+	ret0, _ := ret[0].([]domain.ProductBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByProductId indicates an expected call of FindAllByProductId.
+func (mr *MockProductBarcodeRepositoryMockRecorder) FindAllByProductId(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByProductId", reflect.TypeOf((*MockProductBarcodeRepository)(nil).FindAllByProductId), ctx, productId)
+}
+
+// FindByCode mocks base method.
+func (m *MockProductBarcodeRepository) FindByCode(ctx context.Context, code string) (domain.ProductBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCode", ctx, code)
+	ret0, _ := ret[0].(domain.ProductBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCode indicates an expected call of FindByCode.
+func (mr *MockProductBarcodeRepositoryMockRecorder) FindByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCode", reflect.TypeOf((*MockProductBarcodeRepository)(nil).FindByCode), ctx, code)
+}
+
+// FindLastInternal mocks base method.
+func (m *MockProductBarcodeRepository) FindLastInternal(ctx context.Context) (domain.ProductBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastInternal", ctx)
+	ret0, _ := ret[0].(domain.ProductBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastInternal indicates an expected call of FindLastInternal.
+func (mr *MockProductBarcodeRepositoryMockRecorder) FindLastInternal(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastInternal", reflect.TypeOf((*MockProductBarcodeRepository)(nil).FindLastInternal), ctx)
+}
+
+// FindProductIdsWithoutBarcode mocks base method.
+func (m *MockProductBarcodeRepository) FindProductIdsWithoutBarcode(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProductIdsWithoutBarcode", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProductIdsWithoutBarcode indicates an expected call of FindProductIdsWithoutBarcode.
+func (mr *MockProductBarcodeRepositoryMockRecorder) FindProductIdsWithoutBarcode(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductIdsWithoutBarcode", reflect.TypeOf((*MockProductBarcodeRepository)(nil).FindProductIdsWithoutBarcode), ctx)
+}
+
+// Save mocks base method.
+func (m *MockProductBarcodeRepository) Save(ctx context.Context, barcode domain.ProductBarcode) (domain.ProductBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, barcode)
+	ret0, _ := ret[0].(domain.ProductBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockProductBarcodeRepositoryMockRecorder) Save(ctx, barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProductBarcodeRepository)(nil).Save), ctx, barcode)
+}
@@ -41,6 +41,34 @@ func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AdjustStock mocks base method.
+func (m *MockProductRepository) AdjustStock(ctx context.Context, productId string, delta int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, productId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockProductRepositoryMockRecorder) AdjustStock(ctx, productId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockProductRepository)(nil).AdjustStock), ctx, productId, delta)
+}
+
+// AdjustStockAllowingNegative mocks base method.
+func (m *MockProductRepository) AdjustStockAllowingNegative(ctx context.Context, productId string, delta int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStockAllowingNegative", ctx, productId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustStockAllowingNegative indicates an expected call of AdjustStockAllowingNegative.
+func (mr *MockProductRepositoryMockRecorder) AdjustStockAllowingNegative(ctx, productId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStockAllowingNegative", reflect.TypeOf((*MockProductRepository)(nil).AdjustStockAllowingNegative), ctx, productId, delta)
+}
+
 // Delete mocks base method.
 func (m *MockProductRepository) Delete(ctx context.Context, product domain.Product) error {
 	m.ctrl.T.Helper()
@@ -70,6 +98,51 @@ func (mr *MockProductRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductRepository)(nil).FindAll), ctx)
 }
 
+// FindAllById mocks base method.
+func (m *MockProductRepository) FindAllById(ctx context.Context, productIds []string) ([]domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllById", ctx, productIds)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllById indicates an expected call of FindAllById.
+func (mr *MockProductRepositoryMockRecorder) FindAllById(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllById", reflect.TypeOf((*MockProductRepository)(nil).FindAllById), ctx, productIds)
+}
+
+// FindAllBySKU mocks base method.
+func (m *MockProductRepository) FindAllBySKU(ctx context.Context, skus []string) ([]domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllBySKU", ctx, skus)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllBySKU indicates an expected call of FindAllBySKU.
+func (mr *MockProductRepositoryMockRecorder) FindAllBySKU(ctx, skus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllBySKU", reflect.TypeOf((*MockProductRepository)(nil).FindAllBySKU), ctx, skus)
+}
+
+// FindAllInCategoryTree mocks base method.
+func (m *MockProductRepository) FindAllInCategoryTree(ctx context.Context, categoryId uint64) ([]domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllInCategoryTree", ctx, categoryId)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllInCategoryTree indicates an expected call of FindAllInCategoryTree.
+func (mr *MockProductRepositoryMockRecorder) FindAllInCategoryTree(ctx, categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllInCategoryTree", reflect.TypeOf((*MockProductRepository)(nil).FindAllInCategoryTree), ctx, categoryId)
+}
+
 // FindById mocks base method.
 func (m *MockProductRepository) FindById(ctx context.Context, productId string) (domain.Product, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +158,20 @@ func (mr *MockProductRepositoryMockRecorder) FindById(ctx, productId any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockProductRepository)(nil).FindById), ctx, productId)
 }
 
+// FindInBatches mocks base method.
+func (m *MockProductRepository) FindInBatches(ctx context.Context, batchSize int, fn func([]domain.Product) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBatches", ctx, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockProductRepositoryMockRecorder) FindInBatches(ctx, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockProductRepository)(nil).FindInBatches), ctx, batchSize, fn)
+}
+
 // Save mocks base method.
 func (m *MockProductRepository) Save(ctx context.Context, product domain.Product) (domain.Product, error) {
 	m.ctrl.T.Helper()
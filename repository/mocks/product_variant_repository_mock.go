@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/product_variant_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/product_variant_repository.go -destination=repository/mocks/product_variant_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockProductVariantRepository is a mock of ProductVariantRepository interface.
+type MockProductVariantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductVariantRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProductVariantRepositoryMockRecorder is the mock recorder for MockProductVariantRepository.
+type MockProductVariantRepositoryMockRecorder struct {
+	mock *MockProductVariantRepository
+}
+
+// NewMockProductVariantRepository creates a new mock instance.
+func NewMockProductVariantRepository(ctrl *gomock.Controller) *MockProductVariantRepository {
+	mock := &MockProductVariantRepository{ctrl: ctrl}
+	mock.recorder = &MockProductVariantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductVariantRepository) EXPECT() *MockProductVariantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockProductVariantRepository) AdjustStock(ctx context.Context, variantId uint64, delta int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, variantId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockProductVariantRepositoryMockRecorder) AdjustStock(ctx, variantId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockProductVariantRepository)(nil).AdjustStock), ctx, variantId, delta)
+}
+
+// AdjustStockAllowingNegative mocks base method.
+func (m *MockProductVariantRepository) AdjustStockAllowingNegative(ctx context.Context, variantId uint64, delta int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStockAllowingNegative", ctx, variantId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustStockAllowingNegative indicates an expected call of AdjustStockAllowingNegative.
+func (mr *MockProductVariantRepositoryMockRecorder) AdjustStockAllowingNegative(ctx, variantId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStockAllowingNegative", reflect.TypeOf((*MockProductVariantRepository)(nil).AdjustStockAllowingNegative), ctx, variantId, delta)
+}
+
+// Delete mocks base method.
+func (m *MockProductVariantRepository) Delete(ctx context.Context, variant domain.ProductVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, variant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductVariantRepositoryMockRecorder) Delete(ctx, variant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductVariantRepository)(nil).Delete), ctx, variant)
+}
+
+// FindAllByProductId mocks base method.
+func (m *MockProductVariantRepository) FindAllByProductId(ctx context.Context, productId string) ([]domain.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByProductId", ctx, productId)
+	ret0, _ := ret[0].([]domain.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByProductId indicates an expected call of FindAllByProductId.
+func (mr *MockProductVariantRepositoryMockRecorder) FindAllByProductId(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByProductId", reflect.TypeOf((*MockProductVariantRepository)(nil).FindAllByProductId), ctx, productId)
+}
+
+// FindAllBySKU mocks base method.
+func (m *MockProductVariantRepository) FindAllBySKU(ctx context.Context, skus []string) ([]domain.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllBySKU", ctx, skus)
+	ret0, _ := ret[0].([]domain.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllBySKU indicates an expected call of FindAllBySKU.
+func (mr *MockProductVariantRepositoryMockRecorder) FindAllBySKU(ctx, skus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllBySKU", reflect.TypeOf((*MockProductVariantRepository)(nil).FindAllBySKU), ctx, skus)
+}
+
+// FindById mocks base method.
+func (m *MockProductVariantRepository) FindById(ctx context.Context, variantId uint64) (domain.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, variantId)
+	ret0, _ := ret[0].(domain.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockProductVariantRepositoryMockRecorder) FindById(ctx, variantId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockProductVariantRepository)(nil).FindById), ctx, variantId)
+}
+
+// FindOptions mocks base method.
+func (m *MockProductVariantRepository) FindOptions(ctx context.Context, productId string) ([]domain.ProductOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOptions", ctx, productId)
+	ret0, _ := ret[0].([]domain.ProductOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOptions indicates an expected call of FindOptions.
+func (mr *MockProductVariantRepositoryMockRecorder) FindOptions(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOptions", reflect.TypeOf((*MockProductVariantRepository)(nil).FindOptions), ctx, productId)
+}
+
+// ReplaceOptions mocks base method.
+func (m *MockProductVariantRepository) ReplaceOptions(ctx context.Context, productId string, options []domain.ProductOption) ([]domain.ProductOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceOptions", ctx, productId, options)
+	ret0, _ := ret[0].([]domain.ProductOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceOptions indicates an expected call of ReplaceOptions.
+func (mr *MockProductVariantRepositoryMockRecorder) ReplaceOptions(ctx, productId, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOptions", reflect.TypeOf((*MockProductVariantRepository)(nil).ReplaceOptions), ctx, productId, options)
+}
+
+// SaveAll mocks base method.
+func (m *MockProductVariantRepository) SaveAll(ctx context.Context, variants []domain.ProductVariant) ([]domain.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAll", ctx, variants)
+	ret0, _ := ret[0].([]domain.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveAll indicates an expected call of SaveAll.
+func (mr *MockProductVariantRepositoryMockRecorder) SaveAll(ctx, variants any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAll", reflect.TypeOf((*MockProductVariantRepository)(nil).SaveAll), ctx, variants)
+}
+
+// Update mocks base method.
+func (m *MockProductVariantRepository) Update(ctx context.Context, variant domain.ProductVariant) (domain.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, variant)
+	ret0, _ := ret[0].(domain.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProductVariantRepositoryMockRecorder) Update(ctx, variant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductVariantRepository)(nil).Update), ctx, variant)
+}
@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/purchase_order_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/purchase_order_repository.go -destination=repository/mocks/purchase_order_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockPurchaseOrderRepository is a mock of PurchaseOrderRepository interface.
+type MockPurchaseOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseOrderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPurchaseOrderRepositoryMockRecorder is the mock recorder for MockPurchaseOrderRepository.
+type MockPurchaseOrderRepositoryMockRecorder struct {
+	mock *MockPurchaseOrderRepository
+}
+
+// NewMockPurchaseOrderRepository creates a new mock instance.
+func NewMockPurchaseOrderRepository(ctrl *gomock.Controller) *MockPurchaseOrderRepository {
+	mock := &MockPurchaseOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockPurchaseOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseOrderRepository) EXPECT() *MockPurchaseOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPurchaseOrderRepository) Delete(ctx context.Context, order domain.PurchaseOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) Delete(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).Delete), ctx, order)
+}
+
+// FindAll mocks base method.
+func (m *MockPurchaseOrderRepository) FindAll(ctx context.Context, status string, supplierId uint64) ([]domain.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, status, supplierId)
+	ret0, _ := ret[0].([]domain.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) FindAll(ctx, status, supplierId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).FindAll), ctx, status, supplierId)
+}
+
+// FindById mocks base method.
+func (m *MockPurchaseOrderRepository) FindById(ctx context.Context, orderId uint64) (domain.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, orderId)
+	ret0, _ := ret[0].(domain.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) FindById(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).FindById), ctx, orderId)
+}
+
+// FindOutstanding mocks base method.
+func (m *MockPurchaseOrderRepository) FindOutstanding(ctx context.Context) ([]domain.OutstandingQuantity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOutstanding", ctx)
+	ret0, _ := ret[0].([]domain.OutstandingQuantity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOutstanding indicates an expected call of FindOutstanding.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) FindOutstanding(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOutstanding", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).FindOutstanding), ctx)
+}
+
+// ReplaceLines mocks base method.
+func (m *MockPurchaseOrderRepository) ReplaceLines(ctx context.Context, orderId uint64, lines []domain.PurchaseOrderLine) ([]domain.PurchaseOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceLines", ctx, orderId, lines)
+	ret0, _ := ret[0].([]domain.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceLines indicates an expected call of ReplaceLines.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) ReplaceLines(ctx, orderId, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceLines", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).ReplaceLines), ctx, orderId, lines)
+}
+
+// Save mocks base method.
+func (m *MockPurchaseOrderRepository) Save(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, order)
+	ret0, _ := ret[0].(domain.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) Save(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).Save), ctx, order)
+}
+
+// Update mocks base method.
+func (m *MockPurchaseOrderRepository) Update(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, order)
+	ret0, _ := ret[0].(domain.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) Update(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).Update), ctx, order)
+}
+
+// UpdateLine mocks base method.
+func (m *MockPurchaseOrderRepository) UpdateLine(ctx context.Context, line domain.PurchaseOrderLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLine", ctx, line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLine indicates an expected call of UpdateLine.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) UpdateLine(ctx, line any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLine", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).UpdateLine), ctx, line)
+}
@@ -0,0 +1,207 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/report_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/report_repository.go -destination=repository/mocks/report_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CategoryMargins mocks base method.
+func (m *MockReportRepository) CategoryMargins(ctx context.Context, from, to time.Time) ([]domain.MarginTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CategoryMargins", ctx, from, to)
+	ret0, _ := ret[0].([]domain.MarginTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CategoryMargins indicates an expected call of CategoryMargins.
+func (mr *MockReportRepositoryMockRecorder) CategoryMargins(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryMargins", reflect.TypeOf((*MockReportRepository)(nil).CategoryMargins), ctx, from, to)
+}
+
+// CategorySales mocks base method.
+func (m *MockReportRepository) CategorySales(ctx context.Context, from, to time.Time) ([]domain.CategorySales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CategorySales", ctx, from, to)
+	ret0, _ := ret[0].([]domain.CategorySales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CategorySales indicates an expected call of CategorySales.
+func (mr *MockReportRepositoryMockRecorder) CategorySales(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategorySales", reflect.TypeOf((*MockReportRepository)(nil).CategorySales), ctx, from, to)
+}
+
+// DiscountTotals mocks base method.
+func (m *MockReportRepository) DiscountTotals(ctx context.Context, from, to time.Time) ([]domain.ProductSales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscountTotals", ctx, from, to)
+	ret0, _ := ret[0].([]domain.ProductSales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscountTotals indicates an expected call of DiscountTotals.
+func (mr *MockReportRepositoryMockRecorder) DiscountTotals(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscountTotals", reflect.TypeOf((*MockReportRepository)(nil).DiscountTotals), ctx, from, to)
+}
+
+// InventoryValuation mocks base method.
+func (m *MockReportRepository) InventoryValuation(ctx context.Context, at time.Time) ([]domain.InventoryValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InventoryValuation", ctx, at)
+	ret0, _ := ret[0].([]domain.InventoryValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InventoryValuation indicates an expected call of InventoryValuation.
+func (mr *MockReportRepositoryMockRecorder) InventoryValuation(ctx, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InventoryValuation", reflect.TypeOf((*MockReportRepository)(nil).InventoryValuation), ctx, at)
+}
+
+// PaymentTypeTotals mocks base method.
+func (m *MockReportRepository) PaymentTypeTotals(ctx context.Context, from, to time.Time) ([]domain.PaymentTypeTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaymentTypeTotals", ctx, from, to)
+	ret0, _ := ret[0].([]domain.PaymentTypeTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaymentTypeTotals indicates an expected call of PaymentTypeTotals.
+func (mr *MockReportRepositoryMockRecorder) PaymentTypeTotals(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentTypeTotals", reflect.TypeOf((*MockReportRepository)(nil).PaymentTypeTotals), ctx, from, to)
+}
+
+// ProductMargins mocks base method.
+func (m *MockReportRepository) ProductMargins(ctx context.Context, from, to time.Time) ([]domain.MarginTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductMargins", ctx, from, to)
+	ret0, _ := ret[0].([]domain.MarginTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductMargins indicates an expected call of ProductMargins.
+func (mr *MockReportRepositoryMockRecorder) ProductMargins(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductMargins", reflect.TypeOf((*MockReportRepository)(nil).ProductMargins), ctx, from, to)
+}
+
+// ProductSales mocks base method.
+func (m *MockReportRepository) ProductSales(ctx context.Context, from, to time.Time, orderBy string, limit int) ([]domain.ProductSales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductSales", ctx, from, to, orderBy, limit)
+	ret0, _ := ret[0].([]domain.ProductSales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductSales indicates an expected call of ProductSales.
+func (mr *MockReportRepositoryMockRecorder) ProductSales(ctx, from, to, orderBy, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductSales", reflect.TypeOf((*MockReportRepository)(nil).ProductSales), ctx, from, to, orderBy, limit)
+}
+
+// SalesByEmployee mocks base method.
+func (m *MockReportRepository) SalesByEmployee(ctx context.Context, from, to time.Time) ([]domain.SalesTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesByEmployee", ctx, from, to)
+	ret0, _ := ret[0].([]domain.SalesTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesByEmployee indicates an expected call of SalesByEmployee.
+func (mr *MockReportRepositoryMockRecorder) SalesByEmployee(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesByEmployee", reflect.TypeOf((*MockReportRepository)(nil).SalesByEmployee), ctx, from, to)
+}
+
+// SalesByStore mocks base method.
+func (m *MockReportRepository) SalesByStore(ctx context.Context, from, to time.Time) ([]domain.SalesTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesByStore", ctx, from, to)
+	ret0, _ := ret[0].([]domain.SalesTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesByStore indicates an expected call of SalesByStore.
+func (mr *MockReportRepositoryMockRecorder) SalesByStore(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesByStore", reflect.TypeOf((*MockReportRepository)(nil).SalesByStore), ctx, from, to)
+}
+
+// TaxTotals mocks base method.
+func (m *MockReportRepository) TaxTotals(ctx context.Context, from, to time.Time) ([]domain.TaxTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaxTotals", ctx, from, to)
+	ret0, _ := ret[0].([]domain.TaxTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TaxTotals indicates an expected call of TaxTotals.
+func (mr *MockReportRepositoryMockRecorder) TaxTotals(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaxTotals", reflect.TypeOf((*MockReportRepository)(nil).TaxTotals), ctx, from, to)
+}
+
+// WalkOrderTotals mocks base method.
+func (m *MockReportRepository) WalkOrderTotals(ctx context.Context, from, to time.Time, batchSize int, fn func([]domain.OrderTotal) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalkOrderTotals", ctx, from, to, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalkOrderTotals indicates an expected call of WalkOrderTotals.
+func (mr *MockReportRepositoryMockRecorder) WalkOrderTotals(ctx, from, to, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalkOrderTotals", reflect.TypeOf((*MockReportRepository)(nil).WalkOrderTotals), ctx, from, to, batchSize, fn)
+}
@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/shift_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/shift_repository.go -destination=repository/mocks/shift_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockShiftRepository is a mock of ShiftRepository interface.
+type MockShiftRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShiftRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockShiftRepositoryMockRecorder is the mock recorder for MockShiftRepository.
+type MockShiftRepositoryMockRecorder struct {
+	mock *MockShiftRepository
+}
+
+// NewMockShiftRepository creates a new mock instance.
+func NewMockShiftRepository(ctrl *gomock.Controller) *MockShiftRepository {
+	mock := &MockShiftRepository{ctrl: ctrl}
+	mock.recorder = &MockShiftRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShiftRepository) EXPECT() *MockShiftRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CashTotals mocks base method.
+func (m *MockShiftRepository) CashTotals(ctx context.Context, shiftId uint64) (domain.ShiftCashTotals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CashTotals", ctx, shiftId)
+	ret0, _ := ret[0].(domain.ShiftCashTotals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CashTotals indicates an expected call of CashTotals.
+func (mr *MockShiftRepositoryMockRecorder) CashTotals(ctx, shiftId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CashTotals", reflect.TypeOf((*MockShiftRepository)(nil).CashTotals), ctx, shiftId)
+}
+
+// FindAll mocks base method.
+func (m *MockShiftRepository) FindAll(ctx context.Context, employeeId, status string) ([]domain.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, employeeId, status)
+	ret0, _ := ret[0].([]domain.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockShiftRepositoryMockRecorder) FindAll(ctx, employeeId, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockShiftRepository)(nil).FindAll), ctx, employeeId, status)
+}
+
+// FindById mocks base method.
+func (m *MockShiftRepository) FindById(ctx context.Context, shiftId uint64) (domain.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, shiftId)
+	ret0, _ := ret[0].(domain.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockShiftRepositoryMockRecorder) FindById(ctx, shiftId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockShiftRepository)(nil).FindById), ctx, shiftId)
+}
+
+// FindCashMovements mocks base method.
+func (m *MockShiftRepository) FindCashMovements(ctx context.Context, shiftId uint64) ([]domain.CashMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCashMovements", ctx, shiftId)
+	ret0, _ := ret[0].([]domain.CashMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCashMovements indicates an expected call of FindCashMovements.
+func (mr *MockShiftRepositoryMockRecorder) FindCashMovements(ctx, shiftId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCashMovements", reflect.TypeOf((*MockShiftRepository)(nil).FindCashMovements), ctx, shiftId)
+}
+
+// FindOpenByEmployeeId mocks base method.
+func (m *MockShiftRepository) FindOpenByEmployeeId(ctx context.Context, employeeId string) (domain.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenByEmployeeId", ctx, employeeId)
+	ret0, _ := ret[0].(domain.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenByEmployeeId indicates an expected call of FindOpenByEmployeeId.
+func (mr *MockShiftRepositoryMockRecorder) FindOpenByEmployeeId(ctx, employeeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenByEmployeeId", reflect.TypeOf((*MockShiftRepository)(nil).FindOpenByEmployeeId), ctx, employeeId)
+}
+
+// Save mocks base method.
+func (m *MockShiftRepository) Save(ctx context.Context, shift domain.Shift) (domain.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, shift)
+	ret0, _ := ret[0].(domain.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockShiftRepositoryMockRecorder) Save(ctx, shift any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockShiftRepository)(nil).Save), ctx, shift)
+}
+
+// SaveCashMovement mocks base method.
+func (m *MockShiftRepository) SaveCashMovement(ctx context.Context, movement domain.CashMovement) (domain.CashMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCashMovement", ctx, movement)
+	ret0, _ := ret[0].(domain.CashMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCashMovement indicates an expected call of SaveCashMovement.
+func (mr *MockShiftRepositoryMockRecorder) SaveCashMovement(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCashMovement", reflect.TypeOf((*MockShiftRepository)(nil).SaveCashMovement), ctx, movement)
+}
+
+// Update mocks base method.
+func (m *MockShiftRepository) Update(ctx context.Context, shift domain.Shift) (domain.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, shift)
+	ret0, _ := ret[0].(domain.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockShiftRepositoryMockRecorder) Update(ctx, shift any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockShiftRepository)(nil).Update), ctx, shift)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/stock_transfer_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/stock_transfer_repository.go -destination=repository/mocks/stock_transfer_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockStockTransferRepository is a mock of StockTransferRepository interface.
+type MockStockTransferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockTransferRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStockTransferRepositoryMockRecorder is the mock recorder for MockStockTransferRepository.
+type MockStockTransferRepositoryMockRecorder struct {
+	mock *MockStockTransferRepository
+}
+
+// NewMockStockTransferRepository creates a new mock instance.
+func NewMockStockTransferRepository(ctrl *gomock.Controller) *MockStockTransferRepository {
+	mock := &MockStockTransferRepository{ctrl: ctrl}
+	mock.recorder = &MockStockTransferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockTransferRepository) EXPECT() *MockStockTransferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockStockTransferRepository) FindAll(ctx context.Context, storeId uint64, status string) ([]domain.StockTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, storeId, status)
+	ret0, _ := ret[0].([]domain.StockTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStockTransferRepositoryMockRecorder) FindAll(ctx, storeId, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStockTransferRepository)(nil).FindAll), ctx, storeId, status)
+}
+
+// FindById mocks base method.
+func (m *MockStockTransferRepository) FindById(ctx context.Context, transferId uint64) (domain.StockTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, transferId)
+	ret0, _ := ret[0].(domain.StockTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockStockTransferRepositoryMockRecorder) FindById(ctx, transferId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockStockTransferRepository)(nil).FindById), ctx, transferId)
+}
+
+// Save mocks base method.
+func (m *MockStockTransferRepository) Save(ctx context.Context, transfer domain.StockTransfer) (domain.StockTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, transfer)
+	ret0, _ := ret[0].(domain.StockTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockStockTransferRepositoryMockRecorder) Save(ctx, transfer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockStockTransferRepository)(nil).Save), ctx, transfer)
+}
+
+// Update mocks base method.
+func (m *MockStockTransferRepository) Update(ctx context.Context, transfer domain.StockTransfer) (domain.StockTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, transfer)
+	ret0, _ := ret[0].(domain.StockTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStockTransferRepositoryMockRecorder) Update(ctx, transfer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStockTransferRepository)(nil).Update), ctx, transfer)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/stocktake_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/stocktake_repository.go -destination=repository/mocks/stocktake_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockStocktakeRepository is a mock of StocktakeRepository interface.
+type MockStocktakeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStocktakeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStocktakeRepositoryMockRecorder is the mock recorder for MockStocktakeRepository.
+type MockStocktakeRepositoryMockRecorder struct {
+	mock *MockStocktakeRepository
+}
+
+// NewMockStocktakeRepository creates a new mock instance.
+func NewMockStocktakeRepository(ctrl *gomock.Controller) *MockStocktakeRepository {
+	mock := &MockStocktakeRepository{ctrl: ctrl}
+	mock.recorder = &MockStocktakeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStocktakeRepository) EXPECT() *MockStocktakeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockStocktakeRepository) FindAll(ctx context.Context, status string) ([]domain.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, status)
+	ret0, _ := ret[0].([]domain.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStocktakeRepositoryMockRecorder) FindAll(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStocktakeRepository)(nil).FindAll), ctx, status)
+}
+
+// FindById mocks base method.
+func (m *MockStocktakeRepository) FindById(ctx context.Context, stocktakeId uint64) (domain.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, stocktakeId)
+	ret0, _ := ret[0].(domain.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockStocktakeRepositoryMockRecorder) FindById(ctx, stocktakeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockStocktakeRepository)(nil).FindById), ctx, stocktakeId)
+}
+
+// Save mocks base method.
+func (m *MockStocktakeRepository) Save(ctx context.Context, stocktake domain.Stocktake) (domain.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, stocktake)
+	ret0, _ := ret[0].(domain.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockStocktakeRepositoryMockRecorder) Save(ctx, stocktake any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockStocktakeRepository)(nil).Save), ctx, stocktake)
+}
+
+// SaveLines mocks base method.
+func (m *MockStocktakeRepository) SaveLines(ctx context.Context, lines []domain.StocktakeLine) ([]domain.StocktakeLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLines", ctx, lines)
+	ret0, _ := ret[0].([]domain.StocktakeLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveLines indicates an expected call of SaveLines.
+func (mr *MockStocktakeRepositoryMockRecorder) SaveLines(ctx, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLines", reflect.TypeOf((*MockStocktakeRepository)(nil).SaveLines), ctx, lines)
+}
+
+// Update mocks base method.
+func (m *MockStocktakeRepository) Update(ctx context.Context, stocktake domain.Stocktake) (domain.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, stocktake)
+	ret0, _ := ret[0].(domain.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStocktakeRepositoryMockRecorder) Update(ctx, stocktake any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStocktakeRepository)(nil).Update), ctx, stocktake)
+}
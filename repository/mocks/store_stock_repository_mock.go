@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/store_stock_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/store_stock_repository.go -destination=repository/mocks/store_stock_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockStoreStockRepository is a mock of StoreStockRepository interface.
+type MockStoreStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreStockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreStockRepositoryMockRecorder is the mock recorder for MockStoreStockRepository.
+type MockStoreStockRepositoryMockRecorder struct {
+	mock *MockStoreStockRepository
+}
+
+// NewMockStoreStockRepository creates a new mock instance.
+func NewMockStoreStockRepository(ctrl *gomock.Controller) *MockStoreStockRepository {
+	mock := &MockStoreStockRepository{ctrl: ctrl}
+	mock.recorder = &MockStoreStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreStockRepository) EXPECT() *MockStoreStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Adjust mocks base method.
+func (m *MockStoreStockRepository) Adjust(ctx context.Context, productId string, variantId uint64, delta int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adjust", ctx, productId, variantId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Adjust indicates an expected call of Adjust.
+func (mr *MockStoreStockRepositoryMockRecorder) Adjust(ctx, productId, variantId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adjust", reflect.TypeOf((*MockStoreStockRepository)(nil).Adjust), ctx, productId, variantId, delta)
+}
+
+// AdjustAllowingNegative mocks base method.
+func (m *MockStoreStockRepository) AdjustAllowingNegative(ctx context.Context, productId string, variantId uint64, delta int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustAllowingNegative", ctx, productId, variantId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustAllowingNegative indicates an expected call of AdjustAllowingNegative.
+func (mr *MockStoreStockRepositoryMockRecorder) AdjustAllowingNegative(ctx, productId, variantId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustAllowingNegative", reflect.TypeOf((*MockStoreStockRepository)(nil).AdjustAllowingNegative), ctx, productId, variantId, delta)
+}
+
+// Find mocks base method.
+func (m *MockStoreStockRepository) Find(ctx context.Context, productId string, variantId uint64) (domain.StoreStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, productId, variantId)
+	ret0, _ := ret[0].(domain.StoreStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockStoreStockRepositoryMockRecorder) Find(ctx, productId, variantId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockStoreStockRepository)(nil).Find), ctx, productId, variantId)
+}
+
+// FindAll mocks base method.
+func (m *MockStoreStockRepository) FindAll(ctx context.Context, productId string) ([]domain.StoreStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, productId)
+	ret0, _ := ret[0].([]domain.StoreStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStoreStockRepositoryMockRecorder) FindAll(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStoreStockRepository)(nil).FindAll), ctx, productId)
+}
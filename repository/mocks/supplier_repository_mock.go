@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/supplier_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/supplier_repository.go -destination=repository/mocks/supplier_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockSupplierRepository is a mock of SupplierRepository interface.
+type MockSupplierRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupplierRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSupplierRepositoryMockRecorder is the mock recorder for MockSupplierRepository.
+type MockSupplierRepositoryMockRecorder struct {
+	mock *MockSupplierRepository
+}
+
+// NewMockSupplierRepository creates a new mock instance.
+func NewMockSupplierRepository(ctrl *gomock.Controller) *MockSupplierRepository {
+	mock := &MockSupplierRepository{ctrl: ctrl}
+	mock.recorder = &MockSupplierRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupplierRepository) EXPECT() *MockSupplierRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSupplierRepository) Delete(ctx context.Context, supplier domain.Supplier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, supplier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSupplierRepositoryMockRecorder) Delete(ctx, supplier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplierRepository)(nil).Delete), ctx, supplier)
+}
+
+// FindAll mocks base method.
+func (m *MockSupplierRepository) FindAll(ctx context.Context) ([]domain.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]domain.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSupplierRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSupplierRepository)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockSupplierRepository) FindById(ctx context.Context, supplierId uint64) (domain.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, supplierId)
+	ret0, _ := ret[0].(domain.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockSupplierRepositoryMockRecorder) FindById(ctx, supplierId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockSupplierRepository)(nil).FindById), ctx, supplierId)
+}
+
+// IsInUse mocks base method.
+func (m *MockSupplierRepository) IsInUse(ctx context.Context, supplierId uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInUse", ctx, supplierId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsInUse indicates an expected call of IsInUse.
+func (mr *MockSupplierRepositoryMockRecorder) IsInUse(ctx, supplierId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInUse", reflect.TypeOf((*MockSupplierRepository)(nil).IsInUse), ctx, supplierId)
+}
+
+// Save mocks base method.
+func (m *MockSupplierRepository) Save(ctx context.Context, supplier domain.Supplier) (domain.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, supplier)
+	ret0, _ := ret[0].(domain.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSupplierRepositoryMockRecorder) Save(ctx, supplier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSupplierRepository)(nil).Save), ctx, supplier)
+}
+
+// Update mocks base method.
+func (m *MockSupplierRepository) Update(ctx context.Context, supplier domain.Supplier) (domain.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, supplier)
+	ret0, _ := ret[0].(domain.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSupplierRepositoryMockRecorder) Update(ctx, supplier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplierRepository)(nil).Update), ctx, supplier)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/tax_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/tax_repository.go -destination=repository/mocks/tax_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	domain "github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+// MockTaxRepository is a mock of TaxRepository interface.
+type MockTaxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTaxRepositoryMockRecorder is the mock recorder for MockTaxRepository.
+type MockTaxRepositoryMockRecorder struct {
+	mock *MockTaxRepository
+}
+
+// NewMockTaxRepository creates a new mock instance.
+func NewMockTaxRepository(ctrl *gomock.Controller) *MockTaxRepository {
+	mock := &MockTaxRepository{ctrl: ctrl}
+	mock.recorder = &MockTaxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRepository) EXPECT() *MockTaxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAllById mocks base method.
+func (m *MockTaxRepository) FindAllById(ctx context.Context, taxIds []string) ([]domain.Tax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllById", ctx, taxIds)
+	ret0, _ := ret[0].([]domain.Tax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllById indicates an expected call of FindAllById.
+func (mr *MockTaxRepositoryMockRecorder) FindAllById(ctx, taxIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllById", reflect.TypeOf((*MockTaxRepository)(nil).FindAllById), ctx, taxIds)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type OrderRepository interface {
+	Save(ctx context.Context, order domain.Order) (domain.Order, error)
+	Update(ctx context.Context, order domain.Order) (domain.Order, error)
+	FindById(ctx context.Context, orderId string) (domain.Order, error)
+	FindByIdForUpdate(ctx context.Context, orderId string) (domain.Order, error)
+	FindAll(ctx context.Context) ([]domain.Order, error)
+	FindAllByReconciliationStatus(ctx context.Context, status string) ([]domain.Order, error)
+	FindAllByCustomer(ctx context.Context, customerId string) ([]domain.Order, error)
+	FindPageByCustomer(ctx context.Context, customerId string, offset int, limit int) ([]domain.Order, int64, error)
+	SummaryByCustomer(ctx context.Context, customerId string) (domain.CustomerOrderSummary, error)
+	ProductPurchasesByCustomer(ctx context.Context, customerId string, limit int) ([]domain.CustomerProductPurchase, error)
+	ReassignCustomer(ctx context.Context, fromCustomerId string, toCustomerId string) (int64, error)
+	FindInBatches(ctx context.Context, batchSize int, fn func(orders []domain.Order) error) error
+}
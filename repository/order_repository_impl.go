@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &OrderRepositoryImpl{db: db}
+}
+
+// Save order together with its items
+func (repository *OrderRepositoryImpl) Save(ctx context.Context, order domain.Order) (domain.Order, error) {
+	order.Version = 1
+	for i := range order.Payments {
+		order.Payments[i].Version = 1
+	}
+	err := dbFromContext(ctx, repository.db).Create(&order).Error
+	if isDuplicatePrimaryKey(err) {
+		return domain.Order{}, ErrOrderExists
+	} else if err != nil {
+		return domain.Order{}, err
+	}
+	return order, nil
+}
+
+// Update order, without its items and payments
+func (repository *OrderRepositoryImpl) Update(ctx context.Context, order domain.Order) (domain.Order, error) {
+	expectedVersion := order.Version
+	order.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&order).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(&order)
+	if result.Error != nil {
+		return domain.Order{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.Order{}, ErrVersionConflict
+	}
+	return order, nil
+}
+
+// FindById - Get order by ID with its items
+func (repository *OrderRepositoryImpl) FindById(ctx context.Context, orderId string) (domain.Order, error) {
+	var order domain.Order
+	err := dbFromContext(ctx, repository.db).Preload("OrderItems").Preload("Payments").Where("id = ?", orderId).Take(&order).Error
+	return order, err
+}
+
+// FindByIdForUpdate - Get order by ID with its items and payments, locking the order for
+// the rest of the transaction
+func (repository *OrderRepositoryImpl) FindByIdForUpdate(ctx context.Context, orderId string) (domain.Order, error) {
+	var order domain.Order
+	err := dbFromContext(ctx, repository.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("OrderItems").
+		Preload("Payments").
+		Where("id = ?", orderId).
+		Take(&order).Error
+	return order, err
+}
+
+// FindAll - Get all orders with their items
+func (repository *OrderRepositoryImpl) FindAll(ctx context.Context) ([]domain.Order, error) {
+	var orders []domain.Order
+	return orders, dbFromContext(ctx, repository.db).Preload("OrderItems").Preload("Payments").Order("order_date DESC").Find(&orders).Error
+}
+
+// FindAllByReconciliationStatus - Get the offline orders with a reconciliation status, or all of them for an empty status
+func (repository *OrderRepositoryImpl) FindAllByReconciliationStatus(ctx context.Context, status string) ([]domain.Order, error) {
+	query := dbFromContext(ctx, repository.db).Where("reconciliation_status <> ''")
+	if status != "" {
+		query = query.Where("reconciliation_status = ?", status)
+	}
+
+	var orders []domain.Order
+	return orders, query.Preload("OrderItems").Preload("Payments").Order("order_date DESC").Find(&orders).Error
+}
+
+// FindAllByCustomer - Get all orders of a customer with their items, oldest first
+func (repository *OrderRepositoryImpl) FindAllByCustomer(ctx context.Context, customerId string) ([]domain.Order, error) {
+	var orders []domain.Order
+	err := dbFromContext(ctx, repository.db).Preload("OrderItems").Preload("Payments").
+		Where("customer_id = ?", customerId).
+		Order("order_date, id").
+		Find(&orders).Error
+	return orders, err
+}
+
+// FindPageByCustomer - Get limit orders of a customer from offset, newest first, with the count of all their orders
+func (repository *OrderRepositoryImpl) FindPageByCustomer(ctx context.Context, customerId string, offset int, limit int) ([]domain.Order, int64, error) {
+	query := dbFromContext(ctx, repository.db).Model(&domain.Order{}).Where("customer_id = ?", customerId).Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var orders []domain.Order
+	err := query.Preload("OrderItems").Preload("Payments").
+		Order("order_date DESC, id").
+		Offset(offset).
+		Limit(limit).
+		Find(&orders).Error
+	return orders, total, err
+}
+
+// SummaryByCustomer - Sum up the orders of a customer
+func (repository *OrderRepositoryImpl) SummaryByCustomer(ctx context.Context, customerId string) (domain.CustomerOrderSummary, error) {
+	var summary domain.CustomerOrderSummary
+	err := dbFromContext(ctx, repository.db).
+		Model(&domain.Order{}).
+		Select("COUNT(*) AS order_count, COALESCE(SUM(total_amount), 0) AS total_spent, MIN(order_date) AS first_order_date, MAX(order_date) AS last_order_date").
+		Where("customer_id = ?", customerId).
+		Scan(&summary).Error
+	return summary, err
+}
+
+// ProductPurchasesByCustomer - Get the limit products a customer bought most often
+func (repository *OrderRepositoryImpl) ProductPurchasesByCustomer(ctx context.Context, customerId string, limit int) ([]domain.CustomerProductPurchase, error) {
+	var purchases []domain.CustomerProductPurchase
+	err := dbFromContext(ctx, repository.db).
+		Model(&domain.Order{}).
+		Select("order_items.product_id, COALESCE(MAX(products.product_name), '') AS product_name, SUM(order_items.quantity) AS quantity, "+
+			"COUNT(DISTINCT orders.id) AS order_count, SUM(order_items.total_price) AS revenue, MAX(orders.order_date) AS last_order_date").
+		Joins("JOIN order_items ON order_items.order_id = orders.id").
+		Joins("LEFT JOIN products ON products.id = order_items.product_id").
+		Where("orders.customer_id = ?", customerId).
+		Group("order_items.product_id").
+		Order("order_count DESC, quantity DESC, order_items.product_id").
+		Limit(limit).
+		Find(&purchases).Error
+	return purchases, err
+}
+
+// ReassignCustomer - Move the orders of a customer to another one, returning how many moved
+func (repository *OrderRepositoryImpl) ReassignCustomer(ctx context.Context, fromCustomerId string, toCustomerId string) (int64, error) {
+	result := dbFromContext(ctx, repository.db).
+		Model(&domain.Order{}).
+		Where("customer_id = ?", fromCustomerId).
+		Updates(map[string]interface{}{"customer_id": toCustomerId, "version": gorm.Expr("version + 1")})
+	return result.RowsAffected, result.Error
+}
+
+// FindInBatches - Walk all orders batchSize rows at a time, each batch with its items
+func (repository *OrderRepositoryImpl) FindInBatches(ctx context.Context, batchSize int, fn func(orders []domain.Order) error) error {
+	var orders []domain.Order
+	return dbFromContext(ctx, repository.db).Preload("OrderItems").Preload("Payments").FindInBatches(&orders, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(orders)
+	}).Error
+}
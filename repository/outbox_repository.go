@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"time"
+)
+
+type OutboxRepository interface {
+	Save(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error)
+	FindAfter(ctx context.Context, afterId uint64, storeId uint64, limit int) ([]domain.OutboxEvent, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]domain.OutboxEvent, error)
+	FindAllByAggregate(ctx context.Context, aggregateType string, aggregateId string) ([]domain.OutboxEvent, error)
+	Claim(ctx context.Context, event domain.OutboxEvent, until time.Time) (bool, error)
+	UpdateDelivery(ctx context.Context, event domain.OutboxEvent) error
+	UpdatePayload(ctx context.Context, event domain.OutboxEvent) error
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+type OutboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &OutboxRepositoryImpl{db: db}
+}
+
+// Save event to the outbox, due for delivery right away
+func (repository *OutboxRepositoryImpl) Save(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error) {
+	if event.NextAttemptAt.IsZero() {
+		event.NextAttemptAt = event.OccurredAt
+	}
+	if err := dbFromContext(ctx, repository.db).Create(&event).Error; err != nil {
+		return domain.OutboxEvent{}, err
+	}
+	return event, nil
+}
+
+// FindAfter - Get the events after an id, oldest first. With a store, only the events
+// raised at that store or at none
+func (repository *OutboxRepositoryImpl) FindAfter(ctx context.Context, afterId uint64, storeId uint64, limit int) ([]domain.OutboxEvent, error) {
+	query := dbFromContext(ctx, repository.db).Where("id > ?", afterId)
+	if storeId != 0 {
+		query = query.Where("origin_store_id IN ?", []uint64{0, storeId})
+	}
+
+	var events []domain.OutboxEvent
+	err := query.Order("id").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// FindDue - Get the oldest undelivered events that are due for a delivery attempt
+func (repository *OutboxRepositoryImpl) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.OutboxEvent, error) {
+	var events []domain.OutboxEvent
+	err := dbFromContext(ctx, repository.db).
+		Where("delivered_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// FindAllByAggregate - Get the events of an aggregate, oldest first
+func (repository *OutboxRepositoryImpl) FindAllByAggregate(ctx context.Context, aggregateType string, aggregateId string) ([]domain.OutboxEvent, error) {
+	var events []domain.OutboxEvent
+	err := dbFromContext(ctx, repository.db).
+		Where("aggregate_type = ? AND aggregate_id = ?", aggregateType, aggregateId).
+		Order("id").
+		Find(&events).Error
+	return events, err
+}
+
+// Claim - Hold an event back from other dispatchers until a time, unless one has claimed it already
+func (repository *OutboxRepositoryImpl) Claim(ctx context.Context, event domain.OutboxEvent, until time.Time) (bool, error) {
+	result := dbFromContext(ctx, repository.db).
+		Model(&domain.OutboxEvent{}).
+		Where("id = ? AND delivered_at IS NULL AND next_attempt_at = ?", event.Id, event.NextAttemptAt).
+		Update("next_attempt_at", until)
+	return result.RowsAffected == 1, result.Error
+}
+
+// UpdateDelivery - Record the outcome of a delivery attempt
+func (repository *OutboxRepositoryImpl) UpdateDelivery(ctx context.Context, event domain.OutboxEvent) error {
+	return dbFromContext(ctx, repository.db).
+		Model(&event).
+		Select("attempts", "next_attempt_at", "delivered_at", "last_error").
+		Updates(&event).Error
+}
+
+// UpdatePayload - Rewrite the payload of an event, to redact personal data
+func (repository *OutboxRepositoryImpl) UpdatePayload(ctx context.Context, event domain.OutboxEvent) error {
+	return dbFromContext(ctx, repository.db).Model(&event).Update("payload", event.Payload).Error
+}
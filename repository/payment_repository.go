@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type PaymentRepository interface {
+	Save(ctx context.Context, payment domain.Payment) (domain.Payment, error)
+	FindAllByOrderId(ctx context.Context, orderId string) ([]domain.Payment, error)
+}
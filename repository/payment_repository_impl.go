@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type PaymentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &PaymentRepositoryImpl{db: db}
+}
+
+// Save payment
+func (repository *PaymentRepositoryImpl) Save(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+	payment.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&payment).Error; err != nil {
+		return domain.Payment{}, err
+	}
+	return payment, nil
+}
+
+// FindAllByOrderId - Get the payments of an order in the order they were made
+func (repository *PaymentRepositoryImpl) FindAllByOrderId(ctx context.Context, orderId string) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	return payments, dbFromContext(ctx, repository.db).Where("order_id = ?", orderId).Order("payment_date, id").Find(&payments).Error
+}
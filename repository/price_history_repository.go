@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type PriceHistoryRepository interface {
+	Save(ctx context.Context, history domain.PriceHistory) (domain.PriceHistory, error)
+	FindAllByProductId(ctx context.Context, productId string) ([]domain.PriceHistory, error)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type PriceHistoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPriceHistoryRepository(db *gorm.DB) PriceHistoryRepository {
+	return &PriceHistoryRepositoryImpl{db: db}
+}
+
+// Save price history
+func (repository *PriceHistoryRepositoryImpl) Save(ctx context.Context, history domain.PriceHistory) (domain.PriceHistory, error) {
+	if err := dbFromContext(ctx, repository.db).Create(&history).Error; err != nil {
+		return domain.PriceHistory{}, err
+	}
+	return history, nil
+}
+
+// FindAllByProductId - Get the price changes of a product, newest first
+func (repository *PriceHistoryRepositoryImpl) FindAllByProductId(ctx context.Context, productId string) ([]domain.PriceHistory, error) {
+	var histories []domain.PriceHistory
+	return histories, dbFromContext(ctx, repository.db).Where("product_id = ?", productId).Order("changed_at DESC, id DESC").Find(&histories).Error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type PriceListRepository interface {
+	Save(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error)
+	Update(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error)
+	Delete(ctx context.Context, priceList domain.PriceList) error
+	FindById(ctx context.Context, priceListId uint64) (domain.PriceList, error)
+	FindAll(ctx context.Context) ([]domain.PriceList, error)
+	SaveItem(ctx context.Context, item domain.PriceListItem) (domain.PriceListItem, error)
+	DeleteItem(ctx context.Context, item domain.PriceListItem) error
+	FindItem(ctx context.Context, priceListId uint64, productId string) (domain.PriceListItem, error)
+	FindItems(ctx context.Context, priceListId uint64) ([]domain.PriceListItem, error)
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PriceListRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPriceListRepository(db *gorm.DB) PriceListRepository {
+	return &PriceListRepositoryImpl{db: db}
+}
+
+// Save price list
+func (repository *PriceListRepositoryImpl) Save(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	priceList.Version = 1
+	if err := dbFromContext(ctx, repository.db).Omit(clause.Associations).Create(&priceList).Error; err != nil {
+		return domain.PriceList{}, err
+	}
+	return priceList, nil
+}
+
+// Update price list
+func (repository *PriceListRepositoryImpl) Update(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	expectedVersion := priceList.Version
+	priceList.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&priceList).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(&priceList)
+	if result.Error != nil {
+		return domain.PriceList{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.PriceList{}, ErrVersionConflict
+	}
+	return priceList, nil
+}
+
+// Delete removes the price list together with its items and unassigns its customers
+func (repository *PriceListRepositoryImpl) Delete(ctx context.Context, priceList domain.PriceList) error {
+	return dbFromContext(ctx, repository.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("price_list_id = ?", priceList.Id).Delete(&domain.PriceListItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Customer{}).Where("price_list_id = ?", priceList.Id).Update("price_list_id", nil).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("version = ?", priceList.Version).Delete(&priceList)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+}
+
+// FindById - Get price list by ID
+func (repository *PriceListRepositoryImpl) FindById(ctx context.Context, priceListId uint64) (domain.PriceList, error) {
+	var priceList domain.PriceList
+	err := dbFromContext(ctx, repository.db).Take(&priceList, "id = ?", priceListId).Error
+	return priceList, err
+}
+
+// FindAll price lists
+func (repository *PriceListRepositoryImpl) FindAll(ctx context.Context) ([]domain.PriceList, error) {
+	var priceLists []domain.PriceList
+	return priceLists, dbFromContext(ctx, repository.db).Order("code").Find(&priceLists).Error
+}
+
+// SaveItem - Insert the price of a product in a price list, or replace it when it already has one
+func (repository *PriceListRepositoryImpl) SaveItem(ctx context.Context, item domain.PriceListItem) (domain.PriceListItem, error) {
+	err := dbFromContext(ctx, repository.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "price_list_id"}, {Name: "product_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price"}),
+	}).Create(&item).Error
+	if err != nil {
+		return domain.PriceListItem{}, err
+	}
+	return item, nil
+}
+
+// DeleteItem - Remove the price of a product from a price list
+func (repository *PriceListRepositoryImpl) DeleteItem(ctx context.Context, item domain.PriceListItem) error {
+	return dbFromContext(ctx, repository.db).
+		Where("price_list_id = ? AND product_id = ?", item.PriceListId, item.ProductID).
+		Delete(&domain.PriceListItem{}).Error
+}
+
+// FindItem - Get the price of a product in a price list
+func (repository *PriceListRepositoryImpl) FindItem(ctx context.Context, priceListId uint64, productId string) (domain.PriceListItem, error) {
+	var item domain.PriceListItem
+	err := dbFromContext(ctx, repository.db).Take(&item, "price_list_id = ? AND product_id = ?", priceListId, productId).Error
+	return item, err
+}
+
+// FindItems - Get every product price of a price list
+func (repository *PriceListRepositoryImpl) FindItems(ctx context.Context, priceListId uint64) ([]domain.PriceListItem, error) {
+	var items []domain.PriceListItem
+	return items, dbFromContext(ctx, repository.db).Where("price_list_id = ?", priceListId).Order("product_id").Find(&items).Error
+}
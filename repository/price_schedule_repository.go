@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"time"
+)
+
+type PriceScheduleRepository interface {
+	Save(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error)
+	Update(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error)
+	FindById(ctx context.Context, scheduleId uint64) (domain.PriceSchedule, error)
+	FindAll(ctx context.Context, productId string, status string) ([]domain.PriceSchedule, error)
+	FindDue(ctx context.Context, now time.Time) ([]domain.PriceSchedule, error)
+	FindEffective(ctx context.Context, priceListId *uint64, productId string, at time.Time) (domain.PriceSchedule, error)
+	CountOverlapping(ctx context.Context, schedule domain.PriceSchedule) (int64, error)
+}
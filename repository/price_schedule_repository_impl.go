@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PriceScheduleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPriceScheduleRepository(db *gorm.DB) PriceScheduleRepository {
+	return &PriceScheduleRepositoryImpl{db: db}
+}
+
+// openScheduleStatuses are the statuses of schedules that still affect prices.
+var openScheduleStatuses = []string{domain.PriceScheduleStatusPending, domain.PriceScheduleStatusActive}
+
+// Save price schedule
+func (repository *PriceScheduleRepositoryImpl) Save(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error) {
+	if err := dbFromContext(ctx, repository.db).Create(&schedule).Error; err != nil {
+		return domain.PriceSchedule{}, err
+	}
+	return schedule, nil
+}
+
+// Update price schedule
+func (repository *PriceScheduleRepositoryImpl) Update(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error) {
+	if err := dbFromContext(ctx, repository.db).Save(&schedule).Error; err != nil {
+		return domain.PriceSchedule{}, err
+	}
+	return schedule, nil
+}
+
+// FindById - Get price schedule by ID
+func (repository *PriceScheduleRepositoryImpl) FindById(ctx context.Context, scheduleId uint64) (domain.PriceSchedule, error) {
+	var schedule domain.PriceSchedule
+	err := dbFromContext(ctx, repository.db).Take(&schedule, "id = ?", scheduleId).Error
+	return schedule, err
+}
+
+// FindAll - Get price schedules, optionally only those of a product or with a status
+func (repository *PriceScheduleRepositoryImpl) FindAll(ctx context.Context, productId string, status string) ([]domain.PriceSchedule, error) {
+	query := dbFromContext(ctx, repository.db).Order("effective_from, id")
+	if productId != "" {
+		query = query.Where("product_id = ?", productId)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var schedules []domain.PriceSchedule
+	return schedules, query.Find(&schedules).Error
+}
+
+// FindDue - Get the pending schedules that should have started and the active ones that should have ended by now
+func (repository *PriceScheduleRepositoryImpl) FindDue(ctx context.Context, now time.Time) ([]domain.PriceSchedule, error) {
+	var schedules []domain.PriceSchedule
+	return schedules, dbFromContext(ctx, repository.db).
+		Where("(status = ? AND effective_from <= ?) OR (status = ? AND effective_to <= ?)",
+			domain.PriceScheduleStatusPending, now, domain.PriceScheduleStatusActive, now).
+		Order("effective_from, id").
+		Find(&schedules).Error
+}
+
+// FindEffective - Get the open schedule of a product in a price list, or of its own price when priceListId is nil, covering at
+func (repository *PriceScheduleRepositoryImpl) FindEffective(ctx context.Context, priceListId *uint64, productId string, at time.Time) (domain.PriceSchedule, error) {
+	var schedule domain.PriceSchedule
+	err := priceListScope(dbFromContext(ctx, repository.db), priceListId).
+		Where("product_id = ? AND status IN ?", productId, openScheduleStatuses).
+		Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at).
+		Order("effective_from DESC").
+		Take(&schedule).Error
+	return schedule, err
+}
+
+// CountOverlapping - Count the open schedules for the same product and price list whose window overlaps the given one
+func (repository *PriceScheduleRepositoryImpl) CountOverlapping(ctx context.Context, schedule domain.PriceSchedule) (int64, error) {
+	query := priceListScope(dbFromContext(ctx, repository.db).Model(&domain.PriceSchedule{}), schedule.PriceListId).
+		Where("product_id = ? AND status IN ?", schedule.ProductID, openScheduleStatuses).
+		Where("effective_to IS NULL OR effective_to > ?", schedule.EffectiveFrom)
+	if schedule.EffectiveTo != nil {
+		query = query.Where("effective_from < ?", *schedule.EffectiveTo)
+	}
+	if schedule.Id != 0 {
+		query = query.Where("id <> ?", schedule.Id)
+	}
+
+	var count int64
+	return count, query.Count(&count).Error
+}
+
+func priceListScope(db *gorm.DB, priceListId *uint64) *gorm.DB {
+	if priceListId == nil {
+		return db.Where("price_list_id IS NULL")
+	}
+	return db.Where("price_list_id = ?", *priceListId)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type ProductBarcodeRepository interface {
+	Save(ctx context.Context, barcode domain.ProductBarcode) (domain.ProductBarcode, error)
+	Delete(ctx context.Context, barcode domain.ProductBarcode) error
+	FindByCode(ctx context.Context, code string) (domain.ProductBarcode, error)
+	FindAllByProductId(ctx context.Context, productId string) ([]domain.ProductBarcode, error)
+	FindLastInternal(ctx context.Context) (domain.ProductBarcode, error)
+	FindProductIdsWithoutBarcode(ctx context.Context) ([]string, error)
+}
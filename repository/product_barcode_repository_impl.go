@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ProductBarcodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewProductBarcodeRepository(db *gorm.DB) ProductBarcodeRepository {
+	return &ProductBarcodeRepositoryImpl{db: db}
+}
+
+// Save barcode
+func (repository *ProductBarcodeRepositoryImpl) Save(ctx context.Context, barcode domain.ProductBarcode) (domain.ProductBarcode, error) {
+	barcode.CreatedAt = time.Now()
+	if err := dbFromContext(ctx, repository.db).Create(&barcode).Error; err != nil {
+		return domain.ProductBarcode{}, err
+	}
+	return barcode, nil
+}
+
+// Delete barcode
+func (repository *ProductBarcodeRepositoryImpl) Delete(ctx context.Context, barcode domain.ProductBarcode) error {
+	return dbFromContext(ctx, repository.db).Delete(&barcode).Error
+}
+
+// FindByCode - Get barcode by its code
+func (repository *ProductBarcodeRepositoryImpl) FindByCode(ctx context.Context, code string) (domain.ProductBarcode, error) {
+	var barcode domain.ProductBarcode
+	err := dbFromContext(ctx, repository.db).Take(&barcode, "code = ?", code).Error
+	return barcode, err
+}
+
+// FindAllByProductId - Get all barcodes of a product
+func (repository *ProductBarcodeRepositoryImpl) FindAllByProductId(ctx context.Context, productId string) ([]domain.ProductBarcode, error) {
+	var barcodes []domain.ProductBarcode
+	return barcodes, dbFromContext(ctx, repository.db).Where("product_id = ?", productId).Order("id").Find(&barcodes).Error
+}
+
+// FindLastInternal - Get the generated barcode with the highest code
+func (repository *ProductBarcodeRepositoryImpl) FindLastInternal(ctx context.Context) (domain.ProductBarcode, error) {
+	var barcode domain.ProductBarcode
+	err := dbFromContext(ctx, repository.db).Where("internal = ?", true).Order("code DESC").Take(&barcode).Error
+	return barcode, err
+}
+
+// FindProductIdsWithoutBarcode - Get the products that have no barcode at all
+func (repository *ProductBarcodeRepositoryImpl) FindProductIdsWithoutBarcode(ctx context.Context) ([]string, error) {
+	var productIds []string
+	err := dbFromContext(ctx, repository.db).
+		Model(&domain.Product{}).
+		Where("NOT EXISTS (?)", repository.db.Model(&domain.ProductBarcode{}).Select("1").Where("product_barcodes.product_id = products.id")).
+		Order("id").
+		Pluck("id", &productIds).Error
+	return productIds, err
+}
@@ -11,4 +11,10 @@ type ProductRepository interface {
 	Delete(ctx context.Context, product domain.Product) error
 	FindById(ctx context.Context, productId string) (domain.Product, error)
 	FindAll(ctx context.Context) ([]domain.Product, error)
+	FindAllById(ctx context.Context, productIds []string) ([]domain.Product, error)
+	FindAllInCategoryTree(ctx context.Context, categoryId uint64) ([]domain.Product, error)
+	FindInBatches(ctx context.Context, batchSize int, fn func(products []domain.Product) error) error
+	FindAllBySKU(ctx context.Context, skus []string) ([]domain.Product, error)
+	AdjustStock(ctx context.Context, productId string, delta int) error
+	AdjustStockAllowingNegative(ctx context.Context, productId string, delta int) error
 }
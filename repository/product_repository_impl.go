@@ -2,9 +2,9 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepositoryImpl struct {
@@ -16,33 +16,169 @@ func NewProductRepository(db *gorm.DB) ProductRepository {
 }
 
 func (repository *ProductRepositoryImpl) Save(ctx context.Context, product domain.Product) (domain.Product, error) {
-	if err := repository.db.WithContext(ctx).Create(&product).Error; err != nil {
+	product.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&product).Error; err != nil {
 		return domain.Product{}, err
 	}
 	return product, nil
 }
 
 func (repository *ProductRepositoryImpl) Update(ctx context.Context, product domain.Product) (domain.Product, error) {
-	if err := repository.db.WithContext(ctx).Save(&product).Error; err != nil {
-		return domain.Product{}, err
+	expectedVersion := product.Version
+	product.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&product).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(&product)
+	if result.Error != nil {
+		return domain.Product{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.Product{}, ErrVersionConflict
 	}
 	return product, nil
 }
 
+// Delete removes the product together with its options, variants and barcodes
 func (repository *ProductRepositoryImpl) Delete(ctx context.Context, product domain.Product) error {
-	return repository.db.WithContext(ctx).Delete(&product).Error
+	return dbFromContext(ctx, repository.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", product.ProductID).Delete(&domain.ProductOption{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", product.ProductID).Delete(&domain.ProductBarcode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", product.ProductID).Delete(&domain.ProductVariant{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("version = ?", product.Version).Delete(&product)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
 }
 
 func (repository *ProductRepositoryImpl) FindById(ctx context.Context, productId string) (domain.Product, error) {
 	var product domain.Product
-	err := repository.db.WithContext(ctx).First(&product, "product_id = ?", productId).Error
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return product, errors.New("product not found")
-	}
+	err := dbFromContext(ctx, repository.db).
+		Preload("Options", productOptionOrder).
+		Preload("Variants", productVariantOrder).
+		Preload("Barcodes", productBarcodeOrder).
+		First(&product, "id = ?", productId).Error
 	return product, err
 }
 
+// FindAll returns every product with its options and variants rolled up under it
 func (repository *ProductRepositoryImpl) FindAll(ctx context.Context) ([]domain.Product, error) {
 	var products []domain.Product
-	return products, repository.db.WithContext(ctx).Find(&products).Error
+	return products, dbFromContext(ctx, repository.db).
+		Preload("Options", productOptionOrder).
+		Preload("Variants", productVariantOrder).
+		Preload("Barcodes", productBarcodeOrder).
+		Find(&products).Error
+}
+
+// FindAllById returns the products with the given ids, with their options and variants
+func (repository *ProductRepositoryImpl) FindAllById(ctx context.Context, productIds []string) ([]domain.Product, error) {
+	var products []domain.Product
+	if len(productIds) == 0 {
+		return products, nil
+	}
+	return products, dbFromContext(ctx, repository.db).
+		Preload("Options", productOptionOrder).
+		Preload("Variants", productVariantOrder).
+		Preload("Barcodes", productBarcodeOrder).
+		Where("id IN ?", productIds).
+		Find(&products).Error
+}
+
+// categoryTreeIds selects the id of a category and of all its descendants.
+const categoryTreeIds = `WITH RECURSIVE category_tree AS (
+	SELECT id FROM categories WHERE id = ?
+	UNION ALL
+	SELECT categories.id FROM categories JOIN category_tree ON categories.parent_id = category_tree.id
+) SELECT id FROM category_tree`
+
+// FindAllInCategoryTree returns the products of a category and of all its descendants
+func (repository *ProductRepositoryImpl) FindAllInCategoryTree(ctx context.Context, categoryId uint64) ([]domain.Product, error) {
+	var products []domain.Product
+	return products, dbFromContext(ctx, repository.db).
+		Preload("Options", productOptionOrder).
+		Preload("Variants", productVariantOrder).
+		Preload("Barcodes", productBarcodeOrder).
+		Where("category_id IN ("+categoryTreeIds+")", categoryId).
+		Find(&products).Error
+}
+
+func productOptionOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+func productVariantOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+func productBarcodeOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+func (repository *ProductRepositoryImpl) FindAllBySKU(ctx context.Context, skus []string) ([]domain.Product, error) {
+	var products []domain.Product
+	if len(skus) == 0 {
+		return products, nil
+	}
+	return products, dbFromContext(ctx, repository.db).Where("product_sku IN ?", skus).Find(&products).Error
+}
+
+// FindInBatches - Walk all products batchSize rows at a time
+func (repository *ProductRepositoryImpl) FindInBatches(ctx context.Context, batchSize int, fn func(products []domain.Product) error) error {
+	var products []domain.Product
+	return dbFromContext(ctx, repository.db).FindInBatches(&products, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(products)
+	}).Error
+}
+
+// AdjustStock - Add delta to the stock of a product in a single statement, refusing to go below zero
+func (repository *ProductRepositoryImpl) AdjustStock(ctx context.Context, productId string, delta int) error {
+	return adjustStock(dbFromContext(ctx, repository.db).Model(&domain.Product{}).Where("id = ?", productId), delta)
+}
+
+// AdjustStockAllowingNegative - Add delta to the stock of a product, also when that takes it below zero
+func (repository *ProductRepositoryImpl) AdjustStockAllowingNegative(ctx context.Context, productId string, delta int) error {
+	return adjustStockAllowingNegative(dbFromContext(ctx, repository.db).Model(&domain.Product{}).Where("id = ?", productId), delta)
+}
+
+// adjustStock adds delta to stock_qty of the rows selected by query and bumps their
+// version, failing with ErrInsufficientStock when the stock would become negative.
+func adjustStock(query *gorm.DB, delta int) error {
+	result := query.
+		Where("stock_qty + ? >= 0", delta).
+		Updates(map[string]interface{}{
+			"stock_qty": gorm.Expr("stock_qty + ?", delta),
+			"version":   gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsufficientStock
+	}
+	return nil
+}
+
+// adjustStockAllowingNegative adds delta to stock_qty of the rows selected by query and
+// bumps their version, like adjustStock but without a floor at zero.
+func adjustStockAllowingNegative(query *gorm.DB, delta int) error {
+	return query.Updates(map[string]interface{}{
+		"stock_qty": gorm.Expr("stock_qty + ?", delta),
+		"version":   gorm.Expr("version + 1"),
+	}).Error
 }
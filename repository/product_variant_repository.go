@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type ProductVariantRepository interface {
+	FindOptions(ctx context.Context, productId string) ([]domain.ProductOption, error)
+	ReplaceOptions(ctx context.Context, productId string, options []domain.ProductOption) ([]domain.ProductOption, error)
+	SaveAll(ctx context.Context, variants []domain.ProductVariant) ([]domain.ProductVariant, error)
+	Update(ctx context.Context, variant domain.ProductVariant) (domain.ProductVariant, error)
+	Delete(ctx context.Context, variant domain.ProductVariant) error
+	FindById(ctx context.Context, variantId uint64) (domain.ProductVariant, error)
+	FindAllByProductId(ctx context.Context, productId string) ([]domain.ProductVariant, error)
+	FindAllBySKU(ctx context.Context, skus []string) ([]domain.ProductVariant, error)
+	AdjustStock(ctx context.Context, variantId uint64, delta int) error
+	AdjustStockAllowingNegative(ctx context.Context, variantId uint64, delta int) error
+}
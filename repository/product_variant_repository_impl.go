@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type ProductVariantRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewProductVariantRepository(db *gorm.DB) ProductVariantRepository {
+	return &ProductVariantRepositoryImpl{db: db}
+}
+
+// FindOptions - Get the option definitions of a product in display order
+func (repository *ProductVariantRepositoryImpl) FindOptions(ctx context.Context, productId string) ([]domain.ProductOption, error) {
+	var options []domain.ProductOption
+	return options, dbFromContext(ctx, repository.db).Where("product_id = ?", productId).Order("position").Find(&options).Error
+}
+
+// ReplaceOptions - Replace all option definitions of a product
+func (repository *ProductVariantRepositoryImpl) ReplaceOptions(ctx context.Context, productId string, options []domain.ProductOption) ([]domain.ProductOption, error) {
+	err := dbFromContext(ctx, repository.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productId).Delete(&domain.ProductOption{}).Error; err != nil {
+			return err
+		}
+		if len(options) == 0 {
+			return nil
+		}
+		return tx.Create(&options).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// SaveAll - Insert new variants
+func (repository *ProductVariantRepositoryImpl) SaveAll(ctx context.Context, variants []domain.ProductVariant) ([]domain.ProductVariant, error) {
+	if len(variants) == 0 {
+		return variants, nil
+	}
+	for i := range variants {
+		variants[i].Version = 1
+	}
+	if err := dbFromContext(ctx, repository.db).Create(&variants).Error; err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// Update variant
+func (repository *ProductVariantRepositoryImpl) Update(ctx context.Context, variant domain.ProductVariant) (domain.ProductVariant, error) {
+	expectedVersion := variant.Version
+	variant.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&variant).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Updates(&variant)
+	if result.Error != nil {
+		return domain.ProductVariant{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ProductVariant{}, ErrVersionConflict
+	}
+	return variant, nil
+}
+
+// Delete variant together with its barcodes
+func (repository *ProductVariantRepositoryImpl) Delete(ctx context.Context, variant domain.ProductVariant) error {
+	return dbFromContext(ctx, repository.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("variant_id = ?", variant.Id).Delete(&domain.ProductBarcode{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("version = ?", variant.Version).Delete(&variant)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+}
+
+// FindById - Get variant by ID
+func (repository *ProductVariantRepositoryImpl) FindById(ctx context.Context, variantId uint64) (domain.ProductVariant, error) {
+	var variant domain.ProductVariant
+	err := dbFromContext(ctx, repository.db).Take(&variant, "id = ?", variantId).Error
+	return variant, err
+}
+
+// FindAllByProductId - Get all variants of a product
+func (repository *ProductVariantRepositoryImpl) FindAllByProductId(ctx context.Context, productId string) ([]domain.ProductVariant, error) {
+	var variants []domain.ProductVariant
+	return variants, dbFromContext(ctx, repository.db).Where("product_id = ?", productId).Order("id").Find(&variants).Error
+}
+
+// FindAllBySKU - Get the variants using any of the given SKUs
+func (repository *ProductVariantRepositoryImpl) FindAllBySKU(ctx context.Context, skus []string) ([]domain.ProductVariant, error) {
+	var variants []domain.ProductVariant
+	if len(skus) == 0 {
+		return variants, nil
+	}
+	return variants, dbFromContext(ctx, repository.db).Where("sku IN ?", skus).Find(&variants).Error
+}
+
+// AdjustStock - Add delta to the stock of a variant in a single statement, refusing to go below zero
+func (repository *ProductVariantRepositoryImpl) AdjustStock(ctx context.Context, variantId uint64, delta int) error {
+	return adjustStock(dbFromContext(ctx, repository.db).Model(&domain.ProductVariant{}).Where("id = ?", variantId), delta)
+}
+
+// AdjustStockAllowingNegative - Add delta to the stock of a variant, also when that takes it below zero
+func (repository *ProductVariantRepositoryImpl) AdjustStockAllowingNegative(ctx context.Context, variantId uint64, delta int) error {
+	return adjustStockAllowingNegative(dbFromContext(ctx, repository.db).Model(&domain.ProductVariant{}).Where("id = ?", variantId), delta)
+}
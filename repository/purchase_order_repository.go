@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type PurchaseOrderRepository interface {
+	Save(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error)
+	Update(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error)
+	ReplaceLines(ctx context.Context, orderId uint64, lines []domain.PurchaseOrderLine) ([]domain.PurchaseOrderLine, error)
+	UpdateLine(ctx context.Context, line domain.PurchaseOrderLine) error
+	Delete(ctx context.Context, order domain.PurchaseOrder) error
+	FindById(ctx context.Context, orderId uint64) (domain.PurchaseOrder, error)
+	FindAll(ctx context.Context, status string, supplierId uint64) ([]domain.PurchaseOrder, error)
+	FindOutstanding(ctx context.Context) ([]domain.OutstandingQuantity, error)
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB) PurchaseOrderRepository {
+	return &PurchaseOrderRepositoryImpl{db: db}
+}
+
+// Save purchase order together with its lines
+func (repository *PurchaseOrderRepositoryImpl) Save(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+	order.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&order).Error; err != nil {
+		return domain.PurchaseOrder{}, err
+	}
+	return order, nil
+}
+
+// Update purchase order, without its lines
+func (repository *PurchaseOrderRepositoryImpl) Update(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+	expectedVersion := order.Version
+	order.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&order).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(&order)
+	if result.Error != nil {
+		return domain.PurchaseOrder{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.PurchaseOrder{}, ErrVersionConflict
+	}
+	return order, nil
+}
+
+// ReplaceLines - Replace the lines of a purchase order
+func (repository *PurchaseOrderRepositoryImpl) ReplaceLines(ctx context.Context, orderId uint64, lines []domain.PurchaseOrderLine) ([]domain.PurchaseOrderLine, error) {
+	db := dbFromContext(ctx, repository.db)
+	if err := db.Where("purchase_order_id = ?", orderId).Delete(&domain.PurchaseOrderLine{}).Error; err != nil {
+		return nil, err
+	}
+	for i := range lines {
+		lines[i].Id = 0
+		lines[i].PurchaseOrderID = orderId
+	}
+	if len(lines) == 0 {
+		return lines, nil
+	}
+	return lines, db.Create(&lines).Error
+}
+
+// UpdateLine - Update the received quantity and cost of a purchase order line
+func (repository *PurchaseOrderRepositoryImpl) UpdateLine(ctx context.Context, line domain.PurchaseOrderLine) error {
+	return dbFromContext(ctx, repository.db).
+		Model(&line).
+		Select("received_quantity", "unit_cost").
+		Updates(&line).Error
+}
+
+// Delete purchase order together with its lines
+func (repository *PurchaseOrderRepositoryImpl) Delete(ctx context.Context, order domain.PurchaseOrder) error {
+	db := dbFromContext(ctx, repository.db)
+	result := db.Where("version = ?", order.Version).Delete(&order)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return db.Where("purchase_order_id = ?", order.Id).Delete(&domain.PurchaseOrderLine{}).Error
+}
+
+// FindById - Get purchase order by ID
+func (repository *PurchaseOrderRepositoryImpl) FindById(ctx context.Context, orderId uint64) (domain.PurchaseOrder, error) {
+	var order domain.PurchaseOrder
+	err := dbFromContext(ctx, repository.db).Preload("Lines").Take(&order, "id = ?", orderId).Error
+	return order, err
+}
+
+// FindAll - Get the purchase orders, newest first, optionally only those with a status or of a supplier
+func (repository *PurchaseOrderRepositoryImpl) FindAll(ctx context.Context, status string, supplierId uint64) ([]domain.PurchaseOrder, error) {
+	query := dbFromContext(ctx, repository.db).Preload("Lines").Order("created_at DESC, id DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if supplierId != 0 {
+		query = query.Where("supplier_id = ?", supplierId)
+	}
+	var orders []domain.PurchaseOrder
+	return orders, query.Find(&orders).Error
+}
+
+// FindOutstanding - Sum up what is still expected on the purchase orders that are not yet received
+func (repository *PurchaseOrderRepositoryImpl) FindOutstanding(ctx context.Context) ([]domain.OutstandingQuantity, error) {
+	var outstanding []domain.OutstandingQuantity
+	err := dbFromContext(ctx, repository.db).
+		Model(&domain.PurchaseOrder{}).
+		Select("purchase_order_lines.product_id AS product_id, purchase_order_lines.variant_id AS variant_id, SUM(purchase_order_lines.quantity - purchase_order_lines.received_quantity) AS quantity").
+		Joins("JOIN purchase_order_lines ON purchase_order_lines.purchase_order_id = purchase_orders.id").
+		Where("purchase_orders.status IN ?", []string{domain.PurchaseOrderStatusDraft, domain.PurchaseOrderStatusSent, domain.PurchaseOrderStatusPartiallyReceived}).
+		Group("purchase_order_lines.product_id, purchase_order_lines.variant_id").
+		Find(&outstanding).Error
+	return outstanding, err
+}
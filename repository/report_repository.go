@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"time"
+)
+
+// ReportRepository aggregates the orders placed in [from, to) and the inventory ledger.
+type ReportRepository interface {
+	WalkOrderTotals(ctx context.Context, from time.Time, to time.Time, batchSize int, fn func(totals []domain.OrderTotal) error) error
+	SalesByStore(ctx context.Context, from time.Time, to time.Time) ([]domain.SalesTotal, error)
+	SalesByEmployee(ctx context.Context, from time.Time, to time.Time) ([]domain.SalesTotal, error)
+	ProductSales(ctx context.Context, from time.Time, to time.Time, orderBy string, limit int) ([]domain.ProductSales, error)
+	CategorySales(ctx context.Context, from time.Time, to time.Time) ([]domain.CategorySales, error)
+	PaymentTypeTotals(ctx context.Context, from time.Time, to time.Time) ([]domain.PaymentTypeTotal, error)
+	TaxTotals(ctx context.Context, from time.Time, to time.Time) ([]domain.TaxTotal, error)
+	DiscountTotals(ctx context.Context, from time.Time, to time.Time) ([]domain.ProductSales, error)
+	ProductMargins(ctx context.Context, from time.Time, to time.Time) ([]domain.MarginTotal, error)
+	CategoryMargins(ctx context.Context, from time.Time, to time.Time) ([]domain.MarginTotal, error)
+	InventoryValuation(ctx context.Context, at time.Time) ([]domain.InventoryValue, error)
+}
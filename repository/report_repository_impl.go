@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Columns product sales can be ranked by.
+var productSalesOrder = map[string]string{
+	"revenue":  "revenue",
+	"quantity": "quantity",
+}
+
+type ReportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &ReportRepositoryImpl{db: db}
+}
+
+// orders starts every report from the orders placed in [from, to). The queries are built
+// on the Order model so that they stay within the store of the request.
+func (repository *ReportRepositoryImpl) orders(ctx context.Context, from time.Time, to time.Time) *gorm.DB {
+	return dbFromContext(ctx, repository.db).
+		Model(&domain.Order{}).
+		Where("orders.order_date >= ? AND orders.order_date < ?", from, to)
+}
+
+// WalkOrderTotals - Walk the date and amount of the orders batchSize rows at a time
+func (repository *ReportRepositoryImpl) WalkOrderTotals(ctx context.Context, from time.Time, to time.Time, batchSize int, fn func(totals []domain.OrderTotal) error) error {
+	var orders []domain.Order
+	return repository.orders(ctx, from, to).
+		Select("id, order_date, total_amount").
+		FindInBatches(&orders, batchSize, func(tx *gorm.DB, batch int) error {
+			totals := make([]domain.OrderTotal, len(orders))
+			for i, order := range orders {
+				totals[i] = domain.OrderTotal{OrderDate: order.OrderDate, TotalAmount: order.TotalAmount}
+			}
+			return fn(totals)
+		}).Error
+}
+
+// SalesByStore - Sum up the orders of every store
+func (repository *ReportRepositoryImpl) SalesByStore(ctx context.Context, from time.Time, to time.Time) ([]domain.SalesTotal, error) {
+	var totals []domain.SalesTotal
+	err := repository.orders(ctx, from, to).
+		Select("CAST(orders.store_id AS CHAR) AS `key`, COALESCE(MAX(stores.name), '') AS label, COUNT(*) AS order_count, SUM(orders.total_amount) AS revenue").
+		Joins("LEFT JOIN stores ON stores.id = orders.store_id").
+		Group("orders.store_id").
+		Order("revenue DESC").
+		Find(&totals).Error
+	return totals, err
+}
+
+// SalesByEmployee - Sum up the orders taken by every employee
+func (repository *ReportRepositoryImpl) SalesByEmployee(ctx context.Context, from time.Time, to time.Time) ([]domain.SalesTotal, error) {
+	var totals []domain.SalesTotal
+	err := repository.orders(ctx, from, to).
+		Select("orders.employee_id AS `key`, COALESCE(MAX(employees.name), '') AS label, COUNT(*) AS order_count, SUM(orders.total_amount) AS revenue").
+		Joins("LEFT JOIN employees ON employees.id = orders.employee_id").
+		Group("orders.employee_id").
+		Order("revenue DESC").
+		Find(&totals).Error
+	return totals, err
+}
+
+// ProductSales - Get the limit best selling products by revenue or quantity
+func (repository *ReportRepositoryImpl) ProductSales(ctx context.Context, from time.Time, to time.Time, orderBy string, limit int) ([]domain.ProductSales, error) {
+	column, ok := productSalesOrder[orderBy]
+	if !ok {
+		return nil, fmt.Errorf("cannot rank product sales by %q", orderBy)
+	}
+
+	var sales []domain.ProductSales
+	err := repository.productSales(ctx, from, to).
+		Order(column + " DESC, product_id").
+		Limit(limit).
+		Find(&sales).Error
+	return sales, err
+}
+
+// CategorySales - Sum up the sales of the products of every category
+func (repository *ReportRepositoryImpl) CategorySales(ctx context.Context, from time.Time, to time.Time) ([]domain.CategorySales, error) {
+	var sales []domain.CategorySales
+	err := repository.orders(ctx, from, to).
+		Select("COALESCE(products.category_id, 0) AS category_id, COALESCE(MAX(categories.name), '') AS category_name, COUNT(DISTINCT orders.id) AS order_count, SUM(order_items.quantity) AS quantity, SUM(order_items.total_price) AS revenue").
+		Joins("JOIN order_items ON order_items.order_id = orders.id").
+		Joins("LEFT JOIN products ON products.id = order_items.product_id").
+		Joins("LEFT JOIN categories ON categories.id = products.category_id").
+		Group("COALESCE(products.category_id, 0)").
+		Order("revenue DESC").
+		Find(&sales).Error
+	return sales, err
+}
+
+// PaymentTypeTotals - Sum up the completed payments of the orders by payment type
+func (repository *ReportRepositoryImpl) PaymentTypeTotals(ctx context.Context, from time.Time, to time.Time) ([]domain.PaymentTypeTotal, error) {
+	var totals []domain.PaymentTypeTotal
+	err := repository.orders(ctx, from, to).
+		Select("payments.payment_type AS payment_type, COUNT(*) AS payment_count, SUM(payments.amount) AS amount").
+		Joins("JOIN payments ON payments.order_id = orders.id").
+		Where("payments.status = ?", domain.PaymentStatusCompleted).
+		Group("payments.payment_type").
+		Order("amount DESC").
+		Find(&totals).Error
+	return totals, err
+}
+
+// TaxTotals - Sum up the tax included in the sales by tax type and rate
+func (repository *ReportRepositoryImpl) TaxTotals(ctx context.Context, from time.Time, to time.Time) ([]domain.TaxTotal, error) {
+	var totals []domain.TaxTotal
+	err := repository.orders(ctx, from, to).
+		Select("order_items.tax_type AS tax_type, order_items.tax_rate AS tax_rate, SUM(order_items.total_price) AS revenue, SUM(order_items.tax_amount) AS tax_amount").
+		Joins("JOIN order_items ON order_items.order_id = orders.id").
+		Group("order_items.tax_type, order_items.tax_rate").
+		Order("tax_type, tax_rate").
+		Find(&totals).Error
+	return totals, err
+}
+
+// DiscountTotals - Get the products sold below their regular price and what that cost
+func (repository *ReportRepositoryImpl) DiscountTotals(ctx context.Context, from time.Time, to time.Time) ([]domain.ProductSales, error) {
+	var sales []domain.ProductSales
+	err := repository.productSales(ctx, from, to).
+		Where("order_items.discount_amount > 0").
+		Order("discount_amount DESC, product_id").
+		Find(&sales).Error
+	return sales, err
+}
+
+// ProductMargins - Sum up the sales, net of tax, and their cost of goods by product
+func (repository *ReportRepositoryImpl) ProductMargins(ctx context.Context, from time.Time, to time.Time) ([]domain.MarginTotal, error) {
+	var margins []domain.MarginTotal
+	err := repository.orders(ctx, from, to).
+		Select("order_items.product_id AS `key`, COALESCE(MAX(products.product_name), '') AS label, " + marginColumns).
+		Joins("JOIN order_items ON order_items.order_id = orders.id").
+		Joins("LEFT JOIN products ON products.id = order_items.product_id").
+		Group("order_items.product_id").
+		Order("revenue - cost DESC, `key`").
+		Find(&margins).Error
+	return margins, err
+}
+
+// CategoryMargins - Sum up the sales, net of tax, and their cost of goods by category
+func (repository *ReportRepositoryImpl) CategoryMargins(ctx context.Context, from time.Time, to time.Time) ([]domain.MarginTotal, error) {
+	var margins []domain.MarginTotal
+	err := repository.orders(ctx, from, to).
+		Select("CAST(COALESCE(products.category_id, 0) AS CHAR) AS `key`, COALESCE(MAX(categories.name), '') AS label, " + marginColumns).
+		Joins("JOIN order_items ON order_items.order_id = orders.id").
+		Joins("LEFT JOIN products ON products.id = order_items.product_id").
+		Joins("LEFT JOIN categories ON categories.id = products.category_id").
+		Group("COALESCE(products.category_id, 0)").
+		Order("revenue - cost DESC, `key`").
+		Find(&margins).Error
+	return margins, err
+}
+
+// InventoryValuation - Sum up the inventory ledger before at into the stock of every
+// product and variant and its value at cost
+func (repository *ReportRepositoryImpl) InventoryValuation(ctx context.Context, at time.Time) ([]domain.InventoryValue, error) {
+	var values []domain.InventoryValue
+	err := dbFromContext(ctx, repository.db).
+		Model(&domain.InventoryMovement{}).
+		Select("inventory_movements.product_id AS product_id, COALESCE(MAX(products.product_name), '') AS product_name, inventory_movements.variant_id AS variant_id, SUM(inventory_movements.quantity) AS quantity, SUM(inventory_movements.total_cost) AS value").
+		Joins("LEFT JOIN products ON products.id = inventory_movements.product_id").
+		Where("inventory_movements.created_at < ?", at).
+		Group("inventory_movements.product_id, inventory_movements.variant_id").
+		Having("SUM(inventory_movements.quantity) <> 0 OR SUM(inventory_movements.total_cost) <> 0").
+		Order("product_id, variant_id").
+		Find(&values).Error
+	return values, err
+}
+
+// marginColumns sums up order items into a MarginTotal, taking the tax out of the revenue.
+const marginColumns = "SUM(order_items.quantity) AS quantity, SUM(order_items.total_price - order_items.tax_amount) AS revenue, SUM(order_items.cost_amount) AS cost"
+
+func (repository *ReportRepositoryImpl) productSales(ctx context.Context, from time.Time, to time.Time) *gorm.DB {
+	return repository.orders(ctx, from, to).
+		Select("order_items.product_id AS product_id, COALESCE(MAX(products.product_name), '') AS product_name, SUM(order_items.quantity) AS quantity, SUM(order_items.total_price) AS revenue, SUM(order_items.regular_price * order_items.quantity) AS regular_revenue, SUM(order_items.discount_amount) AS discount_amount").
+		Joins("JOIN order_items ON order_items.order_id = orders.id").
+		Joins("LEFT JOIN products ON products.id = order_items.product_id").
+		Group("order_items.product_id")
+}
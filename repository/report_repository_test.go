@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestReportRepositoryStaysInStore(t *testing.T) {
+	db := newDryRunDB(t)
+	var statements []string
+	assert.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+
+	repo := NewReportRepository(db)
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "7")
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	_, err := repo.TaxTotals(storeCtx, from, to)
+	assert.NoError(t, err)
+	_, err = repo.ProductSales(storeCtx, from, to, "quantity", 10)
+	assert.NoError(t, err)
+	_, err = repo.ProductSales(storeCtx, from, to, "price; DROP TABLE orders", 10)
+	assert.Error(t, err)
+
+	assert.Len(t, statements, 2)
+	for _, statement := range statements {
+		assert.Contains(t, statement, "`orders`.`store_id` = ?")
+		assert.Contains(t, statement, "JOIN order_items ON order_items.order_id = orders.id")
+	}
+	assert.Contains(t, statements[1], "ORDER BY quantity DESC, product_id LIMIT ?")
+}
+
+func TestInventoryValuationStaysInStore(t *testing.T) {
+	db := newDryRunDB(t)
+	var statement string
+	assert.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+	}))
+
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "7")
+	_, err := NewReportRepository(db).InventoryValuation(storeCtx, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Contains(t, statement, "`inventory_movements`.`store_id` = ?")
+	assert.Contains(t, statement, "inventory_movements.created_at < ?")
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type ShiftRepository interface {
+	Save(ctx context.Context, shift domain.Shift) (domain.Shift, error)
+	Update(ctx context.Context, shift domain.Shift) (domain.Shift, error)
+	FindById(ctx context.Context, shiftId uint64) (domain.Shift, error)
+	FindOpenByEmployeeId(ctx context.Context, employeeId string) (domain.Shift, error)
+	FindAll(ctx context.Context, employeeId string, status string) ([]domain.Shift, error)
+	SaveCashMovement(ctx context.Context, movement domain.CashMovement) (domain.CashMovement, error)
+	FindCashMovements(ctx context.Context, shiftId uint64) ([]domain.CashMovement, error)
+	CashTotals(ctx context.Context, shiftId uint64) (domain.ShiftCashTotals, error)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type ShiftRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewShiftRepository(db *gorm.DB) ShiftRepository {
+	return &ShiftRepositoryImpl{db: db}
+}
+
+// Save shift
+func (repository *ShiftRepositoryImpl) Save(ctx context.Context, shift domain.Shift) (domain.Shift, error) {
+	shift.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&shift).Error; err != nil {
+		return domain.Shift{}, err
+	}
+	return shift, nil
+}
+
+// Update shift
+func (repository *ShiftRepositoryImpl) Update(ctx context.Context, shift domain.Shift) (domain.Shift, error) {
+	expectedVersion := shift.Version
+	shift.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&shift).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Updates(&shift)
+	if result.Error != nil {
+		return domain.Shift{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.Shift{}, ErrVersionConflict
+	}
+	return shift, nil
+}
+
+// FindById - Get shift by ID
+func (repository *ShiftRepositoryImpl) FindById(ctx context.Context, shiftId uint64) (domain.Shift, error) {
+	var shift domain.Shift
+	err := dbFromContext(ctx, repository.db).Take(&shift, "id = ?", shiftId).Error
+	return shift, err
+}
+
+// FindOpenByEmployeeId - Get the open shift of an employee
+func (repository *ShiftRepositoryImpl) FindOpenByEmployeeId(ctx context.Context, employeeId string) (domain.Shift, error) {
+	var shift domain.Shift
+	err := dbFromContext(ctx, repository.db).Take(&shift, "employee_id = ? AND status = ?", employeeId, domain.ShiftStatusOpen).Error
+	return shift, err
+}
+
+// FindAll - Get shifts, newest first, optionally only those of an employee or with a status
+func (repository *ShiftRepositoryImpl) FindAll(ctx context.Context, employeeId string, status string) ([]domain.Shift, error) {
+	query := dbFromContext(ctx, repository.db).Order("opened_at DESC, id DESC")
+	if employeeId != "" {
+		query = query.Where("employee_id = ?", employeeId)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var shifts []domain.Shift
+	return shifts, query.Find(&shifts).Error
+}
+
+// SaveCashMovement - Record cash put into or taken out of the till
+func (repository *ShiftRepositoryImpl) SaveCashMovement(ctx context.Context, movement domain.CashMovement) (domain.CashMovement, error) {
+	if err := dbFromContext(ctx, repository.db).Create(&movement).Error; err != nil {
+		return domain.CashMovement{}, err
+	}
+	return movement, nil
+}
+
+// FindCashMovements - Get the cash movements of a shift in the order they happened
+func (repository *ShiftRepositoryImpl) FindCashMovements(ctx context.Context, shiftId uint64) ([]domain.CashMovement, error) {
+	var movements []domain.CashMovement
+	return movements, dbFromContext(ctx, repository.db).Where("shift_id = ?", shiftId).Order("created_at, id").Find(&movements).Error
+}
+
+// CashTotals - Sum the completed cash payments and the cash movements of a shift
+func (repository *ShiftRepositoryImpl) CashTotals(ctx context.Context, shiftId uint64) (domain.ShiftCashTotals, error) {
+	var totals domain.ShiftCashTotals
+	db := dbFromContext(ctx, repository.db)
+
+	err := db.Model(&domain.Payment{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("shift_id = ? AND payment_type = ? AND status = ?", shiftId, domain.PaymentTypeCash, domain.PaymentStatusCompleted).
+		Scan(&totals.CashSales).Error
+	if err != nil {
+		return domain.ShiftCashTotals{}, err
+	}
+
+	err = db.Model(&domain.CashMovement{}).
+		Select("COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE 0 END), 0) AS cash_in, COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE 0 END), 0) AS cash_out",
+			domain.CashMovementIn, domain.CashMovementOut).
+		Where("shift_id = ?", shiftId).
+		Row().Scan(&totals.CashIn, &totals.CashOut)
+	if err != nil {
+		return domain.ShiftCashTotals{}, err
+	}
+	return totals, nil
+}
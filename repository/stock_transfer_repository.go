@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type StockTransferRepository interface {
+	Save(ctx context.Context, transfer domain.StockTransfer) (domain.StockTransfer, error)
+	Update(ctx context.Context, transfer domain.StockTransfer) (domain.StockTransfer, error)
+	FindById(ctx context.Context, transferId uint64) (domain.StockTransfer, error)
+	FindAll(ctx context.Context, storeId uint64, status string) ([]domain.StockTransfer, error)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockTransferRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewStockTransferRepository(db *gorm.DB) StockTransferRepository {
+	return &StockTransferRepositoryImpl{db: db}
+}
+
+// Save stock transfer together with its items
+func (repository *StockTransferRepositoryImpl) Save(ctx context.Context, transfer domain.StockTransfer) (domain.StockTransfer, error) {
+	transfer.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&transfer).Error; err != nil {
+		return domain.StockTransfer{}, err
+	}
+	return transfer, nil
+}
+
+// Update stock transfer
+func (repository *StockTransferRepositoryImpl) Update(ctx context.Context, transfer domain.StockTransfer) (domain.StockTransfer, error) {
+	expectedVersion := transfer.Version
+	transfer.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&transfer).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(&transfer)
+	if result.Error != nil {
+		return domain.StockTransfer{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.StockTransfer{}, ErrVersionConflict
+	}
+	return transfer, nil
+}
+
+// FindById - Get stock transfer by ID
+func (repository *StockTransferRepositoryImpl) FindById(ctx context.Context, transferId uint64) (domain.StockTransfer, error) {
+	var transfer domain.StockTransfer
+	err := dbFromContext(ctx, repository.db).Preload("Items").Take(&transfer, "id = ?", transferId).Error
+	return transfer, err
+}
+
+// FindAll - Get the transfers from or to a store, newest first, optionally only those with a status
+func (repository *StockTransferRepositoryImpl) FindAll(ctx context.Context, storeId uint64, status string) ([]domain.StockTransfer, error) {
+	query := dbFromContext(ctx, repository.db).
+		Preload("Items").
+		Where("from_store_id = ? OR to_store_id = ?", storeId, storeId).
+		Order("shipped_at DESC, id DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var transfers []domain.StockTransfer
+	return transfers, query.Find(&transfers).Error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type StocktakeRepository interface {
+	Save(ctx context.Context, stocktake domain.Stocktake) (domain.Stocktake, error)
+	Update(ctx context.Context, stocktake domain.Stocktake) (domain.Stocktake, error)
+	SaveLines(ctx context.Context, lines []domain.StocktakeLine) ([]domain.StocktakeLine, error)
+	FindById(ctx context.Context, stocktakeId uint64) (domain.Stocktake, error)
+	FindAll(ctx context.Context, status string) ([]domain.Stocktake, error)
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StocktakeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewStocktakeRepository(db *gorm.DB) StocktakeRepository {
+	return &StocktakeRepositoryImpl{db: db}
+}
+
+// Save stocktake together with its lines
+func (repository *StocktakeRepositoryImpl) Save(ctx context.Context, stocktake domain.Stocktake) (domain.Stocktake, error) {
+	stocktake.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&stocktake).Error; err != nil {
+		return domain.Stocktake{}, err
+	}
+	return stocktake, nil
+}
+
+// Update stocktake, without its lines
+func (repository *StocktakeRepositoryImpl) Update(ctx context.Context, stocktake domain.Stocktake) (domain.Stocktake, error) {
+	expectedVersion := stocktake.Version
+	stocktake.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&stocktake).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(&stocktake)
+	if result.Error != nil {
+		return domain.Stocktake{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.Stocktake{}, ErrVersionConflict
+	}
+	return stocktake, nil
+}
+
+// SaveLines - Add new lines to a stocktake and update the counts of existing ones
+func (repository *StocktakeRepositoryImpl) SaveLines(ctx context.Context, lines []domain.StocktakeLine) ([]domain.StocktakeLine, error) {
+	if len(lines) == 0 {
+		return lines, nil
+	}
+	return lines, dbFromContext(ctx, repository.db).Save(&lines).Error
+}
+
+// FindById - Get stocktake by ID
+func (repository *StocktakeRepositoryImpl) FindById(ctx context.Context, stocktakeId uint64) (domain.Stocktake, error) {
+	var stocktake domain.Stocktake
+	err := dbFromContext(ctx, repository.db).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("product_id, variant_id")
+		}).
+		Take(&stocktake, "id = ?", stocktakeId).Error
+	return stocktake, err
+}
+
+// FindAll - Get the stocktakes, newest first, optionally only those with a status
+func (repository *StocktakeRepositoryImpl) FindAll(ctx context.Context, status string) ([]domain.Stocktake, error) {
+	query := dbFromContext(ctx, repository.db).Order("created_at DESC, id DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var stocktakes []domain.Stocktake
+	return stocktakes, query.Find(&stocktakes).Error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type StoreRepository interface {
+	Save(ctx context.Context, store domain.Store) (domain.Store, error)
+	Update(ctx context.Context, store domain.Store) (domain.Store, error)
+	Delete(ctx context.Context, store domain.Store) error
+	FindById(ctx context.Context, storeId uint64) (domain.Store, error)
+	FindAll(ctx context.Context) ([]domain.Store, error)
+	IsInUse(ctx context.Context, storeId uint64) (bool, error)
+}
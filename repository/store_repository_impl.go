@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type StoreRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewStoreRepository(db *gorm.DB) StoreRepository {
+	return &StoreRepositoryImpl{db: db}
+}
+
+// Save store
+func (repository *StoreRepositoryImpl) Save(ctx context.Context, store domain.Store) (domain.Store, error) {
+	store.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&store).Error; err != nil {
+		return domain.Store{}, err
+	}
+	return store, nil
+}
+
+// Update store
+func (repository *StoreRepositoryImpl) Update(ctx context.Context, store domain.Store) (domain.Store, error) {
+	expectedVersion := store.Version
+	store.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&store).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Updates(&store)
+	if result.Error != nil {
+		return domain.Store{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.Store{}, ErrVersionConflict
+	}
+	return store, nil
+}
+
+// Delete store
+func (repository *StoreRepositoryImpl) Delete(ctx context.Context, store domain.Store) error {
+	result := dbFromContext(ctx, repository.db).Where("version = ?", store.Version).Delete(&store)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// FindById - Get store by ID
+func (repository *StoreRepositoryImpl) FindById(ctx context.Context, storeId uint64) (domain.Store, error) {
+	var store domain.Store
+	err := dbFromContext(ctx, repository.db).Take(&store, "id = ?", storeId).Error
+	return store, err
+}
+
+// FindAll stores
+func (repository *StoreRepositoryImpl) FindAll(ctx context.Context) ([]domain.Store, error) {
+	var stores []domain.Store
+	return stores, dbFromContext(ctx, repository.db).Order("code").Find(&stores).Error
+}
+
+// IsInUse - Check whether employees, shifts, orders, stock or transfers still refer to a store
+func (repository *StoreRepositoryImpl) IsInUse(ctx context.Context, storeId uint64) (bool, error) {
+	db := dbFromContext(WithoutStoreScope(ctx), repository.db)
+	checks := []*gorm.DB{
+		db.Model(&domain.Employee{}).Where("store_id = ?", storeId),
+		db.Model(&domain.Shift{}).Where("store_id = ?", storeId),
+		db.Model(&domain.Order{}).Where("store_id = ?", storeId),
+		db.Model(&domain.StoreStock{}).Where("store_id = ? AND quantity <> 0", storeId),
+		db.Model(&domain.StockTransfer{}).Where("(from_store_id = ? OR to_store_id = ?) AND status = ?", storeId, storeId, domain.StockTransferStatusInTransit),
+	}
+	for _, check := range checks {
+		var count int64
+		if err := check.Limit(1).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+)
+
+const storeColumn = "store_id"
+
+type allStoresKey struct{}
+
+// WithoutStoreScope lifts the store scope for work that has to see every store, such as
+// resolving the store of an employee or running background jobs.
+func WithoutStoreScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allStoresKey{}, true)
+}
+
+func isWithoutStoreScope(ctx context.Context) bool {
+	unscoped, _ := ctx.Value(allStoresKey{}).(bool)
+	return unscoped
+}
+
+// RegisterStoreScopeCallbacks hooks into GORM so that every model with a store_id column
+// is confined to the store of the request: queries, updates and deletes get a store_id
+// condition and created rows are stamped with the store. A statement on such a model
+// fails with ErrStoreRequired when the context carries no store.
+func RegisterStoreScopeCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Query().Before("gorm:query").Register("store:query", storeScopeWhere); err != nil {
+		return err
+	}
+	if err := callback.Row().Before("gorm:row").Register("store:row", storeScopeWhere); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("store:update", storeScopeWhere); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("store:delete", storeScopeWhere); err != nil {
+		return err
+	}
+	return callback.Create().Before("gorm:create").Register("store:create", storeScopeCreate)
+}
+
+// storeField returns the store_id field of the statement's model, or nil when the model
+// is not store-scoped.
+func storeField(db *gorm.DB) *schema.Field {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil
+	}
+	return db.Statement.Schema.LookUpField(storeColumn)
+}
+
+func storeScopeWhere(db *gorm.DB) {
+	if storeField(db) == nil || isWithoutStoreScope(db.Statement.Context) {
+		return
+	}
+	storeId, ok := helper.StoreIdFromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrStoreRequired)
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: storeColumn}, Value: storeId},
+	}})
+}
+
+func storeScopeCreate(db *gorm.DB) {
+	field := storeField(db)
+	if field == nil || isWithoutStoreScope(db.Statement.Context) {
+		return
+	}
+	storeId, ok := helper.StoreIdFromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrStoreRequired)
+		return
+	}
+
+	stamp := func(row reflect.Value) {
+		value, isZero := field.ValueOf(db.Statement.Context, row)
+		if isZero {
+			if err := field.Set(db.Statement.Context, row, storeId); err != nil {
+				_ = db.AddError(err)
+			}
+		} else if value != storeId {
+			_ = db.AddError(ErrStoreMismatch)
+		}
+	}
+
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			stamp(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		stamp(value)
+	}
+}
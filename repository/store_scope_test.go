@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// newDryRunDB returns a GORM handle that builds statements without a database server.
+func newDryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:password@tcp(127.0.0.1:3306)/test",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	require.NoError(t, RegisterStoreScopeCallbacks(db))
+	return db
+}
+
+func TestStoreScopeCallbacks(t *testing.T) {
+	db := newDryRunDB(t)
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "7")
+
+	t.Run("queries of store-scoped models are filtered by store", func(t *testing.T) {
+		var orders []domain.Order
+		statement := db.WithContext(storeCtx).Where("customer_id = ?", "c-1").Find(&orders).Statement
+		assert.NoError(t, statement.Error)
+		assert.Contains(t, statement.SQL.String(), "`orders`.`store_id` = ?")
+		assert.Contains(t, statement.Vars, uint64(7))
+	})
+
+	t.Run("updates and deletes are filtered by store", func(t *testing.T) {
+		shift := domain.Shift{Id: 3, StoreID: 7}
+		statement := db.WithContext(storeCtx).Model(&shift).Update("note", "x").Statement
+		assert.Contains(t, statement.SQL.String(), "`shifts`.`store_id` = ?")
+
+		employee := domain.Employee{EmployeeID: "e-1"}
+		statement = db.WithContext(storeCtx).Delete(&employee).Statement
+		assert.Contains(t, statement.SQL.String(), "`employees`.`store_id` = ?")
+	})
+
+	t.Run("created rows are stamped with the store", func(t *testing.T) {
+		employees := []domain.Employee{{EmployeeID: "e-1"}, {EmployeeID: "e-2"}}
+		assert.NoError(t, db.WithContext(storeCtx).Create(&employees).Error)
+		assert.Equal(t, uint64(7), employees[0].StoreID)
+		assert.Equal(t, uint64(7), employees[1].StoreID)
+	})
+
+	t.Run("creating a row for another store fails", func(t *testing.T) {
+		employee := domain.Employee{EmployeeID: "e-1", StoreID: 8}
+		assert.ErrorIs(t, db.WithContext(storeCtx).Create(&employee).Error, ErrStoreMismatch)
+	})
+
+	t.Run("store-scoped models require a store", func(t *testing.T) {
+		var orders []domain.Order
+		assert.ErrorIs(t, db.WithContext(context.Background()).Find(&orders).Error, ErrStoreRequired)
+	})
+
+	t.Run("other models are not scoped", func(t *testing.T) {
+		var products []domain.Product
+		statement := db.WithContext(context.Background()).Find(&products).Statement
+		assert.NoError(t, statement.Error)
+		assert.NotContains(t, statement.SQL.String(), "store_id")
+	})
+
+	t.Run("the scope can be lifted", func(t *testing.T) {
+		var employees []domain.Employee
+		statement := db.WithContext(WithoutStoreScope(context.Background())).Find(&employees).Statement
+		assert.NoError(t, statement.Error)
+		assert.NotContains(t, statement.SQL.String(), "store_id")
+	})
+}
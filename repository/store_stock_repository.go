@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type StoreStockRepository interface {
+	Find(ctx context.Context, productId string, variantId uint64) (domain.StoreStock, error)
+	FindAll(ctx context.Context, productId string) ([]domain.StoreStock, error)
+	Adjust(ctx context.Context, productId string, variantId uint64, delta int) error
+	AdjustAllowingNegative(ctx context.Context, productId string, variantId uint64, delta int) error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StoreStockRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewStoreStockRepository(db *gorm.DB) StoreStockRepository {
+	return &StoreStockRepositoryImpl{db: db}
+}
+
+// Find - Get the stock of a product or variant at the store, locking the row for the
+// rest of the transaction
+func (repository *StoreStockRepositoryImpl) Find(ctx context.Context, productId string, variantId uint64) (domain.StoreStock, error) {
+	var stock domain.StoreStock
+	err := dbFromContext(ctx, repository.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&stock, "product_id = ? AND variant_id = ?", productId, variantId).Error
+	return stock, err
+}
+
+// FindAll - Get the stock levels of the store, optionally only those of a product
+func (repository *StoreStockRepositoryImpl) FindAll(ctx context.Context, productId string) ([]domain.StoreStock, error) {
+	query := dbFromContext(ctx, repository.db).Order("product_id, variant_id")
+	if productId != "" {
+		query = query.Where("product_id = ?", productId)
+	}
+	var stocks []domain.StoreStock
+	return stocks, query.Find(&stocks).Error
+}
+
+// Adjust - Add delta to the stock of a product or variant at the store. Taking stock the
+// store does not have fails with ErrInsufficientStock.
+func (repository *StoreStockRepositoryImpl) Adjust(ctx context.Context, productId string, variantId uint64, delta int) error {
+	db := dbFromContext(ctx, repository.db)
+	if delta > 0 {
+		stock := domain.StoreStock{ProductID: productId, VariantID: variantId, Quantity: delta}
+		return db.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{"quantity": gorm.Expr("quantity + ?", delta)}),
+		}).Create(&stock).Error
+	}
+
+	result := db.Model(&domain.StoreStock{}).
+		Where("product_id = ? AND variant_id = ? AND quantity + ? >= 0", productId, variantId, delta).
+		Update("quantity", gorm.Expr("quantity + ?", delta))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsufficientStock
+	}
+	return nil
+}
+
+// AdjustAllowingNegative - Add delta to the stock of the store, also when that takes it below zero
+func (repository *StoreStockRepositoryImpl) AdjustAllowingNegative(ctx context.Context, productId string, variantId uint64, delta int) error {
+	stock := domain.StoreStock{ProductID: productId, VariantID: variantId, Quantity: delta}
+	return dbFromContext(ctx, repository.db).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{"quantity": gorm.Expr("quantity + ?", delta)}),
+	}).Create(&stock).Error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type SupplierRepository interface {
+	Save(ctx context.Context, supplier domain.Supplier) (domain.Supplier, error)
+	Update(ctx context.Context, supplier domain.Supplier) (domain.Supplier, error)
+	Delete(ctx context.Context, supplier domain.Supplier) error
+	FindById(ctx context.Context, supplierId uint64) (domain.Supplier, error)
+	FindAll(ctx context.Context) ([]domain.Supplier, error)
+	IsInUse(ctx context.Context, supplierId uint64) (bool, error)
+}
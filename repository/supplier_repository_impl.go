@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type SupplierRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSupplierRepository(db *gorm.DB) SupplierRepository {
+	return &SupplierRepositoryImpl{db: db}
+}
+
+// Save supplier
+func (repository *SupplierRepositoryImpl) Save(ctx context.Context, supplier domain.Supplier) (domain.Supplier, error) {
+	supplier.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&supplier).Error; err != nil {
+		return domain.Supplier{}, err
+	}
+	return supplier, nil
+}
+
+// Update supplier
+func (repository *SupplierRepositoryImpl) Update(ctx context.Context, supplier domain.Supplier) (domain.Supplier, error) {
+	expectedVersion := supplier.Version
+	supplier.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&supplier).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Updates(&supplier)
+	if result.Error != nil {
+		return domain.Supplier{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.Supplier{}, ErrVersionConflict
+	}
+	return supplier, nil
+}
+
+// Delete supplier
+func (repository *SupplierRepositoryImpl) Delete(ctx context.Context, supplier domain.Supplier) error {
+	result := dbFromContext(ctx, repository.db).Where("version = ?", supplier.Version).Delete(&supplier)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// FindById - Get supplier by ID
+func (repository *SupplierRepositoryImpl) FindById(ctx context.Context, supplierId uint64) (domain.Supplier, error) {
+	var supplier domain.Supplier
+	err := dbFromContext(ctx, repository.db).Take(&supplier, "id = ?", supplierId).Error
+	return supplier, err
+}
+
+// FindAll suppliers
+func (repository *SupplierRepositoryImpl) FindAll(ctx context.Context) ([]domain.Supplier, error) {
+	var suppliers []domain.Supplier
+	return suppliers, dbFromContext(ctx, repository.db).Order("code").Find(&suppliers).Error
+}
+
+// IsInUse - Check whether products or purchase orders of any store still refer to a supplier
+func (repository *SupplierRepositoryImpl) IsInUse(ctx context.Context, supplierId uint64) (bool, error) {
+	db := dbFromContext(WithoutStoreScope(ctx), repository.db)
+	checks := []*gorm.DB{
+		db.Model(&domain.Product{}).Where("preferred_supplier_id = ?", supplierId),
+		db.Model(&domain.PurchaseOrder{}).Where("supplier_id = ?", supplierId),
+	}
+	for _, check := range checks {
+		var count int64
+		if err := check.Limit(1).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type TaxRepository interface {
+	FindAllById(ctx context.Context, taxIds []string) ([]domain.Tax, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type TaxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTaxRepository(db *gorm.DB) TaxRepository {
+	return &TaxRepositoryImpl{db: db}
+}
+
+// FindAllById - Get the taxes with the given ids
+func (repository *TaxRepositoryImpl) FindAllById(ctx context.Context, taxIds []string) ([]domain.Tax, error) {
+	var taxes []domain.Tax
+	if len(taxIds) == 0 {
+		return taxes, nil
+	}
+	return taxes, dbFromContext(ctx, repository.db).Where("id IN ?", taxIds).Find(&taxes).Error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+)
+
+type TransactionManager interface {
+	// Transaction runs fn in a database transaction. Repositories called with the ctx
+	// passed to fn take part in that transaction; it commits when fn returns nil.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+type transactionKey struct{}
+
+type TransactionManagerImpl struct {
+	db *gorm.DB
+}
+
+func NewTransactionManager(db *gorm.DB) TransactionManager {
+	return &TransactionManagerImpl{db: db}
+}
+
+func (manager *TransactionManagerImpl) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return dbFromContext(ctx, manager.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, transactionKey{}, tx))
+	})
+}
+
+// dbFromContext returns the transaction started by TransactionManager when ctx carries
+// one, and db otherwise, bound to ctx either way.
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// mysqlDuplicateEntry is the MySQL error number of an insert or update that breaks a
+// unique index.
+const mysqlDuplicateEntry = 1062
+
+// duplicateKeyPattern finds the index in "Duplicate entry 'x' for key 'table.index'";
+// MySQL before 8.0.19 leaves out the table.
+var duplicateKeyPattern = regexp.MustCompile(`for key '(?:[^'.]+\.)?([^']+)'`)
+
+func init() {
+	schema.RegisterSerializer("emptynull", emptyNullSerializer{})
+}
+
+// emptyNullSerializer stores an empty string as NULL and reads NULL back as an empty
+// string. It is used on optional columns with a unique index, which allows any number of
+// NULLs but only one empty string.
+type emptyNullSerializer struct{}
+
+func (emptyNullSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch dbValue := dbValue.(type) {
+	case nil:
+	case []byte:
+		value = string(dbValue)
+	case string:
+		value = dbValue
+	default:
+		return fmt.Errorf("cannot read %T into %s", dbValue, field.Name)
+	}
+	return field.Set(ctx, dst, value)
+}
+
+func (emptyNullSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	if value, _ := fieldValue.(string); value != "" {
+		return value, nil
+	}
+	return nil, nil
+}
+
+// RegisterUniqueConstraintCallbacks hooks into GORM so that an insert or update breaking
+// a unique index fails with an exception.ConflictError naming the field, instead of the
+// MySQL error.
+func RegisterUniqueConstraintCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().After("gorm:create").Register("unique:create", translateDuplicateKey); err != nil {
+		return err
+	}
+	return callback.Update().After("gorm:update").Register("unique:update", translateDuplicateKey)
+}
+
+// emptyNullColumns are the columns with the emptynull serializer, by table.
+var emptyNullColumns = map[string][]string{
+	"products":  {"product_sku"},
+	"customers": {"customer_email", "customer_phone"},
+	"employees": {"email", "phone", "api_key_hash"},
+}
+
+// PrepareUniqueIndexes clears the empty values of the columns stored with the emptynull
+// serializer to NULL, which their unique indexes require. It runs before the migration
+// that creates the indexes, and fails without creating any when a column holds the same
+// value more than once, listing the values to resolve first.
+func PrepareUniqueIndexes(db *gorm.DB) error {
+	tables := make([]string, 0, len(emptyNullColumns))
+	for table := range emptyNullColumns {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	var duplicates []string
+	for _, table := range tables {
+		if !db.Migrator().HasTable(table) {
+			continue
+		}
+		for _, column := range emptyNullColumns[table] {
+			if !db.Migrator().HasColumn(table, column) {
+				continue
+			}
+			if err := db.Exec(fmt.Sprintf("UPDATE %s SET %s = NULL WHERE %s = ''", table, column, column)).Error; err != nil {
+				return err
+			}
+
+			var values []struct {
+				Value string
+				Count int
+			}
+			err := db.Table(table).
+				Select(column + " AS value, COUNT(*) AS count").
+				Where(column + " IS NOT NULL").
+				Group(column).
+				Having("COUNT(*) > 1").
+				Order(column).
+				Scan(&values).Error
+			if err != nil {
+				return err
+			}
+			for _, value := range values {
+				duplicates = append(duplicates, fmt.Sprintf("%s.%s '%s' (%d rows)", table, column, value.Value, value.Count))
+			}
+		}
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("values used more than once where they must be unique, resolve them before starting: %s", strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+func translateDuplicateKey(db *gorm.DB) {
+	if conflict := duplicateKeyConflict(db.Statement.Schema, db.Error); conflict != nil {
+		db.Error = conflict
+	}
+}
+
+// isDuplicatePrimaryKey reports whether err is a duplicate key error on the primary key,
+// which duplicateKeyConflict leaves alone.
+func isDuplicatePrimaryKey(err error) bool {
+	var mysqlError *mysql.MySQLError
+	if !errors.As(err, &mysqlError) || mysqlError.Number != mysqlDuplicateEntry {
+		return false
+	}
+	match := duplicateKeyPattern.FindStringSubmatch(mysqlError.Message)
+	return match != nil && match[1] == "PRIMARY"
+}
+
+// duplicateKeyConflict returns the ConflictError of a duplicate key error on a unique
+// index of the model, nil for any other error. The field is the index column in the
+// naming of the API, e.g. "email" for Customer.Email.
+func duplicateKeyConflict(model *schema.Schema, err error) error {
+	var mysqlError *mysql.MySQLError
+	if model == nil || !errors.As(err, &mysqlError) || mysqlError.Number != mysqlDuplicateEntry {
+		return nil
+	}
+	match := duplicateKeyPattern.FindStringSubmatch(mysqlError.Message)
+	if match == nil {
+		return nil
+	}
+
+	for _, index := range model.ParseIndexes() {
+		if index.Name != match[1] {
+			continue
+		}
+		var fields []string
+		for _, indexField := range index.Fields {
+			fields = append(fields, schema.NamingStrategy{}.ColumnName("", indexField.Name))
+		}
+		field := strings.Join(fields, ",")
+		return exception.NewFieldConflictError(field, fmt.Sprintf("%s is already used by another %s", field, strings.ToLower(model.Name)))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+func TestDuplicateKeyConflict(t *testing.T) {
+	customerSchema, err := schema.Parse(&domain.Customer{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	t.Run("duplicate entry names the field", func(t *testing.T) {
+		err := duplicateKeyConflict(customerSchema, &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@b.c' for key 'customers.uq_customer_email'"})
+		var conflict exception.ConflictError
+		require.True(t, errors.As(err, &conflict))
+		assert.Equal(t, "email", conflict.Field)
+	})
+
+	t.Run("index without table prefix", func(t *testing.T) {
+		err := duplicateKeyConflict(customerSchema, &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '0812' for key 'uq_customer_phone'"})
+		var conflict exception.ConflictError
+		require.True(t, errors.As(err, &conflict))
+		assert.Equal(t, "phone", conflict.Field)
+	})
+
+	t.Run("other errors are left alone", func(t *testing.T) {
+		assert.Nil(t, duplicateKeyConflict(customerSchema, &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"}))
+		assert.Nil(t, duplicateKeyConflict(customerSchema, errors.New("connection refused")))
+		assert.Nil(t, duplicateKeyConflict(customerSchema, &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"}))
+	})
+}
+
+func TestIsDuplicatePrimaryKey(t *testing.T) {
+	assert.True(t, isDuplicatePrimaryKey(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'o-1' for key 'orders.PRIMARY'"}))
+	assert.True(t, isDuplicatePrimaryKey(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'o-1' for key 'PRIMARY'"}))
+	assert.False(t, isDuplicatePrimaryKey(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@b.c' for key 'customers.uq_customer_email'"}))
+	assert.False(t, isDuplicatePrimaryKey(errors.New("connection refused")))
+	assert.False(t, isDuplicatePrimaryKey(nil))
+}
+
+func TestEmptySkuIsStoredAsNull(t *testing.T) {
+	db := newDryRunDB(t)
+
+	// skuValue returns the bound value of the SKU, the only serialized column of a product.
+	skuValue := func(vars []interface{}) driver.Value {
+		for _, v := range vars {
+			if valuer, ok := v.(driver.Valuer); ok {
+				value, err := valuer.Value()
+				require.NoError(t, err)
+				return value
+			}
+		}
+		t.Fatal("no serialized value bound")
+		return nil
+	}
+
+	product := domain.Product{ProductID: "p-1", Name: "Tea"}
+	statement := db.Create(&product).Statement
+	require.NoError(t, statement.Error)
+	assert.Nil(t, skuValue(statement.Vars))
+
+	product = domain.Product{ProductID: "p-2", Name: "Coffee", SKU: "CF-1"}
+	statement = db.Create(&product).Statement
+	require.NoError(t, statement.Error)
+	assert.Equal(t, "CF-1", skuValue(statement.Vars))
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// variantBarcodeColumn is the column variants kept their barcode in before all barcodes
+// moved to the product_barcodes table.
+const variantBarcodeColumn = "barcode"
+
+// MigrateVariantBarcodes moves the barcodes still kept on product_variants into
+// product_barcodes and drops the old column, so a code lives in one table only. It fails
+// without changing anything when a code is already used by another product or variant,
+// listing the codes to resolve first. It runs after the migration of product_barcodes,
+// and runs again harmlessly when the column could not be dropped.
+func MigrateVariantBarcodes(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&domain.ProductVariant{}, variantBarcodeColumn) {
+		return nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var variants []struct {
+			Id        uint64
+			ProductID string
+			Barcode   string
+		}
+		err := tx.Table("product_variants").
+			Select("id, product_id, barcode").
+			Where("barcode IS NOT NULL AND barcode <> ''").
+			Order("id").
+			Scan(&variants).Error
+		if err != nil {
+			return err
+		}
+
+		owners := map[string]string{}
+		var codes []string
+		for _, variant := range variants {
+			codes = append(codes, variant.Barcode)
+		}
+		if len(codes) > 0 {
+			var barcodes []domain.ProductBarcode
+			if err := tx.Where("code IN ?", codes).Find(&barcodes).Error; err != nil {
+				return err
+			}
+			for _, barcode := range barcodes {
+				owners[barcode.Code] = barcodeOwner(barcode.ProductID, barcode.VariantID)
+			}
+		}
+
+		var conflicts []string
+		var barcodes []domain.ProductBarcode
+		now := time.Now()
+		for _, variant := range variants {
+			variantId := variant.Id
+			owner := barcodeOwner(variant.ProductID, &variantId)
+			if existing, ok := owners[variant.Barcode]; ok {
+				if existing != owner {
+					conflicts = append(conflicts, fmt.Sprintf("%s (%s and %s)", variant.Barcode, existing, owner))
+				}
+				continue
+			}
+			owners[variant.Barcode] = owner
+			barcodes = append(barcodes, domain.ProductBarcode{
+				ProductID: variant.ProductID,
+				VariantID: &variantId,
+				Code:      variant.Barcode,
+				Type:      variantBarcodeType(variant.Barcode),
+				CreatedAt: now,
+				UpdatedAt: now,
+			})
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("variant barcodes already used elsewhere, resolve them before starting: %s", strings.Join(conflicts, ", "))
+		}
+
+		if len(barcodes) == 0 {
+			return nil
+		}
+		return tx.Create(&barcodes).Error
+	})
+	if err != nil {
+		return err
+	}
+	return db.Migrator().DropColumn(&domain.ProductVariant{}, variantBarcodeColumn)
+}
+
+func barcodeOwner(productId string, variantId *uint64) string {
+	if variantId == nil {
+		return "product " + productId
+	}
+	return fmt.Sprintf("variant %d of product %s", *variantId, productId)
+}
+
+// variantBarcodeType returns the type of a code stored on a variant, which was always
+// normalised to EAN-8 or EAN-13.
+func variantBarcodeType(code string) string {
+	if len(code) == 8 {
+		return helper.BarcodeTypeEAN8
+	}
+	return helper.BarcodeTypeEAN13
+}
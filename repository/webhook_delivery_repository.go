@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"time"
+)
+
+type WebhookDeliveryRepository interface {
+	Save(ctx context.Context, delivery domain.WebhookDelivery) error
+	FindById(ctx context.Context, deliveryId uint64) (domain.WebhookDelivery, error)
+	FindAll(ctx context.Context, subscriptionId uint64, status string, limit int) ([]domain.WebhookDelivery, error)
+	FindAllByEventIds(ctx context.Context, eventIds []uint64) ([]domain.WebhookDelivery, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error)
+	Claim(ctx context.Context, delivery domain.WebhookDelivery, until time.Time) (bool, error)
+	UpdateAttempt(ctx context.Context, delivery domain.WebhookDelivery) error
+	UpdatePayload(ctx context.Context, delivery domain.WebhookDelivery) error
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type WebhookDeliveryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &WebhookDeliveryRepositoryImpl{db: db}
+}
+
+// Save webhook delivery, unless the subscription already has one for the event
+func (repository *WebhookDeliveryRepositoryImpl) Save(ctx context.Context, delivery domain.WebhookDelivery) error {
+	// The unique index on subscription and event keeps a redelivered outbox event from
+	// being sent twice.
+	return dbFromContext(ctx, repository.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&delivery).Error
+}
+
+// FindById - Get webhook delivery by ID
+func (repository *WebhookDeliveryRepositoryImpl) FindById(ctx context.Context, deliveryId uint64) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := dbFromContext(ctx, repository.db).Take(&delivery, "id = ?", deliveryId).Error
+	return delivery, err
+}
+
+// FindAll - Get the latest webhook deliveries, optionally of one subscription and status
+func (repository *WebhookDeliveryRepositoryImpl) FindAll(ctx context.Context, subscriptionId uint64, status string, limit int) ([]domain.WebhookDelivery, error) {
+	query := dbFromContext(ctx, repository.db)
+	if subscriptionId != 0 {
+		query = query.Where("subscription_id = ?", subscriptionId)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var deliveries []domain.WebhookDelivery
+	return deliveries, query.Order("id DESC").Limit(limit).Find(&deliveries).Error
+}
+
+// FindAllByEventIds - Get the webhook deliveries of the events
+func (repository *WebhookDeliveryRepositoryImpl) FindAllByEventIds(ctx context.Context, eventIds []uint64) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	if len(eventIds) == 0 {
+		return deliveries, nil
+	}
+	return deliveries, dbFromContext(ctx, repository.db).Where("event_id IN ?", eventIds).Order("id").Find(&deliveries).Error
+}
+
+// FindDue - Get the oldest pending webhook deliveries that are due for an attempt
+func (repository *WebhookDeliveryRepositoryImpl) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := dbFromContext(ctx, repository.db).
+		Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryPending, now).
+		Order("id").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// Claim - Hold a delivery back from other dispatchers until a time, unless one has claimed it already
+func (repository *WebhookDeliveryRepositoryImpl) Claim(ctx context.Context, delivery domain.WebhookDelivery, until time.Time) (bool, error) {
+	result := dbFromContext(ctx, repository.db).
+		Model(&domain.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.Id, delivery.Status, delivery.NextAttemptAt).
+		Update("next_attempt_at", until)
+	return result.RowsAffected == 1, result.Error
+}
+
+// UpdateAttempt - Record the outcome of a delivery attempt
+func (repository *WebhookDeliveryRepositoryImpl) UpdateAttempt(ctx context.Context, delivery domain.WebhookDelivery) error {
+	return dbFromContext(ctx, repository.db).
+		Model(&delivery).
+		Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at").
+		Updates(&delivery).Error
+}
+
+// UpdatePayload - Rewrite the payload of a delivery, to redact personal data
+func (repository *WebhookDeliveryRepositoryImpl) UpdatePayload(ctx context.Context, delivery domain.WebhookDelivery) error {
+	return dbFromContext(ctx, repository.db).Model(&delivery).Update("payload", delivery.Payload).Error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+)
+
+type WebhookSubscriptionRepository interface {
+	Save(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	Update(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	Delete(ctx context.Context, subscription domain.WebhookSubscription) error
+	FindById(ctx context.Context, subscriptionId uint64) (domain.WebhookSubscription, error)
+	FindAll(ctx context.Context) ([]domain.WebhookSubscription, error)
+	FindActive(ctx context.Context) ([]domain.WebhookSubscription, error)
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscriptionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepositoryImpl{db: db}
+}
+
+// Save webhook subscription
+func (repository *WebhookSubscriptionRepositoryImpl) Save(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	subscription.Version = 1
+	if err := dbFromContext(ctx, repository.db).Create(&subscription).Error; err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	return subscription, nil
+}
+
+// Update webhook subscription
+func (repository *WebhookSubscriptionRepositoryImpl) Update(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	expectedVersion := subscription.Version
+	subscription.Version++
+
+	result := dbFromContext(ctx, repository.db).
+		Model(&subscription).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Updates(&subscription)
+	if result.Error != nil {
+		return domain.WebhookSubscription{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.WebhookSubscription{}, ErrVersionConflict
+	}
+	return subscription, nil
+}
+
+// Delete webhook subscription
+func (repository *WebhookSubscriptionRepositoryImpl) Delete(ctx context.Context, subscription domain.WebhookSubscription) error {
+	result := dbFromContext(ctx, repository.db).Where("version = ?", subscription.Version).Delete(&subscription)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// FindById - Get webhook subscription by ID
+func (repository *WebhookSubscriptionRepositoryImpl) FindById(ctx context.Context, subscriptionId uint64) (domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	err := dbFromContext(ctx, repository.db).Take(&subscription, "id = ?", subscriptionId).Error
+	return subscription, err
+}
+
+// FindAll webhook subscriptions
+func (repository *WebhookSubscriptionRepositoryImpl) FindAll(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	return subscriptions, dbFromContext(ctx, repository.db).Order("id").Find(&subscriptions).Error
+}
+
+// FindActive - Get the webhook subscriptions that are sent events
+func (repository *WebhookSubscriptionRepositoryImpl) FindActive(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	return subscriptions, dbFromContext(ctx, repository.db).Where("active = ?", true).Order("id").Find(&subscriptions).Error
+}
@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type AuditLogService interface {
+	FindAll(ctx context.Context, request web.AuditLogFilterRequest) ([]web.AuditLogResponse, error)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+)
+
+type AuditLogServiceImpl struct {
+	AuditLogRepository repository.AuditLogRepository
+}
+
+func NewAuditLogService(auditLogRepository repository.AuditLogRepository) AuditLogService {
+	return &AuditLogServiceImpl{
+		AuditLogRepository: auditLogRepository,
+	}
+}
+
+func (service *AuditLogServiceImpl) FindAll(ctx context.Context, request web.AuditLogFilterRequest) ([]web.AuditLogResponse, error) {
+	auditLogs, err := service.AuditLogRepository.FindAll(ctx, domain.AuditLogFilter{
+		EntityType: request.EntityType,
+		EntityId:   request.EntityId,
+		Actor:      request.Actor,
+		From:       request.From,
+		To:         request.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToAuditLogResponses(auditLogs), nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFindAllAuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuditLogRepository(ctrl)
+	auditLogService := NewAuditLogService(mockRepo)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		input     web.AuditLogFilterRequest
+		mock      func()
+		expect    []web.AuditLogResponse
+		expectErr bool
+	}{
+		{
+			name:  "success",
+			input: web.AuditLogFilterRequest{EntityType: "products", Actor: "employee:7", From: &from},
+			mock: func() {
+				mockRepo.EXPECT().
+					FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "products", Actor: "employee:7", From: &from}).
+					Return([]domain.AuditLog{{
+						Id:         1,
+						EntityType: "products",
+						EntityId:   "10",
+						Action:     "update",
+						Actor:      "employee:7",
+						Changes:    `{"product_price":{"before":10,"after":12}}`,
+						ClientIP:   "10.0.0.1",
+						RequestId:  "req-1",
+						CreatedAt:  createdAt,
+					}}, nil)
+			},
+			expect: []web.AuditLogResponse{{
+				Id:         1,
+				EntityType: "products",
+				EntityId:   "10",
+				Action:     "update",
+				Actor:      "employee:7",
+				Changes:    json.RawMessage(`{"product_price":{"before":10,"after":12}}`),
+				ClientIP:   "10.0.0.1",
+				RequestId:  "req-1",
+				CreatedAt:  createdAt,
+			}},
+			expectErr: false,
+		},
+		{
+			name:  "repository error",
+			input: web.AuditLogFilterRequest{},
+			mock: func() {
+				mockRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			expect:    nil,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			resp, err := auditLogService.FindAll(context.Background(), tt.input)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, resp)
+			}
+		})
+	}
+}
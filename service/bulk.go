@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+)
+
+// MaxBulkItems is the largest number of create, update and delete items accepted by a
+// single bulk request.
+const MaxBulkItems = 500
+
+var errBulkFailed = errors.New("bulk request has failed items")
+
+type bulkOperation struct {
+	index  int
+	action string
+	run    func(ctx context.Context) (interface{}, error)
+}
+
+// validateBulk checks the mode and size of a bulk request and returns the mode to use.
+func validateBulk(mode string, items int) (string, error) {
+	if mode == "" {
+		mode = web.BulkModeAllOrNothing
+	}
+	if mode != web.BulkModeAllOrNothing && mode != web.BulkModeBestEffort {
+		return "", exception.NewBadRequestError(fmt.Sprintf("mode must be %s or %s", web.BulkModeAllOrNothing, web.BulkModeBestEffort))
+	}
+	if items == 0 {
+		return "", exception.NewBadRequestError("bulk request has no items")
+	}
+	if items > MaxBulkItems {
+		return "", exception.NewBadRequestError(fmt.Sprintf("bulk request accepts at most %d items", MaxBulkItems))
+	}
+	return mode, nil
+}
+
+// executeBulk runs every operation and reports the outcome of each one. In best-effort
+// mode each operation stands on its own. In all-or-nothing mode they share a transaction
+// that is rolled back when any of them fails, and the ones that succeeded are reported
+// as rolled back.
+func executeBulk(ctx context.Context, transactionManager repository.TransactionManager, mode string, operations []bulkOperation) (web.BulkResponse, error) {
+	response := web.BulkResponse{Mode: mode, Results: make([]web.BulkItemResult, len(operations))}
+
+	runAll := func(ctx context.Context) error {
+		for i, operation := range operations {
+			result := web.BulkItemResult{Index: operation.index, Action: operation.action}
+			data, err := operation.run(ctx)
+			if err != nil {
+				result.Status = web.BulkStatusFailed
+				result.Error = err.Error()
+				response.Failed++
+			} else {
+				result.Status = web.BulkStatusOk
+				result.Data = data
+				response.Succeeded++
+			}
+			response.Results[i] = result
+		}
+		if response.Failed > 0 {
+			return errBulkFailed
+		}
+		return nil
+	}
+
+	if mode == web.BulkModeBestEffort {
+		_ = runAll(ctx)
+		return response, nil
+	}
+
+	err := transactionManager.Transaction(ctx, runAll)
+	if errors.Is(err, errBulkFailed) {
+		for i := range response.Results {
+			if response.Results[i].Status == web.BulkStatusOk {
+				response.Results[i].Status = web.BulkStatusRolledBack
+				response.Results[i].Data = nil
+			}
+		}
+		response.Succeeded = 0
+		return response, nil
+	} else if err != nil {
+		return web.BulkResponse{}, err
+	}
+
+	return response, nil
+}
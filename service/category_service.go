@@ -8,7 +8,11 @@ import (
 type CategoryService interface {
 	Create(ctx context.Context, request web.CategoryCreateRequest) (web.CategoryResponse, error)
 	Update(ctx context.Context, request web.CategoryUpdateRequest) (web.CategoryResponse, error)
-	Delete(ctx context.Context, categoryId uint64) error
-	FindById(ctx context.Context, categoryId uint64) (web.CategoryResponse, error)
-	FindAll(ctx context.Context) ([]web.CategoryResponse, error)
+	Delete(ctx context.Context, categoryId uint64, version uint64) error
+	Move(ctx context.Context, request web.CategoryMoveRequest) (web.CategoryResponse, error)
+	FindById(ctx context.Context, categoryId uint64, withProductCounts bool) (web.CategoryResponse, error)
+	FindAll(ctx context.Context, withProductCounts bool) ([]web.CategoryResponse, error)
+	FindTree(ctx context.Context, withProductCounts bool) ([]web.CategoryTreeResponse, error)
+	Export(ctx context.Context, fn func(response web.CategoryResponse) error) error
+	Bulk(ctx context.Context, request web.CategoryBulkRequest) (web.BulkResponse, error)
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/helper"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
@@ -14,12 +15,14 @@ import (
 
 type CategoryServiceImpl struct {
 	CategoryRepository repository.CategoryRepository
+	TransactionManager repository.TransactionManager
 	Validate           *validator.Validate
 }
 
-func NewCategoryService(categoryRepository repository.CategoryRepository, validate *validator.Validate) CategoryService {
+func NewCategoryService(categoryRepository repository.CategoryRepository, transactionManager repository.TransactionManager, validate *validator.Validate) CategoryService {
 	return &CategoryServiceImpl{
 		CategoryRepository: categoryRepository,
+		TransactionManager: transactionManager,
 		Validate:           validate,
 	}
 }
@@ -30,7 +33,15 @@ func (service *CategoryServiceImpl) Create(ctx context.Context, request web.Cate
 		return web.CategoryResponse{}, err
 	}
 
-	category := domain.Category{Name: request.Name}
+	if request.ParentId != nil {
+		if _, err := service.CategoryRepository.FindById(ctx, *request.ParentId); errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.CategoryResponse{}, exception.NewNotFoundError("Parent category not found")
+		} else if err != nil {
+			return web.CategoryResponse{}, err
+		}
+	}
+
+	category := domain.Category{Name: request.Name, ParentId: request.ParentId}
 	savedCategory, err := service.CategoryRepository.Save(ctx, category)
 	if err != nil {
 		return web.CategoryResponse{}, err
@@ -51,10 +62,15 @@ func (service *CategoryServiceImpl) Update(ctx context.Context, request web.Cate
 	} else if err != nil {
 		return web.CategoryResponse{}, err
 	}
+	if request.Version != 0 && request.Version != category.Version {
+		return web.CategoryResponse{}, exception.NewConflictError("Category has been modified")
+	}
 
 	category.Name = request.Name
 	updatedCategory, err := service.CategoryRepository.Update(ctx, category)
-	if err != nil {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return web.CategoryResponse{}, exception.NewConflictError("Category has been modified")
+	} else if err != nil {
 		return web.CategoryResponse{}, err
 	}
 
@@ -62,19 +78,91 @@ func (service *CategoryServiceImpl) Update(ctx context.Context, request web.Cate
 }
 
 // Delete Category
-func (service *CategoryServiceImpl) Delete(ctx context.Context, categoryId uint64) error {
+func (service *CategoryServiceImpl) Delete(ctx context.Context, categoryId uint64, version uint64) error {
 	category, err := service.CategoryRepository.FindById(ctx, categoryId)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return exception.NewNotFoundError("Category not found")
 	} else if err != nil {
 		return err
 	}
+	if version != 0 && version != category.Version {
+		return exception.NewConflictError("Category has been modified")
+	}
+
+	children, err := service.CategoryRepository.CountChildren(ctx, categoryId)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return exception.NewConflictError("Category has child categories, move or delete them first")
+	}
+
+	err = service.CategoryRepository.Delete(ctx, category)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Category has been modified")
+	}
+	return err
+}
+
+// Move re-parents a Category, refusing moves that would put it under its own subtree
+func (service *CategoryServiceImpl) Move(ctx context.Context, request web.CategoryMoveRequest) (web.CategoryResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.CategoryResponse{}, err
+	}
+
+	// The tree is read locked, so a concurrent move cannot make the checked tree stale
+	// and create a cycle between the check and the update.
+	var updatedCategory domain.Category
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		categories, err := service.CategoryRepository.FindAllForUpdate(ctx)
+		if err != nil {
+			return err
+		}
+		byId := map[uint64]domain.Category{}
+		for _, category := range categories {
+			byId[category.Id] = category
+		}
+
+		category, ok := byId[request.Id]
+		if !ok {
+			return exception.NewNotFoundError("Category not found")
+		}
+		if request.Version != 0 && request.Version != category.Version {
+			return exception.NewConflictError("Category has been modified")
+		}
+		if request.ParentId != nil {
+			if _, ok := byId[*request.ParentId]; !ok {
+				return exception.NewNotFoundError("Parent category not found")
+			}
+			// Walking up from the new parent must not reach the category being moved.
+			visited := map[uint64]bool{}
+			for ancestorId := request.ParentId; ancestorId != nil; ancestorId = byId[*ancestorId].ParentId {
+				if *ancestorId == category.Id {
+					return exception.NewBadRequestError("A category cannot be moved under itself or one of its descendants")
+				}
+				if visited[*ancestorId] {
+					return fmt.Errorf("category tree has a cycle through category %d", *ancestorId)
+				}
+				visited[*ancestorId] = true
+			}
+		}
+
+		category.ParentId = request.ParentId
+		updatedCategory, err = service.CategoryRepository.Update(ctx, category)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return exception.NewConflictError("Category has been modified")
+		}
+		return err
+	})
+	if err != nil {
+		return web.CategoryResponse{}, err
+	}
 
-	return service.CategoryRepository.Delete(ctx, category)
+	return helper.ToCategoryResponse(updatedCategory), nil
 }
 
 // Find Category By ID
-func (service *CategoryServiceImpl) FindById(ctx context.Context, categoryId uint64) (web.CategoryResponse, error) {
+func (service *CategoryServiceImpl) FindById(ctx context.Context, categoryId uint64, withProductCounts bool) (web.CategoryResponse, error) {
 	category, err := service.CategoryRepository.FindById(ctx, categoryId)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return web.CategoryResponse{}, exception.NewNotFoundError("Category not found")
@@ -82,15 +170,94 @@ func (service *CategoryServiceImpl) FindById(ctx context.Context, categoryId uin
 		return web.CategoryResponse{}, err
 	}
 
-	return helper.ToCategoryResponse(category), nil
+	categoryResponse := helper.ToCategoryResponse(category)
+	if withProductCounts {
+		counts, err := service.CategoryRepository.CountProducts(ctx)
+		if err != nil {
+			return web.CategoryResponse{}, err
+		}
+		count := counts[category.Id]
+		categoryResponse.ProductCount = &count
+	}
+	return categoryResponse, nil
 }
 
 // Find All Categories
-func (service *CategoryServiceImpl) FindAll(ctx context.Context) ([]web.CategoryResponse, error) {
+func (service *CategoryServiceImpl) FindAll(ctx context.Context, withProductCounts bool) ([]web.CategoryResponse, error) {
+	categories, err := service.CategoryRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryResponses := helper.ToCategoryResponses(categories)
+	if withProductCounts {
+		counts, err := service.CategoryRepository.CountProducts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range categoryResponses {
+			count := counts[categoryResponses[i].Id]
+			categoryResponses[i].ProductCount = &count
+		}
+	}
+	return categoryResponses, nil
+}
+
+// FindTree returns the root Categories with their descendants nested under them
+func (service *CategoryServiceImpl) FindTree(ctx context.Context, withProductCounts bool) ([]web.CategoryTreeResponse, error) {
 	categories, err := service.CategoryRepository.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return helper.ToCategoryResponses(categories), nil
+	var counts map[uint64]int
+	if withProductCounts {
+		if counts, err = service.CategoryRepository.CountProducts(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return buildCategoryTree(categories, counts), nil
+}
+
+// Bulk Create, Update and Delete Categories
+func (service *CategoryServiceImpl) Bulk(ctx context.Context, request web.CategoryBulkRequest) (web.BulkResponse, error) {
+	mode, err := validateBulk(request.Mode, len(request.Create)+len(request.Update)+len(request.Delete))
+	if err != nil {
+		return web.BulkResponse{}, err
+	}
+
+	var operations []bulkOperation
+	for i, item := range request.Create {
+		item := item
+		operations = append(operations, bulkOperation{index: i, action: web.BulkActionCreate, run: func(ctx context.Context) (interface{}, error) {
+			return service.Create(ctx, item)
+		}})
+	}
+	for i, item := range request.Update {
+		item := item
+		operations = append(operations, bulkOperation{index: i, action: web.BulkActionUpdate, run: func(ctx context.Context) (interface{}, error) {
+			return service.Update(ctx, item)
+		}})
+	}
+	for i, item := range request.Delete {
+		item := item
+		operations = append(operations, bulkOperation{index: i, action: web.BulkActionDelete, run: func(ctx context.Context) (interface{}, error) {
+			return nil, service.Delete(ctx, item.Id, item.Version)
+		}})
+	}
+
+	return executeBulk(ctx, service.TransactionManager, mode, operations)
+}
+
+// Export streams every category to fn, reading ExportBatchSize rows at a time
+func (service *CategoryServiceImpl) Export(ctx context.Context, fn func(response web.CategoryResponse) error) error {
+	return service.CategoryRepository.FindInBatches(ctx, ExportBatchSize, func(categories []domain.Category) error {
+		for _, category := range categories {
+			if err := fn(helper.ToCategoryResponse(category)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"github.com/aronipurwanto/go-restful-api/model/web"
 	"github.com/aronipurwanto/go-restful-api/repository/mocks"
@@ -18,7 +19,7 @@ func TestCreateCategory(t *testing.T) {
 
 	mockRepo := mocks.NewMockCategoryRepository(ctrl)
 	mockValidator := validator.New()
-	categoryService := NewCategoryService(mockRepo, mockValidator)
+	categoryService := NewCategoryService(mockRepo, mocks.NewMockTransactionManager(ctrl), mockValidator)
 
 	tests := []struct {
 		name      string
@@ -73,7 +74,7 @@ func TestDeleteCategory(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockCategoryRepository(ctrl)
-	categoryService := NewCategoryService(mockRepo, validator.New())
+	categoryService := NewCategoryService(mockRepo, mocks.NewMockTransactionManager(ctrl), validator.New())
 
 	tests := []struct {
 		name       string
@@ -86,10 +87,20 @@ func TestDeleteCategory(t *testing.T) {
 			categoryId: 1,
 			mock: func() {
 				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Category{Id: 1, Name: "Electronics"}, nil)
+				mockRepo.EXPECT().CountChildren(gomock.Any(), uint64(1)).Return(int64(0), nil)
 				mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectErr: false,
 		},
+		{
+			name:       "has children",
+			categoryId: 2,
+			mock: func() {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(2)).Return(domain.Category{Id: 2, Name: "Phones"}, nil)
+				mockRepo.EXPECT().CountChildren(gomock.Any(), uint64(2)).Return(int64(3), nil)
+			},
+			expectErr: true,
+		},
 		{
 			name:       "not found",
 			categoryId: 99,
@@ -103,7 +114,7 @@ func TestDeleteCategory(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			err := categoryService.Delete(context.Background(), tt.categoryId)
+			err := categoryService.Delete(context.Background(), tt.categoryId, 0)
 			if tt.expectErr {
 				assert.Error(t, err)
 			} else {
@@ -168,7 +179,7 @@ func TestUpdateCategory(t *testing.T) {
 			mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
 			tt.mock(mockCategoryRepo)
 
-			service := NewCategoryService(mockCategoryRepo, validator.New())
+			service := NewCategoryService(mockCategoryRepo, mocks.NewMockTransactionManager(ctrl), validator.New())
 			_, err := service.Update(context.Background(), tt.input)
 
 			if tt.expects != nil {
@@ -213,8 +224,8 @@ func TestFindAllCategories(t *testing.T) {
 			mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
 			tt.mock(mockCategoryRepo)
 
-			service := NewCategoryService(mockCategoryRepo, validator.New())
-			result, err := service.FindAll(context.Background())
+			service := NewCategoryService(mockCategoryRepo, mocks.NewMockTransactionManager(ctrl), validator.New())
+			result, err := service.FindAll(context.Background(), false)
 			assert.Equal(t, tt.expects, result)
 			assert.Equal(t, tt.err, err)
 		})
@@ -256,10 +267,125 @@ func TestFindByIdCategory(t *testing.T) {
 			mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
 			tt.mock(mockCategoryRepo)
 
-			service := NewCategoryService(mockCategoryRepo, validator.New())
-			result, err := service.FindById(context.Background(), tt.input)
+			service := NewCategoryService(mockCategoryRepo, mocks.NewMockTransactionManager(ctrl), validator.New())
+			result, err := service.FindById(context.Background(), tt.input, false)
 			assert.Equal(t, tt.expects, result)
 			assert.Equal(t, tt.err, err)
 		})
 	}
 }
+
+func TestMoveCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	categoryService := NewCategoryService(mockRepo, newPassthroughTransactionManager(ctrl), validator.New())
+
+	parent := func(id uint64) *uint64 { return &id }
+	// 1 -> 2 -> 3, and 4 on its own
+	categories := []domain.Category{
+		{Id: 1, Name: "Electronics", Version: 1},
+		{Id: 2, Name: "Phones", ParentId: parent(1), Version: 1},
+		{Id: 3, Name: "Smartphones", ParentId: parent(2), Version: 1},
+		{Id: 4, Name: "Clothing", Version: 1},
+	}
+
+	tests := []struct {
+		name      string
+		input     web.CategoryMoveRequest
+		mock      func()
+		expect    web.CategoryResponse
+		expectErr error
+	}{
+		{
+			name:  "move under another root",
+			input: web.CategoryMoveRequest{Id: 2, ParentId: parent(4)},
+			mock: func() {
+				mockRepo.EXPECT().FindAllForUpdate(gomock.Any()).Return(categories, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), domain.Category{Id: 2, Name: "Phones", ParentId: parent(4), Version: 1}).
+					Return(domain.Category{Id: 2, Name: "Phones", ParentId: parent(4), Version: 2}, nil)
+			},
+			expect: web.CategoryResponse{Id: 2, Name: "Phones", ParentId: parent(4), Version: 2},
+		},
+		{
+			name:  "move to root",
+			input: web.CategoryMoveRequest{Id: 3},
+			mock: func() {
+				mockRepo.EXPECT().FindAllForUpdate(gomock.Any()).Return(categories, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), domain.Category{Id: 3, Name: "Smartphones", Version: 1}).
+					Return(domain.Category{Id: 3, Name: "Smartphones", Version: 2}, nil)
+			},
+			expect: web.CategoryResponse{Id: 3, Name: "Smartphones", Version: 2},
+		},
+		{
+			name:  "move under own descendant",
+			input: web.CategoryMoveRequest{Id: 1, ParentId: parent(3)},
+			mock: func() {
+				mockRepo.EXPECT().FindAllForUpdate(gomock.Any()).Return(categories, nil)
+			},
+			expectErr: exception.NewBadRequestError("A category cannot be moved under itself or one of its descendants"),
+		},
+		{
+			name:  "move under itself",
+			input: web.CategoryMoveRequest{Id: 4, ParentId: parent(4)},
+			mock: func() {
+				mockRepo.EXPECT().FindAllForUpdate(gomock.Any()).Return(categories, nil)
+			},
+			expectErr: exception.NewBadRequestError("A category cannot be moved under itself or one of its descendants"),
+		},
+		{
+			name:  "corrupt tree with a cycle",
+			input: web.CategoryMoveRequest{Id: 4, ParentId: parent(5)},
+			mock: func() {
+				mockRepo.EXPECT().FindAllForUpdate(gomock.Any()).Return(append(categories,
+					domain.Category{Id: 5, Name: "Loop", ParentId: parent(6), Version: 1},
+					domain.Category{Id: 6, Name: "Back", ParentId: parent(5), Version: 1},
+				), nil)
+			},
+			expectErr: errors.New("category tree has a cycle through category 5"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			result, err := categoryService.Move(context.Background(), tt.input)
+			if tt.expectErr != nil {
+				assert.Equal(t, tt.expectErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, result)
+			}
+		})
+	}
+}
+
+func TestFindCategoryTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	categoryService := NewCategoryService(mockRepo, mocks.NewMockTransactionManager(ctrl), validator.New())
+
+	parent := func(id uint64) *uint64 { return &id }
+	count := func(n int) *int { return &n }
+	mockRepo.EXPECT().FindAll(gomock.Any()).Return([]domain.Category{
+		{Id: 1, Name: "Electronics"},
+		{Id: 2, Name: "Phones", ParentId: parent(1)},
+		{Id: 3, Name: "Smartphones", ParentId: parent(2)},
+		{Id: 4, Name: "Clothing"},
+	}, nil)
+	mockRepo.EXPECT().CountProducts(gomock.Any()).Return(map[uint64]int{1: 1, 3: 5, 4: 2}, nil)
+
+	tree, err := categoryService.FindTree(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Equal(t, []web.CategoryTreeResponse{
+		{Id: 1, Name: "Electronics", ProductCount: count(1), TotalProductCount: count(6), Children: []web.CategoryTreeResponse{
+			{Id: 2, Name: "Phones", ParentId: parent(1), ProductCount: count(0), TotalProductCount: count(5), Children: []web.CategoryTreeResponse{
+				{Id: 3, Name: "Smartphones", ParentId: parent(2), ProductCount: count(5), TotalProductCount: count(5), Children: []web.CategoryTreeResponse{}},
+			}},
+		}},
+		{Id: 4, Name: "Clothing", ProductCount: count(2), TotalProductCount: count(2), Children: []web.CategoryTreeResponse{}},
+	}, tree)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// buildCategoryTree nests categories under their parents. Categories whose parent no
+// longer exists are returned as roots. Product counts are filled in when counts is
+// not nil.
+func buildCategoryTree(categories []domain.Category, counts map[uint64]int) []web.CategoryTreeResponse {
+	exists := map[uint64]bool{}
+	for _, category := range categories {
+		exists[category.Id] = true
+	}
+
+	children := map[uint64][]domain.Category{}
+	var roots []domain.Category
+	for _, category := range categories {
+		if category.ParentId == nil || !exists[*category.ParentId] {
+			roots = append(roots, category)
+		} else {
+			children[*category.ParentId] = append(children[*category.ParentId], category)
+		}
+	}
+
+	var build func(category domain.Category) web.CategoryTreeResponse
+	build = func(category domain.Category) web.CategoryTreeResponse {
+		node := web.CategoryTreeResponse{
+			Id:       category.Id,
+			Name:     category.Name,
+			ParentId: category.ParentId,
+			Version:  category.Version,
+			Children: []web.CategoryTreeResponse{},
+		}
+		total := counts[category.Id]
+		for _, child := range children[category.Id] {
+			childNode := build(child)
+			if childNode.TotalProductCount != nil {
+				total += *childNode.TotalProductCount
+			}
+			node.Children = append(node.Children, childNode)
+		}
+		if counts != nil {
+			count := counts[category.Id]
+			node.ProductCount = &count
+			node.TotalProductCount = &total
+		}
+		return node
+	}
+
+	tree := []web.CategoryTreeResponse{}
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree
+}
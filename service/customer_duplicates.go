@@ -0,0 +1,106 @@
+package service
+
+import (
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"math"
+	"sort"
+)
+
+const (
+	// DuplicateMaxSharedContact is the number of customers above which a phone number or
+	// email address is taken to be a placeholder, such as the store's own number given
+	// for walk-in customers, and not evidence of a duplicate.
+	DuplicateMaxSharedContact = 20
+	// DuplicateHighNameSimilarity and DuplicateMediumNameSimilarity are the name
+	// similarities from which a duplicate is of high and medium confidence. Below them the
+	// customers share a phone or email but the names differ, e.g. family members.
+	DuplicateHighNameSimilarity   = 0.85
+	DuplicateMediumNameSimilarity = 0.7
+)
+
+// Contacts two customers can share.
+const (
+	DuplicateMatchPhone = "phone"
+	DuplicateMatchEmail = "email"
+)
+
+// findDuplicateCustomers pairs up the customers that share a normalised phone number or
+// email address and rates every pair by how alike the names are. Only customers with a
+// contact in common are compared, as names alone match too many different people.
+func findDuplicateCustomers(customers []domain.Customer) []web.CustomerDuplicateResponse {
+	byPhone := map[string][]int{}
+	byEmail := map[string][]int{}
+	for i, customer := range customers {
+		if phone := helper.NormalizePhone(customer.Phone); phone != "" {
+			byPhone[phone] = append(byPhone[phone], i)
+		}
+		if email := helper.NormalizeEmail(customer.Email); email != "" {
+			byEmail[email] = append(byEmail[email], i)
+		}
+	}
+
+	type pair struct{ first, second int }
+	matches := map[pair][]string{}
+	addMatches := func(groups map[string][]int, matchedOn string) {
+		for _, group := range groups {
+			if len(group) < 2 || len(group) > DuplicateMaxSharedContact {
+				continue
+			}
+			for i := 0; i < len(group); i++ {
+				for j := i + 1; j < len(group); j++ {
+					key := pair{group[i], group[j]}
+					matches[key] = append(matches[key], matchedOn)
+				}
+			}
+		}
+	}
+	addMatches(byPhone, DuplicateMatchPhone)
+	addMatches(byEmail, DuplicateMatchEmail)
+
+	duplicates := make([]web.CustomerDuplicateResponse, 0, len(matches))
+	for key, matchedOn := range matches {
+		customer, duplicate := customers[key.first], customers[key.second]
+		if duplicate.CustomerID < customer.CustomerID {
+			customer, duplicate = duplicate, customer
+		}
+		similarity := math.Round(helper.NameSimilarity(customer.Name, duplicate.Name)*100) / 100
+		duplicates = append(duplicates, web.CustomerDuplicateResponse{
+			Customer:       helper.ToCustomerResponse(customer),
+			Duplicate:      helper.ToCustomerResponse(duplicate),
+			MatchedOn:      matchedOn,
+			NameSimilarity: similarity,
+			Confidence:     duplicateConfidence(similarity, len(matchedOn)),
+		})
+	}
+
+	rank := map[string]int{web.DuplicateConfidenceHigh: 0, web.DuplicateConfidenceMedium: 1, web.DuplicateConfidenceLow: 2}
+	sort.Slice(duplicates, func(i, j int) bool {
+		a, b := duplicates[i], duplicates[j]
+		if a.Confidence != b.Confidence {
+			return rank[a.Confidence] < rank[b.Confidence]
+		}
+		if a.NameSimilarity != b.NameSimilarity {
+			return a.NameSimilarity > b.NameSimilarity
+		}
+		if a.Customer.CustomerID != b.Customer.CustomerID {
+			return a.Customer.CustomerID < b.Customer.CustomerID
+		}
+		return a.Duplicate.CustomerID < b.Duplicate.CustomerID
+	})
+	return duplicates
+}
+
+// duplicateConfidence rates a pair by the similarity of the names; a pair sharing both
+// phone and email is of medium confidence at least.
+func duplicateConfidence(nameSimilarity float64, contactsShared int) string {
+	switch {
+	case nameSimilarity >= DuplicateHighNameSimilarity:
+		return web.DuplicateConfidenceHigh
+	case nameSimilarity >= DuplicateMediumNameSimilarity || contactsShared > 1:
+		return web.DuplicateConfidenceMedium
+	default:
+		return web.DuplicateConfidenceLow
+	}
+}
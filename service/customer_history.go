@@ -0,0 +1,94 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+const (
+	// DefaultCustomerOrdersPageSize is the number of orders in a page of the purchase
+	// history when no size is given.
+	DefaultCustomerOrdersPageSize = 20
+	// FavouriteProductsLimit is the number of products in the favourites of a customer.
+	FavouriteProductsLimit = 5
+	// LoyaltyHistoryLimit is the number of changes in the loyalty history of a customer.
+	LoyaltyHistoryLimit = 50
+)
+
+// customerSummary is the lifetime value of a customer from the sums of their orders.
+func customerSummary(summary domain.CustomerOrderSummary) web.CustomerSummaryResponse {
+	response := web.CustomerSummaryResponse{
+		TotalSpent: summary.TotalSpent,
+		VisitCount: summary.OrderCount,
+		FirstVisit: summary.FirstOrderDate,
+		LastVisit:  summary.LastOrderDate,
+	}
+	if summary.OrderCount > 0 {
+		response.AverageBasket = summary.TotalSpent / float64(summary.OrderCount)
+	}
+	return response
+}
+
+func favouriteProducts(purchases []domain.CustomerProductPurchase) []web.CustomerFavouriteProductResponse {
+	favourites := make([]web.CustomerFavouriteProductResponse, 0, len(purchases))
+	for _, purchase := range purchases {
+		favourites = append(favourites, web.CustomerFavouriteProductResponse{
+			ProductID:     purchase.ProductID,
+			ProductName:   purchase.ProductName,
+			Quantity:      purchase.Quantity,
+			OrderCount:    purchase.OrderCount,
+			TotalSpent:    purchase.Revenue,
+			LastPurchased: purchase.LastOrderDate,
+		})
+	}
+	return favourites
+}
+
+// loyaltyHistory picks the changes of the loyalty points out of the audit log entries of
+// a customer, in the order of the entries and at most limit of them. Entries that leave
+// the points as they were are skipped, as is the delete of the customer.
+func loyaltyHistory(auditLogs []domain.AuditLog, limit int) ([]web.LoyaltyEntryResponse, error) {
+	history := []web.LoyaltyEntryResponse{}
+	for _, auditLog := range auditLogs {
+		if len(history) == limit {
+			break
+		}
+
+		var changes map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(auditLog.Changes), &changes); err != nil {
+			return nil, err
+		}
+		change, ok := changes["loyalty_pts"]
+		if !ok {
+			continue
+		}
+		var points struct {
+			Before *float64 `json:"before"`
+			After  *float64 `json:"after"`
+		}
+		if err := json.Unmarshal(change, &points); err != nil {
+			return nil, err
+		}
+		if points.After == nil {
+			continue
+		}
+
+		var before float64
+		if points.Before != nil {
+			before = *points.Before
+		}
+		if *points.After == before {
+			continue
+		}
+		history = append(history, web.LoyaltyEntryResponse{
+			Change:    int(*points.After - before),
+			Balance:   int(*points.After),
+			Action:    auditLog.Action,
+			Actor:     auditLog.Actor,
+			RequestId: auditLog.RequestId,
+			CreatedAt: auditLog.CreatedAt,
+		})
+	}
+	return history, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository"
+)
+
+// CustomerRetentionBatchSize is the number of inactive customers read at a time by the
+// retention job.
+const CustomerRetentionBatchSize = 100
+
+var (
+	// customerPersonalColumns are the columns of a customer holding personal data, as
+	// named in the changes of the audit log.
+	customerPersonalColumns = []string{"customer_name", "customer_email", "customer_phone", "customer_address"}
+	// customerPersonalFields are the same in the JSON of customer events.
+	customerPersonalFields = []string{"name", "email", "phone", "address"}
+)
+
+// scrubCustomer clears the personal data of a customer. The orders, loyalty points and
+// price list are kept, so the sales stay accounted for.
+func scrubCustomer(customer *domain.Customer) {
+	customer.Name = domain.AnonymisedCustomerName
+	customer.Email = ""
+	customer.Phone = ""
+	customer.Address = ""
+}
+
+// redactAuditLogs redacts the personal data of a customer from the changes of their audit
+// log entries and returns the entries that changed.
+func redactAuditLogs(auditLogs []domain.AuditLog) ([]domain.AuditLog, error) {
+	var redacted []domain.AuditLog
+	for _, auditLog := range auditLogs {
+		changes, changed, err := helper.RedactJSON(auditLog.Changes, customerPersonalColumns...)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			auditLog.Changes = changes
+			redacted = append(redacted, auditLog)
+		}
+	}
+	return redacted, nil
+}
+
+// mergedCustomerIds returns the ids of the customers merged into a customer, also those
+// merged into one of them before, as recorded by the merge audit log entries.
+func mergedCustomerIds(ctx context.Context, auditLogRepository repository.AuditLogRepository, customerId string) ([]string, error) {
+	merges, err := auditLogRepository.FindAll(ctx, domain.AuditLogFilter{EntityType: "customers", Action: domain.AuditActionMerge})
+	if err != nil {
+		return nil, err
+	}
+	duplicatesBySurvivor := map[string][]string{}
+	for _, merge := range merges {
+		var changes struct {
+			MergedInto struct {
+				After json.Number `json:"after"`
+			} `json:"merged_into"`
+		}
+		if err := json.Unmarshal([]byte(merge.Changes), &changes); err != nil {
+			return nil, err
+		}
+		survivorId := changes.MergedInto.After.String()
+		duplicatesBySurvivor[survivorId] = append(duplicatesBySurvivor[survivorId], merge.EntityId)
+	}
+
+	var mergedIds []string
+	seen := map[string]bool{customerId: true}
+	pending := []string{customerId}
+	for len(pending) > 0 {
+		survivorId := pending[0]
+		pending = pending[1:]
+		for _, duplicateId := range duplicatesBySurvivor[survivorId] {
+			if !seen[duplicateId] {
+				seen[duplicateId] = true
+				mergedIds = append(mergedIds, duplicateId)
+				pending = append(pending, duplicateId)
+			}
+		}
+	}
+	return mergedIds, nil
+}
@@ -3,12 +3,22 @@ package service
 import (
 	"context"
 	"github.com/aronipurwanto/go-restful-api/model/web"
+	"time"
 )
 
 type CustomerService interface {
 	Create(ctx context.Context, request web.CustomerCreateRequest) (web.CustomerResponse, error)
 	Update(ctx context.Context, request web.CustomerUpdateRequest) (web.CustomerResponse, error)
-	Delete(ctx context.Context, customerId string) error
+	Delete(ctx context.Context, customerId string, version uint64) error
 	FindById(ctx context.Context, customerId string) (web.CustomerResponse, error)
 	FindAll(ctx context.Context) ([]web.CustomerResponse, error)
+	FindOrders(ctx context.Context, request web.CustomerOrdersRequest) (web.CustomerOrdersResponse, error)
+	Profile(ctx context.Context, customerId string) (web.CustomerProfileResponse, error)
+	DataExport(ctx context.Context, customerId string) (web.CustomerDataExportResponse, error)
+	Anonymise(ctx context.Context, customerId string) (web.CustomerAnonymiseResponse, error)
+	AnonymiseInactive(ctx context.Context, before time.Time) (int, error)
+	Export(ctx context.Context, fn func(response web.CustomerResponse) error) error
+	FindDuplicates(ctx context.Context) ([]web.CustomerDuplicateResponse, error)
+	Merge(ctx context.Context, request web.CustomerMergeRequest) (web.CustomerMergeResponse, error)
+	Bulk(ctx context.Context, request web.CustomerBulkRequest) (web.BulkResponse, error)
 }
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/helper"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
@@ -11,17 +13,29 @@ import (
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type CustomerServiceImpl struct {
-	CustomerRepository repository.CustomerRepository
-	Validate           *validator.Validate
+	CustomerRepository        repository.CustomerRepository
+	OrderRepository           repository.OrderRepository
+	AuditLogRepository        repository.AuditLogRepository
+	OutboxRepository          repository.OutboxRepository
+	WebhookDeliveryRepository repository.WebhookDeliveryRepository
+	TransactionManager        repository.TransactionManager
+	Validate                  *validator.Validate
 }
 
-func NewCustomerService(customerRepository repository.CustomerRepository, validate *validator.Validate) CustomerService {
+func NewCustomerService(customerRepository repository.CustomerRepository, orderRepository repository.OrderRepository, auditLogRepository repository.AuditLogRepository, outboxRepository repository.OutboxRepository, webhookDeliveryRepository repository.WebhookDeliveryRepository, transactionManager repository.TransactionManager, validate *validator.Validate) CustomerService {
 	return &CustomerServiceImpl{
-		CustomerRepository: customerRepository,
-		Validate:           validate,
+		CustomerRepository:        customerRepository,
+		OrderRepository:           orderRepository,
+		AuditLogRepository:        auditLogRepository,
+		OutboxRepository:          outboxRepository,
+		WebhookDeliveryRepository: webhookDeliveryRepository,
+		TransactionManager:        transactionManager,
+		Validate:                  validate,
 	}
 }
 
@@ -31,11 +45,16 @@ func (service *CustomerServiceImpl) Create(ctx context.Context, request web.Cust
 	}
 
 	customer := domain.Customer{
-		Name:       request.Name,
-		Email:      request.Email,
-		Phone:      request.Phone,
-		Address:    request.Address,
-		LoyaltyPts: request.LoyaltyPts,
+		Name:        request.Name,
+		Email:       request.Email,
+		Phone:       request.Phone,
+		Address:     request.Address,
+		LoyaltyPts:  request.LoyaltyPts,
+		PriceListId: request.PriceListId,
+	}
+
+	if err := checkCustomerContactsUnused(ctx, service.CustomerRepository, customer); err != nil {
+		return web.CustomerResponse{}, err
 	}
 
 	savedCustomer, err := service.CustomerRepository.Save(ctx, customer)
@@ -57,14 +76,31 @@ func (service *CustomerServiceImpl) Update(ctx context.Context, request web.Cust
 	} else if err != nil {
 		return web.CustomerResponse{}, err
 	}
+	if request.Version != 0 && request.Version != customer.Version {
+		return web.CustomerResponse{}, exception.NewConflictError("Customer has been modified")
+	}
 
 	customer.Name = request.Name
 	customer.Email = request.Email
 	customer.Phone = request.Phone
 	customer.Address = request.Address
 	customer.LoyaltyPts = request.LoyaltyPts
+	customer.PriceListId = request.PriceListId
+	if err := checkCustomerContactsUnused(ctx, service.CustomerRepository, customer); err != nil {
+		return web.CustomerResponse{}, err
+	}
 
-	updatedCustomer, err := service.CustomerRepository.Update(ctx, customer)
+	var updatedCustomer domain.Customer
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		updatedCustomer, err = service.CustomerRepository.Update(ctx, customer)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return exception.NewConflictError("Customer has been modified")
+		} else if err != nil {
+			return err
+		}
+		return publishEvent(ctx, service.OutboxRepository, domain.EventCustomerUpdated, domain.AggregateCustomer, strconv.FormatUint(updatedCustomer.CustomerID, 10), helper.ToCustomerResponse(updatedCustomer))
+	})
 	if err != nil {
 		return web.CustomerResponse{}, err
 	}
@@ -72,15 +108,22 @@ func (service *CustomerServiceImpl) Update(ctx context.Context, request web.Cust
 	return helper.ToCustomerResponse(updatedCustomer), nil
 }
 
-func (service *CustomerServiceImpl) Delete(ctx context.Context, customerId string) error {
+func (service *CustomerServiceImpl) Delete(ctx context.Context, customerId string, version uint64) error {
 	customer, err := service.CustomerRepository.FindById(ctx, customerId)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return exception.NewNotFoundError("Customer not found")
 	} else if err != nil {
 		return err
 	}
+	if version != 0 && version != customer.Version {
+		return exception.NewConflictError("Customer has been modified")
+	}
 
-	return service.CustomerRepository.Delete(ctx, customer)
+	err = service.CustomerRepository.Delete(ctx, customer)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Customer has been modified")
+	}
+	return err
 }
 
 func (service *CustomerServiceImpl) FindById(ctx context.Context, customerId string) (web.CustomerResponse, error) {
@@ -102,3 +145,474 @@ func (service *CustomerServiceImpl) FindAll(ctx context.Context) ([]web.Customer
 
 	return helper.ToCustomerResponses(customers), nil
 }
+
+// FindOrders returns a page of the orders of a customer, newest first. A customer is
+// served in every store, so the orders of all stores are included.
+func (service *CustomerServiceImpl) FindOrders(ctx context.Context, request web.CustomerOrdersRequest) (web.CustomerOrdersResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.CustomerOrdersResponse{}, err
+	}
+	if request.Page == 0 {
+		request.Page = 1
+	}
+	if request.Size == 0 {
+		request.Size = DefaultCustomerOrdersPageSize
+	}
+
+	customer, err := findCustomer(ctx, service.CustomerRepository, request.CustomerID)
+	if err != nil {
+		return web.CustomerOrdersResponse{}, err
+	}
+
+	offset := (request.Page - 1) * request.Size
+	orders, total, err := service.OrderRepository.FindPageByCustomer(repository.WithoutStoreScope(ctx), strconv.FormatUint(customer.CustomerID, 10), offset, request.Size)
+	if err != nil {
+		return web.CustomerOrdersResponse{}, err
+	}
+
+	response := web.CustomerOrdersResponse{
+		Orders:     make([]web.OrderResponse, 0, len(orders)),
+		Page:       request.Page,
+		Size:       request.Size,
+		TotalCount: total,
+		HasMore:    int64(offset+len(orders)) < total,
+	}
+	for _, order := range orders {
+		response.Orders = append(response.Orders, helper.ToOrderResponse(order))
+	}
+	return response, nil
+}
+
+// Profile returns the customer with their lifetime value and favourite products over the
+// orders of all stores, and the loyalty points with the latest changes to them.
+func (service *CustomerServiceImpl) Profile(ctx context.Context, customerId string) (web.CustomerProfileResponse, error) {
+	customer, err := service.CustomerRepository.FindById(ctx, customerId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.CustomerProfileResponse{}, exception.NewNotFoundError("Customer not found")
+	} else if err != nil {
+		return web.CustomerProfileResponse{}, err
+	}
+	customerId = strconv.FormatUint(customer.CustomerID, 10)
+
+	allStores := repository.WithoutStoreScope(ctx)
+	summary, err := service.OrderRepository.SummaryByCustomer(allStores, customerId)
+	if err != nil {
+		return web.CustomerProfileResponse{}, err
+	}
+	purchases, err := service.OrderRepository.ProductPurchasesByCustomer(allStores, customerId, FavouriteProductsLimit)
+	if err != nil {
+		return web.CustomerProfileResponse{}, err
+	}
+	auditLogs, err := service.AuditLogRepository.FindAll(ctx, domain.AuditLogFilter{EntityType: "customers", EntityId: customerId})
+	if err != nil {
+		return web.CustomerProfileResponse{}, err
+	}
+	history, err := loyaltyHistory(auditLogs, LoyaltyHistoryLimit)
+	if err != nil {
+		return web.CustomerProfileResponse{}, err
+	}
+
+	return web.CustomerProfileResponse{
+		Customer:          helper.ToCustomerResponse(customer),
+		Summary:           customerSummary(summary),
+		FavouriteProducts: favouriteProducts(purchases),
+		Loyalty: web.CustomerLoyaltyResponse{
+			Balance: customer.LoyaltyPts,
+			History: history,
+		},
+	}, nil
+}
+
+// DataExport returns everything held on a customer: the customer, their orders in every
+// store, the loyalty history, the audit log and the events about them.
+func (service *CustomerServiceImpl) DataExport(ctx context.Context, customerId string) (web.CustomerDataExportResponse, error) {
+	customer, err := service.CustomerRepository.FindById(ctx, customerId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.CustomerDataExportResponse{}, exception.NewNotFoundError("Customer not found")
+	} else if err != nil {
+		return web.CustomerDataExportResponse{}, err
+	}
+	customerId = strconv.FormatUint(customer.CustomerID, 10)
+
+	orders, err := service.OrderRepository.FindAllByCustomer(repository.WithoutStoreScope(ctx), customerId)
+	if err != nil {
+		return web.CustomerDataExportResponse{}, err
+	}
+	auditLogs, err := service.AuditLogRepository.FindAll(ctx, domain.AuditLogFilter{EntityType: "customers", EntityId: customerId})
+	if err != nil {
+		return web.CustomerDataExportResponse{}, err
+	}
+	history, err := loyaltyHistory(auditLogs, len(auditLogs))
+	if err != nil {
+		return web.CustomerDataExportResponse{}, err
+	}
+	events, err := service.OutboxRepository.FindAllByAggregate(ctx, domain.AggregateCustomer, customerId)
+	if err != nil {
+		return web.CustomerDataExportResponse{}, err
+	}
+
+	response := web.CustomerDataExportResponse{
+		ExportedAt:     time.Now(),
+		Customer:       helper.ToCustomerResponse(customer),
+		Orders:         make([]web.OrderResponse, 0, len(orders)),
+		LoyaltyHistory: history,
+		AuditLog:       make([]web.AuditLogResponse, 0, len(auditLogs)),
+		Events:         make([]web.EventEnvelope, 0, len(events)),
+	}
+	for _, order := range orders {
+		response.Orders = append(response.Orders, helper.ToOrderResponse(order))
+	}
+	for _, auditLog := range auditLogs {
+		response.AuditLog = append(response.AuditLog, helper.ToAuditLogResponse(auditLog))
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, helper.ToEventEnvelope(event))
+	}
+	return response, nil
+}
+
+// Anonymise scrubs the personal data of a customer, keeping their orders for the
+// accounts. See anonymise.
+func (service *CustomerServiceImpl) Anonymise(ctx context.Context, customerId string) (web.CustomerAnonymiseResponse, error) {
+	customer, err := service.CustomerRepository.FindById(ctx, customerId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.CustomerAnonymiseResponse{}, exception.NewNotFoundError("Customer not found")
+	} else if err != nil {
+		return web.CustomerAnonymiseResponse{}, err
+	}
+	if customer.AnonymisedAt != nil {
+		return web.CustomerAnonymiseResponse{}, exception.NewConflictError("Customer has already been anonymised")
+	}
+
+	return service.anonymise(ctx, customer)
+}
+
+// AnonymiseInactive anonymises the customers that have neither changed nor placed an
+// order since before, and returns how many were. A customer that fails is skipped, so it
+// does not hold up the others; the errors are returned together at the end.
+func (service *CustomerServiceImpl) AnonymiseInactive(ctx context.Context, before time.Time) (int, error) {
+	var anonymised int
+	var errs []error
+	var afterId uint64
+	for {
+		customers, err := service.CustomerRepository.FindInactive(ctx, before, afterId, CustomerRetentionBatchSize)
+		if err != nil {
+			return anonymised, err
+		}
+
+		for _, customer := range customers {
+			afterId = customer.CustomerID
+			if _, err := service.anonymise(ctx, customer); err != nil {
+				errs = append(errs, fmt.Errorf("customer %d: %w", customer.CustomerID, err))
+				continue
+			}
+			anonymised++
+		}
+
+		if len(customers) < CustomerRetentionBatchSize {
+			return anonymised, errors.Join(errs...)
+		}
+	}
+}
+
+// anonymise scrubs the personal data of a customer and redacts it from where it was
+// copied to: the changes of their audit log entries, which include the scrubbing itself,
+// and the payloads of the events about them and of the webhook deliveries of those. The
+// same is redacted for the customers that were merged into this one. A
+// CustomerAnonymised event tells the subscribers to do the same.
+func (service *CustomerServiceImpl) anonymise(ctx context.Context, customer domain.Customer) (web.CustomerAnonymiseResponse, error) {
+	var response web.CustomerAnonymiseResponse
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		scrubCustomer(&customer)
+		customer.AnonymisedAt = &now
+		updatedCustomer, err := service.CustomerRepository.Update(ctx, customer)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return exception.NewConflictError("Customer has been modified")
+		} else if err != nil {
+			return err
+		}
+		response.Customer = helper.ToCustomerResponse(updatedCustomer)
+		customerId := strconv.FormatUint(updatedCustomer.CustomerID, 10)
+
+		// The customers merged into this one are the same person, whose data their audit
+		// log entries and events still hold
+		mergedIds, err := mergedCustomerIds(ctx, service.AuditLogRepository, customerId)
+		if err != nil {
+			return err
+		}
+		for _, redactedId := range append([]string{customerId}, mergedIds...) {
+			if err := service.redactCustomerTrail(ctx, redactedId, &response); err != nil {
+				return err
+			}
+		}
+
+		return publishEvent(ctx, service.OutboxRepository, domain.EventCustomerAnonymised, domain.AggregateCustomer, customerId, response.Customer)
+	})
+	if err != nil {
+		return web.CustomerAnonymiseResponse{}, err
+	}
+
+	return response, nil
+}
+
+// redactCustomerTrail redacts the personal data of a customer from the changes of their
+// audit log entries and the payloads of the events about them and of the webhook
+// deliveries of those, counting what it redacted in response.
+func (service *CustomerServiceImpl) redactCustomerTrail(ctx context.Context, customerId string, response *web.CustomerAnonymiseResponse) error {
+	auditLogs, err := service.AuditLogRepository.FindAll(ctx, domain.AuditLogFilter{EntityType: "customers", EntityId: customerId})
+	if err != nil {
+		return err
+	}
+	redactedAuditLogs, err := redactAuditLogs(auditLogs)
+	if err != nil {
+		return err
+	}
+	for _, auditLog := range redactedAuditLogs {
+		if err := service.AuditLogRepository.UpdateChanges(ctx, auditLog); err != nil {
+			return err
+		}
+	}
+	response.AuditLogsRedacted += len(redactedAuditLogs)
+
+	events, err := service.OutboxRepository.FindAllByAggregate(ctx, domain.AggregateCustomer, customerId)
+	if err != nil {
+		return err
+	}
+	eventIds := make([]uint64, 0, len(events))
+	for _, event := range events {
+		eventIds = append(eventIds, event.Id)
+		payload, changed, err := helper.RedactJSON(event.Payload, customerPersonalFields...)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		event.Payload = payload
+		if err := service.OutboxRepository.UpdatePayload(ctx, event); err != nil {
+			return err
+		}
+		response.EventsRedacted++
+	}
+
+	deliveries, err := service.WebhookDeliveryRepository.FindAllByEventIds(ctx, eventIds)
+	if err != nil {
+		return err
+	}
+	for _, delivery := range deliveries {
+		payload, changed, err := helper.RedactJSON(delivery.Payload, customerPersonalFields...)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		delivery.Payload = payload
+		if err := service.WebhookDeliveryRepository.UpdatePayload(ctx, delivery); err != nil {
+			return err
+		}
+		response.WebhookDeliveriesRedacted++
+	}
+	return nil
+}
+
+// FindDuplicates returns the pairs of customers that are likely the same person, most
+// likely first. See findDuplicateCustomers.
+func (service *CustomerServiceImpl) FindDuplicates(ctx context.Context) ([]web.CustomerDuplicateResponse, error) {
+	var customers []domain.Customer
+	err := service.CustomerRepository.FindInBatches(ctx, ExportBatchSize, func(batch []domain.Customer) error {
+		customers = append(customers, batch...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findDuplicateCustomers(customers), nil
+}
+
+// Merge folds duplicate customers into the surviving one: their orders, and with them the
+// payments, are moved to the survivor in every store, their loyalty points are added to
+// the survivor's and contact details the survivor lacks are taken over. The duplicates
+// are then deleted. Every merged customer leaves an audit log entry that names the
+// survivor, next to the audited update and deletes.
+func (service *CustomerServiceImpl) Merge(ctx context.Context, request web.CustomerMergeRequest) (web.CustomerMergeResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.CustomerMergeResponse{}, err
+	}
+	seen := map[uint64]bool{request.SurvivorID: true}
+	for _, duplicateId := range request.DuplicateIDs {
+		if seen[duplicateId] {
+			return web.CustomerMergeResponse{}, exception.NewBadRequestError("Customer " + strconv.FormatUint(duplicateId, 10) + " is given more than once")
+		}
+		seen[duplicateId] = true
+	}
+
+	response := web.CustomerMergeResponse{MergedCustomerIDs: request.DuplicateIDs}
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		survivor, err := findCustomer(ctx, service.CustomerRepository, request.SurvivorID)
+		if err != nil {
+			return err
+		}
+		survivorId := strconv.FormatUint(survivor.CustomerID, 10)
+
+		for _, duplicateId := range request.DuplicateIDs {
+			duplicate, err := findCustomer(ctx, service.CustomerRepository, duplicateId)
+			if err != nil {
+				return err
+			}
+
+			ordersMoved, err := service.OrderRepository.ReassignCustomer(repository.WithoutStoreScope(ctx), strconv.FormatUint(duplicateId, 10), survivorId)
+			if err != nil {
+				return err
+			}
+			response.OrdersMoved += ordersMoved
+			response.LoyaltyPtsMoved += duplicate.LoyaltyPts
+			mergeCustomer(&survivor, duplicate)
+
+			err = service.CustomerRepository.Delete(ctx, duplicate)
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return exception.NewConflictError("Customer has been modified")
+			} else if err != nil {
+				return err
+			}
+			if err := service.auditMerge(ctx, duplicate, survivor, ordersMoved); err != nil {
+				return err
+			}
+		}
+
+		updatedSurvivor, err := service.CustomerRepository.Update(ctx, survivor)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return exception.NewConflictError("Customer has been modified")
+		} else if err != nil {
+			return err
+		}
+		response.Customer = helper.ToCustomerResponse(updatedSurvivor)
+
+		if err := publishEvent(ctx, service.OutboxRepository, domain.EventCustomerUpdated, domain.AggregateCustomer, survivorId, response.Customer); err != nil {
+			return err
+		}
+		return publishEvent(ctx, service.OutboxRepository, domain.EventCustomerMerged, domain.AggregateCustomer, survivorId, web.CustomerMergedEvent{
+			Customer:          response.Customer,
+			MergedCustomerIDs: request.DuplicateIDs,
+		})
+	})
+	if err != nil {
+		return web.CustomerMergeResponse{}, err
+	}
+
+	return response, nil
+}
+
+// auditMerge writes the audit log entry of a customer merged into the survivor.
+func (service *CustomerServiceImpl) auditMerge(ctx context.Context, duplicate domain.Customer, survivor domain.Customer, ordersMoved int64) error {
+	changes, err := json.Marshal(map[string]interface{}{
+		"merged_into":  map[string]interface{}{"before": nil, "after": survivor.CustomerID},
+		"orders_moved": map[string]interface{}{"before": nil, "after": ordersMoved},
+		"loyalty_pts":  map[string]interface{}{"before": duplicate.LoyaltyPts, "after": 0},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = service.AuditLogRepository.Save(ctx, domain.AuditLog{
+		EntityType: "customers",
+		EntityId:   strconv.FormatUint(duplicate.CustomerID, 10),
+		Action:     domain.AuditActionMerge,
+		Actor:      helper.ActorFromContext(ctx),
+		Changes:    string(changes),
+		ClientIP:   helper.ClientIPFromContext(ctx),
+		RequestId:  helper.RequestIdFromContext(ctx),
+		CreatedAt:  time.Now(),
+	})
+	return err
+}
+
+// mergeCustomer adds the loyalty points of a duplicate to the survivor and fills in the
+// details the survivor has no value for.
+func mergeCustomer(survivor *domain.Customer, duplicate domain.Customer) {
+	survivor.LoyaltyPts += duplicate.LoyaltyPts
+	if survivor.Email == "" {
+		survivor.Email = duplicate.Email
+	}
+	if survivor.Phone == "" {
+		survivor.Phone = duplicate.Phone
+	}
+	if survivor.Address == "" {
+		survivor.Address = duplicate.Address
+	}
+	if survivor.PriceListId == nil {
+		survivor.PriceListId = duplicate.PriceListId
+	}
+}
+
+// checkCustomerContactsUnused fails with a field conflict when another customer has the
+// email address or phone number of the customer. The unique indexes enforce the same,
+// this only reports it before anything is written.
+func checkCustomerContactsUnused(ctx context.Context, customerRepository repository.CustomerRepository, customer domain.Customer) error {
+	others, err := customerRepository.FindAllByEmailOrPhone(ctx, customer.Email, customer.Phone)
+	if err != nil {
+		return err
+	}
+	for _, other := range others {
+		if other.CustomerID == customer.CustomerID {
+			continue
+		}
+		otherId := strconv.FormatUint(other.CustomerID, 10)
+		if customer.Email != "" && strings.EqualFold(other.Email, customer.Email) {
+			return exception.NewFieldConflictError("email", "email "+customer.Email+" is already used by customer "+otherId)
+		}
+		return exception.NewFieldConflictError("phone", "phone "+customer.Phone+" is already used by customer "+otherId)
+	}
+	return nil
+}
+
+func findCustomer(ctx context.Context, customerRepository repository.CustomerRepository, customerId uint64) (domain.Customer, error) {
+	customer, err := customerRepository.FindById(ctx, strconv.FormatUint(customerId, 10))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Customer{}, exception.NewNotFoundError("Customer " + strconv.FormatUint(customerId, 10) + " not found")
+	}
+	return customer, err
+}
+
+func (service *CustomerServiceImpl) Bulk(ctx context.Context, request web.CustomerBulkRequest) (web.BulkResponse, error) {
+	mode, err := validateBulk(request.Mode, len(request.Create)+len(request.Update)+len(request.Delete))
+	if err != nil {
+		return web.BulkResponse{}, err
+	}
+
+	var operations []bulkOperation
+	for i, item := range request.Create {
+		item := item
+		operations = append(operations, bulkOperation{index: i, action: web.BulkActionCreate, run: func(ctx context.Context) (interface{}, error) {
+			return service.Create(ctx, item)
+		}})
+	}
+	for i, item := range request.Update {
+		item := item
+		operations = append(operations, bulkOperation{index: i, action: web.BulkActionUpdate, run: func(ctx context.Context) (interface{}, error) {
+			return service.Update(ctx, item)
+		}})
+	}
+	for i, item := range request.Delete {
+		item := item
+		operations = append(operations, bulkOperation{index: i, action: web.BulkActionDelete, run: func(ctx context.Context) (interface{}, error) {
+			return nil, service.Delete(ctx, strconv.FormatUint(item.CustomerID, 10), item.Version)
+		}})
+	}
+
+	return executeBulk(ctx, service.TransactionManager, mode, operations)
+}
+
+// Export streams every customer to fn, reading ExportBatchSize rows at a time
+func (service *CustomerServiceImpl) Export(ctx context.Context, fn func(response web.CustomerResponse) error) error {
+	return service.CustomerRepository.FindInBatches(ctx, ExportBatchSize, func(customers []domain.Customer) error {
+		for _, customer := range customers {
+			if err := fn(helper.ToCustomerResponse(customer)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -3,13 +3,17 @@ package service
 import (
 	"context"
 	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
 	"github.com/aronipurwanto/go-restful-api/repository/mocks"
 	"github.com/go-playground/validator/v10"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 	"testing"
+	"time"
 )
 
 func TestCreateCustomer(t *testing.T) {
@@ -18,7 +22,7 @@ func TestCreateCustomer(t *testing.T) {
 
 	mockRepo := mocks.NewMockCustomerRepository(ctrl)
 	mockValidator := validator.New()
-	customerService := NewCustomerService(mockRepo, mockValidator)
+	customerService := NewCustomerService(mockRepo, nil, nil, newMockOutboxRepository(ctrl), nil, newPassthroughTransactionManager(ctrl), mockValidator)
 
 	tests := []struct {
 		name      string
@@ -33,6 +37,7 @@ func TestCreateCustomer(t *testing.T) {
 				Name: "John Doe", Email: "john@example.com", Phone: "123456789", Address: "123 Street", LoyaltyPts: 10,
 			},
 			mock: func() {
+				mockRepo.EXPECT().FindAllByEmailOrPhone(gomock.Any(), "john@example.com", "123456789").Return(nil, nil)
 				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Customer{CustomerID: 1, Name: "John Doe", Email: "john@example.com", Phone: "123456789", Address: "123 Street", LoyaltyPts: 10}, nil)
 			},
 			expect:    web.CustomerResponse{CustomerID: 1, Name: "John Doe", Email: "john@example.com", Phone: "123456789", Address: "123 Street", LoyaltyPts: 10},
@@ -51,11 +56,24 @@ func TestCreateCustomer(t *testing.T) {
 				Name: "Jane Doe", Email: "jane@example.com", Phone: "987654321", Address: "456 Avenue", LoyaltyPts: 20,
 			},
 			mock: func() {
+				mockRepo.EXPECT().FindAllByEmailOrPhone(gomock.Any(), "jane@example.com", "987654321").Return(nil, nil)
 				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Customer{}, errors.New("database error"))
 			},
 			expect:    web.CustomerResponse{},
 			expectErr: true,
 		},
+		{
+			name: "email already used",
+			input: web.CustomerCreateRequest{
+				Name: "Johnny", Email: "John@Example.com", Phone: "555000111",
+			},
+			mock: func() {
+				mockRepo.EXPECT().FindAllByEmailOrPhone(gomock.Any(), "John@Example.com", "555000111").
+					Return([]domain.Customer{{CustomerID: 1, Email: "john@example.com", Phone: "123456789"}}, nil)
+			},
+			expect:    web.CustomerResponse{},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,3 +89,451 @@ func TestCreateCustomer(t *testing.T) {
 		})
 	}
 }
+
+func TestFindDuplicateCustomers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCustomerRepository(ctrl)
+	customerService := NewCustomerService(mockRepo, nil, nil, nil, nil, nil, validator.New())
+
+	customers := []domain.Customer{
+		{CustomerID: 1, Name: "Budi Santoso", Email: "budi@example.com", Phone: "0812-3456-7890"},
+		{CustomerID: 2, Name: "santoso, budi", Email: "other@example.com", Phone: "+62 812 3456 7890"},
+		{CustomerID: 3, Name: "Siti Aminah", Email: " BUDI@example.com", Phone: "021 555 0101"},
+		{CustomerID: 4, Name: "Budi Santosa", Email: "budi.s@example.com", Phone: "6281234567890"},
+		{CustomerID: 5, Name: "Andi", Email: "andi@example.com", Phone: "12"},
+		{CustomerID: 6, Name: "Andi", Email: "andi2@example.com", Phone: "12"},
+	}
+	mockRepo.EXPECT().FindInBatches(gomock.Any(), ExportBatchSize, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, batchSize int, fn func(customers []domain.Customer) error) error {
+			if err := fn(customers[:3]); err != nil {
+				return err
+			}
+			return fn(customers[3:])
+		})
+
+	duplicates, err := customerService.FindDuplicates(context.Background())
+	assert.NoError(t, err)
+	type match struct {
+		customer, duplicate uint64
+		matchedOn           []string
+		confidence          string
+	}
+	var matches []match
+	for _, duplicate := range duplicates {
+		matches = append(matches, match{duplicate.Customer.CustomerID, duplicate.Duplicate.CustomerID, duplicate.MatchedOn, duplicate.Confidence})
+	}
+	assert.Equal(t, []match{
+		{1, 2, []string{DuplicateMatchPhone}, web.DuplicateConfidenceHigh},
+		{1, 4, []string{DuplicateMatchPhone}, web.DuplicateConfidenceHigh},
+		{2, 4, []string{DuplicateMatchPhone}, web.DuplicateConfidenceHigh},
+		{1, 3, []string{DuplicateMatchEmail}, web.DuplicateConfidenceLow},
+	}, matches, "phones and emails match in any format, a number too short to be one never does")
+}
+
+func TestMergeCustomers(t *testing.T) {
+	priceListId := uint64(7)
+	type repos struct {
+		customer *mocks.MockCustomerRepository
+		order    *mocks.MockOrderRepository
+		auditLog *mocks.MockAuditLogRepository
+	}
+
+	tests := []struct {
+		name      string
+		input     web.CustomerMergeRequest
+		mock      func(r repos)
+		expect    web.CustomerMergeResponse
+		expectErr error
+	}{
+		{
+			name:  "success",
+			input: web.CustomerMergeRequest{SurvivorID: 1, DuplicateIDs: []uint64{2, 3}},
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{CustomerID: 1, Name: "Budi", Phone: "0812", LoyaltyPts: 10, Version: 4}, nil)
+				r.customer.EXPECT().FindById(gomock.Any(), "2").Return(domain.Customer{CustomerID: 2, Email: "budi@example.com", LoyaltyPts: 5, Version: 1}, nil)
+				r.order.EXPECT().ReassignCustomer(gomock.Any(), "2", "1").Return(int64(3), nil)
+				r.customer.EXPECT().Delete(gomock.Any(), domain.Customer{CustomerID: 2, Email: "budi@example.com", LoyaltyPts: 5, Version: 1}).Return(nil)
+				r.customer.EXPECT().FindById(gomock.Any(), "3").Return(domain.Customer{CustomerID: 3, Email: "other@example.com", PriceListId: &priceListId, LoyaltyPts: 7, Version: 2}, nil)
+				r.order.EXPECT().ReassignCustomer(gomock.Any(), "3", "1").Return(int64(1), nil)
+				r.customer.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				r.auditLog.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, auditLog domain.AuditLog) (domain.AuditLog, error) {
+						assert.Equal(t, "merge", auditLog.Action)
+						assert.Contains(t, auditLog.Changes, `"merged_into":{"after":1,"before":null}`)
+						return auditLog, nil
+					}).Times(2)
+				r.customer.EXPECT().Update(gomock.Any(), domain.Customer{CustomerID: 1, Name: "Budi", Phone: "0812", Email: "budi@example.com", PriceListId: &priceListId, LoyaltyPts: 22, Version: 4}).
+					DoAndReturn(func(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
+						customer.Version++
+						return customer, nil
+					})
+			},
+			expect: web.CustomerMergeResponse{
+				Customer:          web.CustomerResponse{CustomerID: 1, Name: "Budi", Phone: "0812", Email: "budi@example.com", PriceListId: &priceListId, LoyaltyPts: 22, Version: 5},
+				MergedCustomerIDs: []uint64{2, 3},
+				OrdersMoved:       4,
+				LoyaltyPtsMoved:   12,
+			},
+		},
+		{
+			name:      "survivor among the duplicates",
+			input:     web.CustomerMergeRequest{SurvivorID: 1, DuplicateIDs: []uint64{2, 1}},
+			mock:      func(r repos) {},
+			expectErr: exception.BadRequestError{},
+		},
+		{
+			name:  "duplicate not found",
+			input: web.CustomerMergeRequest{SurvivorID: 1, DuplicateIDs: []uint64{9}},
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{CustomerID: 1}, nil)
+				r.customer.EXPECT().FindById(gomock.Any(), "9").Return(domain.Customer{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+		{
+			name:  "survivor modified meanwhile",
+			input: web.CustomerMergeRequest{SurvivorID: 1, DuplicateIDs: []uint64{2}},
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{CustomerID: 1}, nil)
+				r.customer.EXPECT().FindById(gomock.Any(), "2").Return(domain.Customer{CustomerID: 2}, nil)
+				r.order.EXPECT().ReassignCustomer(gomock.Any(), "2", "1").Return(int64(0), nil)
+				r.customer.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				r.auditLog.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.AuditLog{}, nil)
+				r.customer.EXPECT().Update(gomock.Any(), gomock.Any()).Return(domain.Customer{}, repository.ErrVersionConflict)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:      "no duplicates",
+			input:     web.CustomerMergeRequest{SurvivorID: 1},
+			mock:      func(r repos) {},
+			expectErr: validator.ValidationErrors{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			r := repos{
+				customer: mocks.NewMockCustomerRepository(ctrl),
+				order:    mocks.NewMockOrderRepository(ctrl),
+				auditLog: mocks.NewMockAuditLogRepository(ctrl),
+			}
+			tt.mock(r)
+
+			customerService := NewCustomerService(r.customer, r.order, r.auditLog, newMockOutboxRepository(ctrl), nil, newPassthroughTransactionManager(ctrl), validator.New())
+			response, err := customerService.Merge(context.Background(), tt.input)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, response)
+		})
+	}
+}
+
+func TestFindCustomerOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	customerRepo := mocks.NewMockCustomerRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	customerService := NewCustomerService(customerRepo, orderRepo, nil, nil, nil, nil, validator.New())
+
+	t.Run("page of orders from every store", func(t *testing.T) {
+		customerRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{CustomerID: 1}, nil)
+		orderRepo.EXPECT().FindPageByCustomer(gomock.Any(), "1", 2, 2).
+			Return([]domain.Order{{OrderID: "o-3", CustomerID: "1", StoreID: 2}, {OrderID: "o-4", CustomerID: "1", StoreID: 1}}, int64(5), nil)
+
+		response, err := customerService.FindOrders(context.Background(), web.CustomerOrdersRequest{CustomerID: 1, Page: 2, Size: 2})
+		assert.NoError(t, err)
+		assert.Len(t, response.Orders, 2)
+		assert.Equal(t, "o-3", response.Orders[0].OrderID)
+		assert.Equal(t, int64(5), response.TotalCount)
+		assert.True(t, response.HasMore)
+	})
+
+	t.Run("default page size", func(t *testing.T) {
+		customerRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{CustomerID: 1}, nil)
+		orderRepo.EXPECT().FindPageByCustomer(gomock.Any(), "1", 0, DefaultCustomerOrdersPageSize).Return(nil, int64(0), nil)
+
+		response, err := customerService.FindOrders(context.Background(), web.CustomerOrdersRequest{CustomerID: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, []web.OrderResponse{}, response.Orders)
+		assert.Equal(t, 1, response.Page)
+		assert.False(t, response.HasMore)
+	})
+
+	t.Run("customer not found", func(t *testing.T) {
+		customerRepo.EXPECT().FindById(gomock.Any(), "9").Return(domain.Customer{}, gorm.ErrRecordNotFound)
+
+		_, err := customerService.FindOrders(context.Background(), web.CustomerOrdersRequest{CustomerID: 9})
+		assert.IsType(t, exception.NotFoundError{}, err)
+	})
+
+	t.Run("page size too large", func(t *testing.T) {
+		_, err := customerService.FindOrders(context.Background(), web.CustomerOrdersRequest{CustomerID: 1, Size: 1000})
+		assert.IsType(t, validator.ValidationErrors{}, err)
+	})
+}
+
+func TestCustomerProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	customerRepo := mocks.NewMockCustomerRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	auditLogRepo := mocks.NewMockAuditLogRepository(ctrl)
+	customerService := NewCustomerService(customerRepo, orderRepo, auditLogRepo, nil, nil, nil, validator.New())
+
+	firstVisit := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	lastVisit := time.Date(2025, 3, 1, 18, 30, 0, 0, time.UTC)
+	customerRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{CustomerID: 1, Name: "Budi", LoyaltyPts: 30}, nil)
+	orderRepo.EXPECT().SummaryByCustomer(gomock.Any(), "1").
+		Return(domain.CustomerOrderSummary{OrderCount: 4, TotalSpent: 250, FirstOrderDate: &firstVisit, LastOrderDate: &lastVisit}, nil)
+	orderRepo.EXPECT().ProductPurchasesByCustomer(gomock.Any(), "1", FavouriteProductsLimit).
+		Return([]domain.CustomerProductPurchase{{ProductID: "p-1", ProductName: "Kopi Susu", Quantity: 6, OrderCount: 4, Revenue: 120, LastOrderDate: lastVisit}}, nil)
+	auditLogRepo.EXPECT().FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "customers", EntityId: "1"}).Return([]domain.AuditLog{
+		{Action: "update", Actor: "kasir", Changes: `{"loyalty_pts":{"before":10,"after":30}}`, CreatedAt: lastVisit},
+		{Action: "update", Changes: `{"customer_address":{"before":"","after":"Jl. Merdeka 1"}}`},
+		{Action: "create", Changes: `{"customer_name":{"before":null,"after":"Budi"},"loyalty_pts":{"before":null,"after":10}}`, CreatedAt: firstVisit},
+	}, nil)
+
+	response, err := customerService.Profile(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, web.CustomerSummaryResponse{TotalSpent: 250, VisitCount: 4, AverageBasket: 62.5, FirstVisit: &firstVisit, LastVisit: &lastVisit}, response.Summary)
+	assert.Equal(t, []web.CustomerFavouriteProductResponse{{ProductID: "p-1", ProductName: "Kopi Susu", Quantity: 6, OrderCount: 4, TotalSpent: 120, LastPurchased: lastVisit}}, response.FavouriteProducts)
+	assert.Equal(t, web.CustomerLoyaltyResponse{Balance: 30, History: []web.LoyaltyEntryResponse{
+		{Change: 20, Balance: 30, Action: "update", Actor: "kasir", CreatedAt: lastVisit},
+		{Change: 10, Balance: 10, Action: "create", CreatedAt: firstVisit},
+	}}, response.Loyalty)
+}
+
+func TestAnonymiseCustomer(t *testing.T) {
+	type repos struct {
+		customer *mocks.MockCustomerRepository
+		auditLog *mocks.MockAuditLogRepository
+		outbox   *mocks.MockOutboxRepository
+		delivery *mocks.MockWebhookDeliveryRepository
+	}
+
+	tests := []struct {
+		name      string
+		mock      func(r repos)
+		expect    web.CustomerAnonymiseResponse
+		expectErr error
+	}{
+		{
+			name: "success",
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "1").
+					Return(domain.Customer{CustomerID: 1, Name: "Budi", Email: "budi@example.com", Phone: "0812", Address: "Jl. Merdeka 1", LoyaltyPts: 30, Version: 2}, nil)
+				r.customer.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
+						assert.Equal(t, domain.AnonymisedCustomerName, customer.Name)
+						assert.Empty(t, customer.Email+customer.Phone+customer.Address)
+						assert.Equal(t, 30, customer.LoyaltyPts)
+						assert.NotNil(t, customer.AnonymisedAt)
+						customer.AnonymisedAt = nil
+						customer.Version++
+						return customer, nil
+					})
+				r.auditLog.EXPECT().FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "customers", Action: domain.AuditActionMerge}).Return(nil, nil)
+				r.auditLog.EXPECT().FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "customers", EntityId: "1"}).Return([]domain.AuditLog{
+					{Id: 2, Changes: `{"customer_email":{"before":"budi@example.com","after":""},"customer_name":{"before":"Budi","after":"Anonymised"}}`},
+					{Id: 1, Changes: `{"loyalty_pts":{"before":null,"after":30}}`},
+				}, nil)
+				r.auditLog.EXPECT().UpdateChanges(gomock.Any(), domain.AuditLog{
+					Id:      2,
+					Changes: `{"customer_email":{"after":"","before":"[redacted]"},"customer_name":{"after":"[redacted]","before":"[redacted]"}}`,
+				}).Return(nil)
+				r.outbox.EXPECT().FindAllByAggregate(gomock.Any(), domain.AggregateCustomer, "1").Return([]domain.OutboxEvent{
+					{Id: 7, Payload: `{"customer_id":1,"name":"Budi","loyalty_points":30}`},
+				}, nil)
+				r.outbox.EXPECT().UpdatePayload(gomock.Any(), domain.OutboxEvent{Id: 7, Payload: `{"customer_id":1,"loyalty_points":30,"name":"[redacted]"}`}).Return(nil)
+				r.delivery.EXPECT().FindAllByEventIds(gomock.Any(), []uint64{7}).Return([]domain.WebhookDelivery{
+					{Id: 3, EventID: 7, Payload: `{"id":7,"data":{"customer_id":1,"phone":"0812"}}`},
+				}, nil)
+				r.delivery.EXPECT().UpdatePayload(gomock.Any(), domain.WebhookDelivery{Id: 3, EventID: 7, Payload: `{"data":{"customer_id":1,"phone":"[redacted]"},"id":7}`}).Return(nil)
+				r.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error) {
+						assert.Equal(t, domain.EventCustomerAnonymised, event.Type)
+						assert.NotContains(t, event.Payload, "budi")
+						return event, nil
+					})
+			},
+			expect: web.CustomerAnonymiseResponse{
+				Customer:                  web.CustomerResponse{CustomerID: 1, Name: domain.AnonymisedCustomerName, LoyaltyPts: 30, Version: 3},
+				AuditLogsRedacted:         1,
+				EventsRedacted:            1,
+				WebhookDeliveriesRedacted: 1,
+			},
+		},
+		{
+			name: "customers merged into the customer are redacted too",
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{CustomerID: 1, Name: "Budi", Version: 2}, nil)
+				r.customer.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
+						customer.AnonymisedAt = nil
+						return customer, nil
+					})
+				// 4 was merged into 1, and 5 into 4 before that; 6 was merged into another customer
+				r.auditLog.EXPECT().FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "customers", Action: domain.AuditActionMerge}).Return([]domain.AuditLog{
+					{Id: 9, EntityId: "4", Action: domain.AuditActionMerge, Changes: `{"merged_into":{"before":null,"after":1}}`},
+					{Id: 8, EntityId: "6", Action: domain.AuditActionMerge, Changes: `{"merged_into":{"before":null,"after":3}}`},
+					{Id: 7, EntityId: "5", Action: domain.AuditActionMerge, Changes: `{"merged_into":{"before":null,"after":4}}`},
+				}, nil)
+				for _, customerId := range []string{"1", "4", "5"} {
+					r.outbox.EXPECT().FindAllByAggregate(gomock.Any(), domain.AggregateCustomer, customerId).Return(nil, nil)
+				}
+				r.auditLog.EXPECT().FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "customers", EntityId: "1"}).Return(nil, nil)
+				r.auditLog.EXPECT().FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "customers", EntityId: "4"}).Return([]domain.AuditLog{
+					{Id: 5, Action: "delete", Changes: `{"customer_email":{"before":"budi4@example.com","after":null},"customer_name":{"before":"Budi","after":null}}`},
+				}, nil)
+				r.auditLog.EXPECT().UpdateChanges(gomock.Any(), domain.AuditLog{
+					Id:      5,
+					Action:  "delete",
+					Changes: `{"customer_email":{"after":null,"before":"[redacted]"},"customer_name":{"after":null,"before":"[redacted]"}}`,
+				}).Return(nil)
+				r.auditLog.EXPECT().FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "customers", EntityId: "5"}).Return([]domain.AuditLog{
+					{Id: 3, Action: "delete", Changes: `{"customer_phone":{"before":"0813","after":null}}`},
+				}, nil)
+				r.auditLog.EXPECT().UpdateChanges(gomock.Any(), domain.AuditLog{
+					Id:      3,
+					Action:  "delete",
+					Changes: `{"customer_phone":{"after":null,"before":"[redacted]"}}`,
+				}).Return(nil)
+				r.delivery.EXPECT().FindAllByEventIds(gomock.Any(), []uint64{}).Return(nil, nil).Times(3)
+				r.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.OutboxEvent{}, nil)
+			},
+			expect: web.CustomerAnonymiseResponse{
+				Customer:          web.CustomerResponse{CustomerID: 1, Name: domain.AnonymisedCustomerName, Version: 2},
+				AuditLogsRedacted: 2,
+			},
+		},
+		{
+			name: "already anonymised",
+			mock: func(r repos) {
+				anonymisedAt := time.Now()
+				r.customer.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{CustomerID: 1, AnonymisedAt: &anonymisedAt}, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name: "not found",
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			r := repos{
+				customer: mocks.NewMockCustomerRepository(ctrl),
+				auditLog: mocks.NewMockAuditLogRepository(ctrl),
+				outbox:   mocks.NewMockOutboxRepository(ctrl),
+				delivery: mocks.NewMockWebhookDeliveryRepository(ctrl),
+			}
+			tt.mock(r)
+
+			customerService := NewCustomerService(r.customer, nil, r.auditLog, r.outbox, r.delivery, newPassthroughTransactionManager(ctrl), validator.New())
+			response, err := customerService.Anonymise(context.Background(), "1")
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, response)
+		})
+	}
+}
+
+func TestAnonymiseInactiveCustomers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	customerRepo := mocks.NewMockCustomerRepository(ctrl)
+	auditLogRepo := mocks.NewMockAuditLogRepository(ctrl)
+	outboxRepo := newMockOutboxRepository(ctrl)
+	deliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	customerService := NewCustomerService(customerRepo, nil, auditLogRepo, outboxRepo, deliveryRepo, newPassthroughTransactionManager(ctrl), validator.New())
+
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	firstBatch := make([]domain.Customer, CustomerRetentionBatchSize)
+	for i := range firstBatch {
+		firstBatch[i] = domain.Customer{CustomerID: uint64(i + 1), Name: "Customer"}
+	}
+	customerRepo.EXPECT().FindInactive(gomock.Any(), before, uint64(0), CustomerRetentionBatchSize).Return(firstBatch, nil)
+	customerRepo.EXPECT().FindInactive(gomock.Any(), before, uint64(CustomerRetentionBatchSize), CustomerRetentionBatchSize).
+		Return([]domain.Customer{{CustomerID: 500, Name: "Last"}}, nil)
+
+	// The customer with id 2 has been modified meanwhile and is skipped
+	customerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
+			if customer.CustomerID == 2 {
+				return domain.Customer{}, repository.ErrVersionConflict
+			}
+			return customer, nil
+		}).Times(CustomerRetentionBatchSize + 1)
+	auditLogRepo.EXPECT().FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "customers", Action: domain.AuditActionMerge}).Return(nil, nil).Times(CustomerRetentionBatchSize)
+	auditLogRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, nil).Times(CustomerRetentionBatchSize)
+	outboxRepo.EXPECT().FindAllByAggregate(gomock.Any(), domain.AggregateCustomer, gomock.Any()).Return(nil, nil).Times(CustomerRetentionBatchSize)
+	deliveryRepo.EXPECT().FindAllByEventIds(gomock.Any(), []uint64{}).Return(nil, nil).Times(CustomerRetentionBatchSize)
+
+	anonymised, err := customerService.AnonymiseInactive(context.Background(), before)
+	assert.Equal(t, CustomerRetentionBatchSize, anonymised)
+	assert.ErrorContains(t, err, "customer 2:")
+}
+
+func TestCustomerDataExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	customerRepo := mocks.NewMockCustomerRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	auditLogRepo := mocks.NewMockAuditLogRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	customerService := NewCustomerService(customerRepo, orderRepo, auditLogRepo, outboxRepo, nil, nil, validator.New())
+
+	customerRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Customer{CustomerID: 1, Name: "Budi", Email: "budi@example.com"}, nil)
+	orderRepo.EXPECT().FindAllByCustomer(gomock.Any(), "1").Return([]domain.Order{{OrderID: "o-1", CustomerID: "1", StoreID: 2}}, nil)
+	auditLogRepo.EXPECT().FindAll(gomock.Any(), domain.AuditLogFilter{EntityType: "customers", EntityId: "1"}).Return([]domain.AuditLog{
+		{Id: 1, EntityType: "customers", EntityId: "1", Action: "create", Changes: `{"loyalty_pts":{"before":null,"after":10}}`},
+	}, nil)
+	outboxRepo.EXPECT().FindAllByAggregate(gomock.Any(), domain.AggregateCustomer, "1").Return([]domain.OutboxEvent{
+		{Id: 4, Type: domain.EventCustomerUpdated, AggregateType: domain.AggregateCustomer, AggregateID: "1", Payload: `{"customer_id":1}`},
+	}, nil)
+
+	response, err := customerService.DataExport(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "budi@example.com", response.Customer.Email)
+	assert.Len(t, response.Orders, 1)
+	assert.Equal(t, []web.LoyaltyEntryResponse{{Change: 10, Balance: 10, Action: "create"}}, response.LoyaltyHistory)
+	assert.Len(t, response.AuditLog, 1)
+	assert.Len(t, response.Events, 1)
+	assert.Equal(t, domain.EventCustomerUpdated, response.Events[0].Type)
+}
+
+func TestCustomerPhoneConflictWithoutEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCustomerRepository(ctrl)
+	mockRepo.EXPECT().FindAllByEmailOrPhone(gomock.Any(), "", "0812").
+		Return([]domain.Customer{{CustomerID: 1, Phone: "0812"}}, nil)
+
+	err := checkCustomerContactsUnused(context.Background(), mockRepo, domain.Customer{CustomerID: 2, Phone: "0812"})
+	var conflict exception.ConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, "phone", conflict.Field)
+	assert.Equal(t, "phone 0812 is already used by customer 1", conflict.Error())
+}
@@ -8,7 +8,9 @@ import (
 type EmployeeService interface {
 	Create(ctx context.Context, request web.EmployeeCreateRequest) (web.EmployeeResponse, error)
 	Update(ctx context.Context, request web.EmployeeUpdateRequest) (web.EmployeeResponse, error)
-	Delete(ctx context.Context, employeeId string) error
+	Delete(ctx context.Context, employeeId string, version uint64) error
 	FindById(ctx context.Context, employeeId string) (web.EmployeeResponse, error)
 	FindAll(ctx context.Context) ([]web.EmployeeResponse, error)
+	Export(ctx context.Context, fn func(response web.EmployeeResponse) error) error
+	IssueApiKey(ctx context.Context, employeeId string) (web.EmployeeApiKeyResponse, error)
 }
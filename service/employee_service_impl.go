@@ -10,6 +10,7 @@ import (
 	"github.com/aronipurwanto/go-restful-api/repository"
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
+	"strings"
 )
 
 type EmployeeServiceImpl struct {
@@ -38,6 +39,10 @@ func (service *EmployeeServiceImpl) Create(ctx context.Context, request web.Empl
 		DateHired: request.DateHired,
 	}
 
+	if err := checkEmployeeContactsUnused(ctx, service.EmployeeRepository, employee); err != nil {
+		return web.EmployeeResponse{}, err
+	}
+
 	savedEmployee, err := service.EmployeeRepository.Save(ctx, employee)
 	if err != nil {
 		return web.EmployeeResponse{}, err
@@ -57,30 +62,45 @@ func (service *EmployeeServiceImpl) Update(ctx context.Context, request web.Empl
 	} else if err != nil {
 		return web.EmployeeResponse{}, err
 	}
+	if request.Version != 0 && request.Version != employee.Version {
+		return web.EmployeeResponse{}, exception.NewConflictError("Employee has been modified")
+	}
 
 	employee.Name = request.Name
 	employee.Role = request.Role
 	employee.Email = request.Email
 	employee.Phone = request.Phone
 	employee.DateHired = request.DateHired
+	if err := checkEmployeeContactsUnused(ctx, service.EmployeeRepository, employee); err != nil {
+		return web.EmployeeResponse{}, err
+	}
 
 	updatedEmployee, err := service.EmployeeRepository.Update(ctx, employee)
-	if err != nil {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return web.EmployeeResponse{}, exception.NewConflictError("Employee has been modified")
+	} else if err != nil {
 		return web.EmployeeResponse{}, err
 	}
 
 	return helper.ToEmployeeResponse(updatedEmployee), nil
 }
 
-func (service *EmployeeServiceImpl) Delete(ctx context.Context, employeeId string) error {
+func (service *EmployeeServiceImpl) Delete(ctx context.Context, employeeId string, version uint64) error {
 	employee, err := service.EmployeeRepository.FindById(ctx, employeeId)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return exception.NewNotFoundError("Employee not found")
 	} else if err != nil {
 		return err
 	}
+	if version != 0 && version != employee.Version {
+		return exception.NewConflictError("Employee has been modified")
+	}
 
-	return service.EmployeeRepository.Delete(ctx, employee)
+	err = service.EmployeeRepository.Delete(ctx, employee)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Employee has been modified")
+	}
+	return err
 }
 
 func (service *EmployeeServiceImpl) FindById(ctx context.Context, employeeId string) (web.EmployeeResponse, error) {
@@ -102,3 +122,71 @@ func (service *EmployeeServiceImpl) FindAll(ctx context.Context) ([]web.Employee
 
 	return helper.ToEmployeeResponses(employees), nil
 }
+
+// Export streams every employee to fn, reading ExportBatchSize rows at a time
+func (service *EmployeeServiceImpl) Export(ctx context.Context, fn func(response web.EmployeeResponse) error) error {
+	return service.EmployeeRepository.FindInBatches(ctx, ExportBatchSize, func(employees []domain.Employee) error {
+		for _, employee := range employees {
+			if err := fn(helper.ToEmployeeResponse(employee)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// IssueApiKey gives an employee a new API key, replacing the previous one. Only the system
+// API key or an admin can issue keys.
+func (service *EmployeeServiceImpl) IssueApiKey(ctx context.Context, employeeId string) (web.EmployeeApiKeyResponse, error) {
+	if helper.EmployeeIdFromContext(ctx) != "" {
+		issuer, err := requestEmployee(ctx, service.EmployeeRepository)
+		if err != nil {
+			return web.EmployeeApiKeyResponse{}, err
+		}
+		if !strings.EqualFold(issuer.Role, domain.EmployeeRoleAdmin) {
+			return web.EmployeeApiKeyResponse{}, exception.NewForbiddenError("Only admins can issue API keys")
+		}
+	}
+
+	employee, err := service.EmployeeRepository.FindById(ctx, employeeId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.EmployeeApiKeyResponse{}, exception.NewNotFoundError("Employee not found")
+	} else if err != nil {
+		return web.EmployeeApiKeyResponse{}, err
+	}
+
+	apiKey, err := helper.NewApiKey()
+	if err != nil {
+		return web.EmployeeApiKeyResponse{}, err
+	}
+	employee.ApiKeyHash = helper.HashApiKey(apiKey)
+
+	_, err = service.EmployeeRepository.Update(ctx, employee)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return web.EmployeeApiKeyResponse{}, exception.NewConflictError("Employee has been modified")
+	} else if err != nil {
+		return web.EmployeeApiKeyResponse{}, err
+	}
+
+	return web.EmployeeApiKeyResponse{EmployeeID: employee.EmployeeID, ApiKey: apiKey}, nil
+}
+
+// checkEmployeeContactsUnused fails with a field conflict when another employee, of any
+// store, has the email address or phone number of the employee. The unique indexes
+// enforce the same, this only reports it before anything is written.
+func checkEmployeeContactsUnused(ctx context.Context, employeeRepository repository.EmployeeRepository, employee domain.Employee) error {
+	others, err := employeeRepository.FindAllByEmailOrPhone(repository.WithoutStoreScope(ctx), employee.Email, employee.Phone)
+	if err != nil {
+		return err
+	}
+	for _, other := range others {
+		if other.EmployeeID == employee.EmployeeID {
+			continue
+		}
+		if employee.Email != "" && strings.EqualFold(other.Email, employee.Email) {
+			return exception.NewFieldConflictError("email", "email "+employee.Email+" is already used by employee "+other.EmployeeID)
+		}
+		return exception.NewFieldConflictError("phone", "phone "+employee.Phone+" is already used by employee "+other.EmployeeID)
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"github.com/aronipurwanto/go-restful-api/model/web"
 	"github.com/aronipurwanto/go-restful-api/repository/mocks"
@@ -33,6 +35,7 @@ func TestCreateEmployee(t *testing.T) {
 				Name: "John Doe", Role: "Developer", Email: "john@example.com", Phone: "1234567890", DateHired: "2025-01-01",
 			},
 			mock: func() {
+				mockRepo.EXPECT().FindAllByEmailOrPhone(gomock.Any(), "john@example.com", "1234567890").Return(nil, nil)
 				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Employee{
 					EmployeeID: "1", Name: "John Doe", Role: "Developer", Email: "john@example.com", Phone: "1234567890", DateHired: "2025-01-01",
 				}, nil)
@@ -49,6 +52,18 @@ func TestCreateEmployee(t *testing.T) {
 			expect:    web.EmployeeResponse{},
 			expectErr: true,
 		},
+		{
+			name: "phone already used",
+			input: web.EmployeeCreateRequest{
+				Name: "Jane Doe", Role: "Cashier", Email: "jane@example.com", Phone: "1234567890", DateHired: "2025-01-01",
+			},
+			mock: func() {
+				mockRepo.EXPECT().FindAllByEmailOrPhone(gomock.Any(), "jane@example.com", "1234567890").
+					Return([]domain.Employee{{EmployeeID: "1", Email: "john@example.com", Phone: "1234567890"}}, nil)
+			},
+			expect:    web.EmployeeResponse{},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,3 +131,51 @@ func TestFindByIdEmployee(t *testing.T) {
 		})
 	}
 }
+
+func TestIssueEmployeeApiKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEmployeeRepository(ctrl)
+	employeeService := NewEmployeeService(mockRepo, validator.New())
+	cashier := domain.Employee{EmployeeID: "e-1", Role: domain.EmployeeRoleCashier, StoreID: 3, Version: 2}
+
+	t.Run("issued with the system key", func(t *testing.T) {
+		mockRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(cashier, nil)
+		var saved domain.Employee
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, employee domain.Employee) (domain.Employee, error) {
+				saved = employee
+				return employee, nil
+			})
+
+		resp, err := employeeService.IssueApiKey(context.Background(), "e-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "e-1", resp.EmployeeID)
+		assert.Len(t, resp.ApiKey, 64)
+		assert.Equal(t, helper.HashApiKey(resp.ApiKey), saved.ApiKeyHash, "only the hash is stored")
+	})
+
+	t.Run("refused to a cashier", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), helper.ContextKeyEmployee, "e-1")
+		mockRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(cashier, nil)
+
+		_, err := employeeService.IssueApiKey(ctx, "e-1")
+		assert.Equal(t, exception.NewForbiddenError("Only admins can issue API keys"), err)
+	})
+}
+
+func TestEmployeePhoneConflictWithoutEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEmployeeRepository(ctrl)
+	mockRepo.EXPECT().FindAllByEmailOrPhone(gomock.Any(), "", "0812").
+		Return([]domain.Employee{{EmployeeID: "1", Phone: "0812"}}, nil)
+
+	err := checkEmployeeContactsUnused(context.Background(), mockRepo, domain.Employee{EmployeeID: "2", Phone: "0812"})
+	var conflict exception.ConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, "phone", conflict.Field)
+	assert.Equal(t, "phone 0812 is already used by employee 1", conflict.Error())
+}
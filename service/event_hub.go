@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"slices"
+	"sync"
+)
+
+// EventHubBufferSize is the number of events a subscriber of the hub can fall behind
+// before it is dropped.
+const EventHubBufferSize = 256
+
+// EventHub fans the events delivered to it out to the clients of the live event stream.
+// Every subscriber has a buffer of EventHubBufferSize events; a subscriber that lets it
+// fill up is dropped, by closing its channel, so that a slow client never holds up the
+// delivery of events to the others.
+type EventHub struct {
+	mutex       sync.Mutex
+	subscribers map[*EventSubscription]struct{}
+}
+
+// EventSubscription receives the events matching its request on Events until it is
+// unsubscribed or dropped, which closes Events.
+type EventSubscription struct {
+	Request web.EventStreamRequest
+	Events  <-chan web.EventEnvelope
+	events  chan web.EventEnvelope
+}
+
+func NewEventHub() *EventHub {
+	return &EventHub{subscribers: map[*EventSubscription]struct{}{}}
+}
+
+// Subscribe starts receiving the events matching the request
+func (hub *EventHub) Subscribe(request web.EventStreamRequest) *EventSubscription {
+	events := make(chan web.EventEnvelope, EventHubBufferSize)
+	subscription := &EventSubscription{Request: request, Events: events, events: events}
+
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	hub.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+// Unsubscribe stops the subscription, unless it was dropped already
+func (hub *EventHub) Unsubscribe(subscription *EventSubscription) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	hub.remove(subscription)
+}
+
+// Publish hands the event to every subscriber it matches without waiting for any of them.
+// It is an EventHandler, to subscribe the hub to the EventBus.
+func (hub *EventHub) Publish(ctx context.Context, event web.EventEnvelope) error {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	for subscription := range hub.subscribers {
+		if !matchesEventStream(subscription.Request, event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			hub.remove(subscription)
+		}
+	}
+	return nil
+}
+
+// Subscribers returns the number of subscribers
+func (hub *EventHub) Subscribers() int {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	return len(hub.subscribers)
+}
+
+func (hub *EventHub) remove(subscription *EventSubscription) {
+	if _, ok := hub.subscribers[subscription]; ok {
+		delete(hub.subscribers, subscription)
+		close(subscription.events)
+	}
+}
+
+func matchesEventStream(request web.EventStreamRequest, event web.EventEnvelope) bool {
+	if len(request.Types) > 0 && !slices.Contains(request.Types, event.Type) {
+		return false
+	}
+	if len(request.AggregateTypes) > 0 && !slices.Contains(request.AggregateTypes, event.AggregateType) {
+		return false
+	}
+	return request.StoreID == 0 || event.StoreID == 0 || event.StoreID == request.StoreID
+}
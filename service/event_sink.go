@@ -0,0 +1,168 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// EventSink receives the domain events of the outbox. Events are delivered at least
+// once, so a sink can see an event again after a failed or interrupted delivery.
+type EventSink interface {
+	Name() string
+	Deliver(ctx context.Context, event web.EventEnvelope) error
+}
+
+// EventHandler handles an event delivered to an in-process subscriber.
+type EventHandler func(ctx context.Context, event web.EventEnvelope) error
+
+// EventBus delivers events to handlers subscribed in the same process.
+type EventBus struct {
+	mutex    sync.RWMutex
+	handlers map[string][]EventHandler
+}
+
+// AllEvents subscribes a handler to every event type.
+const AllEvents = "*"
+
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: map[string][]EventHandler{}}
+}
+
+// Subscribe registers a handler for an event type, or for all of them with AllEvents
+func (bus *EventBus) Subscribe(eventType string, handler EventHandler) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.handlers[eventType] = append(bus.handlers[eventType], handler)
+}
+
+func (bus *EventBus) Name() string {
+	return "subscribers"
+}
+
+// Deliver calls every handler of the event type, also when one of them fails
+func (bus *EventBus) Deliver(ctx context.Context, event web.EventEnvelope) error {
+	bus.mutex.RLock()
+	handlers := append(append([]EventHandler{}, bus.handlers[event.Type]...), bus.handlers[AllEvents]...)
+	bus.mutex.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WebhookSink posts every event as JSON to a URL. With a secret, every request is signed
+// as described at SignWebhookPayload. Any response other than 2xx fails the delivery with
+// a WebhookResponseError.
+type WebhookSink struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+// WebhookResponseError is a webhook that answered with a status other than 2xx.
+type WebhookResponseError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (err WebhookResponseError) Error() string {
+	return fmt.Sprintf("webhook %s answered %s", err.URL, err.Status)
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (sink *WebhookSink) Name() string {
+	return "webhook " + sink.URL
+}
+
+func (sink *WebhookSink) Deliver(ctx context.Context, event web.EventEnvelope) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Event-Id", fmt.Sprint(event.Id))
+	request.Header.Set("X-Event-Type", event.Type)
+	if sink.Secret != "" {
+		timestamp := time.Now()
+		request.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+		request.Header.Set(WebhookSignatureHeader, SignWebhookPayload(sink.Secret, timestamp, body))
+	}
+
+	response, err := sink.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return WebhookResponseError{URL: sink.URL, StatusCode: response.StatusCode, Status: response.Status}
+	}
+	return nil
+}
+
+const (
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// SignWebhookPayload is the signature of a webhook request: "sha256=" followed by the hex
+// HMAC-SHA256, keyed with the secret, of the Unix timestamp in X-Webhook-Timestamp, a dot
+// and the request body. Receivers recompute it to check that a request is ours, and reject
+// old timestamps so that a captured request cannot be replayed.
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WriterSink writes every event as a line of JSON, e.g. to stdout or a file for testing.
+type WriterSink struct {
+	name   string
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+func NewWriterSink(name string, writer io.Writer) *WriterSink {
+	return &WriterSink{name: name, writer: writer}
+}
+
+func (sink *WriterSink) Name() string {
+	return sink.name
+}
+
+func (sink *WriterSink) Deliver(ctx context.Context, event web.EventEnvelope) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	_, err = sink.writer.Write(append(line, '\n'))
+	return err
+}
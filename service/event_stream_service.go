@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// EventStream is the connection to a client of the live event stream.
+type EventStream interface {
+	Send(event web.EventEnvelope) error
+	KeepAlive() error
+}
+
+type EventStreamService interface {
+	Subscribe(ctx context.Context, request web.EventStreamRequest) (*EventSubscription, error)
+	Stream(ctx context.Context, subscription *EventSubscription, stream EventStream) error
+	Unsubscribe(subscription *EventSubscription)
+}
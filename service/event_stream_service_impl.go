@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
+const (
+	// EventReplayBatchSize is the number of events read at a time when a stream is resumed.
+	EventReplayBatchSize = 100
+	// EventStreamKeepAlive is how often an idle stream is written to, which tells that the
+	// client has gone away.
+	EventStreamKeepAlive = 15 * time.Second
+)
+
+// ErrEventStreamDropped ends the stream of a client that fell behind the live events. The
+// client reconnects with the id of the last event it has seen to get the ones it missed.
+var ErrEventStreamDropped = errors.New("event stream dropped, the client fell behind")
+
+type EventStreamServiceImpl struct {
+	OutboxRepository repository.OutboxRepository
+	EventHub         *EventHub
+	Validate         *validator.Validate
+}
+
+func NewEventStreamService(outboxRepository repository.OutboxRepository, eventHub *EventHub, validate *validator.Validate) EventStreamService {
+	return &EventStreamServiceImpl{
+		OutboxRepository: outboxRepository,
+		EventHub:         eventHub,
+		Validate:         validate,
+	}
+}
+
+// Subscribe starts receiving the live events for a client, confined to the store of the
+// request when it has one. Events are only received once they are delivered from the
+// outbox, so they trail the changes by up to a dispatch interval.
+func (service *EventStreamServiceImpl) Subscribe(ctx context.Context, request web.EventStreamRequest) (*EventSubscription, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return nil, err
+	}
+	request.StoreID, _ = helper.StoreIdFromContext(ctx)
+
+	return service.EventHub.Subscribe(request), nil
+}
+
+// Stream sends the events of the subscription to the client until either goes away. A
+// resumed subscription first gets the events after the last one the client has seen,
+// read from the outbox; live events it has been sent that way are skipped. Like any
+// delivery of events, the stream is at least once and not strictly in id order, as a
+// transaction can commit an event after one with a higher id: such an event arrives live,
+// whatever its id. The stream ends with ErrEventStreamDropped when the client falls
+// behind.
+func (service *EventStreamServiceImpl) Stream(ctx context.Context, subscription *EventSubscription, stream EventStream) error {
+	replayed := map[uint64]bool{}
+	lastEventId := subscription.Request.LastEventId
+	if lastEventId != 0 {
+		for {
+			events, err := service.OutboxRepository.FindAfter(ctx, lastEventId, subscription.Request.StoreID, EventReplayBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, event := range events {
+				envelope := helper.ToEventEnvelope(event)
+				if matchesEventStream(subscription.Request, envelope) {
+					if err := stream.Send(envelope); err != nil {
+						return err
+					}
+				}
+				replayed[event.Id] = true
+				lastEventId = event.Id
+			}
+			if len(events) < EventReplayBatchSize {
+				break
+			}
+		}
+	}
+
+	keepAlive := time.NewTicker(EventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return ErrEventStreamDropped
+			}
+			if replayed[event.Id] {
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-keepAlive.C:
+			if err := stream.KeepAlive(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Unsubscribe stops receiving the live events for a client
+func (service *EventStreamServiceImpl) Unsubscribe(subscription *EventSubscription) {
+	service.EventHub.Unsubscribe(subscription)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEventHub(t *testing.T) {
+	hub := NewEventHub()
+	orders := hub.Subscribe(web.EventStreamRequest{Types: []string{domain.EventOrderPaid}, StoreID: 1})
+	everything := hub.Subscribe(web.EventStreamRequest{})
+
+	assert.NoError(t, hub.Publish(context.Background(), web.EventEnvelope{Id: 1, Type: domain.EventOrderPaid, StoreID: 1}))
+	assert.NoError(t, hub.Publish(context.Background(), web.EventEnvelope{Id: 2, Type: domain.EventOrderPaid, StoreID: 2}))
+	assert.NoError(t, hub.Publish(context.Background(), web.EventEnvelope{Id: 3, Type: domain.EventStockAdjusted, StoreID: 1}))
+	assert.NoError(t, hub.Publish(context.Background(), web.EventEnvelope{Id: 4, Type: domain.EventOrderPaid}))
+
+	assert.Equal(t, []uint64{1, 4}, receivedIds(orders), "events of other stores and types are left out")
+	assert.Equal(t, []uint64{1, 2, 3, 4}, receivedIds(everything))
+
+	for i := 0; i <= EventHubBufferSize; i++ {
+		assert.NoError(t, hub.Publish(context.Background(), web.EventEnvelope{Id: uint64(10 + i), Type: domain.EventStockAdjusted}))
+	}
+	assert.Len(t, receivedIds(everything), EventHubBufferSize)
+	_, open := <-everything.Events
+	assert.False(t, open, "a subscriber that falls behind is dropped")
+	assert.Equal(t, 1, hub.Subscribers())
+
+	hub.Unsubscribe(orders)
+	hub.Unsubscribe(everything)
+	assert.Zero(t, hub.Subscribers())
+}
+
+func TestEventStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	hub := NewEventHub()
+	eventStreamService := NewEventStreamService(outboxRepo, hub, validator.New())
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+
+	_, err := eventStreamService.Subscribe(storeCtx, web.EventStreamRequest{Types: []string{"Unknown"}})
+	assert.IsType(t, validator.ValidationErrors{}, err)
+
+	subscription, err := eventStreamService.Subscribe(storeCtx, web.EventStreamRequest{AggregateTypes: []string{domain.AggregateOrder}, LastEventId: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), subscription.Request.StoreID, "confined to the store of the request")
+
+	// events delivered while the missed ones are read are held for the client
+	assert.NoError(t, hub.Publish(context.Background(), web.EventEnvelope{Id: 7, Type: domain.EventOrderPaid, AggregateType: domain.AggregateOrder}))
+	// 4 was committed after 7, by a transaction that took longer
+	assert.NoError(t, hub.Publish(context.Background(), web.EventEnvelope{Id: 4, Type: domain.EventOrderPaid, AggregateType: domain.AggregateOrder}))
+	assert.NoError(t, hub.Publish(context.Background(), web.EventEnvelope{Id: 9, Type: domain.EventOrderPaid, AggregateType: domain.AggregateOrder}))
+	outboxRepo.EXPECT().FindAfter(gomock.Any(), uint64(5), uint64(1), EventReplayBatchSize).Return([]domain.OutboxEvent{
+		{Id: 6, Type: domain.EventProductCreated, AggregateType: domain.AggregateProduct},
+		{Id: 7, Type: domain.EventOrderPaid, AggregateType: domain.AggregateOrder, OriginStoreID: 1, Payload: "{}"},
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &recordingEventStream{stop: 3, cancel: cancel}
+	assert.NoError(t, eventStreamService.Stream(ctx, subscription, stream))
+	assert.Equal(t, []uint64{7, 4, 9}, stream.ids, "the missed events come first, without sending them twice, and late commits are not lost")
+	eventStreamService.Unsubscribe(subscription)
+
+	subscription, err = eventStreamService.Subscribe(storeCtx, web.EventStreamRequest{})
+	assert.NoError(t, err)
+	for i := 0; i <= EventHubBufferSize; i++ {
+		assert.NoError(t, hub.Publish(context.Background(), web.EventEnvelope{Id: uint64(10 + i), AggregateType: domain.AggregateOrder}))
+	}
+	stream = &recordingEventStream{}
+	err = eventStreamService.Stream(context.Background(), subscription, stream)
+	assert.ErrorIs(t, err, ErrEventStreamDropped)
+	assert.Len(t, stream.ids, EventHubBufferSize)
+	eventStreamService.Unsubscribe(subscription)
+}
+
+// recordingEventStream records the ids of the events sent, and cancels the stream once it
+// has got stop events.
+type recordingEventStream struct {
+	ids    []uint64
+	stop   int
+	cancel context.CancelFunc
+}
+
+func (stream *recordingEventStream) Send(event web.EventEnvelope) error {
+	stream.ids = append(stream.ids, event.Id)
+	if len(stream.ids) == stream.stop {
+		stream.cancel()
+	}
+	return nil
+}
+
+func (stream *recordingEventStream) KeepAlive() error {
+	return nil
+}
+
+func receivedIds(subscription *EventSubscription) []uint64 {
+	var ids []uint64
+	for {
+		select {
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, event.Id)
+		default:
+			return ids
+		}
+	}
+}
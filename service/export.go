@@ -0,0 +1,5 @@
+package service
+
+// ExportBatchSize is the number of rows read from the database at a time while
+// streaming an export.
+const ExportBatchSize = 500
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"time"
+)
+
+// restockItem books a delivery of movement.Quantity units at movement.UnitCost into the
+// store of the request: it adds them to the stock of the store and across all stores,
+// values them, writes the ledger and records when the product was last restocked.
+func restockItem(ctx context.Context, storeStockRepository repository.StoreStockRepository, productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, inventoryRepository repository.InventoryRepository, outboxRepository repository.OutboxRepository, product domain.Product, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+	if err := storeStockRepository.Adjust(ctx, movement.ProductID, movement.VariantID, movement.Quantity); err != nil {
+		return domain.InventoryMovement{}, err
+	}
+	var err error
+	if movement.VariantID != 0 {
+		err = productVariantRepository.AdjustStock(ctx, movement.VariantID, movement.Quantity)
+	} else {
+		err = productRepository.AdjustStock(ctx, movement.ProductID, movement.Quantity)
+	}
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+
+	if movement, err = addCostLayers(ctx, inventoryRepository, product, movement); err != nil {
+		return domain.InventoryMovement{}, err
+	}
+	if movement, err = saveMovement(ctx, inventoryRepository, outboxRepository, movement); err != nil {
+		return domain.InventoryMovement{}, err
+	}
+	return movement, inventoryRepository.MarkRestocked(ctx, movement.ProductID, movement.VariantID, movement.CreatedAt)
+}
+
+// adjustItem books a correction of movement.Quantity units, negative for stock lost, at
+// the store of the request and across all stores, and writes it to the ledger. Stock found
+// is valued at the product's cost price, stock lost at what it cost.
+func adjustItem(ctx context.Context, storeStockRepository repository.StoreStockRepository, productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, inventoryRepository repository.InventoryRepository, outboxRepository repository.OutboxRepository, product domain.Product, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+	delta := movement.Quantity
+	err := storeStockRepository.Adjust(ctx, movement.ProductID, movement.VariantID, delta)
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return domain.InventoryMovement{}, exception.NewConflictError("Stock at this store of product " + movement.ProductID + " would go below zero")
+	} else if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+	if movement.VariantID != 0 {
+		err = productVariantRepository.AdjustStock(ctx, movement.VariantID, delta)
+	} else {
+		err = productRepository.AdjustStock(ctx, movement.ProductID, delta)
+	}
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return domain.InventoryMovement{}, exception.NewConflictError("Stock across all stores of product " + movement.ProductID + " would go below zero")
+	} else if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+
+	movement.Type = domain.MovementTypeAdjustment
+	if delta > 0 {
+		movement.UnitCost = product.CostPrice
+		movement, err = addCostLayers(ctx, inventoryRepository, product, movement)
+	} else {
+		movement.Quantity = -delta
+		movement, err = takeCostLayers(ctx, inventoryRepository, product, movement)
+	}
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+	return saveMovement(ctx, inventoryRepository, outboxRepository, movement)
+}
+
+// saveMovement writes a movement to the inventory ledger and publishes it as a
+// StockAdjusted event.
+func saveMovement(ctx context.Context, inventoryRepository repository.InventoryRepository, outboxRepository repository.OutboxRepository, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+	movement, err := inventoryRepository.SaveMovement(ctx, movement)
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+	return movement, publishEvent(ctx, outboxRepository, domain.EventStockAdjusted, domain.AggregateProduct, movement.ProductID, helper.ToInventoryMovementResponse(movement))
+}
+
+// addCostLayers books movement.Quantity units coming into the store of the request at
+// movement.UnitCost. FIFO products get a new cost layer, weighted average products fold
+// the units into their average. It returns the movement, valued, for the ledger.
+func addCostLayers(ctx context.Context, inventoryRepository repository.InventoryRepository, product domain.Product, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+	layers, err := inventoryRepository.FindCostLayers(ctx, movement.ProductID, movement.VariantID)
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+
+	now := time.Now()
+	layer := domain.CostLayer{ProductID: movement.ProductID, VariantID: movement.VariantID}
+	if product.CostMethod != domain.CostMethodFIFO && len(layers) > 0 {
+		if layer, err = averageCostLayers(ctx, inventoryRepository, layers); err != nil {
+			return domain.InventoryMovement{}, err
+		}
+	}
+	quantity := layer.Quantity + movement.Quantity
+	layer.UnitCost = (layer.UnitCost*float64(layer.Quantity) + movement.UnitCost*float64(movement.Quantity)) / float64(quantity)
+	layer.Quantity = quantity
+	layer.ReceivedAt = now
+	if _, err := inventoryRepository.SaveCostLayer(ctx, layer); err != nil {
+		return domain.InventoryMovement{}, err
+	}
+
+	movement.TotalCost = helper.RoundMoney(movement.UnitCost * float64(movement.Quantity))
+	movement.CreatedBy = helper.ActorFromContext(ctx)
+	movement.CreatedAt = now
+	return movement, nil
+}
+
+// takeCostLayers books movement.Quantity units going out of the store of the request.
+// FIFO products take them from the oldest cost layers, weighted average products at the
+// average. Units without a cost layer, e.g. stock counted in before it was costed, go at
+// the product's cost price. It returns the movement, with a negative quantity and cost,
+// for the ledger.
+func takeCostLayers(ctx context.Context, inventoryRepository repository.InventoryRepository, product domain.Product, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+	layers, err := inventoryRepository.FindCostLayers(ctx, movement.ProductID, movement.VariantID)
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+	if product.CostMethod != domain.CostMethodFIFO && len(layers) > 1 {
+		layer, err := averageCostLayers(ctx, inventoryRepository, layers)
+		if err != nil {
+			return domain.InventoryMovement{}, err
+		}
+		layers = []domain.CostLayer{layer}
+	}
+
+	remaining := movement.Quantity
+	var cost float64
+	for _, layer := range layers {
+		if remaining == 0 {
+			break
+		}
+		taken := min(remaining, layer.Quantity)
+		cost += float64(taken) * layer.UnitCost
+		remaining -= taken
+
+		layer.Quantity -= taken
+		if layer.Quantity > 0 {
+			_, err = inventoryRepository.SaveCostLayer(ctx, layer)
+		} else {
+			err = inventoryRepository.DeleteCostLayer(ctx, layer)
+		}
+		if err != nil {
+			return domain.InventoryMovement{}, err
+		}
+	}
+	cost = helper.RoundMoney(cost + float64(remaining)*product.CostPrice)
+
+	movement.UnitCost = cost / float64(movement.Quantity)
+	movement.Quantity = -movement.Quantity
+	movement.TotalCost = -cost
+	movement.CreatedBy = helper.ActorFromContext(ctx)
+	movement.CreatedAt = time.Now()
+	return movement, nil
+}
+
+// averageCostLayers folds the cost layers of a product that is valued at weighted average
+// into the first one, which happens when a product switches from FIFO.
+func averageCostLayers(ctx context.Context, inventoryRepository repository.InventoryRepository, layers []domain.CostLayer) (domain.CostLayer, error) {
+	average := layers[0]
+	if len(layers) == 1 {
+		return average, nil
+	}
+
+	value := average.UnitCost * float64(average.Quantity)
+	for _, layer := range layers[1:] {
+		value += layer.UnitCost * float64(layer.Quantity)
+		average.Quantity += layer.Quantity
+		if err := inventoryRepository.DeleteCostLayer(ctx, layer); err != nil {
+			return domain.CostLayer{}, err
+		}
+	}
+	average.UnitCost = value / float64(average.Quantity)
+	return average, nil
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/audit_log_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/audit_log_service.go -destination=service/mocks/audit_log_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockAuditLogService is a mock of AuditLogService interface.
+type MockAuditLogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditLogServiceMockRecorder is the mock recorder for MockAuditLogService.
+type MockAuditLogServiceMockRecorder struct {
+	mock *MockAuditLogService
+}
+
+// NewMockAuditLogService creates a new mock instance.
+func NewMockAuditLogService(ctrl *gomock.Controller) *MockAuditLogService {
+	mock := &MockAuditLogService{ctrl: ctrl}
+	mock.recorder = &MockAuditLogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogService) EXPECT() *MockAuditLogServiceMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockAuditLogService) FindAll(ctx context.Context, request web.AuditLogFilterRequest) ([]web.AuditLogResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, request)
+	ret0, _ := ret[0].([]web.AuditLogResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAuditLogServiceMockRecorder) FindAll(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAuditLogService)(nil).FindAll), ctx, request)
+}
@@ -41,6 +41,21 @@ func (m *MockCategoryService) EXPECT() *MockCategoryServiceMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockCategoryService) Bulk(ctx context.Context, request web.CategoryBulkRequest) (web.BulkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", ctx, request)
+	ret0, _ := ret[0].(web.BulkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockCategoryServiceMockRecorder) Bulk(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockCategoryService)(nil).Bulk), ctx, request)
+}
+
 // Create mocks base method.
 func (m *MockCategoryService) Create(ctx context.Context, request web.CategoryCreateRequest) (web.CategoryResponse, error) {
 	m.ctrl.T.Helper()
@@ -57,47 +72,91 @@ func (mr *MockCategoryServiceMockRecorder) Create(ctx, request any) *gomock.Call
 }
 
 // Delete mocks base method.
-func (m *MockCategoryService) Delete(ctx context.Context, categoryId uint64) error {
+func (m *MockCategoryService) Delete(ctx context.Context, categoryId, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, categoryId)
+	ret := m.ctrl.Call(m, "Delete", ctx, categoryId, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCategoryServiceMockRecorder) Delete(ctx, categoryId any) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) Delete(ctx, categoryId, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryService)(nil).Delete), ctx, categoryId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryService)(nil).Delete), ctx, categoryId, version)
+}
+
+// Export mocks base method.
+func (m *MockCategoryService) Export(ctx context.Context, fn func(web.CategoryResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockCategoryServiceMockRecorder) Export(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCategoryService)(nil).Export), ctx, fn)
 }
 
 // FindAll mocks base method.
-func (m *MockCategoryService) FindAll(ctx context.Context) ([]web.CategoryResponse, error) {
+func (m *MockCategoryService) FindAll(ctx context.Context, withProductCounts bool) ([]web.CategoryResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "FindAll", ctx, withProductCounts)
 	ret0, _ := ret[0].([]web.CategoryResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockCategoryServiceMockRecorder) FindAll(ctx any) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) FindAll(ctx, withProductCounts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryService)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryService)(nil).FindAll), ctx, withProductCounts)
 }
 
 // FindById mocks base method.
-func (m *MockCategoryService) FindById(ctx context.Context, categoryId uint64) (web.CategoryResponse, error) {
+func (m *MockCategoryService) FindById(ctx context.Context, categoryId uint64, withProductCounts bool) (web.CategoryResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", ctx, categoryId)
+	ret := m.ctrl.Call(m, "FindById", ctx, categoryId, withProductCounts)
 	ret0, _ := ret[0].(web.CategoryResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindById indicates an expected call of FindById.
-func (mr *MockCategoryServiceMockRecorder) FindById(ctx, categoryId any) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) FindById(ctx, categoryId, withProductCounts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCategoryService)(nil).FindById), ctx, categoryId, withProductCounts)
+}
+
+// FindTree mocks base method.
+func (m *MockCategoryService) FindTree(ctx context.Context, withProductCounts bool) ([]web.CategoryTreeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTree", ctx, withProductCounts)
+	ret0, _ := ret[0].([]web.CategoryTreeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTree indicates an expected call of FindTree.
+func (mr *MockCategoryServiceMockRecorder) FindTree(ctx, withProductCounts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTree", reflect.TypeOf((*MockCategoryService)(nil).FindTree), ctx, withProductCounts)
+}
+
+// Move mocks base method.
+func (m *MockCategoryService) Move(ctx context.Context, request web.CategoryMoveRequest) (web.CategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, request)
+	ret0, _ := ret[0].(web.CategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockCategoryServiceMockRecorder) Move(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCategoryService)(nil).FindById), ctx, categoryId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockCategoryService)(nil).Move), ctx, request)
 }
 
 // Update mocks base method.
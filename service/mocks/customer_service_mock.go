@@ -13,6 +13,7 @@ import (
 	context "context"
 	"github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 
 	web "github.com/aronipurwanto/go-restful-api/model/web"
 )
@@ -41,6 +42,51 @@ func (m *MockCustomerService) EXPECT() *MockCustomerServiceMockRecorder {
 	return m.recorder
 }
 
+// Anonymise mocks base method.
+func (m *MockCustomerService) Anonymise(ctx context.Context, customerId string) (web.CustomerAnonymiseResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymise", ctx, customerId)
+	ret0, _ := ret[0].(web.CustomerAnonymiseResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Anonymise indicates an expected call of Anonymise.
+func (mr *MockCustomerServiceMockRecorder) Anonymise(ctx, customerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymise", reflect.TypeOf((*MockCustomerService)(nil).Anonymise), ctx, customerId)
+}
+
+// AnonymiseInactive mocks base method.
+func (m *MockCustomerService) AnonymiseInactive(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymiseInactive", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymiseInactive indicates an expected call of AnonymiseInactive.
+func (mr *MockCustomerServiceMockRecorder) AnonymiseInactive(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymiseInactive", reflect.TypeOf((*MockCustomerService)(nil).AnonymiseInactive), ctx, before)
+}
+
+// Bulk mocks base method.
+func (m *MockCustomerService) Bulk(ctx context.Context, request web.CustomerBulkRequest) (web.BulkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", ctx, request)
+	ret0, _ := ret[0].(web.BulkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockCustomerServiceMockRecorder) Bulk(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockCustomerService)(nil).Bulk), ctx, request)
+}
+
 // Create mocks base method.
 func (m *MockCustomerService) Create(ctx context.Context, request web.CustomerCreateRequest) (web.CustomerResponse, error) {
 	m.ctrl.T.Helper()
@@ -56,18 +102,47 @@ func (mr *MockCustomerServiceMockRecorder) Create(ctx, request any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerService)(nil).Create), ctx, request)
 }
 
+// DataExport mocks base method.
+func (m *MockCustomerService) DataExport(ctx context.Context, customerId string) (web.CustomerDataExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataExport", ctx, customerId)
+	ret0, _ := ret[0].(web.CustomerDataExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DataExport indicates an expected call of DataExport.
+func (mr *MockCustomerServiceMockRecorder) DataExport(ctx, customerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataExport", reflect.TypeOf((*MockCustomerService)(nil).DataExport), ctx, customerId)
+}
+
 // Delete mocks base method.
-func (m *MockCustomerService) Delete(ctx context.Context, customerId string) error {
+func (m *MockCustomerService) Delete(ctx context.Context, customerId string, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, customerId)
+	ret := m.ctrl.Call(m, "Delete", ctx, customerId, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCustomerServiceMockRecorder) Delete(ctx, customerId any) *gomock.Call {
+func (mr *MockCustomerServiceMockRecorder) Delete(ctx, customerId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerService)(nil).Delete), ctx, customerId, version)
+}
+
+// Export mocks base method.
+func (m *MockCustomerService) Export(ctx context.Context, fn func(web.CustomerResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockCustomerServiceMockRecorder) Export(ctx, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerService)(nil).Delete), ctx, customerId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCustomerService)(nil).Export), ctx, fn)
 }
 
 // FindAll mocks base method.
@@ -100,6 +175,66 @@ func (mr *MockCustomerServiceMockRecorder) FindById(ctx, customerId any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCustomerService)(nil).FindById), ctx, customerId)
 }
 
+// FindDuplicates mocks base method.
+func (m *MockCustomerService) FindDuplicates(ctx context.Context) ([]web.CustomerDuplicateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicates", ctx)
+	ret0, _ := ret[0].([]web.CustomerDuplicateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicates indicates an expected call of FindDuplicates.
+func (mr *MockCustomerServiceMockRecorder) FindDuplicates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicates", reflect.TypeOf((*MockCustomerService)(nil).FindDuplicates), ctx)
+}
+
+// FindOrders mocks base method.
+func (m *MockCustomerService) FindOrders(ctx context.Context, request web.CustomerOrdersRequest) (web.CustomerOrdersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrders", ctx, request)
+	ret0, _ := ret[0].(web.CustomerOrdersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrders indicates an expected call of FindOrders.
+func (mr *MockCustomerServiceMockRecorder) FindOrders(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrders", reflect.TypeOf((*MockCustomerService)(nil).FindOrders), ctx, request)
+}
+
+// Merge mocks base method.
+func (m *MockCustomerService) Merge(ctx context.Context, request web.CustomerMergeRequest) (web.CustomerMergeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, request)
+	ret0, _ := ret[0].(web.CustomerMergeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockCustomerServiceMockRecorder) Merge(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockCustomerService)(nil).Merge), ctx, request)
+}
+
+// Profile mocks base method.
+func (m *MockCustomerService) Profile(ctx context.Context, customerId string) (web.CustomerProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile", ctx, customerId)
+	ret0, _ := ret[0].(web.CustomerProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *MockCustomerServiceMockRecorder) Profile(ctx, customerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockCustomerService)(nil).Profile), ctx, customerId)
+}
+
 // Update mocks base method.
 func (m *MockCustomerService) Update(ctx context.Context, request web.CustomerUpdateRequest) (web.CustomerResponse, error) {
 	m.ctrl.T.Helper()
@@ -57,17 +57,31 @@ func (mr *MockEmployeeServiceMockRecorder) Create(ctx, request any) *gomock.Call
 }
 
 // Delete mocks base method.
-func (m *MockEmployeeService) Delete(ctx context.Context, employeeId string) error {
+func (m *MockEmployeeService) Delete(ctx context.Context, employeeId string, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, employeeId)
+	ret := m.ctrl.Call(m, "Delete", ctx, employeeId, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockEmployeeServiceMockRecorder) Delete(ctx, employeeId any) *gomock.Call {
+func (mr *MockEmployeeServiceMockRecorder) Delete(ctx, employeeId, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEmployeeService)(nil).Delete), ctx, employeeId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEmployeeService)(nil).Delete), ctx, employeeId, version)
+}
+
+// Export mocks base method.
+func (m *MockEmployeeService) Export(ctx context.Context, fn func(web.EmployeeResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockEmployeeServiceMockRecorder) Export(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockEmployeeService)(nil).Export), ctx, fn)
 }
 
 // FindAll mocks base method.
@@ -100,6 +114,21 @@ func (mr *MockEmployeeServiceMockRecorder) FindById(ctx, employeeId any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockEmployeeService)(nil).FindById), ctx, employeeId)
 }
 
+// IssueApiKey mocks base method.
+func (m *MockEmployeeService) IssueApiKey(ctx context.Context, employeeId string) (web.EmployeeApiKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueApiKey", ctx, employeeId)
+	ret0, _ := ret[0].(web.EmployeeApiKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueApiKey indicates an expected call of IssueApiKey.
+func (mr *MockEmployeeServiceMockRecorder) IssueApiKey(ctx, employeeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueApiKey", reflect.TypeOf((*MockEmployeeService)(nil).IssueApiKey), ctx, employeeId)
+}
+
 // Update mocks base method.
 func (m *MockEmployeeService) Update(ctx context.Context, request web.EmployeeUpdateRequest) (web.EmployeeResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/order_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/order_service.go -destination=service/mocks/order_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockOrderService is a mock of OrderService interface.
+type MockOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderServiceMockRecorder is the mock recorder for MockOrderService.
+type MockOrderServiceMockRecorder struct {
+	mock *MockOrderService
+}
+
+// NewMockOrderService creates a new mock instance.
+func NewMockOrderService(ctrl *gomock.Controller) *MockOrderService {
+	mock := &MockOrderService{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// AddPayment mocks base method.
+func (m *MockOrderService) AddPayment(ctx context.Context, request web.PaymentCreateRequest) (web.PaymentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPayment", ctx, request)
+	ret0, _ := ret[0].(web.PaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPayment indicates an expected call of AddPayment.
+func (mr *MockOrderServiceMockRecorder) AddPayment(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPayment", reflect.TypeOf((*MockOrderService)(nil).AddPayment), ctx, request)
+}
+
+// Create mocks base method.
+func (m *MockOrderService) Create(ctx context.Context, request web.OrderCreateRequest) (web.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(web.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderService)(nil).Create), ctx, request)
+}
+
+// Export mocks base method.
+func (m *MockOrderService) Export(ctx context.Context, fn func(web.OrderResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockOrderServiceMockRecorder) Export(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockOrderService)(nil).Export), ctx, fn)
+}
+
+// FindAll mocks base method.
+func (m *MockOrderService) FindAll(ctx context.Context) ([]web.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]web.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockOrderServiceMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderService)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockOrderService) FindById(ctx context.Context, orderId string) (web.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, orderId)
+	ret0, _ := ret[0].(web.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockOrderServiceMockRecorder) FindById(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderService)(nil).FindById), ctx, orderId)
+}
+
+// FindOffline mocks base method.
+func (m *MockOrderService) FindOffline(ctx context.Context, status string) ([]web.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOffline", ctx, status)
+	ret0, _ := ret[0].([]web.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOffline indicates an expected call of FindOffline.
+func (mr *MockOrderServiceMockRecorder) FindOffline(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOffline", reflect.TypeOf((*MockOrderService)(nil).FindOffline), ctx, status)
+}
+
+// UploadOffline mocks base method.
+func (m *MockOrderService) UploadOffline(ctx context.Context, request web.OfflineOrderUploadRequest) (web.OfflineOrderUploadResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadOffline", ctx, request)
+	ret0, _ := ret[0].(web.OfflineOrderUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadOffline indicates an expected call of UploadOffline.
+func (mr *MockOrderServiceMockRecorder) UploadOffline(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadOffline", reflect.TypeOf((*MockOrderService)(nil).UploadOffline), ctx, request)
+}
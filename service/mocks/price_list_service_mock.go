@@ -0,0 +1,190 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/price_list_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/price_list_service.go -destination=service/mocks/price_list_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockPriceListService is a mock of PriceListService interface.
+type MockPriceListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceListServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceListServiceMockRecorder is the mock recorder for MockPriceListService.
+type MockPriceListServiceMockRecorder struct {
+	mock *MockPriceListService
+}
+
+// NewMockPriceListService creates a new mock instance.
+func NewMockPriceListService(ctrl *gomock.Controller) *MockPriceListService {
+	mock := &MockPriceListService{ctrl: ctrl}
+	mock.recorder = &MockPriceListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceListService) EXPECT() *MockPriceListServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPriceListService) Create(ctx context.Context, request web.PriceListCreateRequest) (web.PriceListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(web.PriceListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPriceListServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPriceListService)(nil).Create), ctx, request)
+}
+
+// Delete mocks base method.
+func (m *MockPriceListService) Delete(ctx context.Context, priceListId, version uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, priceListId, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPriceListServiceMockRecorder) Delete(ctx, priceListId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPriceListService)(nil).Delete), ctx, priceListId, version)
+}
+
+// DeleteItem mocks base method.
+func (m *MockPriceListService) DeleteItem(ctx context.Context, priceListId uint64, productId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, priceListId, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockPriceListServiceMockRecorder) DeleteItem(ctx, priceListId, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockPriceListService)(nil).DeleteItem), ctx, priceListId, productId)
+}
+
+// FindAll mocks base method.
+func (m *MockPriceListService) FindAll(ctx context.Context) ([]web.PriceListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]web.PriceListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPriceListServiceMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPriceListService)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockPriceListService) FindById(ctx context.Context, priceListId uint64) (web.PriceListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, priceListId)
+	ret0, _ := ret[0].(web.PriceListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPriceListServiceMockRecorder) FindById(ctx, priceListId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPriceListService)(nil).FindById), ctx, priceListId)
+}
+
+// FindItems mocks base method.
+func (m *MockPriceListService) FindItems(ctx context.Context, priceListId uint64) ([]web.PriceListItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindItems", ctx, priceListId)
+	ret0, _ := ret[0].([]web.PriceListItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindItems indicates an expected call of FindItems.
+func (mr *MockPriceListServiceMockRecorder) FindItems(ctx, priceListId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindItems", reflect.TypeOf((*MockPriceListService)(nil).FindItems), ctx, priceListId)
+}
+
+// FindPriceHistory mocks base method.
+func (m *MockPriceListService) FindPriceHistory(ctx context.Context, productId string) ([]web.PriceHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPriceHistory", ctx, productId)
+	ret0, _ := ret[0].([]web.PriceHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPriceHistory indicates an expected call of FindPriceHistory.
+func (mr *MockPriceListServiceMockRecorder) FindPriceHistory(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPriceHistory", reflect.TypeOf((*MockPriceListService)(nil).FindPriceHistory), ctx, productId)
+}
+
+// ResolvePrice mocks base method.
+func (m *MockPriceListService) ResolvePrice(ctx context.Context, query web.PriceQuery) (web.ResolvedPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvePrice", ctx, query)
+	ret0, _ := ret[0].(web.ResolvedPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolvePrice indicates an expected call of ResolvePrice.
+func (mr *MockPriceListServiceMockRecorder) ResolvePrice(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePrice", reflect.TypeOf((*MockPriceListService)(nil).ResolvePrice), ctx, query)
+}
+
+// SetItem mocks base method.
+func (m *MockPriceListService) SetItem(ctx context.Context, request web.PriceListItemRequest) (web.PriceListItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItem", ctx, request)
+	ret0, _ := ret[0].(web.PriceListItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetItem indicates an expected call of SetItem.
+func (mr *MockPriceListServiceMockRecorder) SetItem(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItem", reflect.TypeOf((*MockPriceListService)(nil).SetItem), ctx, request)
+}
+
+// Update mocks base method.
+func (m *MockPriceListService) Update(ctx context.Context, request web.PriceListUpdateRequest) (web.PriceListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, request)
+	ret0, _ := ret[0].(web.PriceListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPriceListServiceMockRecorder) Update(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPriceListService)(nil).Update), ctx, request)
+}
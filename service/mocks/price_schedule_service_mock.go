@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/price_schedule_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/price_schedule_service.go -destination=service/mocks/price_schedule_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockPriceScheduleService is a mock of PriceScheduleService interface.
+type MockPriceScheduleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceScheduleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceScheduleServiceMockRecorder is the mock recorder for MockPriceScheduleService.
+type MockPriceScheduleServiceMockRecorder struct {
+	mock *MockPriceScheduleService
+}
+
+// NewMockPriceScheduleService creates a new mock instance.
+func NewMockPriceScheduleService(ctrl *gomock.Controller) *MockPriceScheduleService {
+	mock := &MockPriceScheduleService{ctrl: ctrl}
+	mock.recorder = &MockPriceScheduleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceScheduleService) EXPECT() *MockPriceScheduleServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyDue mocks base method.
+func (m *MockPriceScheduleService) ApplyDue(ctx context.Context, now time.Time) (web.PriceScheduleRunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDue", ctx, now)
+	ret0, _ := ret[0].(web.PriceScheduleRunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyDue indicates an expected call of ApplyDue.
+func (mr *MockPriceScheduleServiceMockRecorder) ApplyDue(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDue", reflect.TypeOf((*MockPriceScheduleService)(nil).ApplyDue), ctx, now)
+}
+
+// Cancel mocks base method.
+func (m *MockPriceScheduleService) Cancel(ctx context.Context, scheduleId uint64) (web.PriceScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, scheduleId)
+	ret0, _ := ret[0].(web.PriceScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockPriceScheduleServiceMockRecorder) Cancel(ctx, scheduleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockPriceScheduleService)(nil).Cancel), ctx, scheduleId)
+}
+
+// Create mocks base method.
+func (m *MockPriceScheduleService) Create(ctx context.Context, request web.PriceScheduleCreateRequest) (web.PriceScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(web.PriceScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPriceScheduleServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPriceScheduleService)(nil).Create), ctx, request)
+}
+
+// FindAll mocks base method.
+func (m *MockPriceScheduleService) FindAll(ctx context.Context, filter web.PriceScheduleFilterRequest) ([]web.PriceScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
+	ret0, _ := ret[0].([]web.PriceScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPriceScheduleServiceMockRecorder) FindAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPriceScheduleService)(nil).FindAll), ctx, filter)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/product_barcode_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/product_barcode_service.go -destination=service/mocks/product_barcode_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	io "io"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockProductBarcodeService is a mock of ProductBarcodeService interface.
+type MockProductBarcodeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductBarcodeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProductBarcodeServiceMockRecorder is the mock recorder for MockProductBarcodeService.
+type MockProductBarcodeServiceMockRecorder struct {
+	mock *MockProductBarcodeService
+}
+
+// NewMockProductBarcodeService creates a new mock instance.
+func NewMockProductBarcodeService(ctrl *gomock.Controller) *MockProductBarcodeService {
+	mock := &MockProductBarcodeService{ctrl: ctrl}
+	mock.recorder = &MockProductBarcodeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductBarcodeService) EXPECT() *MockProductBarcodeServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProductBarcodeService) Create(ctx context.Context, request web.ProductBarcodeCreateRequest) (web.ProductBarcodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(web.ProductBarcodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProductBarcodeServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductBarcodeService)(nil).Create), ctx, request)
+}
+
+// Delete mocks base method.
+func (m *MockProductBarcodeService) Delete(ctx context.Context, productId, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, productId, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductBarcodeServiceMockRecorder) Delete(ctx, productId, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductBarcodeService)(nil).Delete), ctx, productId, code)
+}
+
+// FindAll mocks base method.
+func (m *MockProductBarcodeService) FindAll(ctx context.Context, productId string) ([]web.ProductBarcodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, productId)
+	ret0, _ := ret[0].([]web.ProductBarcodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockProductBarcodeServiceMockRecorder) FindAll(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductBarcodeService)(nil).FindAll), ctx, productId)
+}
+
+// GenerateMissing mocks base method.
+func (m *MockProductBarcodeService) GenerateMissing(ctx context.Context) ([]web.ProductBarcodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateMissing", ctx)
+	ret0, _ := ret[0].([]web.ProductBarcodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateMissing indicates an expected call of GenerateMissing.
+func (mr *MockProductBarcodeServiceMockRecorder) GenerateMissing(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMissing", reflect.TypeOf((*MockProductBarcodeService)(nil).GenerateMissing), ctx)
+}
+
+// Lookup mocks base method.
+func (m *MockProductBarcodeService) Lookup(ctx context.Context, code string) (web.ProductBarcodeLookupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", ctx, code)
+	ret0, _ := ret[0].(web.ProductBarcodeLookupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockProductBarcodeServiceMockRecorder) Lookup(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockProductBarcodeService)(nil).Lookup), ctx, code)
+}
+
+// RenderLabels mocks base method.
+func (m *MockProductBarcodeService) RenderLabels(ctx context.Context, request web.BarcodeLabelRequest, writer io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderLabels", ctx, request, writer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenderLabels indicates an expected call of RenderLabels.
+func (mr *MockProductBarcodeServiceMockRecorder) RenderLabels(ctx, request, writer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderLabels", reflect.TypeOf((*MockProductBarcodeService)(nil).RenderLabels), ctx, request, writer)
+}
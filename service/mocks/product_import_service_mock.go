@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/product_import_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/product_import_service.go -destination=service/mocks/product_import_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockProductImportService is a mock of ProductImportService interface.
+type MockProductImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductImportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProductImportServiceMockRecorder is the mock recorder for MockProductImportService.
+type MockProductImportServiceMockRecorder struct {
+	mock *MockProductImportService
+}
+
+// NewMockProductImportService creates a new mock instance.
+func NewMockProductImportService(ctrl *gomock.Controller) *MockProductImportService {
+	mock := &MockProductImportService{ctrl: ctrl}
+	mock.recorder = &MockProductImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductImportService) EXPECT() *MockProductImportServiceMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockProductImportService) Import(ctx context.Context, request web.ProductImportRequest) (web.ProductImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, request)
+	ret0, _ := ret[0].(web.ProductImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockProductImportServiceMockRecorder) Import(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockProductImportService)(nil).Import), ctx, request)
+}
@@ -41,6 +41,21 @@ func (m *MockProductService) EXPECT() *MockProductServiceMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockProductService) Bulk(ctx context.Context, request web.ProductBulkRequest) (web.BulkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", ctx, request)
+	ret0, _ := ret[0].(web.BulkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockProductServiceMockRecorder) Bulk(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockProductService)(nil).Bulk), ctx, request)
+}
+
 // Create mocks base method.
 func (m *MockProductService) Create(ctx context.Context, request web.ProductCreateRequest) (web.ProductResponse, error) {
 	m.ctrl.T.Helper()
@@ -57,17 +72,31 @@ func (mr *MockProductServiceMockRecorder) Create(ctx, request any) *gomock.Call
 }
 
 // Delete mocks base method.
-func (m *MockProductService) Delete(ctx context.Context, productId string) error {
+func (m *MockProductService) Delete(ctx context.Context, productId string, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, productId)
+	ret := m.ctrl.Call(m, "Delete", ctx, productId, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockProductServiceMockRecorder) Delete(ctx, productId any) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) Delete(ctx, productId, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductService)(nil).Delete), ctx, productId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductService)(nil).Delete), ctx, productId, version)
+}
+
+// Export mocks base method.
+func (m *MockProductService) Export(ctx context.Context, fn func(web.ProductResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockProductServiceMockRecorder) Export(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockProductService)(nil).Export), ctx, fn)
 }
 
 // FindAll mocks base method.
@@ -85,6 +114,21 @@ func (mr *MockProductServiceMockRecorder) FindAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductService)(nil).FindAll), ctx)
 }
 
+// FindAllByCategory mocks base method.
+func (m *MockProductService) FindAllByCategory(ctx context.Context, categoryId uint64) ([]web.ProductResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByCategory", ctx, categoryId)
+	ret0, _ := ret[0].([]web.ProductResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByCategory indicates an expected call of FindAllByCategory.
+func (mr *MockProductServiceMockRecorder) FindAllByCategory(ctx, categoryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCategory", reflect.TypeOf((*MockProductService)(nil).FindAllByCategory), ctx, categoryId)
+}
+
 // FindById mocks base method.
 func (m *MockProductService) FindById(ctx context.Context, productId string) (web.ProductResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/purchase_order_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/purchase_order_service.go -destination=service/mocks/purchase_order_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockPurchaseOrderService is a mock of PurchaseOrderService interface.
+type MockPurchaseOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseOrderServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPurchaseOrderServiceMockRecorder is the mock recorder for MockPurchaseOrderService.
+type MockPurchaseOrderServiceMockRecorder struct {
+	mock *MockPurchaseOrderService
+}
+
+// NewMockPurchaseOrderService creates a new mock instance.
+func NewMockPurchaseOrderService(ctrl *gomock.Controller) *MockPurchaseOrderService {
+	mock := &MockPurchaseOrderService{ctrl: ctrl}
+	mock.recorder = &MockPurchaseOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseOrderService) EXPECT() *MockPurchaseOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPurchaseOrderService) Close(ctx context.Context, orderId, version uint64) (web.PurchaseOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, orderId, version)
+	ret0, _ := ret[0].(web.PurchaseOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPurchaseOrderServiceMockRecorder) Close(ctx, orderId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPurchaseOrderService)(nil).Close), ctx, orderId, version)
+}
+
+// Create mocks base method.
+func (m *MockPurchaseOrderService) Create(ctx context.Context, request web.PurchaseOrderCreateRequest) (web.PurchaseOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(web.PurchaseOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPurchaseOrderServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPurchaseOrderService)(nil).Create), ctx, request)
+}
+
+// Delete mocks base method.
+func (m *MockPurchaseOrderService) Delete(ctx context.Context, orderId, version uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, orderId, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPurchaseOrderServiceMockRecorder) Delete(ctx, orderId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPurchaseOrderService)(nil).Delete), ctx, orderId, version)
+}
+
+// FindAll mocks base method.
+func (m *MockPurchaseOrderService) FindAll(ctx context.Context, filter web.PurchaseOrderFilterRequest) ([]web.PurchaseOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
+	ret0, _ := ret[0].([]web.PurchaseOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPurchaseOrderServiceMockRecorder) FindAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPurchaseOrderService)(nil).FindAll), ctx, filter)
+}
+
+// FindById mocks base method.
+func (m *MockPurchaseOrderService) FindById(ctx context.Context, orderId uint64) (web.PurchaseOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, orderId)
+	ret0, _ := ret[0].(web.PurchaseOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPurchaseOrderServiceMockRecorder) FindById(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPurchaseOrderService)(nil).FindById), ctx, orderId)
+}
+
+// Generate mocks base method.
+func (m *MockPurchaseOrderService) Generate(ctx context.Context) (web.PurchaseOrderGenerateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", ctx)
+	ret0, _ := ret[0].(web.PurchaseOrderGenerateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockPurchaseOrderServiceMockRecorder) Generate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockPurchaseOrderService)(nil).Generate), ctx)
+}
+
+// Receive mocks base method.
+func (m *MockPurchaseOrderService) Receive(ctx context.Context, request web.GoodsReceiptRequest) (web.PurchaseOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, request)
+	ret0, _ := ret[0].(web.PurchaseOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockPurchaseOrderServiceMockRecorder) Receive(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockPurchaseOrderService)(nil).Receive), ctx, request)
+}
+
+// Send mocks base method.
+func (m *MockPurchaseOrderService) Send(ctx context.Context, orderId, version uint64) (web.PurchaseOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, orderId, version)
+	ret0, _ := ret[0].(web.PurchaseOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockPurchaseOrderServiceMockRecorder) Send(ctx, orderId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockPurchaseOrderService)(nil).Send), ctx, orderId, version)
+}
+
+// Update mocks base method.
+func (m *MockPurchaseOrderService) Update(ctx context.Context, request web.PurchaseOrderUpdateRequest) (web.PurchaseOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, request)
+	ret0, _ := ret[0].(web.PurchaseOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPurchaseOrderServiceMockRecorder) Update(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPurchaseOrderService)(nil).Update), ctx, request)
+}
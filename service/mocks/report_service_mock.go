@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/report_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/report_service.go -destination=service/mocks/report_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockReportService is a mock of ReportService interface.
+type MockReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReportServiceMockRecorder is the mock recorder for MockReportService.
+type MockReportServiceMockRecorder struct {
+	mock *MockReportService
+}
+
+// NewMockReportService creates a new mock instance.
+func NewMockReportService(ctrl *gomock.Controller) *MockReportService {
+	mock := &MockReportService{ctrl: ctrl}
+	mock.recorder = &MockReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportService) EXPECT() *MockReportServiceMockRecorder {
+	return m.recorder
+}
+
+// Categories mocks base method.
+func (m *MockReportService) Categories(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categories", ctx, request)
+	ret0, _ := ret[0].(web.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Categories indicates an expected call of Categories.
+func (mr *MockReportServiceMockRecorder) Categories(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categories", reflect.TypeOf((*MockReportService)(nil).Categories), ctx, request)
+}
+
+// Discounts mocks base method.
+func (m *MockReportService) Discounts(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discounts", ctx, request)
+	ret0, _ := ret[0].(web.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Discounts indicates an expected call of Discounts.
+func (mr *MockReportServiceMockRecorder) Discounts(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discounts", reflect.TypeOf((*MockReportService)(nil).Discounts), ctx, request)
+}
+
+// InventoryValuation mocks base method.
+func (m *MockReportService) InventoryValuation(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InventoryValuation", ctx, request)
+	ret0, _ := ret[0].(web.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InventoryValuation indicates an expected call of InventoryValuation.
+func (mr *MockReportServiceMockRecorder) InventoryValuation(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InventoryValuation", reflect.TypeOf((*MockReportService)(nil).InventoryValuation), ctx, request)
+}
+
+// Margins mocks base method.
+func (m *MockReportService) Margins(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Margins", ctx, request)
+	ret0, _ := ret[0].(web.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Margins indicates an expected call of Margins.
+func (mr *MockReportServiceMockRecorder) Margins(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Margins", reflect.TypeOf((*MockReportService)(nil).Margins), ctx, request)
+}
+
+// PaymentTypes mocks base method.
+func (m *MockReportService) PaymentTypes(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaymentTypes", ctx, request)
+	ret0, _ := ret[0].(web.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaymentTypes indicates an expected call of PaymentTypes.
+func (mr *MockReportServiceMockRecorder) PaymentTypes(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentTypes", reflect.TypeOf((*MockReportService)(nil).PaymentTypes), ctx, request)
+}
+
+// Sales mocks base method.
+func (m *MockReportService) Sales(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sales", ctx, request)
+	ret0, _ := ret[0].(web.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sales indicates an expected call of Sales.
+func (mr *MockReportServiceMockRecorder) Sales(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sales", reflect.TypeOf((*MockReportService)(nil).Sales), ctx, request)
+}
+
+// Taxes mocks base method.
+func (m *MockReportService) Taxes(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Taxes", ctx, request)
+	ret0, _ := ret[0].(web.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Taxes indicates an expected call of Taxes.
+func (mr *MockReportServiceMockRecorder) Taxes(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Taxes", reflect.TypeOf((*MockReportService)(nil).Taxes), ctx, request)
+}
+
+// TopProducts mocks base method.
+func (m *MockReportService) TopProducts(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopProducts", ctx, request)
+	ret0, _ := ret[0].(web.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopProducts indicates an expected call of TopProducts.
+func (mr *MockReportServiceMockRecorder) TopProducts(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopProducts", reflect.TypeOf((*MockReportService)(nil).TopProducts), ctx, request)
+}
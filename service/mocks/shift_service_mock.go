@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/shift_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/shift_service.go -destination=service/mocks/shift_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockShiftService is a mock of ShiftService interface.
+type MockShiftService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShiftServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockShiftServiceMockRecorder is the mock recorder for MockShiftService.
+type MockShiftServiceMockRecorder struct {
+	mock *MockShiftService
+}
+
+// NewMockShiftService creates a new mock instance.
+func NewMockShiftService(ctrl *gomock.Controller) *MockShiftService {
+	mock := &MockShiftService{ctrl: ctrl}
+	mock.recorder = &MockShiftServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShiftService) EXPECT() *MockShiftServiceMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockShiftService) Close(ctx context.Context, request web.ShiftCloseRequest) (web.ShiftResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, request)
+	ret0, _ := ret[0].(web.ShiftResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockShiftServiceMockRecorder) Close(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockShiftService)(nil).Close), ctx, request)
+}
+
+// FindAll mocks base method.
+func (m *MockShiftService) FindAll(ctx context.Context, filter web.ShiftFilterRequest) ([]web.ShiftResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
+	ret0, _ := ret[0].([]web.ShiftResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockShiftServiceMockRecorder) FindAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockShiftService)(nil).FindAll), ctx, filter)
+}
+
+// FindById mocks base method.
+func (m *MockShiftService) FindById(ctx context.Context, shiftId uint64) (web.ShiftResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, shiftId)
+	ret0, _ := ret[0].(web.ShiftResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockShiftServiceMockRecorder) FindById(ctx, shiftId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockShiftService)(nil).FindById), ctx, shiftId)
+}
+
+// FindCurrent mocks base method.
+func (m *MockShiftService) FindCurrent(ctx context.Context) (web.ShiftResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCurrent", ctx)
+	ret0, _ := ret[0].(web.ShiftResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCurrent indicates an expected call of FindCurrent.
+func (mr *MockShiftServiceMockRecorder) FindCurrent(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCurrent", reflect.TypeOf((*MockShiftService)(nil).FindCurrent), ctx)
+}
+
+// Open mocks base method.
+func (m *MockShiftService) Open(ctx context.Context, request web.ShiftOpenRequest) (web.ShiftResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, request)
+	ret0, _ := ret[0].(web.ShiftResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockShiftServiceMockRecorder) Open(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockShiftService)(nil).Open), ctx, request)
+}
+
+// RecordCashMovement mocks base method.
+func (m *MockShiftService) RecordCashMovement(ctx context.Context, request web.CashMovementRequest) (web.ShiftResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordCashMovement", ctx, request)
+	ret0, _ := ret[0].(web.ShiftResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordCashMovement indicates an expected call of RecordCashMovement.
+func (mr *MockShiftServiceMockRecorder) RecordCashMovement(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordCashMovement", reflect.TypeOf((*MockShiftService)(nil).RecordCashMovement), ctx, request)
+}
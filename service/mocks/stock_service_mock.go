@@ -0,0 +1,207 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/stock_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/stock_service.go -destination=service/mocks/stock_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockStockService is a mock of StockService interface.
+type MockStockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStockServiceMockRecorder is the mock recorder for MockStockService.
+type MockStockServiceMockRecorder struct {
+	mock *MockStockService
+}
+
+// NewMockStockService creates a new mock instance.
+func NewMockStockService(ctrl *gomock.Controller) *MockStockService {
+	mock := &MockStockService{ctrl: ctrl}
+	mock.recorder = &MockStockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockService) EXPECT() *MockStockServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelTransfer mocks base method.
+func (m *MockStockService) CancelTransfer(ctx context.Context, transferId, version uint64) (web.StockTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTransfer", ctx, transferId, version)
+	ret0, _ := ret[0].(web.StockTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelTransfer indicates an expected call of CancelTransfer.
+func (mr *MockStockServiceMockRecorder) CancelTransfer(ctx, transferId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTransfer", reflect.TypeOf((*MockStockService)(nil).CancelTransfer), ctx, transferId, version)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStockService) CreateTransfer(ctx context.Context, request web.StockTransferCreateRequest) (web.StockTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, request)
+	ret0, _ := ret[0].(web.StockTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStockServiceMockRecorder) CreateTransfer(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStockService)(nil).CreateTransfer), ctx, request)
+}
+
+// FindInventories mocks base method.
+func (m *MockStockService) FindInventories(ctx context.Context, lowOnly bool) ([]web.InventoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInventories", ctx, lowOnly)
+	ret0, _ := ret[0].([]web.InventoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInventories indicates an expected call of FindInventories.
+func (mr *MockStockServiceMockRecorder) FindInventories(ctx, lowOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInventories", reflect.TypeOf((*MockStockService)(nil).FindInventories), ctx, lowOnly)
+}
+
+// FindLevels mocks base method.
+func (m *MockStockService) FindLevels(ctx context.Context, productId string) ([]web.StoreStockResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLevels", ctx, productId)
+	ret0, _ := ret[0].([]web.StoreStockResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLevels indicates an expected call of FindLevels.
+func (mr *MockStockServiceMockRecorder) FindLevels(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLevels", reflect.TypeOf((*MockStockService)(nil).FindLevels), ctx, productId)
+}
+
+// FindMovements mocks base method.
+func (m *MockStockService) FindMovements(ctx context.Context, filter web.InventoryMovementFilterRequest) ([]web.InventoryMovementResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMovements", ctx, filter)
+	ret0, _ := ret[0].([]web.InventoryMovementResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMovements indicates an expected call of FindMovements.
+func (mr *MockStockServiceMockRecorder) FindMovements(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMovements", reflect.TypeOf((*MockStockService)(nil).FindMovements), ctx, filter)
+}
+
+// FindTransferById mocks base method.
+func (m *MockStockService) FindTransferById(ctx context.Context, transferId uint64) (web.StockTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransferById", ctx, transferId)
+	ret0, _ := ret[0].(web.StockTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransferById indicates an expected call of FindTransferById.
+func (mr *MockStockServiceMockRecorder) FindTransferById(ctx, transferId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransferById", reflect.TypeOf((*MockStockService)(nil).FindTransferById), ctx, transferId)
+}
+
+// FindTransfers mocks base method.
+func (m *MockStockService) FindTransfers(ctx context.Context, filter web.StockTransferFilterRequest) ([]web.StockTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransfers", ctx, filter)
+	ret0, _ := ret[0].([]web.StockTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransfers indicates an expected call of FindTransfers.
+func (mr *MockStockServiceMockRecorder) FindTransfers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransfers", reflect.TypeOf((*MockStockService)(nil).FindTransfers), ctx, filter)
+}
+
+// ReceiveTransfer mocks base method.
+func (m *MockStockService) ReceiveTransfer(ctx context.Context, transferId, version uint64) (web.StockTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveTransfer", ctx, transferId, version)
+	ret0, _ := ret[0].(web.StockTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveTransfer indicates an expected call of ReceiveTransfer.
+func (mr *MockStockServiceMockRecorder) ReceiveTransfer(ctx, transferId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveTransfer", reflect.TypeOf((*MockStockService)(nil).ReceiveTransfer), ctx, transferId, version)
+}
+
+// Restock mocks base method.
+func (m *MockStockService) Restock(ctx context.Context, request web.StockRestockRequest) (web.InventoryMovementResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restock", ctx, request)
+	ret0, _ := ret[0].(web.InventoryMovementResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restock indicates an expected call of Restock.
+func (mr *MockStockServiceMockRecorder) Restock(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restock", reflect.TypeOf((*MockStockService)(nil).Restock), ctx, request)
+}
+
+// SetLevel mocks base method.
+func (m *MockStockService) SetLevel(ctx context.Context, request web.StoreStockSetRequest) (web.StoreStockResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLevel", ctx, request)
+	ret0, _ := ret[0].(web.StoreStockResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLevel indicates an expected call of SetLevel.
+func (mr *MockStockServiceMockRecorder) SetLevel(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevel", reflect.TypeOf((*MockStockService)(nil).SetLevel), ctx, request)
+}
+
+// SetRestockLevel mocks base method.
+func (m *MockStockService) SetRestockLevel(ctx context.Context, request web.RestockLevelRequest) (web.InventoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRestockLevel", ctx, request)
+	ret0, _ := ret[0].(web.InventoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRestockLevel indicates an expected call of SetRestockLevel.
+func (mr *MockStockServiceMockRecorder) SetRestockLevel(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRestockLevel", reflect.TypeOf((*MockStockService)(nil).SetRestockLevel), ctx, request)
+}
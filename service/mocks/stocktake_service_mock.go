@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/stocktake_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/stocktake_service.go -destination=service/mocks/stocktake_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockStocktakeService is a mock of StocktakeService interface.
+type MockStocktakeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStocktakeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStocktakeServiceMockRecorder is the mock recorder for MockStocktakeService.
+type MockStocktakeServiceMockRecorder struct {
+	mock *MockStocktakeService
+}
+
+// NewMockStocktakeService creates a new mock instance.
+func NewMockStocktakeService(ctrl *gomock.Controller) *MockStocktakeService {
+	mock := &MockStocktakeService{ctrl: ctrl}
+	mock.recorder = &MockStocktakeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStocktakeService) EXPECT() *MockStocktakeServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockStocktakeService) Approve(ctx context.Context, request web.StocktakeApproveRequest) (web.StocktakeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, request)
+	ret0, _ := ret[0].(web.StocktakeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockStocktakeServiceMockRecorder) Approve(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockStocktakeService)(nil).Approve), ctx, request)
+}
+
+// Cancel mocks base method.
+func (m *MockStocktakeService) Cancel(ctx context.Context, stocktakeId, version uint64) (web.StocktakeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, stocktakeId, version)
+	ret0, _ := ret[0].(web.StocktakeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockStocktakeServiceMockRecorder) Cancel(ctx, stocktakeId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockStocktakeService)(nil).Cancel), ctx, stocktakeId, version)
+}
+
+// Count mocks base method.
+func (m *MockStocktakeService) Count(ctx context.Context, request web.StocktakeCountRequest) (web.StocktakeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, request)
+	ret0, _ := ret[0].(web.StocktakeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockStocktakeServiceMockRecorder) Count(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockStocktakeService)(nil).Count), ctx, request)
+}
+
+// Create mocks base method.
+func (m *MockStocktakeService) Create(ctx context.Context, request web.StocktakeCreateRequest) (web.StocktakeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(web.StocktakeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStocktakeServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStocktakeService)(nil).Create), ctx, request)
+}
+
+// FindAll mocks base method.
+func (m *MockStocktakeService) FindAll(ctx context.Context, filter web.StocktakeFilterRequest) ([]web.StocktakeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
+	ret0, _ := ret[0].([]web.StocktakeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStocktakeServiceMockRecorder) FindAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStocktakeService)(nil).FindAll), ctx, filter)
+}
+
+// FindById mocks base method.
+func (m *MockStocktakeService) FindById(ctx context.Context, stocktakeId uint64) (web.StocktakeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, stocktakeId)
+	ret0, _ := ret[0].(web.StocktakeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockStocktakeServiceMockRecorder) FindById(ctx, stocktakeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockStocktakeService)(nil).FindById), ctx, stocktakeId)
+}
+
+// Upload mocks base method.
+func (m *MockStocktakeService) Upload(ctx context.Context, request web.StocktakeUploadRequest) (web.StocktakeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, request)
+	ret0, _ := ret[0].(web.StocktakeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockStocktakeServiceMockRecorder) Upload(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockStocktakeService)(nil).Upload), ctx, request)
+}
+
+// Variances mocks base method.
+func (m *MockStocktakeService) Variances(ctx context.Context, stocktakeId uint64) (web.StocktakeVarianceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Variances", ctx, stocktakeId)
+	ret0, _ := ret[0].(web.StocktakeVarianceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Variances indicates an expected call of Variances.
+func (mr *MockStocktakeServiceMockRecorder) Variances(ctx, stocktakeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Variances", reflect.TypeOf((*MockStocktakeService)(nil).Variances), ctx, stocktakeId)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/supplier_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service/supplier_service.go -destination=service/mocks/supplier_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	"github.com/golang/mock/gomock"
+	reflect "reflect"
+
+	web "github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+// MockSupplierService is a mock of SupplierService interface.
+type MockSupplierService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupplierServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSupplierServiceMockRecorder is the mock recorder for MockSupplierService.
+type MockSupplierServiceMockRecorder struct {
+	mock *MockSupplierService
+}
+
+// NewMockSupplierService creates a new mock instance.
+func NewMockSupplierService(ctrl *gomock.Controller) *MockSupplierService {
+	mock := &MockSupplierService{ctrl: ctrl}
+	mock.recorder = &MockSupplierServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupplierService) EXPECT() *MockSupplierServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSupplierService) Create(ctx context.Context, request web.SupplierCreateRequest) (web.SupplierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, request)
+	ret0, _ := ret[0].(web.SupplierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSupplierServiceMockRecorder) Create(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSupplierService)(nil).Create), ctx, request)
+}
+
+// Delete mocks base method.
+func (m *MockSupplierService) Delete(ctx context.Context, supplierId, version uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, supplierId, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSupplierServiceMockRecorder) Delete(ctx, supplierId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplierService)(nil).Delete), ctx, supplierId, version)
+}
+
+// FindAll mocks base method.
+func (m *MockSupplierService) FindAll(ctx context.Context) ([]web.SupplierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]web.SupplierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSupplierServiceMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSupplierService)(nil).FindAll), ctx)
+}
+
+// FindById mocks base method.
+func (m *MockSupplierService) FindById(ctx context.Context, supplierId uint64) (web.SupplierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, supplierId)
+	ret0, _ := ret[0].(web.SupplierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockSupplierServiceMockRecorder) FindById(ctx, supplierId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockSupplierService)(nil).FindById), ctx, supplierId)
+}
+
+// Update mocks base method.
+func (m *MockSupplierService) Update(ctx context.Context, request web.SupplierUpdateRequest) (web.SupplierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, request)
+	ret0, _ := ret[0].(web.SupplierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSupplierServiceMockRecorder) Update(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplierService)(nil).Update), ctx, request)
+}
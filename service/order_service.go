@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type OrderService interface {
+	Create(ctx context.Context, request web.OrderCreateRequest) (web.OrderResponse, error)
+	UploadOffline(ctx context.Context, request web.OfflineOrderUploadRequest) (web.OfflineOrderUploadResponse, error)
+	AddPayment(ctx context.Context, request web.PaymentCreateRequest) (web.PaymentResponse, error)
+	FindById(ctx context.Context, orderId string) (web.OrderResponse, error)
+	FindAll(ctx context.Context) ([]web.OrderResponse, error)
+	FindOffline(ctx context.Context, status string) ([]web.OrderResponse, error)
+	Export(ctx context.Context, fn func(response web.OrderResponse) error) error
+}
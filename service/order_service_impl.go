@@ -0,0 +1,513 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"math"
+	"strconv"
+	"time"
+)
+
+type OrderServiceImpl struct {
+	OrderRepository          repository.OrderRepository
+	ProductRepository        repository.ProductRepository
+	ProductVariantRepository repository.ProductVariantRepository
+	StoreStockRepository     repository.StoreStockRepository
+	InventoryRepository      repository.InventoryRepository
+	PaymentRepository        repository.PaymentRepository
+	EmployeeRepository       repository.EmployeeRepository
+	ShiftRepository          repository.ShiftRepository
+	PriceListService         PriceListService
+	OutboxRepository         repository.OutboxRepository
+	TransactionManager       repository.TransactionManager
+	Validate                 *validator.Validate
+}
+
+func NewOrderService(orderRepository repository.OrderRepository, productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, storeStockRepository repository.StoreStockRepository, inventoryRepository repository.InventoryRepository, paymentRepository repository.PaymentRepository, employeeRepository repository.EmployeeRepository, shiftRepository repository.ShiftRepository, priceListService PriceListService, outboxRepository repository.OutboxRepository, transactionManager repository.TransactionManager, validate *validator.Validate) OrderService {
+	return &OrderServiceImpl{
+		OrderRepository:          orderRepository,
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+		StoreStockRepository:     storeStockRepository,
+		InventoryRepository:      inventoryRepository,
+		PaymentRepository:        paymentRepository,
+		EmployeeRepository:       employeeRepository,
+		ShiftRepository:          shiftRepository,
+		PriceListService:         priceListService,
+		OutboxRepository:         outboxRepository,
+		TransactionManager:       transactionManager,
+		Validate:                 validate,
+	}
+}
+
+// Create places an order, pricing every item for the customer at the current time and
+// taking the ordered quantities out of the stock of the store at cost. The order date is
+// the server clock; only offline orders keep the date of the terminal. Only a cashier
+// with an open shift can place orders; the order and its cash payments are booked on
+// that shift. An order paid in full publishes OrderPaid.
+func (service *OrderServiceImpl) Create(ctx context.Context, request web.OrderCreateRequest) (web.OrderResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.OrderResponse{}, err
+	}
+
+	shift, err := activeCashierShift(ctx, service.EmployeeRepository, service.ShiftRepository)
+	if err != nil {
+		return web.OrderResponse{}, err
+	}
+
+	order := domain.Order{
+		OrderID:    uuid.NewString(),
+		EmployeeID: shift.EmployeeID,
+		ShiftID:    &shift.Id,
+		OrderDate:  time.Now(),
+	}
+	if request.CustomerID != 0 {
+		order.CustomerID = strconv.FormatUint(request.CustomerID, 10)
+	}
+
+	var savedOrder domain.Order
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		for _, item := range request.Items {
+			price, err := service.PriceListService.ResolvePrice(ctx, web.PriceQuery{
+				CustomerID: request.CustomerID,
+				ProductID:  item.ProductID,
+				VariantID:  item.VariantID,
+				At:         order.OrderDate,
+			})
+			if err != nil {
+				return err
+			}
+
+			orderItem, err := service.sellItem(ctx, order.OrderID, item, price, price.Price, false)
+			if err != nil {
+				return err
+			}
+			order.OrderItems = append(order.OrderItems, orderItem)
+			order.TotalAmount += orderItem.TotalPrice
+		}
+		order.TotalAmount = helper.RoundMoney(order.TotalAmount)
+
+		var paidAmount float64
+		for _, paymentRequest := range request.Payments {
+			paidAmount += paymentRequest.Amount
+			order.Payments = append(order.Payments, newPayment(order.OrderID, paymentRequest, shift))
+		}
+		if helper.RoundMoney(paidAmount) > order.TotalAmount {
+			return exception.NewBadRequestError("payments are more than the order total")
+		}
+
+		var err error
+		if savedOrder, err = service.OrderRepository.Save(ctx, order); err != nil {
+			return err
+		}
+		if len(savedOrder.Payments) > 0 && helper.RoundMoney(paidAmount) == savedOrder.TotalAmount {
+			return publishEvent(ctx, service.OutboxRepository, domain.EventOrderPaid, domain.AggregateOrder, savedOrder.OrderID, helper.ToOrderResponse(savedOrder))
+		}
+		return nil
+	})
+	if err != nil {
+		return web.OrderResponse{}, err
+	}
+
+	return helper.ToOrderResponse(savedOrder), nil
+}
+
+// UploadOffline applies the orders that a terminal took while it was offline, each in a
+// transaction of its own, and reports how every one was reconciled. The sales have been
+// made, so they are not refused over price or stock: an item charged below the current
+// price is booked with the difference as discount (adjusted), while one charged above it,
+// stock that goes below zero or an overpaid order are booked as they are and flagged for
+// review. Orders are booked on the open shift of the cashier uploading them, at the time
+// they were taken. Uploading an order again, also while the first upload is still being
+// booked, returns how it was reconciled the first time.
+func (service *OrderServiceImpl) UploadOffline(ctx context.Context, request web.OfflineOrderUploadRequest) (web.OfflineOrderUploadResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.OfflineOrderUploadResponse{}, err
+	}
+
+	shift, err := activeCashierShift(ctx, service.EmployeeRepository, service.ShiftRepository)
+	if err != nil {
+		return web.OfflineOrderUploadResponse{}, err
+	}
+
+	response := web.OfflineOrderUploadResponse{Results: make([]web.OfflineOrderResult, 0, len(request.Orders))}
+	uploadedAt := time.Now()
+	for _, orderRequest := range request.Orders {
+		result := service.applyOfflineOrder(ctx, orderRequest, shift, uploadedAt)
+		switch result.Status {
+		case domain.ReconciliationAccepted:
+			response.Accepted++
+		case domain.ReconciliationAdjusted:
+			response.Adjusted++
+		case domain.ReconciliationFlagged:
+			response.Flagged++
+		default:
+			response.Rejected++
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response, nil
+}
+
+// applyOfflineOrder books one order taken offline, unless it was booked before.
+func (service *OrderServiceImpl) applyOfflineOrder(ctx context.Context, request web.OfflineOrderRequest, shift domain.Shift, uploadedAt time.Time) web.OfflineOrderResult {
+	result := web.OfflineOrderResult{OrderID: request.OrderID, Issues: []web.ReconciliationIssue{}}
+
+	existingOrder, err := service.OrderRepository.FindById(ctx, request.OrderID)
+	if err == nil {
+		return duplicateOfflineOrder(result, existingOrder)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		result.Status, result.Error = OfflineOrderRejected, err.Error()
+		return result
+	}
+
+	order := domain.Order{
+		OrderID:    request.OrderID,
+		EmployeeID: shift.EmployeeID,
+		ShiftID:    &shift.Id,
+		OrderDate:  request.OrderDate,
+		UploadedAt: &uploadedAt,
+	}
+	if request.CustomerID != 0 {
+		order.CustomerID = strconv.FormatUint(request.CustomerID, 10)
+	}
+	issues := []web.ReconciliationIssue{}
+	if request.OrderDate.After(uploadedAt) {
+		issues = append(issues, web.ReconciliationIssue{Type: domain.IssueFutureOrderDate})
+	}
+
+	var savedOrder domain.Order
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		for _, item := range request.Items {
+			price, err := service.PriceListService.ResolvePrice(ctx, web.PriceQuery{
+				CustomerID: request.CustomerID,
+				ProductID:  item.ProductID,
+				VariantID:  item.VariantID,
+				At:         order.OrderDate,
+			})
+			if err != nil {
+				return err
+			}
+
+			unitPrice := price.Price
+			if item.UnitPrice != nil {
+				unitPrice = helper.RoundMoney(*item.UnitPrice)
+			}
+			priceIssue := web.ReconciliationIssue{ProductID: item.ProductID, VariantID: item.VariantID, Expected: price.Price, Actual: unitPrice}
+			if unitPrice < price.Price {
+				priceIssue.Type = domain.IssuePriceBelowCurrent
+				issues = append(issues, priceIssue)
+			} else if unitPrice > price.Price {
+				priceIssue.Type = domain.IssuePriceAboveCurrent
+				issues = append(issues, priceIssue)
+			}
+
+			var variantId uint64
+			if item.VariantID != nil {
+				variantId = *item.VariantID
+			}
+			stock, err := service.StoreStockRepository.Find(ctx, item.ProductID, variantId)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if stock.Quantity < item.Quantity {
+				issues = append(issues, web.ReconciliationIssue{
+					Type:      domain.IssueNegativeStock,
+					ProductID: item.ProductID,
+					VariantID: item.VariantID,
+					Expected:  float64(stock.Quantity),
+					Actual:    float64(item.Quantity),
+				})
+			}
+
+			orderItem, err := service.sellItem(ctx, order.OrderID, web.OrderItemCreateRequest{
+				ProductID: item.ProductID,
+				VariantID: item.VariantID,
+				Quantity:  item.Quantity,
+			}, price, unitPrice, true)
+			if err != nil {
+				return err
+			}
+			order.OrderItems = append(order.OrderItems, orderItem)
+			order.TotalAmount += orderItem.TotalPrice
+		}
+		order.TotalAmount = helper.RoundMoney(order.TotalAmount)
+
+		var paidAmount float64
+		for _, paymentRequest := range request.Payments {
+			paidAmount += paymentRequest.Amount
+			payment := newPayment(order.OrderID, paymentRequest, shift)
+			payment.PaymentDate = order.OrderDate
+			order.Payments = append(order.Payments, payment)
+		}
+		paidAmount = helper.RoundMoney(paidAmount)
+		if paidAmount > order.TotalAmount {
+			issues = append(issues, web.ReconciliationIssue{Type: domain.IssueOverpaid, Expected: order.TotalAmount, Actual: paidAmount})
+		}
+
+		order.ReconciliationStatus = reconciliationStatus(issues)
+		if len(issues) > 0 {
+			issuesJson, err := json.Marshal(issues)
+			if err != nil {
+				return err
+			}
+			order.ReconciliationIssues = string(issuesJson)
+		}
+
+		var err error
+		if savedOrder, err = service.OrderRepository.Save(ctx, order); err != nil {
+			return err
+		}
+		if len(savedOrder.Payments) > 0 && paidAmount >= savedOrder.TotalAmount {
+			return publishEvent(ctx, service.OutboxRepository, domain.EventOrderPaid, domain.AggregateOrder, savedOrder.OrderID, helper.ToOrderResponse(savedOrder))
+		}
+		return nil
+	})
+	if errors.Is(err, repository.ErrOrderExists) {
+		// Booked meanwhile by a concurrent upload of the same order
+		if existingOrder, findErr := service.OrderRepository.FindById(ctx, request.OrderID); findErr == nil {
+			return duplicateOfflineOrder(result, existingOrder)
+		}
+	}
+	if err != nil {
+		result.Status, result.Error = OfflineOrderRejected, err.Error()
+		return result
+	}
+
+	orderResponse := helper.ToOrderResponse(savedOrder)
+	result.Status = savedOrder.ReconciliationStatus
+	result.Issues = issues
+	result.Order = &orderResponse
+	return result
+}
+
+// duplicateOfflineOrder reports an order uploaded before as it was reconciled then.
+func duplicateOfflineOrder(result web.OfflineOrderResult, existingOrder domain.Order) web.OfflineOrderResult {
+	orderResponse := helper.ToOrderResponse(existingOrder)
+	result.Status = existingOrder.ReconciliationStatus
+	if result.Status == "" {
+		result.Status = domain.ReconciliationAccepted
+	}
+	result.Duplicate = true
+	result.Order = &orderResponse
+	if orderResponse.ReconciliationIssues != nil {
+		result.Issues = orderResponse.ReconciliationIssues
+	}
+	return result
+}
+
+// OfflineOrderRejected is the result of an uploaded order that could not be booked, e.g.
+// because a product no longer exists. It is not booked and can be uploaded again.
+const OfflineOrderRejected = "rejected"
+
+// reconciliationStatus is flagged when any issue needs a review, adjusted when there are
+// only prices booked as discount and accepted without issues.
+func reconciliationStatus(issues []web.ReconciliationIssue) string {
+	status := domain.ReconciliationAccepted
+	for _, issue := range issues {
+		if issue.Type != domain.IssuePriceBelowCurrent {
+			return domain.ReconciliationFlagged
+		}
+		status = domain.ReconciliationAdjusted
+	}
+	return status
+}
+
+// sellItem takes the quantity of an item out of the stock of the store, at the store and
+// across all stores, writes the sale to the ledger at cost and returns the order item
+// charged at unitPrice. Unless allowNegative, a sale that would take the stock below zero
+// fails with a ConflictError.
+func (service *OrderServiceImpl) sellItem(ctx context.Context, orderId string, item web.OrderItemCreateRequest, price web.ResolvedPrice, unitPrice float64, allowNegative bool) (domain.OrderItem, error) {
+	var variantId uint64
+	if item.VariantID != nil {
+		variantId = *item.VariantID
+	}
+
+	var err error
+	if allowNegative {
+		err = service.StoreStockRepository.AdjustAllowingNegative(ctx, item.ProductID, variantId, -item.Quantity)
+	} else {
+		err = service.StoreStockRepository.Adjust(ctx, item.ProductID, variantId, -item.Quantity)
+	}
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return domain.OrderItem{}, exception.NewConflictError("Insufficient stock at this store for product " + item.ProductID)
+	} else if err != nil {
+		return domain.OrderItem{}, err
+	}
+
+	switch {
+	case item.VariantID != nil && allowNegative:
+		err = service.ProductVariantRepository.AdjustStockAllowingNegative(ctx, *item.VariantID, -item.Quantity)
+	case item.VariantID != nil:
+		err = service.ProductVariantRepository.AdjustStock(ctx, *item.VariantID, -item.Quantity)
+	case allowNegative:
+		err = service.ProductRepository.AdjustStockAllowingNegative(ctx, item.ProductID, -item.Quantity)
+	default:
+		err = service.ProductRepository.AdjustStock(ctx, item.ProductID, -item.Quantity)
+	}
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return domain.OrderItem{}, exception.NewConflictError("Insufficient stock for product " + item.ProductID)
+	} else if err != nil {
+		return domain.OrderItem{}, err
+	}
+
+	product, err := findProduct(ctx, service.ProductRepository, item.ProductID)
+	if err != nil {
+		return domain.OrderItem{}, err
+	}
+	movement, err := takeCostLayers(ctx, service.InventoryRepository, product, domain.InventoryMovement{
+		ProductID: item.ProductID,
+		VariantID: variantId,
+		Type:      domain.MovementTypeSale,
+		Quantity:  item.Quantity,
+		Reference: orderId,
+	})
+	if err != nil {
+		return domain.OrderItem{}, err
+	}
+	if _, err := saveMovement(ctx, service.InventoryRepository, service.OutboxRepository, movement); err != nil {
+		return domain.OrderItem{}, err
+	}
+
+	totalPrice := helper.RoundMoney(unitPrice * float64(item.Quantity))
+	return domain.OrderItem{
+		OrderID:        orderId,
+		ProductID:      item.ProductID,
+		VariantID:      item.VariantID,
+		Quantity:       item.Quantity,
+		UnitPrice:      unitPrice,
+		TotalPrice:     totalPrice,
+		RegularPrice:   price.RegularPrice,
+		DiscountAmount: helper.RoundMoney(math.Max(0, price.RegularPrice-unitPrice) * float64(item.Quantity)),
+		TaxType:        price.TaxType,
+		TaxRate:        price.TaxRate,
+		TaxAmount:      helper.RoundMoney(totalPrice * price.TaxRate / (100 + price.TaxRate)),
+		CostAmount:     -movement.TotalCost,
+	}, nil
+}
+
+// AddPayment records a payment for an order. Cash can only be taken by a cashier with an
+// open shift, and is booked on that shift. The payment that settles the order publishes
+// OrderPaid.
+func (service *OrderServiceImpl) AddPayment(ctx context.Context, request web.PaymentCreateRequest) (web.PaymentResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.PaymentResponse{}, err
+	}
+
+	var shift domain.Shift
+	if request.PaymentType == domain.PaymentTypeCash {
+		var err error
+		if shift, err = activeCashierShift(ctx, service.EmployeeRepository, service.ShiftRepository); err != nil {
+			return web.PaymentResponse{}, err
+		}
+	}
+
+	var savedPayment domain.Payment
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		// Locked, so that concurrent payments total the payments one after the other
+		order, err := service.OrderRepository.FindByIdForUpdate(ctx, request.OrderID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.NewNotFoundError("Order not found")
+		} else if err != nil {
+			return err
+		}
+
+		if request.Version != 0 && request.Version != order.Version {
+			return exception.NewConflictError("Order has been modified")
+		}
+
+		paidAmount := helper.ToOrderResponse(order).PaidAmount
+		if helper.RoundMoney(paidAmount+request.Amount) > order.TotalAmount {
+			return exception.NewBadRequestError("payments are more than the order total")
+		}
+
+		if savedPayment, err = service.PaymentRepository.Save(ctx, newPayment(order.OrderID, request, shift)); err != nil {
+			return err
+		}
+		if order, err = service.OrderRepository.Update(ctx, order); errors.Is(err, repository.ErrVersionConflict) {
+			return exception.NewConflictError("Order has been modified")
+		} else if err != nil {
+			return err
+		}
+		if helper.RoundMoney(paidAmount+request.Amount) == order.TotalAmount {
+			order.Payments = append(order.Payments, savedPayment)
+			return publishEvent(ctx, service.OutboxRepository, domain.EventOrderPaid, domain.AggregateOrder, order.OrderID, helper.ToOrderResponse(order))
+		}
+		return nil
+	})
+	if err != nil {
+		return web.PaymentResponse{}, err
+	}
+
+	return helper.ToPaymentResponse(savedPayment), nil
+}
+
+// newPayment makes a completed payment, booking cash on the shift.
+func newPayment(orderId string, request web.PaymentCreateRequest, shift domain.Shift) domain.Payment {
+	payment := domain.Payment{
+		PaymentID:   uuid.NewString(),
+		OrderID:     orderId,
+		Amount:      request.Amount,
+		PaymentType: request.PaymentType,
+		PaymentDate: time.Now(),
+		Status:      domain.PaymentStatusCompleted,
+	}
+	if request.PaymentType == domain.PaymentTypeCash {
+		payment.ShiftID = &shift.Id
+	}
+	return payment
+}
+
+// FindById Order
+func (service *OrderServiceImpl) FindById(ctx context.Context, orderId string) (web.OrderResponse, error) {
+	order, err := service.OrderRepository.FindById(ctx, orderId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.OrderResponse{}, exception.NewNotFoundError("Order not found")
+	} else if err != nil {
+		return web.OrderResponse{}, err
+	}
+
+	return helper.ToOrderResponse(order), nil
+}
+
+// FindOffline returns the orders taken offline, optionally only those with a
+// reconciliation status, e.g. flagged ones to review
+func (service *OrderServiceImpl) FindOffline(ctx context.Context, status string) ([]web.OrderResponse, error) {
+	orders, err := service.OrderRepository.FindAllByReconciliationStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToOrderResponses(orders), nil
+}
+
+// FindAll Orders
+func (service *OrderServiceImpl) FindAll(ctx context.Context) ([]web.OrderResponse, error) {
+	orders, err := service.OrderRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToOrderResponses(orders), nil
+}
+
+// Export streams every order to fn, reading ExportBatchSize rows at a time
+func (service *OrderServiceImpl) Export(ctx context.Context, fn func(response web.OrderResponse) error) error {
+	return service.OrderRepository.FindInBatches(ctx, ExportBatchSize, func(orders []domain.Order) error {
+		for _, order := range orders {
+			if err := fn(helper.ToOrderResponse(order)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
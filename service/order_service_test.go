@@ -0,0 +1,506 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	servicemocks "github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestFindOrderById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrderRepository(ctrl)
+	orderService := NewOrderService(mockRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, validator.New())
+
+	mockRepo.EXPECT().FindById(gomock.Any(), "o-1").Return(domain.Order{
+		OrderID:     "o-1",
+		TotalAmount: 20,
+		OrderItems:  []domain.OrderItem{{OrderID: "o-1", ProductID: "p-1", Quantity: 2, UnitPrice: 10, TotalPrice: 20}},
+	}, nil)
+	response, err := orderService.FindById(context.Background(), "o-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.ItemCount)
+	assert.Equal(t, []web.OrderItemResponse{{ProductID: "p-1", Quantity: 2, UnitPrice: 10, TotalPrice: 20}}, response.OrderItems)
+
+	mockRepo.EXPECT().FindById(gomock.Any(), "o-2").Return(domain.Order{}, gorm.ErrRecordNotFound)
+	_, err = orderService.FindById(context.Background(), "o-2")
+	assert.IsType(t, exception.NotFoundError{}, err)
+}
+
+func TestExportOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrderRepository(ctrl)
+	orderService := NewOrderService(mockRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, validator.New())
+
+	mockRepo.EXPECT().
+		FindInBatches(gomock.Any(), ExportBatchSize, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, batchSize int, fn func(orders []domain.Order) error) error {
+			if err := fn([]domain.Order{{OrderID: "o-1"}, {OrderID: "o-2"}}); err != nil {
+				return err
+			}
+			return fn([]domain.Order{{OrderID: "o-3"}})
+		})
+
+	var exported []string
+	err := orderService.Export(context.Background(), func(response web.OrderResponse) error {
+		exported = append(exported, response.OrderID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"o-1", "o-2", "o-3"}, exported)
+}
+
+// pricedNowMatcher matches a price query made at the current time.
+type pricedNowMatcher struct {
+	query web.PriceQuery
+}
+
+func pricedNow(query web.PriceQuery) gomock.Matcher {
+	return pricedNowMatcher{query: query}
+}
+
+func (matcher pricedNowMatcher) Matches(x interface{}) bool {
+	query, ok := x.(web.PriceQuery)
+	if !ok || time.Since(query.At).Abs() > time.Minute {
+		return false
+	}
+	query.At = time.Time{}
+	return gomock.Eq(matcher.query).Matches(query)
+}
+
+func (matcher pricedNowMatcher) String() string {
+	return fmt.Sprintf("is %v at the current time", matcher.query)
+}
+
+func TestCreateOrder(t *testing.T) {
+	variantId := uint64(3)
+	cashierCtx := context.WithValue(context.Background(), helper.ContextKeyEmployee, "e-1")
+	cashier := domain.Employee{EmployeeID: "e-1", Role: "Cashier"}
+	shift := domain.Shift{Id: 11, EmployeeID: "e-1", Status: domain.ShiftStatusOpen}
+
+	type repos struct {
+		order            *mocks.MockOrderRepository
+		product          *mocks.MockProductRepository
+		variant          *mocks.MockProductVariantRepository
+		storeStock       *mocks.MockStoreStockRepository
+		inventory        *mocks.MockInventoryRepository
+		employee         *mocks.MockEmployeeRepository
+		shift            *mocks.MockShiftRepository
+		priceListService *servicemocks.MockPriceListService
+	}
+	withOpenShift := func(r repos) {
+		r.employee.EXPECT().FindById(gomock.Any(), "e-1").Return(cashier, nil)
+		r.shift.EXPECT().FindOpenByEmployeeId(gomock.Any(), "e-1").Return(shift, nil)
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		input     web.OrderCreateRequest
+		mock      func(r repos)
+		expect    float64
+		expectErr error
+	}{
+		{
+			name: "success",
+			ctx:  cashierCtx,
+			input: web.OrderCreateRequest{CustomerID: 5,
+				Items: []web.OrderItemCreateRequest{
+					{ProductID: "p-1", Quantity: 2},
+					{ProductID: "p-2", VariantID: &variantId, Quantity: 1},
+				},
+				Payments: []web.PaymentCreateRequest{
+					{PaymentType: domain.PaymentTypeCash, Amount: 100},
+					{PaymentType: domain.PaymentTypeCard, Amount: 95},
+				},
+			},
+			mock: func(r repos) {
+				withOpenShift(r)
+				r.priceListService.EXPECT().ResolvePrice(gomock.Any(), pricedNow(web.PriceQuery{CustomerID: 5, ProductID: "p-1"})).
+					Return(web.ResolvedPrice{Price: 90, RegularPrice: 100, TaxRate: 10, TaxType: "VAT", Source: PriceSourcePriceList}, nil)
+				r.storeStock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), -2).Return(nil)
+				r.product.EXPECT().AdjustStock(gomock.Any(), "p-1", -2).Return(nil)
+				r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1", CostMethod: domain.CostMethodFIFO}, nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).
+					Return([]domain.CostLayer{{Id: 1, Quantity: 1, UnitCost: 40}, {Id: 2, Quantity: 5, UnitCost: 50}}, nil)
+				r.inventory.EXPECT().DeleteCostLayer(gomock.Any(), domain.CostLayer{Id: 1, UnitCost: 40}).Return(nil)
+				r.inventory.EXPECT().SaveCostLayer(gomock.Any(), domain.CostLayer{Id: 2, Quantity: 4, UnitCost: 50}).Return(domain.CostLayer{}, nil)
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+						assert.Equal(t, domain.MovementTypeSale, movement.Type)
+						assert.Equal(t, -2, movement.Quantity)
+						assert.Equal(t, -90.0, movement.TotalCost, "the oldest layer goes first")
+						return movement, nil
+					})
+				r.priceListService.EXPECT().ResolvePrice(gomock.Any(), pricedNow(web.PriceQuery{CustomerID: 5, ProductID: "p-2", VariantID: &variantId})).
+					Return(web.ResolvedPrice{Price: 15, Source: PriceSourceVariant}, nil)
+				r.storeStock.EXPECT().Adjust(gomock.Any(), "p-2", variantId, -1).Return(nil)
+				r.variant.EXPECT().AdjustStock(gomock.Any(), variantId, -1).Return(nil)
+				r.product.EXPECT().FindById(gomock.Any(), "p-2").Return(domain.Product{ProductID: "p-2", CostPrice: 8}, nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-2", variantId).Return(nil, nil)
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).Return(domain.InventoryMovement{}, nil)
+				r.order.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, order domain.Order) (domain.Order, error) {
+						assert.Equal(t, "5", order.CustomerID)
+						assert.Equal(t, "e-1", order.EmployeeID)
+						assert.Equal(t, uint64(11), *order.ShiftID)
+						assert.WithinDuration(t, time.Now(), order.OrderDate, time.Minute, "the server clock dates the order")
+						assert.NotEmpty(t, order.OrderID)
+						assert.Equal(t, uint64(11), *order.Payments[0].ShiftID, "cash is booked on the shift")
+						assert.Nil(t, order.Payments[1].ShiftID)
+						assert.Equal(t, 20.0, order.OrderItems[0].DiscountAmount, "sold 10 below the regular price")
+						assert.Equal(t, "VAT", order.OrderItems[0].TaxType)
+						assert.Equal(t, 16.36, order.OrderItems[0].TaxAmount, "tax is included in the price")
+						assert.Zero(t, order.OrderItems[1].DiscountAmount)
+						assert.Equal(t, 90.0, order.OrderItems[0].CostAmount)
+						assert.Equal(t, 8.0, order.OrderItems[1].CostAmount, "stock without a cost layer goes at the cost price")
+						return order, nil
+					})
+			},
+			expect: 195,
+		},
+		{
+			name:  "insufficient stock at the store",
+			ctx:   cashierCtx,
+			input: web.OrderCreateRequest{Items: []web.OrderItemCreateRequest{{ProductID: "p-1", Quantity: 20}}},
+			mock: func(r repos) {
+				withOpenShift(r)
+				r.priceListService.EXPECT().ResolvePrice(gomock.Any(), gomock.Any()).Return(web.ResolvedPrice{Price: 100}, nil)
+				r.storeStock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), -20).Return(repository.ErrInsufficientStock)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:  "overpaid",
+			ctx:   cashierCtx,
+			input: web.OrderCreateRequest{Items: []web.OrderItemCreateRequest{{ProductID: "p-1", Quantity: 1}}, Payments: []web.PaymentCreateRequest{{PaymentType: domain.PaymentTypeCard, Amount: 150}}},
+			mock: func(r repos) {
+				withOpenShift(r)
+				r.priceListService.EXPECT().ResolvePrice(gomock.Any(), gomock.Any()).Return(web.ResolvedPrice{Price: 100}, nil)
+				r.storeStock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), -1).Return(nil)
+				r.product.EXPECT().AdjustStock(gomock.Any(), "p-1", -1).Return(nil)
+				r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return(nil, nil)
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).Return(domain.InventoryMovement{}, nil)
+			},
+			expectErr: exception.BadRequestError{},
+		},
+		{
+			name:  "unknown product",
+			ctx:   cashierCtx,
+			input: web.OrderCreateRequest{Items: []web.OrderItemCreateRequest{{ProductID: "p-9", Quantity: 1}}},
+			mock: func(r repos) {
+				withOpenShift(r)
+				r.priceListService.EXPECT().ResolvePrice(gomock.Any(), gomock.Any()).Return(web.ResolvedPrice{}, exception.NewNotFoundError("Product not found"))
+			},
+			expectErr: exception.NotFoundError{},
+		},
+		{
+			name:      "no employee",
+			ctx:       context.Background(),
+			input:     web.OrderCreateRequest{Items: []web.OrderItemCreateRequest{{ProductID: "p-1", Quantity: 1}}},
+			mock:      func(r repos) {},
+			expectErr: exception.ForbiddenError{},
+		},
+		{
+			name:  "not a cashier",
+			ctx:   cashierCtx,
+			input: web.OrderCreateRequest{Items: []web.OrderItemCreateRequest{{ProductID: "p-1", Quantity: 1}}},
+			mock: func(r repos) {
+				r.employee.EXPECT().FindById(gomock.Any(), "e-1").Return(domain.Employee{EmployeeID: "e-1", Role: "Manager"}, nil)
+			},
+			expectErr: exception.ForbiddenError{},
+		},
+		{
+			name:  "no open shift",
+			ctx:   cashierCtx,
+			input: web.OrderCreateRequest{Items: []web.OrderItemCreateRequest{{ProductID: "p-1", Quantity: 1}}},
+			mock: func(r repos) {
+				r.employee.EXPECT().FindById(gomock.Any(), "e-1").Return(cashier, nil)
+				r.shift.EXPECT().FindOpenByEmployeeId(gomock.Any(), "e-1").Return(domain.Shift{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.ForbiddenError{},
+		},
+		{
+			name:      "no items",
+			ctx:       cashierCtx,
+			input:     web.OrderCreateRequest{},
+			mock:      func(r repos) {},
+			expectErr: validator.ValidationErrors{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			r := repos{
+				order:            mocks.NewMockOrderRepository(ctrl),
+				product:          mocks.NewMockProductRepository(ctrl),
+				variant:          mocks.NewMockProductVariantRepository(ctrl),
+				storeStock:       mocks.NewMockStoreStockRepository(ctrl),
+				inventory:        mocks.NewMockInventoryRepository(ctrl),
+				employee:         mocks.NewMockEmployeeRepository(ctrl),
+				shift:            mocks.NewMockShiftRepository(ctrl),
+				priceListService: servicemocks.NewMockPriceListService(ctrl),
+			}
+			tx := mocks.NewMockTransactionManager(ctrl)
+			tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				}).AnyTimes()
+			tt.mock(r)
+
+			orderService := NewOrderService(r.order, r.product, r.variant, r.storeStock, r.inventory, mocks.NewMockPaymentRepository(ctrl), r.employee, r.shift, r.priceListService, newMockOutboxRepository(ctrl), tx, validator.New())
+			response, err := orderService.Create(tt.ctx, tt.input)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, response.TotalAmount)
+			assert.Equal(t, tt.expect, response.PaidAmount)
+			assert.Equal(t, 2, response.ItemCount)
+			assert.Equal(t, 90.0, response.OrderItems[0].UnitPrice)
+		})
+	}
+}
+
+func TestUploadOfflineOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderDate := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cashierCtx := context.WithValue(context.Background(), helper.ContextKeyEmployee, "e-1")
+	soldAt := 80.0
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	productRepo := mocks.NewMockProductRepository(ctrl)
+	storeStockRepo := mocks.NewMockStoreStockRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	employeeRepo := mocks.NewMockEmployeeRepository(ctrl)
+	shiftRepo := mocks.NewMockShiftRepository(ctrl)
+	priceListService := servicemocks.NewMockPriceListService(ctrl)
+	orderService := NewOrderService(orderRepo, productRepo, mocks.NewMockProductVariantRepository(ctrl), storeStockRepo, inventoryRepo,
+		mocks.NewMockPaymentRepository(ctrl), employeeRepo, shiftRepo, priceListService, newMockOutboxRepository(ctrl), newPassthroughTransactionManager(ctrl), validator.New())
+
+	employeeRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(domain.Employee{EmployeeID: "e-1", Role: "Cashier"}, nil)
+	shiftRepo.EXPECT().FindOpenByEmployeeId(gomock.Any(), "e-1").Return(domain.Shift{Id: 11, EmployeeID: "e-1", Status: domain.ShiftStatusOpen}, nil)
+
+	// order 1 was uploaded before
+	orderRepo.EXPECT().FindById(gomock.Any(), "00000000-0000-4000-8000-000000000001").Return(domain.Order{OrderID: "00000000-0000-4000-8000-000000000001", ReconciliationStatus: domain.ReconciliationAccepted}, nil)
+
+	// order 2 was sold below the current price
+	orderRepo.EXPECT().FindById(gomock.Any(), "00000000-0000-4000-8000-000000000002").Return(domain.Order{}, gorm.ErrRecordNotFound)
+	priceListService.EXPECT().ResolvePrice(gomock.Any(), web.PriceQuery{ProductID: "p-1", At: orderDate}).
+		Return(web.ResolvedPrice{Price: 100, RegularPrice: 100}, nil).Times(2)
+	storeStockRepo.EXPECT().Find(gomock.Any(), "p-1", uint64(0)).Return(domain.StoreStock{Quantity: 5}, nil)
+	storeStockRepo.EXPECT().AdjustAllowingNegative(gomock.Any(), "p-1", uint64(0), -1).Return(nil).Times(2)
+	productRepo.EXPECT().AdjustStockAllowingNegative(gomock.Any(), "p-1", -1).Return(nil).Times(2)
+	productRepo.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil).Times(2)
+	inventoryRepo.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return(nil, nil).Times(2)
+	inventoryRepo.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).Return(domain.InventoryMovement{}, nil).Times(2)
+	orderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, order domain.Order) (domain.Order, error) {
+			assert.Equal(t, orderDate, order.Payments[0].PaymentDate, "paid when the order was taken")
+			assert.NotNil(t, order.UploadedAt)
+			return order, nil
+		}).Times(2)
+
+	// order 3 took the store stock below zero, that a sale is not refused over
+	orderRepo.EXPECT().FindById(gomock.Any(), "00000000-0000-4000-8000-000000000003").Return(domain.Order{}, gorm.ErrRecordNotFound)
+	storeStockRepo.EXPECT().Find(gomock.Any(), "p-1", uint64(0)).Return(domain.StoreStock{}, gorm.ErrRecordNotFound)
+
+	// order 4 is of a product that no longer exists
+	orderRepo.EXPECT().FindById(gomock.Any(), "00000000-0000-4000-8000-000000000004").Return(domain.Order{}, gorm.ErrRecordNotFound)
+	priceListService.EXPECT().ResolvePrice(gomock.Any(), web.PriceQuery{ProductID: "p-9", At: orderDate}).
+		Return(web.ResolvedPrice{}, exception.NewNotFoundError("Product not found"))
+
+	response, err := orderService.UploadOffline(cashierCtx, web.OfflineOrderUploadRequest{Orders: []web.OfflineOrderRequest{
+		{OrderID: "00000000-0000-4000-8000-000000000001", OrderDate: orderDate, Items: []web.OfflineOrderItemRequest{{ProductID: "p-1", Quantity: 1}}},
+		{OrderID: "00000000-0000-4000-8000-000000000002", OrderDate: orderDate, Items: []web.OfflineOrderItemRequest{{ProductID: "p-1", Quantity: 1, UnitPrice: &soldAt}},
+			Payments: []web.PaymentCreateRequest{{PaymentType: domain.PaymentTypeCash, Amount: 80}}},
+		{OrderID: "00000000-0000-4000-8000-000000000003", OrderDate: orderDate, Items: []web.OfflineOrderItemRequest{{ProductID: "p-1", Quantity: 1}},
+			Payments: []web.PaymentCreateRequest{{PaymentType: domain.PaymentTypeCard, Amount: 100}}},
+		{OrderID: "00000000-0000-4000-8000-000000000004", OrderDate: orderDate, Items: []web.OfflineOrderItemRequest{{ProductID: "p-9", Quantity: 1}}},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Accepted)
+	assert.Equal(t, 1, response.Adjusted)
+	assert.Equal(t, 1, response.Flagged)
+	assert.Equal(t, 1, response.Rejected)
+
+	assert.True(t, response.Results[0].Duplicate)
+	assert.Equal(t, domain.ReconciliationAccepted, response.Results[0].Status)
+
+	assert.Equal(t, domain.ReconciliationAdjusted, response.Results[1].Status)
+	assert.Equal(t, []web.ReconciliationIssue{{Type: domain.IssuePriceBelowCurrent, ProductID: "p-1", Expected: 100, Actual: 80}}, response.Results[1].Issues)
+	assert.Equal(t, 20.0, response.Results[1].Order.OrderItems[0].DiscountAmount)
+
+	assert.Equal(t, domain.ReconciliationFlagged, response.Results[2].Status)
+	assert.Equal(t, []web.ReconciliationIssue{{Type: domain.IssueNegativeStock, ProductID: "p-1", Expected: 0, Actual: 1}}, response.Results[2].Issues)
+
+	assert.Equal(t, OfflineOrderRejected, response.Results[3].Status)
+	assert.Equal(t, "Product not found", response.Results[3].Error)
+}
+
+func TestUploadOfflineOrderBookedByConcurrentUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderId := "00000000-0000-4000-8000-000000000001"
+	orderDate := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cashierCtx := context.WithValue(context.Background(), helper.ContextKeyEmployee, "e-1")
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	productRepo := mocks.NewMockProductRepository(ctrl)
+	storeStockRepo := mocks.NewMockStoreStockRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	employeeRepo := mocks.NewMockEmployeeRepository(ctrl)
+	shiftRepo := mocks.NewMockShiftRepository(ctrl)
+	priceListService := servicemocks.NewMockPriceListService(ctrl)
+	orderService := NewOrderService(orderRepo, productRepo, mocks.NewMockProductVariantRepository(ctrl), storeStockRepo, inventoryRepo,
+		mocks.NewMockPaymentRepository(ctrl), employeeRepo, shiftRepo, priceListService, newMockOutboxRepository(ctrl), newPassthroughTransactionManager(ctrl), validator.New())
+
+	employeeRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(domain.Employee{EmployeeID: "e-1", Role: "Cashier"}, nil)
+	shiftRepo.EXPECT().FindOpenByEmployeeId(gomock.Any(), "e-1").Return(domain.Shift{Id: 11, EmployeeID: "e-1", Status: domain.ShiftStatusOpen}, nil)
+	priceListService.EXPECT().ResolvePrice(gomock.Any(), gomock.Any()).Return(web.ResolvedPrice{Price: 100, RegularPrice: 100}, nil)
+	storeStockRepo.EXPECT().Find(gomock.Any(), "p-1", uint64(0)).Return(domain.StoreStock{Quantity: 5}, nil)
+	storeStockRepo.EXPECT().AdjustAllowingNegative(gomock.Any(), "p-1", uint64(0), -1).Return(nil)
+	productRepo.EXPECT().AdjustStockAllowingNegative(gomock.Any(), "p-1", -1).Return(nil)
+	productRepo.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+	inventoryRepo.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return(nil, nil)
+	inventoryRepo.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).Return(domain.InventoryMovement{}, nil)
+
+	// the other upload saves the order between the lookup and the save of this one
+	gomock.InOrder(
+		orderRepo.EXPECT().FindById(gomock.Any(), orderId).Return(domain.Order{}, gorm.ErrRecordNotFound),
+		orderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Order{}, repository.ErrOrderExists),
+		orderRepo.EXPECT().FindById(gomock.Any(), orderId).Return(domain.Order{OrderID: orderId, ReconciliationStatus: domain.ReconciliationAccepted}, nil),
+	)
+
+	response, err := orderService.UploadOffline(cashierCtx, web.OfflineOrderUploadRequest{Orders: []web.OfflineOrderRequest{
+		{OrderID: orderId, OrderDate: orderDate, Items: []web.OfflineOrderItemRequest{{ProductID: "p-1", Quantity: 1}}},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Accepted)
+	assert.Equal(t, 0, response.Rejected)
+	assert.True(t, response.Results[0].Duplicate)
+	assert.Equal(t, domain.ReconciliationAccepted, response.Results[0].Status)
+	assert.Empty(t, response.Results[0].Error)
+}
+
+func TestAddPaymentRejectsPaymentsOnPaidOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	paymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	orderService := NewOrderService(orderRepo, nil, nil, nil, nil, paymentRepo, nil, nil, nil, outboxRepo, newPassthroughTransactionManager(ctrl), validator.New())
+
+	// The order is read locked, so the second payment sees the first one
+	order := domain.Order{OrderID: "o-1", TotalAmount: 100, Version: 1}
+	orderRepo.EXPECT().FindByIdForUpdate(gomock.Any(), "o-1").
+		DoAndReturn(func(ctx context.Context, orderId string) (domain.Order, error) {
+			return order, nil
+		}).Times(2)
+	paymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+			order.Payments = append(order.Payments, payment)
+			return payment, nil
+		})
+	orderRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, updated domain.Order) (domain.Order, error) {
+			order.Version++
+			updated.Version = order.Version
+			return updated, nil
+		})
+	outboxRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.OutboxEvent{}, nil).Times(1)
+
+	request := web.PaymentCreateRequest{OrderID: "o-1", PaymentType: domain.PaymentTypeCard, Amount: 100}
+	_, err := orderService.AddPayment(context.Background(), request)
+	assert.NoError(t, err)
+
+	_, err = orderService.AddPayment(context.Background(), request)
+	var badRequest exception.BadRequestError
+	assert.True(t, errors.As(err, &badRequest))
+}
+
+func TestAddPaymentChecksOrderVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   uint64
+		mock      func(orderRepo *mocks.MockOrderRepository, paymentRepo *mocks.MockPaymentRepository)
+		expectErr error
+	}{
+		{
+			name:    "matching version bumps the order",
+			version: 3,
+			mock: func(orderRepo *mocks.MockOrderRepository, paymentRepo *mocks.MockPaymentRepository) {
+				orderRepo.EXPECT().FindByIdForUpdate(gomock.Any(), "o-1").Return(domain.Order{OrderID: "o-1", TotalAmount: 100, Version: 3}, nil)
+				paymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+						payment.Version = 1
+						return payment, nil
+					})
+				orderRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, order domain.Order) (domain.Order, error) {
+						assert.Equal(t, uint64(3), order.Version)
+						order.Version++
+						return order, nil
+					})
+			},
+		},
+		{
+			name:    "stale version",
+			version: 2,
+			mock: func(orderRepo *mocks.MockOrderRepository, paymentRepo *mocks.MockPaymentRepository) {
+				orderRepo.EXPECT().FindByIdForUpdate(gomock.Any(), "o-1").Return(domain.Order{OrderID: "o-1", TotalAmount: 100, Version: 3}, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name: "order modified while paying",
+			mock: func(orderRepo *mocks.MockOrderRepository, paymentRepo *mocks.MockPaymentRepository) {
+				orderRepo.EXPECT().FindByIdForUpdate(gomock.Any(), "o-1").Return(domain.Order{OrderID: "o-1", TotalAmount: 100, Version: 3}, nil)
+				paymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Payment{}, nil)
+				orderRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(domain.Order{}, repository.ErrVersionConflict)
+			},
+			expectErr: exception.ConflictError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			orderRepo := mocks.NewMockOrderRepository(ctrl)
+			paymentRepo := mocks.NewMockPaymentRepository(ctrl)
+			tt.mock(orderRepo, paymentRepo)
+
+			orderService := NewOrderService(orderRepo, nil, nil, nil, nil, paymentRepo, nil, nil, nil, newMockOutboxRepository(ctrl), newPassthroughTransactionManager(ctrl), validator.New())
+			response, err := orderService.AddPayment(context.Background(), web.PaymentCreateRequest{OrderID: "o-1", PaymentType: domain.PaymentTypeCard, Amount: 40, Version: tt.version})
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(1), response.Version)
+		})
+	}
+}
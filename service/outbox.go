@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"time"
+)
+
+// publishEvent writes a domain event about an aggregate to the outbox. It must be called
+// in the transaction of the change the event describes, so that both are committed or
+// rolled back together.
+func publishEvent(ctx context.Context, outboxRepository repository.OutboxRepository, eventType string, aggregateType string, aggregateId string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	storeId, _ := helper.StoreIdFromContext(ctx)
+	_, err = outboxRepository.Save(ctx, domain.OutboxEvent{
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateId,
+		Payload:       string(payload),
+		OriginStoreID: storeId,
+		Actor:         helper.ActorFromContext(ctx),
+		RequestId:     helper.RequestIdFromContext(ctx),
+		OccurredAt:    time.Now(),
+	})
+	return err
+}
@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+type OutboxDispatcher interface {
+	Dispatch(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"time"
+)
+
+const (
+	// OutboxBatchSize is the number of events a dispatch picks up at most.
+	OutboxBatchSize = 100
+	// OutboxClaimTimeout is how long an event is held back from other dispatchers while
+	// it is delivered; when the dispatcher dies it is picked up again after that.
+	OutboxClaimTimeout = time.Minute
+	// OutboxMaxBackoff is the longest wait between two delivery attempts of an event.
+	OutboxMaxBackoff = time.Hour
+)
+
+type OutboxDispatcherImpl struct {
+	OutboxRepository repository.OutboxRepository
+	Sinks            []EventSink
+}
+
+func NewOutboxDispatcher(outboxRepository repository.OutboxRepository, sinks ...EventSink) OutboxDispatcher {
+	return &OutboxDispatcherImpl{
+		OutboxRepository: outboxRepository,
+		Sinks:            sinks,
+	}
+}
+
+// Dispatch delivers the events that are due to every sink, oldest first, and returns how
+// many were delivered. An event is marked delivered once every sink has taken it;
+// otherwise all sinks get it again on the next attempt, after a backoff that doubles
+// with every failure. Delivery is therefore at least once, never at most once.
+func (dispatcher *OutboxDispatcherImpl) Dispatch(ctx context.Context, now time.Time) (int, error) {
+	events, err := dispatcher.OutboxRepository.FindDue(ctx, now, OutboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, event := range events {
+		claimed, err := dispatcher.OutboxRepository.Claim(ctx, event, now.Add(OutboxClaimTimeout))
+		if err != nil {
+			return delivered, err
+		}
+		if !claimed {
+			continue
+		}
+
+		event.Attempts++
+		if err := dispatcher.deliver(ctx, event); err != nil {
+			event.NextAttemptAt = time.Now().Add(outboxBackoff(event.Attempts))
+			event.LastError = truncate(err.Error(), 1000)
+		} else {
+			deliveredAt := time.Now()
+			event.DeliveredAt = &deliveredAt
+			event.LastError = ""
+			delivered++
+		}
+		if err := dispatcher.OutboxRepository.UpdateDelivery(ctx, event); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+func (dispatcher *OutboxDispatcherImpl) deliver(ctx context.Context, event domain.OutboxEvent) error {
+	envelope := helper.ToEventEnvelope(event)
+	var errs []error
+	for _, sink := range dispatcher.Sinks {
+		if err := sink.Deliver(ctx, envelope); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// outboxBackoff is the wait before the next attempt after the given number of failed
+// ones: 10 seconds, doubling up to OutboxMaxBackoff.
+func outboxBackoff(attempts int) time.Duration {
+	backoff := 10 * time.Second
+	for i := 1; i < attempts && backoff < OutboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, OutboxMaxBackoff)
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length]
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newMockOutboxRepository(ctrl *gomock.Controller) *mocks.MockOutboxRepository {
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	outboxRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.OutboxEvent{}, nil).AnyTimes()
+	return outboxRepo
+}
+
+type failingSink struct {
+	err error
+}
+
+func (sink failingSink) Name() string {
+	return "failing"
+}
+
+func (sink failingSink) Deliver(ctx context.Context, event web.EventEnvelope) error {
+	return sink.err
+}
+
+func TestDispatchOutbox(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	event := domain.OutboxEvent{Id: 7, Type: domain.EventOrderPaid, AggregateType: domain.AggregateOrder, AggregateID: "o-1", Payload: `{"order_id":"o-1"}`, NextAttemptAt: now}
+
+	tests := []struct {
+		name          string
+		sinks         func(bus *EventBus, out *bytes.Buffer) []EventSink
+		claimed       bool
+		expectUpdate  func(t *testing.T, event domain.OutboxEvent)
+		expectCount   int
+		expectWritten bool
+	}{
+		{
+			name: "delivers to every sink and marks the event delivered",
+			sinks: func(bus *EventBus, out *bytes.Buffer) []EventSink {
+				return []EventSink{bus, NewWriterSink("test", out)}
+			},
+			claimed: true,
+			expectUpdate: func(t *testing.T, event domain.OutboxEvent) {
+				assert.Equal(t, 1, event.Attempts)
+				assert.NotNil(t, event.DeliveredAt)
+				assert.Empty(t, event.LastError)
+			},
+			expectCount:   1,
+			expectWritten: true,
+		},
+		{
+			name: "a failing sink schedules a retry",
+			sinks: func(bus *EventBus, out *bytes.Buffer) []EventSink {
+				return []EventSink{bus, failingSink{err: errors.New("connection refused")}}
+			},
+			claimed: true,
+			expectUpdate: func(t *testing.T, event domain.OutboxEvent) {
+				assert.Equal(t, 1, event.Attempts)
+				assert.Nil(t, event.DeliveredAt)
+				assert.Equal(t, "failing: connection refused", event.LastError)
+				assert.True(t, event.NextAttemptAt.After(time.Now()))
+			},
+		},
+		{
+			name: "an event claimed by another dispatcher is skipped",
+			sinks: func(bus *EventBus, out *bytes.Buffer) []EventSink {
+				return []EventSink{bus}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+			bus := NewEventBus()
+			var received []web.EventEnvelope
+			bus.Subscribe(domain.EventOrderPaid, func(ctx context.Context, event web.EventEnvelope) error {
+				received = append(received, event)
+				return nil
+			})
+			out := &bytes.Buffer{}
+
+			outboxRepo.EXPECT().FindDue(gomock.Any(), now, OutboxBatchSize).Return([]domain.OutboxEvent{event}, nil)
+			outboxRepo.EXPECT().Claim(gomock.Any(), event, now.Add(OutboxClaimTimeout)).Return(tt.claimed, nil)
+			if tt.claimed {
+				outboxRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+						tt.expectUpdate(t, event)
+						return nil
+					})
+			}
+
+			count, err := NewOutboxDispatcher(outboxRepo, tt.sinks(bus, out)...).Dispatch(context.Background(), now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectCount, count)
+			if !tt.claimed {
+				assert.Empty(t, received)
+				return
+			}
+			assert.Len(t, received, 1)
+			assert.Equal(t, uint64(7), received[0].Id)
+			assert.JSONEq(t, `{"order_id":"o-1"}`, string(received[0].Data))
+			if tt.expectWritten {
+				var written web.EventEnvelope
+				assert.NoError(t, json.Unmarshal(out.Bytes(), &written))
+				assert.Equal(t, domain.EventOrderPaid, written.Type)
+			}
+		})
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, outboxBackoff(1))
+	assert.Equal(t, 20*time.Second, outboxBackoff(2))
+	assert.Equal(t, 80*time.Second, outboxBackoff(4))
+	assert.Equal(t, OutboxMaxBackoff, outboxBackoff(20))
+}
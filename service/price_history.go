@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"time"
+)
+
+type priceChange struct {
+	productId   string
+	priceListId *uint64
+	oldPrice    *float64
+	newPrice    *float64
+	source      string
+	scheduleId  *uint64
+}
+
+// recordPriceChange writes a PriceHistory row for the change and publishes it as a
+// PriceChanged event, unless the price stayed the same.
+func recordPriceChange(ctx context.Context, priceHistoryRepository repository.PriceHistoryRepository, outboxRepository repository.OutboxRepository, change priceChange) error {
+	if change.oldPrice != nil && change.newPrice != nil && *change.oldPrice == *change.newPrice {
+		return nil
+	}
+	if change.oldPrice == nil && change.newPrice == nil {
+		return nil
+	}
+
+	_, err := priceHistoryRepository.Save(ctx, domain.PriceHistory{
+		ProductID:   change.productId,
+		PriceListId: change.priceListId,
+		OldPrice:    change.oldPrice,
+		NewPrice:    change.newPrice,
+		Source:      change.source,
+		ScheduleId:  change.scheduleId,
+		Actor:       helper.ActorFromContext(ctx),
+		ChangedAt:   time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return publishEvent(ctx, outboxRepository, domain.EventPriceChanged, domain.AggregateProduct, change.productId, web.PriceChangedEvent{
+		ProductID:   change.productId,
+		PriceListID: change.priceListId,
+		OldPrice:    change.oldPrice,
+		NewPrice:    change.newPrice,
+		Source:      change.source,
+		ScheduleID:  change.scheduleId,
+	})
+}
+
+func pricePtr(price float64) *float64 {
+	return &price
+}
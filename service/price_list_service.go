@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type PriceListService interface {
+	Create(ctx context.Context, request web.PriceListCreateRequest) (web.PriceListResponse, error)
+	Update(ctx context.Context, request web.PriceListUpdateRequest) (web.PriceListResponse, error)
+	Delete(ctx context.Context, priceListId uint64, version uint64) error
+	FindById(ctx context.Context, priceListId uint64) (web.PriceListResponse, error)
+	FindAll(ctx context.Context) ([]web.PriceListResponse, error)
+	SetItem(ctx context.Context, request web.PriceListItemRequest) (web.PriceListItemResponse, error)
+	DeleteItem(ctx context.Context, priceListId uint64, productId string) error
+	FindItems(ctx context.Context, priceListId uint64) ([]web.PriceListItemResponse, error)
+	FindPriceHistory(ctx context.Context, productId string) ([]web.PriceHistoryResponse, error)
+	ResolvePrice(ctx context.Context, query web.PriceQuery) (web.ResolvedPrice, error)
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+// Sources of a resolved price, from the most to the least specific.
+const (
+	PriceSourcePriceListSchedule = "price_list_schedule"
+	PriceSourcePriceList         = "price_list"
+	PriceSourceVariant           = "variant"
+	PriceSourceSchedule          = "schedule"
+	PriceSourceProduct           = "product"
+)
+
+type PriceListServiceImpl struct {
+	PriceListRepository      repository.PriceListRepository
+	PriceScheduleRepository  repository.PriceScheduleRepository
+	PriceHistoryRepository   repository.PriceHistoryRepository
+	ProductRepository        repository.ProductRepository
+	ProductVariantRepository repository.ProductVariantRepository
+	CustomerRepository       repository.CustomerRepository
+	OutboxRepository         repository.OutboxRepository
+	TransactionManager       repository.TransactionManager
+	Validate                 *validator.Validate
+}
+
+func NewPriceListService(priceListRepository repository.PriceListRepository, priceScheduleRepository repository.PriceScheduleRepository, priceHistoryRepository repository.PriceHistoryRepository, productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, customerRepository repository.CustomerRepository, outboxRepository repository.OutboxRepository, transactionManager repository.TransactionManager, validate *validator.Validate) PriceListService {
+	return &PriceListServiceImpl{
+		PriceListRepository:      priceListRepository,
+		PriceScheduleRepository:  priceScheduleRepository,
+		PriceHistoryRepository:   priceHistoryRepository,
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+		CustomerRepository:       customerRepository,
+		OutboxRepository:         outboxRepository,
+		TransactionManager:       transactionManager,
+		Validate:                 validate,
+	}
+}
+
+// Create PriceList
+func (service *PriceListServiceImpl) Create(ctx context.Context, request web.PriceListCreateRequest) (web.PriceListResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.PriceListResponse{}, err
+	}
+
+	priceList, err := service.PriceListRepository.Save(ctx, domain.PriceList{Code: request.Code, Name: request.Name})
+	if err != nil {
+		return web.PriceListResponse{}, err
+	}
+
+	return helper.ToPriceListResponse(priceList), nil
+}
+
+// Update PriceList
+func (service *PriceListServiceImpl) Update(ctx context.Context, request web.PriceListUpdateRequest) (web.PriceListResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.PriceListResponse{}, err
+	}
+
+	priceList, err := service.findPriceList(ctx, request.Id)
+	if err != nil {
+		return web.PriceListResponse{}, err
+	}
+	if request.Version != 0 && request.Version != priceList.Version {
+		return web.PriceListResponse{}, exception.NewConflictError("Price list has been modified")
+	}
+
+	priceList.Code = request.Code
+	priceList.Name = request.Name
+
+	updatedPriceList, err := service.PriceListRepository.Update(ctx, priceList)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return web.PriceListResponse{}, exception.NewConflictError("Price list has been modified")
+	} else if err != nil {
+		return web.PriceListResponse{}, err
+	}
+
+	return helper.ToPriceListResponse(updatedPriceList), nil
+}
+
+// Delete PriceList
+func (service *PriceListServiceImpl) Delete(ctx context.Context, priceListId uint64, version uint64) error {
+	priceList, err := service.findPriceList(ctx, priceListId)
+	if err != nil {
+		return err
+	}
+	if version != 0 && version != priceList.Version {
+		return exception.NewConflictError("Price list has been modified")
+	}
+
+	err = service.PriceListRepository.Delete(ctx, priceList)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Price list has been modified")
+	}
+	return err
+}
+
+// FindById PriceList
+func (service *PriceListServiceImpl) FindById(ctx context.Context, priceListId uint64) (web.PriceListResponse, error) {
+	priceList, err := service.findPriceList(ctx, priceListId)
+	if err != nil {
+		return web.PriceListResponse{}, err
+	}
+
+	return helper.ToPriceListResponse(priceList), nil
+}
+
+// FindAll PriceLists
+func (service *PriceListServiceImpl) FindAll(ctx context.Context) ([]web.PriceListResponse, error) {
+	priceLists, err := service.PriceListRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToPriceListResponses(priceLists), nil
+}
+
+// SetItem sets the price of a product in a price list and records the change
+func (service *PriceListServiceImpl) SetItem(ctx context.Context, request web.PriceListItemRequest) (web.PriceListItemResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.PriceListItemResponse{}, err
+	}
+	if _, err := service.findPriceList(ctx, request.PriceListId); err != nil {
+		return web.PriceListItemResponse{}, err
+	}
+	if _, err := findProduct(ctx, service.ProductRepository, request.ProductID); err != nil {
+		return web.PriceListItemResponse{}, err
+	}
+
+	var savedItem domain.PriceListItem
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		var oldPrice *float64
+		item, err := service.PriceListRepository.FindItem(ctx, request.PriceListId, request.ProductID)
+		if err == nil {
+			oldPrice = pricePtr(item.Price)
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		savedItem, err = service.PriceListRepository.SaveItem(ctx, domain.PriceListItem{
+			PriceListId: request.PriceListId,
+			ProductID:   request.ProductID,
+			Price:       request.Price,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordPriceChange(ctx, service.PriceHistoryRepository, service.OutboxRepository, priceChange{
+			productId:   request.ProductID,
+			priceListId: &request.PriceListId,
+			oldPrice:    oldPrice,
+			newPrice:    pricePtr(request.Price),
+			source:      domain.PriceSourceManual,
+		})
+	})
+	if err != nil {
+		return web.PriceListItemResponse{}, err
+	}
+
+	return helper.ToPriceListItemResponse(savedItem), nil
+}
+
+// DeleteItem removes a product from a price list, so that its own price applies again
+func (service *PriceListServiceImpl) DeleteItem(ctx context.Context, priceListId uint64, productId string) error {
+	return service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		item, err := service.PriceListRepository.FindItem(ctx, priceListId, productId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.NewNotFoundError("Price list item not found")
+		} else if err != nil {
+			return err
+		}
+
+		if err := service.PriceListRepository.DeleteItem(ctx, item); err != nil {
+			return err
+		}
+
+		return recordPriceChange(ctx, service.PriceHistoryRepository, service.OutboxRepository, priceChange{
+			productId:   productId,
+			priceListId: &priceListId,
+			oldPrice:    pricePtr(item.Price),
+			source:      domain.PriceSourceManual,
+		})
+	})
+}
+
+// FindItems returns the product prices of a price list
+func (service *PriceListServiceImpl) FindItems(ctx context.Context, priceListId uint64) ([]web.PriceListItemResponse, error) {
+	if _, err := service.findPriceList(ctx, priceListId); err != nil {
+		return nil, err
+	}
+
+	items, err := service.PriceListRepository.FindItems(ctx, priceListId)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToPriceListItemResponses(items), nil
+}
+
+// FindPriceHistory returns the price changes of a product, newest first
+func (service *PriceListServiceImpl) FindPriceHistory(ctx context.Context, productId string) ([]web.PriceHistoryResponse, error) {
+	if _, err := findProduct(ctx, service.ProductRepository, productId); err != nil {
+		return nil, err
+	}
+
+	histories, err := service.PriceHistoryRepository.FindAllByProductId(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToPriceHistoryResponses(histories), nil
+}
+
+// ResolvePrice returns the unit price of a product for a customer at a moment. The
+// first of these that exists wins: a schedule of the customer's price list covering the
+// moment, the customer's price list, the variant's own price, a schedule of the product's
+// own price covering the moment, and finally the product's price.
+func (service *PriceListServiceImpl) ResolvePrice(ctx context.Context, query web.PriceQuery) (web.ResolvedPrice, error) {
+	if query.At.IsZero() {
+		query.At = time.Now()
+	}
+
+	product, err := findProduct(ctx, service.ProductRepository, query.ProductID)
+	if err != nil {
+		return web.ResolvedPrice{}, err
+	}
+	resolved := web.ResolvedPrice{
+		ProductID:    product.ProductID,
+		VariantID:    query.VariantID,
+		RegularPrice: product.Price,
+		TaxRate:      product.TaxRate,
+		TaxType:      product.TaxType,
+	}
+	if resolved.TaxType == "" {
+		resolved.TaxType = domain.DefaultTaxType
+	}
+
+	var variant domain.ProductVariant
+	if query.VariantID != nil {
+		variant, err = service.ProductVariantRepository.FindById(ctx, *query.VariantID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && variant.ProductID != product.ProductID) {
+			return web.ResolvedPrice{}, exception.NewNotFoundError("Variant not found")
+		} else if err != nil {
+			return web.ResolvedPrice{}, err
+		}
+		if variant.Price != nil {
+			resolved.RegularPrice = *variant.Price
+		}
+	}
+
+	if query.CustomerID != 0 {
+		customer, err := service.CustomerRepository.FindById(ctx, strconv.FormatUint(query.CustomerID, 10))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.ResolvedPrice{}, exception.NewNotFoundError("Customer not found")
+		} else if err != nil {
+			return web.ResolvedPrice{}, err
+		}
+
+		if customer.PriceListId != nil {
+			resolved.PriceListId = customer.PriceListId
+
+			schedule, err := service.PriceScheduleRepository.FindEffective(ctx, customer.PriceListId, product.ProductID, query.At)
+			if err == nil {
+				resolved.Price, resolved.Source = schedule.Price, PriceSourcePriceListSchedule
+				return resolved, nil
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return web.ResolvedPrice{}, err
+			}
+
+			item, err := service.PriceListRepository.FindItem(ctx, *customer.PriceListId, product.ProductID)
+			if err == nil {
+				resolved.Price, resolved.Source = item.Price, PriceSourcePriceList
+				return resolved, nil
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return web.ResolvedPrice{}, err
+			}
+			resolved.PriceListId = nil
+		}
+	}
+
+	if variant.Price != nil {
+		resolved.Price, resolved.Source = *variant.Price, PriceSourceVariant
+		return resolved, nil
+	}
+
+	schedule, err := service.PriceScheduleRepository.FindEffective(ctx, nil, product.ProductID, query.At)
+	if err == nil {
+		resolved.Price, resolved.Source = schedule.Price, PriceSourceSchedule
+		return resolved, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.ResolvedPrice{}, err
+	}
+
+	resolved.Price, resolved.Source = product.Price, PriceSourceProduct
+	return resolved, nil
+}
+
+func (service *PriceListServiceImpl) findPriceList(ctx context.Context, priceListId uint64) (domain.PriceList, error) {
+	priceList, err := service.PriceListRepository.FindById(ctx, priceListId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.PriceList{}, exception.NewNotFoundError("Price list not found")
+	}
+	return priceList, err
+}
+
+// findProduct loads a product, reporting a missing one as NotFoundError.
+func findProduct(ctx context.Context, productRepository repository.ProductRepository, productId string) (domain.Product, error) {
+	product, err := productRepository.FindById(ctx, productId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Product{}, exception.NewNotFoundError("Product not found")
+	}
+	return product, err
+}
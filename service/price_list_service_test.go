@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+// newMockPriceHistoryRepository accepts any price history writes, for tests that are not about them.
+func newMockPriceHistoryRepository(ctrl *gomock.Controller) *mocks.MockPriceHistoryRepository {
+	priceHistoryRepo := mocks.NewMockPriceHistoryRepository(ctrl)
+	priceHistoryRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.PriceHistory{}, nil).AnyTimes()
+	return priceHistoryRepo
+}
+
+func TestResolvePrice(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	wholesale := uint64(7)
+	variantId := uint64(3)
+	product := domain.Product{ProductID: "p-1", Price: 100, TaxRate: 10, TaxType: "VAT"}
+
+	type repos struct {
+		priceList *mocks.MockPriceListRepository
+		schedule  *mocks.MockPriceScheduleRepository
+		product   *mocks.MockProductRepository
+		variant   *mocks.MockProductVariantRepository
+		customer  *mocks.MockCustomerRepository
+	}
+
+	tests := []struct {
+		name      string
+		query     web.PriceQuery
+		mock      func(r repos)
+		expect    web.ResolvedPrice
+		expectErr error
+	}{
+		{
+			name:  "product price",
+			query: web.PriceQuery{ProductID: "p-1", At: at},
+			mock: func(r repos) {
+				r.schedule.EXPECT().FindEffective(gomock.Any(), nil, "p-1", at).Return(domain.PriceSchedule{}, gorm.ErrRecordNotFound)
+			},
+			expect: web.ResolvedPrice{ProductID: "p-1", Price: 100, Source: PriceSourceProduct, RegularPrice: 100, TaxRate: 10, TaxType: "VAT"},
+		},
+		{
+			name:  "scheduled product price",
+			query: web.PriceQuery{ProductID: "p-1", At: at},
+			mock: func(r repos) {
+				r.schedule.EXPECT().FindEffective(gomock.Any(), nil, "p-1", at).Return(domain.PriceSchedule{Price: 80}, nil)
+			},
+			expect: web.ResolvedPrice{ProductID: "p-1", Price: 80, Source: PriceSourceSchedule, RegularPrice: 100, TaxRate: 10, TaxType: "VAT"},
+		},
+		{
+			name:  "variant price override",
+			query: web.PriceQuery{ProductID: "p-1", VariantID: &variantId, At: at},
+			mock: func(r repos) {
+				price := 120.0
+				r.variant.EXPECT().FindById(gomock.Any(), variantId).Return(domain.ProductVariant{Id: variantId, ProductID: "p-1", Price: &price}, nil)
+			},
+			expect: web.ResolvedPrice{ProductID: "p-1", VariantID: &variantId, Price: 120, Source: PriceSourceVariant, RegularPrice: 120, TaxRate: 10, TaxType: "VAT"},
+		},
+		{
+			name:  "variant of another product",
+			query: web.PriceQuery{ProductID: "p-1", VariantID: &variantId, At: at},
+			mock: func(r repos) {
+				r.variant.EXPECT().FindById(gomock.Any(), variantId).Return(domain.ProductVariant{Id: variantId, ProductID: "p-2"}, nil)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+		{
+			name:  "customer price list",
+			query: web.PriceQuery{CustomerID: 5, ProductID: "p-1", At: at},
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "5").Return(domain.Customer{CustomerID: 5, PriceListId: &wholesale}, nil)
+				r.schedule.EXPECT().FindEffective(gomock.Any(), &wholesale, "p-1", at).Return(domain.PriceSchedule{}, gorm.ErrRecordNotFound)
+				r.priceList.EXPECT().FindItem(gomock.Any(), wholesale, "p-1").Return(domain.PriceListItem{Price: 90}, nil)
+			},
+			expect: web.ResolvedPrice{ProductID: "p-1", PriceListId: &wholesale, Price: 90, Source: PriceSourcePriceList, RegularPrice: 100, TaxRate: 10, TaxType: "VAT"},
+		},
+		{
+			name:  "scheduled price list price",
+			query: web.PriceQuery{CustomerID: 5, ProductID: "p-1", At: at},
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "5").Return(domain.Customer{CustomerID: 5, PriceListId: &wholesale}, nil)
+				r.schedule.EXPECT().FindEffective(gomock.Any(), &wholesale, "p-1", at).Return(domain.PriceSchedule{Price: 70}, nil)
+			},
+			expect: web.ResolvedPrice{ProductID: "p-1", PriceListId: &wholesale, Price: 70, Source: PriceSourcePriceListSchedule, RegularPrice: 100, TaxRate: 10, TaxType: "VAT"},
+		},
+		{
+			name:  "product missing from price list",
+			query: web.PriceQuery{CustomerID: 5, ProductID: "p-1", At: at},
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "5").Return(domain.Customer{CustomerID: 5, PriceListId: &wholesale}, nil)
+				r.schedule.EXPECT().FindEffective(gomock.Any(), &wholesale, "p-1", at).Return(domain.PriceSchedule{}, gorm.ErrRecordNotFound)
+				r.priceList.EXPECT().FindItem(gomock.Any(), wholesale, "p-1").Return(domain.PriceListItem{}, gorm.ErrRecordNotFound)
+				r.schedule.EXPECT().FindEffective(gomock.Any(), nil, "p-1", at).Return(domain.PriceSchedule{}, gorm.ErrRecordNotFound)
+			},
+			expect: web.ResolvedPrice{ProductID: "p-1", Price: 100, Source: PriceSourceProduct, RegularPrice: 100, TaxRate: 10, TaxType: "VAT"},
+		},
+		{
+			name:  "unknown customer",
+			query: web.PriceQuery{CustomerID: 9, ProductID: "p-1", At: at},
+			mock: func(r repos) {
+				r.customer.EXPECT().FindById(gomock.Any(), "9").Return(domain.Customer{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			r := repos{
+				priceList: mocks.NewMockPriceListRepository(ctrl),
+				schedule:  mocks.NewMockPriceScheduleRepository(ctrl),
+				product:   mocks.NewMockProductRepository(ctrl),
+				variant:   mocks.NewMockProductVariantRepository(ctrl),
+				customer:  mocks.NewMockCustomerRepository(ctrl),
+			}
+			r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(product, nil)
+			tt.mock(r)
+
+			priceListService := NewPriceListService(r.priceList, r.schedule, newMockPriceHistoryRepository(ctrl), r.product, r.variant, r.customer, newMockOutboxRepository(ctrl), mocks.NewMockTransactionManager(ctrl), validator.New())
+			resolved, err := priceListService.ResolvePrice(context.Background(), tt.query)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, resolved)
+		})
+	}
+}
+
+func TestSetPriceListItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	priceListRepo := mocks.NewMockPriceListRepository(ctrl)
+	priceHistoryRepo := mocks.NewMockPriceHistoryRepository(ctrl)
+	productRepo := mocks.NewMockProductRepository(ctrl)
+	tx := mocks.NewMockTransactionManager(ctrl)
+	priceListService := NewPriceListService(priceListRepo, mocks.NewMockPriceScheduleRepository(ctrl), priceHistoryRepo, productRepo, mocks.NewMockProductVariantRepository(ctrl), mocks.NewMockCustomerRepository(ctrl), newMockOutboxRepository(ctrl), tx, validator.New())
+
+	tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	priceListRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.PriceList{Id: 1}, nil)
+	productRepo.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+	priceListRepo.EXPECT().FindItem(gomock.Any(), uint64(1), "p-1").Return(domain.PriceListItem{PriceListId: 1, ProductID: "p-1", Price: 90}, nil)
+	priceListRepo.EXPECT().SaveItem(gomock.Any(), domain.PriceListItem{PriceListId: 1, ProductID: "p-1", Price: 85}).
+		Return(domain.PriceListItem{Id: 4, PriceListId: 1, ProductID: "p-1", Price: 85}, nil)
+	priceHistoryRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, history domain.PriceHistory) (domain.PriceHistory, error) {
+			assert.Equal(t, 90.0, *history.OldPrice)
+			assert.Equal(t, 85.0, *history.NewPrice)
+			assert.Equal(t, uint64(1), *history.PriceListId)
+			assert.Equal(t, domain.PriceSourceManual, history.Source)
+			return history, nil
+		})
+
+	response, err := priceListService.SetItem(context.Background(), web.PriceListItemRequest{PriceListId: 1, ProductID: "p-1", Price: 85})
+	assert.NoError(t, err)
+	assert.Equal(t, web.PriceListItemResponse{PriceListId: 1, ProductID: "p-1", Price: 85}, response)
+}
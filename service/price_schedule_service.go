@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"time"
+)
+
+type PriceScheduleService interface {
+	Create(ctx context.Context, request web.PriceScheduleCreateRequest) (web.PriceScheduleResponse, error)
+	Cancel(ctx context.Context, scheduleId uint64) (web.PriceScheduleResponse, error)
+	FindAll(ctx context.Context, filter web.PriceScheduleFilterRequest) ([]web.PriceScheduleResponse, error)
+	ApplyDue(ctx context.Context, now time.Time) (web.PriceScheduleRunResponse, error)
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+type PriceScheduleServiceImpl struct {
+	PriceScheduleRepository repository.PriceScheduleRepository
+	PriceListRepository     repository.PriceListRepository
+	PriceHistoryRepository  repository.PriceHistoryRepository
+	ProductRepository       repository.ProductRepository
+	OutboxRepository        repository.OutboxRepository
+	TransactionManager      repository.TransactionManager
+	Validate                *validator.Validate
+}
+
+func NewPriceScheduleService(priceScheduleRepository repository.PriceScheduleRepository, priceListRepository repository.PriceListRepository, priceHistoryRepository repository.PriceHistoryRepository, productRepository repository.ProductRepository, outboxRepository repository.OutboxRepository, transactionManager repository.TransactionManager, validate *validator.Validate) PriceScheduleService {
+	return &PriceScheduleServiceImpl{
+		PriceScheduleRepository: priceScheduleRepository,
+		PriceListRepository:     priceListRepository,
+		PriceHistoryRepository:  priceHistoryRepository,
+		ProductRepository:       productRepository,
+		OutboxRepository:        outboxRepository,
+		TransactionManager:      transactionManager,
+		Validate:                validate,
+	}
+}
+
+// Create schedules a price change. Schedules for the same product and price list may not overlap.
+func (service *PriceScheduleServiceImpl) Create(ctx context.Context, request web.PriceScheduleCreateRequest) (web.PriceScheduleResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.PriceScheduleResponse{}, err
+	}
+	if request.EffectiveTo != nil && !request.EffectiveTo.After(request.EffectiveFrom) {
+		return web.PriceScheduleResponse{}, exception.NewBadRequestError("effective_to must be after effective_from")
+	}
+	if _, err := findProduct(ctx, service.ProductRepository, request.ProductID); err != nil {
+		return web.PriceScheduleResponse{}, err
+	}
+	if request.PriceListId != nil {
+		_, err := service.PriceListRepository.FindById(ctx, *request.PriceListId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.PriceScheduleResponse{}, exception.NewNotFoundError("Price list not found")
+		} else if err != nil {
+			return web.PriceScheduleResponse{}, err
+		}
+	}
+
+	schedule := domain.PriceSchedule{
+		PriceListId:   request.PriceListId,
+		ProductID:     request.ProductID,
+		Price:         request.Price,
+		EffectiveFrom: request.EffectiveFrom,
+		EffectiveTo:   request.EffectiveTo,
+		Status:        domain.PriceScheduleStatusPending,
+		CreatedAt:     time.Now(),
+	}
+
+	var savedSchedule domain.PriceSchedule
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		overlapping, err := service.PriceScheduleRepository.CountOverlapping(ctx, schedule)
+		if err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return exception.NewConflictError("Price schedule overlaps another schedule of this product")
+		}
+
+		savedSchedule, err = service.PriceScheduleRepository.Save(ctx, schedule)
+		return err
+	})
+	if err != nil {
+		return web.PriceScheduleResponse{}, err
+	}
+
+	return helper.ToPriceScheduleResponse(savedSchedule), nil
+}
+
+// Cancel a pending schedule, or end an active one early and restore the price it replaced
+func (service *PriceScheduleServiceImpl) Cancel(ctx context.Context, scheduleId uint64) (web.PriceScheduleResponse, error) {
+	var cancelledSchedule domain.PriceSchedule
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		schedule, err := service.PriceScheduleRepository.FindById(ctx, scheduleId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.NewNotFoundError("Price schedule not found")
+		} else if err != nil {
+			return err
+		}
+
+		switch schedule.Status {
+		case domain.PriceScheduleStatusPending:
+		case domain.PriceScheduleStatusActive:
+			if err := service.revert(ctx, schedule); err != nil {
+				return err
+			}
+		default:
+			return exception.NewConflictError("Price schedule has already " + schedule.Status)
+		}
+
+		now := time.Now()
+		schedule.Status = domain.PriceScheduleStatusCancelled
+		schedule.EndedAt = &now
+		cancelledSchedule, err = service.PriceScheduleRepository.Update(ctx, schedule)
+		return err
+	})
+	if err != nil {
+		return web.PriceScheduleResponse{}, err
+	}
+
+	return helper.ToPriceScheduleResponse(cancelledSchedule), nil
+}
+
+// FindAll PriceSchedules
+func (service *PriceScheduleServiceImpl) FindAll(ctx context.Context, filter web.PriceScheduleFilterRequest) ([]web.PriceScheduleResponse, error) {
+	schedules, err := service.PriceScheduleRepository.FindAll(ctx, filter.ProductID, filter.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToPriceScheduleResponses(schedules), nil
+}
+
+// ApplyDue starts the pending schedules whose time has come and ends the active ones
+// whose time is over. Each schedule is handled in its own transaction, so one that fails
+// is skipped and retried on the next run without holding back the others.
+func (service *PriceScheduleServiceImpl) ApplyDue(ctx context.Context, now time.Time) (web.PriceScheduleRunResponse, error) {
+	schedules, err := service.PriceScheduleRepository.FindDue(ctx, now)
+	if err != nil {
+		return web.PriceScheduleRunResponse{}, err
+	}
+
+	var response web.PriceScheduleRunResponse
+	for _, schedule := range schedules {
+		schedule := schedule
+		var applied bool
+		err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+			switch {
+			case schedule.Status == domain.PriceScheduleStatusActive:
+				if err := service.revert(ctx, schedule); err != nil {
+					return err
+				}
+				schedule.Status = domain.PriceScheduleStatusEnded
+				schedule.EndedAt = &now
+			case schedule.EffectiveTo != nil && !schedule.EffectiveTo.After(now):
+				// The whole window passed while the scheduler was not running.
+				schedule.Status = domain.PriceScheduleStatusEnded
+				schedule.EndedAt = &now
+			default:
+				previousPrice, err := service.apply(ctx, schedule)
+				if err != nil {
+					return err
+				}
+				applied = true
+				schedule.Status = domain.PriceScheduleStatusActive
+				schedule.PreviousPrice = previousPrice
+				schedule.AppliedAt = &now
+				if schedule.EffectiveTo == nil {
+					// A permanent change is complete as soon as it is applied.
+					schedule.Status = domain.PriceScheduleStatusEnded
+					schedule.EndedAt = &now
+				}
+			}
+
+			_, err := service.PriceScheduleRepository.Update(ctx, schedule)
+			return err
+		})
+		switch {
+		case err != nil:
+			log.Printf("price schedule %d: %v", schedule.Id, err)
+			response.Skipped++
+		case applied:
+			response.Applied++
+		default:
+			response.Ended++
+		}
+	}
+
+	return response, nil
+}
+
+// apply sets the scheduled price and returns the price it replaced, nil when the product
+// had no price in the schedule's price list.
+func (service *PriceScheduleServiceImpl) apply(ctx context.Context, schedule domain.PriceSchedule) (*float64, error) {
+	previousPrice, err := service.currentPrice(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.setPrice(ctx, schedule, pricePtr(schedule.Price)); err != nil {
+		return nil, err
+	}
+	return previousPrice, nil
+}
+
+// revert restores the price that the schedule replaced.
+func (service *PriceScheduleServiceImpl) revert(ctx context.Context, schedule domain.PriceSchedule) error {
+	return service.setPrice(ctx, schedule, schedule.PreviousPrice)
+}
+
+func (service *PriceScheduleServiceImpl) currentPrice(ctx context.Context, schedule domain.PriceSchedule) (*float64, error) {
+	if schedule.PriceListId == nil {
+		product, err := findProduct(ctx, service.ProductRepository, schedule.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		return pricePtr(product.Price), nil
+	}
+
+	item, err := service.PriceListRepository.FindItem(ctx, *schedule.PriceListId, schedule.ProductID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return pricePtr(item.Price), nil
+}
+
+// setPrice changes the product's own price or its price in the schedule's price list,
+// where a nil price removes the product from the price list, and records the change.
+func (service *PriceScheduleServiceImpl) setPrice(ctx context.Context, schedule domain.PriceSchedule, price *float64) error {
+	var oldPrice *float64
+	if schedule.PriceListId == nil {
+		product, err := findProduct(ctx, service.ProductRepository, schedule.ProductID)
+		if err != nil {
+			return err
+		}
+		if price == nil {
+			return errors.New("product price cannot be removed")
+		}
+		oldPrice = pricePtr(product.Price)
+		product.Price = *price
+		if _, err := service.ProductRepository.Update(ctx, product); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if oldPrice, err = service.currentPrice(ctx, schedule); err != nil {
+			return err
+		}
+		item := domain.PriceListItem{PriceListId: *schedule.PriceListId, ProductID: schedule.ProductID}
+		if price == nil {
+			err = service.PriceListRepository.DeleteItem(ctx, item)
+		} else {
+			item.Price = *price
+			_, err = service.PriceListRepository.SaveItem(ctx, item)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return recordPriceChange(ctx, service.PriceHistoryRepository, service.OutboxRepository, priceChange{
+		productId:   schedule.ProductID,
+		priceListId: schedule.PriceListId,
+		oldPrice:    oldPrice,
+		newPrice:    price,
+		source:      domain.PriceSourceSchedule,
+		scheduleId:  &schedule.Id,
+	})
+}
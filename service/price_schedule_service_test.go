@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestCreatePriceSchedule(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		input     web.PriceScheduleCreateRequest
+		mock      func(scheduleRepo *mocks.MockPriceScheduleRepository, productRepo *mocks.MockProductRepository)
+		expectErr error
+	}{
+		{
+			name:  "success",
+			input: web.PriceScheduleCreateRequest{ProductID: "p-1", Price: 80, EffectiveFrom: from, EffectiveTo: &to},
+			mock: func(scheduleRepo *mocks.MockPriceScheduleRepository, productRepo *mocks.MockProductRepository) {
+				productRepo.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+				scheduleRepo.EXPECT().CountOverlapping(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+				scheduleRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error) {
+						assert.Equal(t, domain.PriceScheduleStatusPending, schedule.Status)
+						schedule.Id = 1
+						return schedule, nil
+					})
+			},
+		},
+		{
+			name:  "overlapping schedule",
+			input: web.PriceScheduleCreateRequest{ProductID: "p-1", Price: 80, EffectiveFrom: from, EffectiveTo: &to},
+			mock: func(scheduleRepo *mocks.MockPriceScheduleRepository, productRepo *mocks.MockProductRepository) {
+				productRepo.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+				scheduleRepo.EXPECT().CountOverlapping(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:      "ends before it starts",
+			input:     web.PriceScheduleCreateRequest{ProductID: "p-1", Price: 80, EffectiveFrom: to, EffectiveTo: &from},
+			mock:      func(scheduleRepo *mocks.MockPriceScheduleRepository, productRepo *mocks.MockProductRepository) {},
+			expectErr: exception.BadRequestError{},
+		},
+		{
+			name:  "unknown product",
+			input: web.PriceScheduleCreateRequest{ProductID: "p-9", Price: 80, EffectiveFrom: from},
+			mock: func(scheduleRepo *mocks.MockPriceScheduleRepository, productRepo *mocks.MockProductRepository) {
+				productRepo.EXPECT().FindById(gomock.Any(), "p-9").Return(domain.Product{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			scheduleRepo := mocks.NewMockPriceScheduleRepository(ctrl)
+			productRepo := mocks.NewMockProductRepository(ctrl)
+			tx := mocks.NewMockTransactionManager(ctrl)
+			tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				}).AnyTimes()
+			tt.mock(scheduleRepo, productRepo)
+
+			scheduleService := NewPriceScheduleService(scheduleRepo, mocks.NewMockPriceListRepository(ctrl), newMockPriceHistoryRepository(ctrl), productRepo, newMockOutboxRepository(ctrl), tx, validator.New())
+			response, err := scheduleService.Create(context.Background(), tt.input)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(1), response.Id)
+		})
+	}
+}
+
+func TestApplyDuePriceSchedules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	wholesale := uint64(7)
+	previousPrice := 100.0
+
+	scheduleRepo := mocks.NewMockPriceScheduleRepository(ctrl)
+	priceListRepo := mocks.NewMockPriceListRepository(ctrl)
+	productRepo := mocks.NewMockProductRepository(ctrl)
+	tx := mocks.NewMockTransactionManager(ctrl)
+	tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	scheduleService := NewPriceScheduleService(scheduleRepo, priceListRepo, newMockPriceHistoryRepository(ctrl), productRepo, newMockOutboxRepository(ctrl), tx, validator.New())
+
+	scheduleRepo.EXPECT().FindDue(gomock.Any(), now).Return([]domain.PriceSchedule{
+		// starts now: the product price drops to 80
+		{Id: 1, ProductID: "p-1", Price: 80, EffectiveFrom: earlier, Status: domain.PriceScheduleStatusPending},
+		// a sale in the wholesale list is over: the product had no wholesale price before it
+		{Id: 2, PriceListId: &wholesale, ProductID: "p-2", Price: 50, EffectiveFrom: earlier.Add(-time.Hour), EffectiveTo: &earlier, Status: domain.PriceScheduleStatusActive},
+		// the whole window passed while the scheduler was down
+		{Id: 3, ProductID: "p-3", Price: 60, EffectiveFrom: earlier.Add(-time.Hour), EffectiveTo: &earlier, Status: domain.PriceScheduleStatusPending},
+		// the product no longer exists
+		{Id: 4, ProductID: "p-4", Price: 60, EffectiveFrom: earlier, Status: domain.PriceScheduleStatusPending},
+	}, nil)
+
+	productRepo.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1", Price: previousPrice, Version: 2}, nil).Times(2)
+	productRepo.EXPECT().Update(gomock.Any(), domain.Product{ProductID: "p-1", Price: 80, Version: 2}).Return(domain.Product{ProductID: "p-1", Price: 80, Version: 3}, nil)
+	scheduleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error) {
+			assert.Equal(t, domain.PriceScheduleStatusEnded, schedule.Status, "permanent changes end once applied")
+			assert.Equal(t, previousPrice, *schedule.PreviousPrice)
+			assert.Equal(t, &now, schedule.AppliedAt)
+			return schedule, nil
+		})
+
+	priceListRepo.EXPECT().FindItem(gomock.Any(), wholesale, "p-2").Return(domain.PriceListItem{PriceListId: wholesale, ProductID: "p-2", Price: 50}, nil)
+	priceListRepo.EXPECT().DeleteItem(gomock.Any(), domain.PriceListItem{PriceListId: wholesale, ProductID: "p-2"}).Return(nil)
+	scheduleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error) {
+			assert.Equal(t, uint64(2), schedule.Id)
+			assert.Equal(t, domain.PriceScheduleStatusEnded, schedule.Status)
+			return schedule, nil
+		})
+
+	scheduleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, schedule domain.PriceSchedule) (domain.PriceSchedule, error) {
+			assert.Equal(t, uint64(3), schedule.Id)
+			assert.Equal(t, domain.PriceScheduleStatusEnded, schedule.Status)
+			assert.Nil(t, schedule.AppliedAt)
+			return schedule, nil
+		})
+
+	productRepo.EXPECT().FindById(gomock.Any(), "p-4").Return(domain.Product{}, errors.New("connection reset"))
+
+	response, err := scheduleService.ApplyDue(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, web.PriceScheduleRunResponse{Applied: 1, Ended: 2, Skipped: 1}, response)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"io"
+)
+
+type ProductBarcodeService interface {
+	Lookup(ctx context.Context, code string) (web.ProductBarcodeLookupResponse, error)
+	FindAll(ctx context.Context, productId string) ([]web.ProductBarcodeResponse, error)
+	Create(ctx context.Context, request web.ProductBarcodeCreateRequest) (web.ProductBarcodeResponse, error)
+	Delete(ctx context.Context, productId string, code string) error
+	GenerateMissing(ctx context.Context) ([]web.ProductBarcodeResponse, error)
+	RenderLabels(ctx context.Context, request web.BarcodeLabelRequest, writer io.Writer) error
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"io"
+)
+
+// MaxBarcodeLabels is the largest number of labels rendered in one sheet request.
+const MaxBarcodeLabels = 1000
+
+type ProductBarcodeServiceImpl struct {
+	ProductRepository        repository.ProductRepository
+	ProductVariantRepository repository.ProductVariantRepository
+	ProductBarcodeRepository repository.ProductBarcodeRepository
+	TransactionManager       repository.TransactionManager
+	Validate                 *validator.Validate
+}
+
+func NewProductBarcodeService(productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, productBarcodeRepository repository.ProductBarcodeRepository, transactionManager repository.TransactionManager, validate *validator.Validate) ProductBarcodeService {
+	return &ProductBarcodeServiceImpl{
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+		ProductBarcodeRepository: productBarcodeRepository,
+		TransactionManager:       transactionManager,
+		Validate:                 validate,
+	}
+}
+
+// Lookup finds the product, and variant if any, a scanned code belongs to.
+func (service *ProductBarcodeServiceImpl) Lookup(ctx context.Context, code string) (web.ProductBarcodeLookupResponse, error) {
+	code, _, err := helper.NormalizeBarcode(code)
+	if err != nil {
+		return web.ProductBarcodeLookupResponse{}, exception.NewBadRequestError(err.Error())
+	}
+
+	barcode, err := service.ProductBarcodeRepository.FindByCode(ctx, code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.ProductBarcodeLookupResponse{}, exception.NewNotFoundError("Barcode not found")
+	} else if err != nil {
+		return web.ProductBarcodeLookupResponse{}, err
+	}
+
+	product, err := service.findProduct(ctx, barcode.ProductID)
+	if err != nil {
+		return web.ProductBarcodeLookupResponse{}, err
+	}
+
+	response := web.ProductBarcodeLookupResponse{
+		Barcode: helper.ToProductBarcodeResponse(barcode),
+		Product: helper.ToProductResponse(product),
+	}
+	if barcode.VariantID != nil {
+		for _, variant := range product.Variants {
+			if variant.Id == *barcode.VariantID {
+				variantResponse := helper.ToProductVariantResponse(product, variant)
+				response.Variant = &variantResponse
+			}
+		}
+	}
+	return response, nil
+}
+
+// FindAll Barcodes of a Product
+func (service *ProductBarcodeServiceImpl) FindAll(ctx context.Context, productId string) ([]web.ProductBarcodeResponse, error) {
+	product, err := service.findProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToProductBarcodeResponses(product.Barcodes), nil
+}
+
+// Create adds a manufacturer barcode to a product or one of its variants
+func (service *ProductBarcodeServiceImpl) Create(ctx context.Context, request web.ProductBarcodeCreateRequest) (web.ProductBarcodeResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.ProductBarcodeResponse{}, err
+	}
+
+	code, barcodeType, err := helper.NormalizeBarcode(request.Code)
+	if err != nil {
+		return web.ProductBarcodeResponse{}, exception.NewBadRequestError(err.Error())
+	}
+
+	if _, err := service.findProduct(ctx, request.ProductID); err != nil {
+		return web.ProductBarcodeResponse{}, err
+	}
+	if request.VariantID != nil {
+		variant, err := service.ProductVariantRepository.FindById(ctx, *request.VariantID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && variant.ProductID != request.ProductID) {
+			return web.ProductBarcodeResponse{}, exception.NewNotFoundError("Variant not found")
+		} else if err != nil {
+			return web.ProductBarcodeResponse{}, err
+		}
+	}
+
+	_, err = service.ProductBarcodeRepository.FindByCode(ctx, code)
+	if err == nil {
+		return web.ProductBarcodeResponse{}, exception.NewConflictError(fmt.Sprintf("barcode %s is already used", code))
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.ProductBarcodeResponse{}, err
+	}
+
+	barcode, err := service.ProductBarcodeRepository.Save(ctx, domain.ProductBarcode{
+		ProductID: request.ProductID,
+		VariantID: request.VariantID,
+		Code:      code,
+		Type:      barcodeType,
+	})
+	if err != nil {
+		return web.ProductBarcodeResponse{}, err
+	}
+
+	return helper.ToProductBarcodeResponse(barcode), nil
+}
+
+// Delete Barcode
+func (service *ProductBarcodeServiceImpl) Delete(ctx context.Context, productId string, code string) error {
+	code, _, err := helper.NormalizeBarcode(code)
+	if err != nil {
+		return exception.NewNotFoundError("Barcode not found")
+	}
+
+	barcode, err := service.ProductBarcodeRepository.FindByCode(ctx, code)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && barcode.ProductID != productId) {
+		return exception.NewNotFoundError("Barcode not found")
+	} else if err != nil {
+		return err
+	}
+
+	return service.ProductBarcodeRepository.Delete(ctx, barcode)
+}
+
+// GenerateMissing gives every product without a barcode an internal EAN-13 code,
+// numbered on from the last internal code handed out.
+func (service *ProductBarcodeServiceImpl) GenerateMissing(ctx context.Context) ([]web.ProductBarcodeResponse, error) {
+	var barcodes []domain.ProductBarcode
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		productIds, err := service.ProductBarcodeRepository.FindProductIdsWithoutBarcode(ctx)
+		if err != nil {
+			return err
+		}
+
+		var sequence uint64
+		last, err := service.ProductBarcodeRepository.FindLastInternal(ctx)
+		if err == nil {
+			sequence, _ = helper.InternalEAN13Sequence(last.Code)
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		for _, productId := range productIds {
+			sequence++
+			barcode, err := service.ProductBarcodeRepository.Save(ctx, domain.ProductBarcode{
+				ProductID: productId,
+				Code:      helper.InternalEAN13(sequence),
+				Type:      helper.BarcodeTypeEAN13,
+				Internal:  true,
+			})
+			if err != nil {
+				return err
+			}
+			barcodes = append(barcodes, barcode)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToProductBarcodeResponses(barcodes), nil
+}
+
+// RenderLabels writes a sheet with copies of a shelf label for each product, using the
+// first barcode of the product itself.
+func (service *ProductBarcodeServiceImpl) RenderLabels(ctx context.Context, request web.BarcodeLabelRequest, writer io.Writer) error {
+	if request.Format != helper.LabelFormatPNG && request.Format != helper.LabelFormatPDF {
+		return exception.NewBadRequestError("format must be png or pdf")
+	}
+	if len(request.ProductIDs) == 0 {
+		return exception.NewBadRequestError("no products selected")
+	}
+	if request.Copies <= 0 {
+		request.Copies = 1
+	}
+	if len(request.ProductIDs)*request.Copies > MaxBarcodeLabels {
+		return exception.NewBadRequestError(fmt.Sprintf("at most %d labels can be printed at once", MaxBarcodeLabels))
+	}
+
+	var labels []helper.BarcodeLabel
+	for _, productId := range request.ProductIDs {
+		product, err := service.findProduct(ctx, productId)
+		if err != nil {
+			return err
+		}
+
+		var code string
+		for _, barcode := range product.Barcodes {
+			if barcode.VariantID == nil {
+				code = barcode.Code
+				break
+			}
+		}
+		if code == "" {
+			return exception.NewBadRequestError(fmt.Sprintf("product %s has no barcode", productId))
+		}
+
+		for i := 0; i < request.Copies; i++ {
+			labels = append(labels, helper.BarcodeLabel{Code: code, Name: product.Name, Price: product.Price})
+		}
+	}
+
+	return helper.RenderBarcodeLabels(request.Format, labels, writer)
+}
+
+func (service *ProductBarcodeServiceImpl) findProduct(ctx context.Context, productId string) (domain.Product, error) {
+	product, err := service.ProductRepository.FindById(ctx, productId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Product{}, exception.NewNotFoundError("Product not found")
+	}
+	return product, err
+}
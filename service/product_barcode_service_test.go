@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestLookupProductBarcode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockVariantRepo := mocks.NewMockProductVariantRepository(ctrl)
+	mockBarcodeRepo := mocks.NewMockProductBarcodeRepository(ctrl)
+	barcodeService := NewProductBarcodeService(mockProductRepo, mockVariantRepo, mockBarcodeRepo, mocks.NewMockTransactionManager(ctrl), validator.New())
+
+	variantId := uint64(7)
+	product := domain.Product{
+		ProductID: "1",
+		Name:      "Tee",
+		Price:     10,
+		Variants:  []domain.ProductVariant{{Id: 7, ProductID: "1", SKU: "TEE-S", Options: `{"Size":"S"}`}},
+		Barcodes:  []domain.ProductBarcode{{ProductID: "1", VariantID: &variantId, Code: "4006381333931", Type: "ean13"}},
+	}
+
+	tests := []struct {
+		name      string
+		code      string
+		mock      func()
+		expectErr error
+		check     func(t *testing.T, response web.ProductBarcodeLookupResponse)
+	}{
+		{
+			name: "upc-a scanned as product barcode",
+			code: "036000291452",
+			mock: func() {
+				mockBarcodeRepo.EXPECT().FindByCode(gomock.Any(), "0036000291452").
+					Return(domain.ProductBarcode{ProductID: "1", Code: "0036000291452", Type: "ean13"}, nil)
+				mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(product, nil)
+			},
+			check: func(t *testing.T, response web.ProductBarcodeLookupResponse) {
+				assert.Equal(t, "1", response.Product.ProductID)
+				assert.Nil(t, response.Variant)
+			},
+		},
+		{
+			name: "variant barcode",
+			code: "4006381333931",
+			mock: func() {
+				mockBarcodeRepo.EXPECT().FindByCode(gomock.Any(), "4006381333931").Return(product.Barcodes[0], nil)
+				mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(product, nil)
+			},
+			check: func(t *testing.T, response web.ProductBarcodeLookupResponse) {
+				assert.Equal(t, &variantId, response.Barcode.VariantID)
+				assert.Equal(t, "TEE-S", response.Variant.SKU)
+				assert.Equal(t, "4006381333931", response.Variant.Barcode)
+			},
+		},
+		{
+			name:      "bad check digit",
+			code:      "4006381333932",
+			mock:      func() {},
+			expectErr: exception.NewBadRequestError(`barcode "4006381333932" has an invalid check digit`),
+		},
+		{
+			name: "unknown barcode",
+			code: "96385074",
+			mock: func() {
+				mockBarcodeRepo.EXPECT().FindByCode(gomock.Any(), "96385074").Return(domain.ProductBarcode{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.NewNotFoundError("Barcode not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			response, err := barcodeService.Lookup(context.Background(), tt.code)
+			if tt.expectErr != nil {
+				assert.Equal(t, tt.expectErr, err)
+			} else {
+				assert.NoError(t, err)
+				tt.check(t, response)
+			}
+		})
+	}
+}
+
+func TestGenerateMissingProductBarcodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBarcodeRepo := mocks.NewMockProductBarcodeRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	barcodeService := NewProductBarcodeService(mocks.NewMockProductRepository(ctrl), mocks.NewMockProductVariantRepository(ctrl), mockBarcodeRepo, mockTxManager, validator.New())
+
+	mockTxManager.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) })
+	mockBarcodeRepo.EXPECT().FindProductIdsWithoutBarcode(gomock.Any()).Return([]string{"3", "5"}, nil)
+	mockBarcodeRepo.EXPECT().FindLastInternal(gomock.Any()).Return(domain.ProductBarcode{Code: "2000000000411", Internal: true}, nil)
+	mockBarcodeRepo.EXPECT().Save(gomock.Any(), domain.ProductBarcode{ProductID: "3", Code: "2000000000428", Type: "ean13", Internal: true}).
+		DoAndReturn(func(ctx context.Context, barcode domain.ProductBarcode) (domain.ProductBarcode, error) {
+			return barcode, nil
+		})
+	mockBarcodeRepo.EXPECT().Save(gomock.Any(), domain.ProductBarcode{ProductID: "5", Code: "2000000000435", Type: "ean13", Internal: true}).
+		DoAndReturn(func(ctx context.Context, barcode domain.ProductBarcode) (domain.ProductBarcode, error) {
+			return barcode, nil
+		})
+
+	responses, err := barcodeService.GenerateMissing(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []web.ProductBarcodeResponse{
+		{ProductID: "3", Code: "2000000000428", Type: "ean13", Internal: true},
+		{ProductID: "5", Code: "2000000000435", Type: "ean13", Internal: true},
+	}, responses)
+}
+
+func TestRenderProductBarcodeLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	barcodeService := NewProductBarcodeService(mockProductRepo, mocks.NewMockProductVariantRepository(ctrl), mocks.NewMockProductBarcodeRepository(ctrl), mocks.NewMockTransactionManager(ctrl), validator.New())
+
+	mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Product{
+		ProductID: "1",
+		Name:      "Tee",
+		Price:     10,
+		Barcodes:  []domain.ProductBarcode{{Code: "4006381333931", Type: "ean13"}},
+	}, nil).Times(2)
+	mockProductRepo.EXPECT().FindById(gomock.Any(), "2").Return(domain.Product{ProductID: "2"}, nil)
+
+	var png bytes.Buffer
+	err := barcodeService.RenderLabels(context.Background(), web.BarcodeLabelRequest{Format: "png", ProductIDs: []string{"1"}, Copies: 4}, &png)
+	assert.NoError(t, err)
+	assert.Equal(t, "\x89PNG", png.String()[:4])
+
+	var pdf bytes.Buffer
+	err = barcodeService.RenderLabels(context.Background(), web.BarcodeLabelRequest{Format: "pdf", ProductIDs: []string{"1", "2"}}, &pdf)
+	assert.Equal(t, exception.NewBadRequestError("product 2 has no barcode"), err)
+
+	err = barcodeService.RenderLabels(context.Background(), web.BarcodeLabelRequest{Format: "gif", ProductIDs: []string{"1"}}, &pdf)
+	assert.Equal(t, exception.NewBadRequestError("format must be png or pdf"), err)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type ProductImportService interface {
+	// Import validates every row and upserts the products by SKU. Nothing is written when
+	// DryRun is set or when any row is invalid.
+	Import(ctx context.Context, request web.ProductImportRequest) (web.ProductImportResponse, error)
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"strconv"
+	"strings"
+)
+
+// MaxImportRows is the largest number of data rows accepted in one import file.
+const MaxImportRows = 5000
+
+// Product fields that can be mapped to spreadsheet columns.
+var productImportFields = []string{"name", "description", "price", "category", "sku", "tax_rate", "tax_type", "cost_price", "cost_method"}
+
+type ProductImportServiceImpl struct {
+	ProductRepository      repository.ProductRepository
+	CategoryRepository     repository.CategoryRepository
+	PriceHistoryRepository repository.PriceHistoryRepository
+	OutboxRepository       repository.OutboxRepository
+	TransactionManager     repository.TransactionManager
+	Validate               *validator.Validate
+}
+
+func NewProductImportService(productRepository repository.ProductRepository, categoryRepository repository.CategoryRepository, priceHistoryRepository repository.PriceHistoryRepository, outboxRepository repository.OutboxRepository, transactionManager repository.TransactionManager, validate *validator.Validate) ProductImportService {
+	return &ProductImportServiceImpl{
+		ProductRepository:      productRepository,
+		CategoryRepository:     categoryRepository,
+		PriceHistoryRepository: priceHistoryRepository,
+		OutboxRepository:       outboxRepository,
+		TransactionManager:     transactionManager,
+		Validate:               validate,
+	}
+}
+
+type productImportRow struct {
+	row     int
+	request web.ProductCreateRequest
+}
+
+func (service *ProductImportServiceImpl) Import(ctx context.Context, request web.ProductImportRequest) (web.ProductImportResponse, error) {
+	if len(request.Rows) < 2 {
+		return web.ProductImportResponse{}, exception.NewBadRequestError("file has no data rows")
+	}
+	if len(request.Rows)-1 > MaxImportRows {
+		return web.ProductImportResponse{}, exception.NewBadRequestError(fmt.Sprintf("file has more than %d rows", MaxImportRows))
+	}
+
+	columns, err := productImportColumns(request.Rows[0], request.Mapping)
+	if err != nil {
+		return web.ProductImportResponse{}, err
+	}
+
+	categories, err := service.CategoryRepository.FindAll(ctx)
+	if err != nil {
+		return web.ProductImportResponse{}, err
+	}
+	categoryIds := map[string]uint64{}
+	for _, category := range categories {
+		categoryIds[strings.ToLower(strings.TrimSpace(category.Name))] = category.Id
+	}
+
+	response := web.ProductImportResponse{DryRun: request.DryRun, TotalRows: len(request.Rows) - 1, Errors: []web.ProductImportRowError{}}
+
+	var rows []productImportRow
+	seen := map[string]int{}
+	for i, cells := range request.Rows[1:] {
+		rowNumber := i + 2
+		row, err := service.parseRow(cells, columns, categoryIds)
+		if err == nil && seen[row.SKU] != 0 {
+			err = fmt.Errorf("sku is already used on row %d", seen[row.SKU])
+		}
+		if err != nil {
+			response.Errors = append(response.Errors, web.ProductImportRowError{Row: rowNumber, SKU: row.SKU, Message: err.Error()})
+			continue
+		}
+		seen[row.SKU] = rowNumber
+		rows = append(rows, productImportRow{row: rowNumber, request: row})
+	}
+
+	skus := make([]string, 0, len(rows))
+	for _, row := range rows {
+		skus = append(skus, row.request.SKU)
+	}
+	existingProducts, err := service.ProductRepository.FindAllBySKU(ctx, skus)
+	if err != nil {
+		return web.ProductImportResponse{}, err
+	}
+	existing := map[string]domain.Product{}
+	for _, product := range existingProducts {
+		existing[product.SKU] = product
+	}
+
+	for _, row := range rows {
+		if _, ok := existing[row.request.SKU]; ok {
+			response.Updates++
+		} else {
+			response.Inserts++
+		}
+	}
+
+	// Nothing is written unless every row is valid.
+	if request.DryRun || len(response.Errors) > 0 {
+		return response, nil
+	}
+
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		for _, row := range rows {
+			product, exists := existing[row.request.SKU]
+			change := priceChange{newPrice: pricePtr(row.request.Price), source: domain.PriceSourceImport}
+			if exists {
+				change.oldPrice = pricePtr(product.Price)
+			}
+			product.Name = row.request.Name
+			product.Description = row.request.Description
+			product.Price = row.request.Price
+			product.CategoryId = row.request.CategoryID
+			product.SKU = row.request.SKU
+			product.TaxRate = row.request.TaxRate
+			product.TaxType = row.request.TaxType
+			product.CostPrice = row.request.CostPrice
+			product.CostMethod = row.request.CostMethod
+
+			var err error
+			if exists {
+				product, err = service.ProductRepository.Update(ctx, product)
+			} else {
+				product, err = service.ProductRepository.Save(ctx, product)
+			}
+			if err != nil {
+				return fmt.Errorf("row %d: %w", row.row, err)
+			}
+
+			change.productId = product.ProductID
+			if err := recordPriceChange(ctx, service.PriceHistoryRepository, service.OutboxRepository, change); err != nil {
+				return fmt.Errorf("row %d: %w", row.row, err)
+			}
+			if !exists {
+				err = publishEvent(ctx, service.OutboxRepository, domain.EventProductCreated, domain.AggregateProduct, product.ProductID, helper.ToProductResponse(product))
+				if err != nil {
+					return fmt.Errorf("row %d: %w", row.row, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return web.ProductImportResponse{}, err
+	}
+
+	response.Committed = true
+	return response, nil
+}
+
+// parseRow turns the cells of one row into a validated ProductCreateRequest.
+func (service *ProductImportServiceImpl) parseRow(cells []string, columns map[string]int, categoryIds map[string]uint64) (web.ProductCreateRequest, error) {
+	cell := func(field string) string {
+		index, ok := columns[field]
+		if !ok || index >= len(cells) {
+			return ""
+		}
+		return strings.TrimSpace(cells[index])
+	}
+
+	request := web.ProductCreateRequest{
+		Name:        cell("name"),
+		Description: cell("description"),
+		SKU:         cell("sku"),
+		TaxType:     cell("tax_type"),
+		CostMethod:  strings.ToLower(cell("cost_method")),
+	}
+	if request.SKU == "" {
+		return request, fmt.Errorf("sku is required")
+	}
+
+	var err error
+	if request.Price, err = strconv.ParseFloat(cell("price"), 64); err != nil {
+		return request, fmt.Errorf("price %q is not a number", cell("price"))
+	}
+	if taxRate := cell("tax_rate"); taxRate != "" {
+		if request.TaxRate, err = strconv.ParseFloat(taxRate, 64); err != nil {
+			return request, fmt.Errorf("tax_rate %q is not a number", taxRate)
+		}
+	}
+	if costPrice := cell("cost_price"); costPrice != "" {
+		if request.CostPrice, err = strconv.ParseFloat(costPrice, 64); err != nil {
+			return request, fmt.Errorf("cost_price %q is not a number", costPrice)
+		}
+	}
+	if categoryName := cell("category"); categoryName != "" {
+		categoryId, ok := categoryIds[strings.ToLower(categoryName)]
+		if !ok {
+			return request, fmt.Errorf("category %q does not exist", categoryName)
+		}
+		request.CategoryID = int(categoryId)
+	}
+
+	if err := service.Validate.Struct(request); err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+// productImportColumns finds the column index of every product field from the header
+// row, using the mapping to translate field names to the file's own headers.
+func productImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	indexes := map[string]int{}
+	for i, title := range header {
+		indexes[strings.ToLower(strings.TrimSpace(title))] = i
+	}
+
+	columns := map[string]int{}
+	for _, field := range productImportFields {
+		title := field
+		if mapped, ok := mapping[field]; ok {
+			title = mapped
+		}
+		if index, ok := indexes[strings.ToLower(strings.TrimSpace(title))]; ok {
+			columns[field] = index
+		}
+	}
+
+	for _, field := range []string{"name", "price", "sku"} {
+		if _, ok := columns[field]; !ok {
+			return nil, exception.NewBadRequestError(fmt.Sprintf("column for %s is missing", field))
+		}
+	}
+	return columns, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestImportProducts(t *testing.T) {
+	header := []string{"Product Name", "price", "stock_qty", "category", "sku"}
+	categories := []domain.Category{{Id: 3, Name: "Electronics"}}
+
+	tests := []struct {
+		name      string
+		input     web.ProductImportRequest
+		mock      func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, tx *mocks.MockTransactionManager)
+		expect    web.ProductImportResponse
+		expectErr bool
+	}{
+		{
+			name: "dry run plans inserts and updates",
+			input: web.ProductImportRequest{
+				DryRun:  true,
+				Mapping: map[string]string{"name": "Product Name"},
+				Rows: [][]string{
+					header,
+					{"Laptop", "1000", "5", "electronics", "LPT-1"},
+					{"Phone", "500", "8", "Electronics", "PHN-1"},
+				},
+			},
+			mock: func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, tx *mocks.MockTransactionManager) {
+				categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categories, nil)
+				productRepo.EXPECT().FindAllBySKU(gomock.Any(), []string{"LPT-1", "PHN-1"}).
+					Return([]domain.Product{{ProductID: "1", SKU: "PHN-1"}}, nil)
+			},
+			expect: web.ProductImportResponse{DryRun: true, TotalRows: 2, Inserts: 1, Updates: 1, Errors: []web.ProductImportRowError{}},
+		},
+		{
+			name: "row errors are reported and nothing is committed",
+			input: web.ProductImportRequest{
+				Mapping: map[string]string{"name": "Product Name"},
+				Rows: [][]string{
+					header,
+					{"Laptop", "abc", "5", "", "LPT-1"},
+					{"Phone", "500", "8", "Toys", "PHN-1"},
+					{"Tablet", "700", "2", "", "TAB-1"},
+					{"Tablet 2", "700", "2", "", "TAB-1"},
+				},
+			},
+			mock: func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, tx *mocks.MockTransactionManager) {
+				categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categories, nil)
+				productRepo.EXPECT().FindAllBySKU(gomock.Any(), []string{"TAB-1"}).Return(nil, nil)
+			},
+			expect: web.ProductImportResponse{TotalRows: 4, Inserts: 1, Errors: []web.ProductImportRowError{
+				{Row: 2, SKU: "LPT-1", Message: `price "abc" is not a number`},
+				{Row: 3, SKU: "PHN-1", Message: `category "Toys" does not exist`},
+				{Row: 5, SKU: "TAB-1", Message: "sku is already used on row 4"},
+			}},
+		},
+		{
+			name: "commit upserts by sku and leaves the stock alone",
+			input: web.ProductImportRequest{
+				Mapping: map[string]string{"name": "Product Name"},
+				Rows: [][]string{
+					header,
+					{"Laptop", "1000", "5", "", "LPT-1"},
+					{"Phone", "500", "8", "", "PHN-1"},
+				},
+			},
+			mock: func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, tx *mocks.MockTransactionManager) {
+				categoryRepo.EXPECT().FindAll(gomock.Any()).Return(categories, nil)
+				productRepo.EXPECT().FindAllBySKU(gomock.Any(), gomock.Any()).
+					Return([]domain.Product{{ProductID: "1", SKU: "PHN-1", StockQty: 3, Version: 2}}, nil)
+				tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				productRepo.EXPECT().Save(gomock.Any(), domain.Product{Name: "Laptop", Price: 1000, SKU: "LPT-1"}).
+					Return(domain.Product{ProductID: "2"}, nil)
+				productRepo.EXPECT().Update(gomock.Any(), domain.Product{ProductID: "1", Name: "Phone", Price: 500, StockQty: 3, SKU: "PHN-1", Version: 2}).
+					Return(domain.Product{ProductID: "1"}, nil)
+			},
+			expect: web.ProductImportResponse{Committed: true, TotalRows: 2, Inserts: 1, Updates: 1, Errors: []web.ProductImportRowError{}},
+		},
+		{
+			name: "missing required column",
+			input: web.ProductImportRequest{
+				Rows: [][]string{{"name", "price"}, {"Laptop", "1000"}},
+			},
+			mock: func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, tx *mocks.MockTransactionManager) {
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			productRepo := mocks.NewMockProductRepository(ctrl)
+			categoryRepo := mocks.NewMockCategoryRepository(ctrl)
+			tx := mocks.NewMockTransactionManager(ctrl)
+			tt.mock(productRepo, categoryRepo, tx)
+
+			importService := NewProductImportService(productRepo, categoryRepo, newMockPriceHistoryRepository(ctrl), newMockOutboxRepository(ctrl), tx, validator.New())
+			resp, err := importService.Import(context.Background(), tt.input)
+			if tt.expectErr {
+				assert.IsType(t, exception.BadRequestError{}, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, resp)
+			}
+		})
+	}
+}
@@ -8,7 +8,10 @@ import (
 type ProductService interface {
 	Create(ctx context.Context, request web.ProductCreateRequest) (web.ProductResponse, error)
 	Update(ctx context.Context, request web.ProductUpdateRequest) (web.ProductResponse, error)
-	Delete(ctx context.Context, productId string) error
+	Delete(ctx context.Context, productId string, version uint64) error
 	FindById(ctx context.Context, productId string) (web.ProductResponse, error)
 	FindAll(ctx context.Context) ([]web.ProductResponse, error)
+	FindAllByCategory(ctx context.Context, categoryId uint64) ([]web.ProductResponse, error)
+	Export(ctx context.Context, fn func(response web.ProductResponse) error) error
+	Bulk(ctx context.Context, request web.ProductBulkRequest) (web.BulkResponse, error)
 }
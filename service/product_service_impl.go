@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/helper"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
@@ -13,14 +14,20 @@ import (
 )
 
 type ProductServiceImpl struct {
-	ProductRepository repository.ProductRepository
-	Validate          *validator.Validate
+	ProductRepository      repository.ProductRepository
+	PriceHistoryRepository repository.PriceHistoryRepository
+	OutboxRepository       repository.OutboxRepository
+	TransactionManager     repository.TransactionManager
+	Validate               *validator.Validate
 }
 
-func NewProductService(productRepository repository.ProductRepository, validate *validator.Validate) ProductService {
+func NewProductService(productRepository repository.ProductRepository, priceHistoryRepository repository.PriceHistoryRepository, outboxRepository repository.OutboxRepository, transactionManager repository.TransactionManager, validate *validator.Validate) ProductService {
 	return &ProductServiceImpl{
-		ProductRepository: productRepository,
-		Validate:          validate,
+		ProductRepository:      productRepository,
+		PriceHistoryRepository: priceHistoryRepository,
+		OutboxRepository:       outboxRepository,
+		TransactionManager:     transactionManager,
+		Validate:               validate,
 	}
 }
 
@@ -33,13 +40,34 @@ func (service *ProductServiceImpl) Create(ctx context.Context, request web.Produ
 		Name:        request.Name,
 		Description: request.Description,
 		Price:       request.Price,
-		StockQty:    request.StockQty,
 		CategoryId:  request.CategoryID,
 		SKU:         request.SKU,
 		TaxRate:     request.TaxRate,
+		TaxType:     request.TaxType,
+		CostPrice:   request.CostPrice,
+		CostMethod:  request.CostMethod,
+		SupplierID:  request.SupplierID,
+	}
+	if err := checkSkuUnused(ctx, service.ProductRepository, product); err != nil {
+		return web.ProductResponse{}, err
 	}
 
-	savedProduct, err := service.ProductRepository.Save(ctx, product)
+	var savedProduct domain.Product
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if savedProduct, err = service.ProductRepository.Save(ctx, product); err != nil {
+			return err
+		}
+		err = recordPriceChange(ctx, service.PriceHistoryRepository, service.OutboxRepository, priceChange{
+			productId: savedProduct.ProductID,
+			newPrice:  pricePtr(savedProduct.Price),
+			source:    domain.PriceSourceManual,
+		})
+		if err != nil {
+			return err
+		}
+		return publishEvent(ctx, service.OutboxRepository, domain.EventProductCreated, domain.AggregateProduct, savedProduct.ProductID, helper.ToProductResponse(savedProduct))
+	})
 	if err != nil {
 		return web.ProductResponse{}, err
 	}
@@ -58,16 +86,41 @@ func (service *ProductServiceImpl) Update(ctx context.Context, request web.Produ
 	} else if err != nil {
 		return web.ProductResponse{}, err
 	}
+	if request.Version != 0 && request.Version != product.Version {
+		return web.ProductResponse{}, exception.NewConflictError("Product has been modified")
+	}
 
+	oldPrice := product.Price
 	product.Name = request.Name
 	product.Description = request.Description
 	product.Price = request.Price
-	product.StockQty = request.StockQty
 	product.CategoryId = request.CategoryID
 	product.SKU = request.SKU
 	product.TaxRate = request.TaxRate
+	product.TaxType = request.TaxType
+	product.CostPrice = request.CostPrice
+	product.CostMethod = request.CostMethod
+	product.SupplierID = request.SupplierID
+	if err := checkSkuUnused(ctx, service.ProductRepository, product); err != nil {
+		return web.ProductResponse{}, err
+	}
 
-	updatedProduct, err := service.ProductRepository.Update(ctx, product)
+	var updatedProduct domain.Product
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		updatedProduct, err = service.ProductRepository.Update(ctx, product)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return exception.NewConflictError("Product has been modified")
+		} else if err != nil {
+			return err
+		}
+		return recordPriceChange(ctx, service.PriceHistoryRepository, service.OutboxRepository, priceChange{
+			productId: updatedProduct.ProductID,
+			oldPrice:  pricePtr(oldPrice),
+			newPrice:  pricePtr(updatedProduct.Price),
+			source:    domain.PriceSourceManual,
+		})
+	})
 	if err != nil {
 		return web.ProductResponse{}, err
 	}
@@ -75,15 +128,22 @@ func (service *ProductServiceImpl) Update(ctx context.Context, request web.Produ
 	return helper.ToProductResponse(updatedProduct), nil
 }
 
-func (service *ProductServiceImpl) Delete(ctx context.Context, productId string) error {
+func (service *ProductServiceImpl) Delete(ctx context.Context, productId string, version uint64) error {
 	product, err := service.ProductRepository.FindById(ctx, productId)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return exception.NewNotFoundError("Product not found")
 	} else if err != nil {
 		return err
 	}
+	if version != 0 && version != product.Version {
+		return exception.NewConflictError("Product has been modified")
+	}
 
-	return service.ProductRepository.Delete(ctx, product)
+	err = service.ProductRepository.Delete(ctx, product)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Product has been modified")
+	}
+	return err
 }
 
 func (service *ProductServiceImpl) FindById(ctx context.Context, productId string) (web.ProductResponse, error) {
@@ -105,3 +165,72 @@ func (service *ProductServiceImpl) FindAll(ctx context.Context) ([]web.ProductRe
 
 	return helper.ToProductResponses(products), nil
 }
+
+// FindAllByCategory returns the products of a category and of all its descendants
+func (service *ProductServiceImpl) FindAllByCategory(ctx context.Context, categoryId uint64) ([]web.ProductResponse, error) {
+	products, err := service.ProductRepository.FindAllInCategoryTree(ctx, categoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToProductResponses(products), nil
+}
+
+func (service *ProductServiceImpl) Bulk(ctx context.Context, request web.ProductBulkRequest) (web.BulkResponse, error) {
+	mode, err := validateBulk(request.Mode, len(request.Create)+len(request.Update)+len(request.Delete))
+	if err != nil {
+		return web.BulkResponse{}, err
+	}
+
+	var operations []bulkOperation
+	for i, item := range request.Create {
+		item := item
+		operations = append(operations, bulkOperation{index: i, action: web.BulkActionCreate, run: func(ctx context.Context) (interface{}, error) {
+			return service.Create(ctx, item)
+		}})
+	}
+	for i, item := range request.Update {
+		item := item
+		operations = append(operations, bulkOperation{index: i, action: web.BulkActionUpdate, run: func(ctx context.Context) (interface{}, error) {
+			return service.Update(ctx, item)
+		}})
+	}
+	for i, item := range request.Delete {
+		item := item
+		operations = append(operations, bulkOperation{index: i, action: web.BulkActionDelete, run: func(ctx context.Context) (interface{}, error) {
+			return nil, service.Delete(ctx, item.ProductID, item.Version)
+		}})
+	}
+
+	return executeBulk(ctx, service.TransactionManager, mode, operations)
+}
+
+// Export streams every product to fn, reading ExportBatchSize rows at a time
+func (service *ProductServiceImpl) Export(ctx context.Context, fn func(response web.ProductResponse) error) error {
+	return service.ProductRepository.FindInBatches(ctx, ExportBatchSize, func(products []domain.Product) error {
+		for _, product := range products {
+			if err := fn(helper.ToProductResponse(product)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// checkSkuUnused fails with a field conflict when another product has the SKU of the
+// product. Products without a SKU never conflict.
+func checkSkuUnused(ctx context.Context, productRepository repository.ProductRepository, product domain.Product) error {
+	if product.SKU == "" {
+		return nil
+	}
+	others, err := productRepository.FindAllBySKU(ctx, []string{product.SKU})
+	if err != nil {
+		return err
+	}
+	for _, other := range others {
+		if other.ProductID != product.ProductID {
+			return exception.NewFieldConflictError("sku", fmt.Sprintf("sku %s is already used by product %s", product.SKU, other.ProductID))
+		}
+	}
+	return nil
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
 	"github.com/aronipurwanto/go-restful-api/model/domain"
 	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
 	"github.com/aronipurwanto/go-restful-api/repository/mocks"
 	"github.com/go-playground/validator/v10"
 	"github.com/golang/mock/gomock"
@@ -12,13 +14,22 @@ import (
 	"testing"
 )
 
+func newPassthroughTransactionManager(ctrl *gomock.Controller) *mocks.MockTransactionManager {
+	tx := mocks.NewMockTransactionManager(ctrl)
+	tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	return tx
+}
+
 func TestCreateProduct(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockProductRepository(ctrl)
 	mockValidator := validator.New()
-	productService := NewProductService(mockRepo, mockValidator)
+	productService := NewProductService(mockRepo, newMockPriceHistoryRepository(ctrl), newMockOutboxRepository(ctrl), newPassthroughTransactionManager(ctrl), mockValidator)
 
 	tests := []struct {
 		name      string
@@ -33,18 +44,17 @@ func TestCreateProduct(t *testing.T) {
 				Name:        "Laptop",
 				Description: "High-end laptop",
 				Price:       1000,
-				StockQty:    10,
 				CategoryID:  1,
 				SKU:         "LPT123",
 				TaxRate:     0.1,
 			},
 			mock: func() {
+				mockRepo.EXPECT().FindAllBySKU(gomock.Any(), []string{"LPT123"}).Return(nil, nil)
 				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Product{
 					ProductID:   "1",
 					Name:        "Laptop",
 					Description: "High-end laptop",
 					Price:       1000,
-					StockQty:    10,
 					CategoryId:  1,
 					SKU:         "LPT123",
 					TaxRate:     0.1,
@@ -55,7 +65,6 @@ func TestCreateProduct(t *testing.T) {
 				Name:        "Laptop",
 				Description: "High-end laptop",
 				Price:       1000,
-				StockQty:    10,
 				CategoryID:  1,
 				SKU:         "LPT123",
 				TaxRate:     0.1,
@@ -77,17 +86,30 @@ func TestCreateProduct(t *testing.T) {
 				Name:        "Smartphone",
 				Description: "Flagship phone",
 				Price:       700,
-				StockQty:    20,
 				CategoryID:  2,
 				SKU:         "SPH456",
 				TaxRate:     0.1,
 			},
 			mock: func() {
+				mockRepo.EXPECT().FindAllBySKU(gomock.Any(), []string{"SPH456"}).Return(nil, nil)
 				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Product{}, errors.New("database error"))
 			},
 			expect:    web.ProductResponse{},
 			expectErr: true,
 		},
+		{
+			name: "sku already used",
+			input: web.ProductCreateRequest{
+				Name:  "Tablet",
+				Price: 500,
+				SKU:   "LPT123",
+			},
+			mock: func() {
+				mockRepo.EXPECT().FindAllBySKU(gomock.Any(), []string{"LPT123"}).Return([]domain.Product{{ProductID: "1", SKU: "LPT123"}}, nil)
+			},
+			expect:    web.ProductResponse{},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -103,3 +125,127 @@ func TestCreateProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateProductVersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProductRepository(ctrl)
+	productService := NewProductService(mockRepo, newMockPriceHistoryRepository(ctrl), newMockOutboxRepository(ctrl), newPassthroughTransactionManager(ctrl), validator.New())
+
+	request := web.ProductUpdateRequest{ProductID: "1", Name: "Laptop", Price: 1200}
+
+	tests := []struct {
+		name    string
+		version uint64
+		mock    func()
+	}{
+		{
+			name:    "stale version in request",
+			version: 2,
+			mock: func() {
+				mockRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Product{ProductID: "1", Version: 3}, nil)
+			},
+		},
+		{
+			name:    "concurrent update",
+			version: 3,
+			mock: func() {
+				mockRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Product{ProductID: "1", Version: 3}, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(domain.Product{}, repository.ErrVersionConflict)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			request.Version = tt.version
+			_, err := productService.Update(context.Background(), request)
+			assert.IsType(t, exception.ConflictError{}, err)
+		})
+	}
+}
+
+func TestBulkProducts(t *testing.T) {
+	validCreate := web.ProductCreateRequest{Name: "Laptop", Price: 1000}
+	invalidCreate := web.ProductCreateRequest{Name: ""}
+
+	tests := []struct {
+		name           string
+		input          web.ProductBulkRequest
+		mock           func(mockRepo *mocks.MockProductRepository, mockTx *mocks.MockTransactionManager)
+		expectStatuses []string
+		expectErr      bool
+	}{
+		{
+			name:  "best effort keeps successful items",
+			input: web.ProductBulkRequest{Mode: web.BulkModeBestEffort, Create: []web.ProductCreateRequest{validCreate, invalidCreate}},
+			mock: func(mockRepo *mocks.MockProductRepository, mockTx *mocks.MockTransactionManager) {
+				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Product{ProductID: "1", Name: "Laptop"}, nil)
+			},
+			expectStatuses: []string{web.BulkStatusOk, web.BulkStatusFailed},
+		},
+		{
+			name:  "all or nothing rolls back on failure",
+			input: web.ProductBulkRequest{Create: []web.ProductCreateRequest{validCreate, invalidCreate}},
+			mock: func(mockRepo *mocks.MockProductRepository, mockTx *mocks.MockTransactionManager) {
+				mockTx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Product{ProductID: "1", Name: "Laptop"}, nil)
+			},
+			expectStatuses: []string{web.BulkStatusRolledBack, web.BulkStatusFailed},
+		},
+		{
+			name:  "delete not found",
+			input: web.ProductBulkRequest{Mode: web.BulkModeBestEffort, Delete: []web.ProductDeleteRequest{{ProductID: "9"}}},
+			mock: func(mockRepo *mocks.MockProductRepository, mockTx *mocks.MockTransactionManager) {
+				mockRepo.EXPECT().FindById(gomock.Any(), "9").Return(domain.Product{}, errors.New("product not found"))
+			},
+			expectStatuses: []string{web.BulkStatusFailed},
+		},
+		{
+			name:      "unknown mode",
+			input:     web.ProductBulkRequest{Mode: "sometimes", Create: []web.ProductCreateRequest{validCreate}},
+			mock:      func(mockRepo *mocks.MockProductRepository, mockTx *mocks.MockTransactionManager) {},
+			expectErr: true,
+		},
+		{
+			name:      "too many items",
+			input:     web.ProductBulkRequest{Create: make([]web.ProductCreateRequest, MaxBulkItems+1)},
+			mock:      func(mockRepo *mocks.MockProductRepository, mockTx *mocks.MockTransactionManager) {},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockProductRepository(ctrl)
+			mockTx := mocks.NewMockTransactionManager(ctrl)
+			tt.mock(mockRepo, mockTx)
+			// Each create or update runs in its own transaction as well.
+			mockTx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				}).AnyTimes()
+
+			productService := NewProductService(mockRepo, newMockPriceHistoryRepository(ctrl), newMockOutboxRepository(ctrl), mockTx, validator.New())
+			resp, err := productService.Bulk(context.Background(), tt.input)
+			if tt.expectErr {
+				assert.IsType(t, exception.BadRequestError{}, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			var statuses []string
+			for _, result := range resp.Results {
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, tt.expectStatuses, statuses)
+		})
+	}
+}
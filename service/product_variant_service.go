@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type ProductVariantService interface {
+	SetOptions(ctx context.Context, request web.ProductOptionsRequest) ([]web.ProductOptionResponse, error)
+	GenerateVariants(ctx context.Context, productId string) ([]web.ProductVariantResponse, error)
+	FindAll(ctx context.Context, productId string) ([]web.ProductVariantResponse, error)
+	Update(ctx context.Context, request web.ProductVariantUpdateRequest) (web.ProductVariantResponse, error)
+	Delete(ctx context.Context, productId string, variantId uint64, version uint64) error
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// MaxProductVariants is the largest number of option combinations a product may have.
+const MaxProductVariants = 100
+
+type ProductVariantServiceImpl struct {
+	ProductRepository        repository.ProductRepository
+	ProductVariantRepository repository.ProductVariantRepository
+	Validate                 *validator.Validate
+}
+
+func NewProductVariantService(productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, validate *validator.Validate) ProductVariantService {
+	return &ProductVariantServiceImpl{
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+		Validate:                 validate,
+	}
+}
+
+// SetOptions replaces the option definitions of a product. Existing variants are kept;
+// GenerateVariants adds the combinations that are still missing.
+func (service *ProductVariantServiceImpl) SetOptions(ctx context.Context, request web.ProductOptionsRequest) ([]web.ProductOptionResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return nil, err
+	}
+	if _, err := service.findProduct(ctx, request.ProductID); err != nil {
+		return nil, err
+	}
+
+	combinations := 1
+	names := map[string]bool{}
+	options := make([]domain.ProductOption, 0, len(request.Options))
+	for i, optionRequest := range request.Options {
+		name := strings.TrimSpace(optionRequest.Name)
+		if names[strings.ToLower(name)] {
+			return nil, exception.NewBadRequestError(fmt.Sprintf("option %q is defined twice", name))
+		}
+		names[strings.ToLower(name)] = true
+
+		values := make([]string, 0, len(optionRequest.Values))
+		seen := map[string]bool{}
+		for _, value := range optionRequest.Values {
+			value = strings.TrimSpace(value)
+			if seen[strings.ToLower(value)] {
+				return nil, exception.NewBadRequestError(fmt.Sprintf("option %q has the value %q twice", name, value))
+			}
+			seen[strings.ToLower(value)] = true
+			values = append(values, value)
+		}
+
+		combinations *= len(values)
+		if combinations > MaxProductVariants {
+			return nil, exception.NewBadRequestError(fmt.Sprintf("options allow more than %d variants", MaxProductVariants))
+		}
+
+		valuesJson, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, domain.ProductOption{
+			ProductID: request.ProductID,
+			Name:      name,
+			Values:    string(valuesJson),
+			Position:  i,
+		})
+	}
+
+	savedOptions, err := service.ProductVariantRepository.ReplaceOptions(ctx, request.ProductID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToProductOptionResponses(savedOptions), nil
+}
+
+// GenerateVariants creates a variant for every option combination that has none yet.
+// New variants get a SKU made of the product SKU and their option values, no price
+// override and no stock.
+func (service *ProductVariantServiceImpl) GenerateVariants(ctx context.Context, productId string) ([]web.ProductVariantResponse, error) {
+	product, err := service.findProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if len(product.Options) == 0 {
+		return nil, exception.NewBadRequestError("product has no options")
+	}
+
+	existing := map[string]bool{}
+	for _, variant := range product.Variants {
+		existing[variant.Options] = true
+	}
+
+	baseSKU := product.SKU
+	if baseSKU == "" {
+		baseSKU = product.ProductID
+	}
+
+	var newVariants []domain.ProductVariant
+	var skus []string
+	for _, combination := range optionCombinations(helper.ToProductOptionResponses(product.Options)) {
+		optionsJson, err := json.Marshal(combination.options)
+		if err != nil {
+			return nil, err
+		}
+		if existing[string(optionsJson)] {
+			continue
+		}
+
+		sku := strings.ToUpper(baseSKU + "-" + strings.Join(combination.values, "-"))
+		sku = strings.ReplaceAll(sku, " ", "")
+		newVariants = append(newVariants, domain.ProductVariant{
+			ProductID: product.ProductID,
+			SKU:       sku,
+			Options:   string(optionsJson),
+		})
+		skus = append(skus, sku)
+	}
+
+	usedVariants, err := service.ProductVariantRepository.FindAllBySKU(ctx, skus)
+	if err != nil {
+		return nil, err
+	}
+	if len(usedVariants) > 0 {
+		return nil, exception.NewConflictError(fmt.Sprintf("sku %s is already used", usedVariants[0].SKU))
+	}
+
+	savedVariants, err := service.ProductVariantRepository.SaveAll(ctx, newVariants)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToProductVariantResponses(product, append(product.Variants, savedVariants...)), nil
+}
+
+// FindAll Variants of a Product
+func (service *ProductVariantServiceImpl) FindAll(ctx context.Context, productId string) ([]web.ProductVariantResponse, error) {
+	product, err := service.findProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToProductVariantResponses(product, product.Variants), nil
+}
+
+// Update Variant
+func (service *ProductVariantServiceImpl) Update(ctx context.Context, request web.ProductVariantUpdateRequest) (web.ProductVariantResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.ProductVariantResponse{}, err
+	}
+
+	product, variant, err := service.findVariant(ctx, request.ProductID, request.VariantID)
+	if err != nil {
+		return web.ProductVariantResponse{}, err
+	}
+	if request.Version != 0 && request.Version != variant.Version {
+		return web.ProductVariantResponse{}, exception.NewConflictError("Variant has been modified")
+	}
+
+	if request.SKU != variant.SKU {
+		usedVariants, err := service.ProductVariantRepository.FindAllBySKU(ctx, []string{request.SKU})
+		if err != nil {
+			return web.ProductVariantResponse{}, err
+		}
+		if len(usedVariants) > 0 {
+			return web.ProductVariantResponse{}, exception.NewConflictError(fmt.Sprintf("sku %s is already used", request.SKU))
+		}
+	}
+
+	variant.SKU = request.SKU
+	variant.Price = request.PriceOverride
+
+	updatedVariant, err := service.ProductVariantRepository.Update(ctx, variant)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return web.ProductVariantResponse{}, exception.NewConflictError("Variant has been modified")
+	} else if err != nil {
+		return web.ProductVariantResponse{}, err
+	}
+
+	return helper.ToProductVariantResponse(product, updatedVariant), nil
+}
+
+// Delete Variant
+func (service *ProductVariantServiceImpl) Delete(ctx context.Context, productId string, variantId uint64, version uint64) error {
+	_, variant, err := service.findVariant(ctx, productId, variantId)
+	if err != nil {
+		return err
+	}
+	if version != 0 && version != variant.Version {
+		return exception.NewConflictError("Variant has been modified")
+	}
+
+	err = service.ProductVariantRepository.Delete(ctx, variant)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Variant has been modified")
+	}
+	return err
+}
+
+func (service *ProductVariantServiceImpl) findProduct(ctx context.Context, productId string) (domain.Product, error) {
+	product, err := service.ProductRepository.FindById(ctx, productId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Product{}, exception.NewNotFoundError("Product not found")
+	}
+	return product, err
+}
+
+func (service *ProductVariantServiceImpl) findVariant(ctx context.Context, productId string, variantId uint64) (domain.Product, domain.ProductVariant, error) {
+	product, err := service.findProduct(ctx, productId)
+	if err != nil {
+		return domain.Product{}, domain.ProductVariant{}, err
+	}
+
+	variant, err := service.ProductVariantRepository.FindById(ctx, variantId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && variant.ProductID != product.ProductID) {
+		return domain.Product{}, domain.ProductVariant{}, exception.NewNotFoundError("Variant not found")
+	} else if err != nil {
+		return domain.Product{}, domain.ProductVariant{}, err
+	}
+	return product, variant, nil
+}
+
+type optionCombination struct {
+	options map[string]string
+	values  []string // in option order, used to build the SKU
+}
+
+// optionCombinations returns every combination of one value per option.
+func optionCombinations(options []web.ProductOptionResponse) []optionCombination {
+	combinations := []optionCombination{{options: map[string]string{}}}
+	for _, option := range options {
+		var next []optionCombination
+		for _, combination := range combinations {
+			for _, value := range option.Values {
+				selected := map[string]string{option.Name: value}
+				for name, other := range combination.options {
+					selected[name] = other
+				}
+				values := append(append([]string(nil), combination.values...), value)
+				next = append(next, optionCombination{options: selected, values: values})
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetProductOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockVariantRepo := mocks.NewMockProductVariantRepository(ctrl)
+	variantService := NewProductVariantService(mockProductRepo, mockVariantRepo, validator.New())
+
+	tests := []struct {
+		name      string
+		input     web.ProductOptionsRequest
+		mock      func()
+		expect    []web.ProductOptionResponse
+		expectErr error
+	}{
+		{
+			name: "success",
+			input: web.ProductOptionsRequest{ProductID: "1", Options: []web.ProductOptionRequest{
+				{Name: "Size", Values: []string{"S", "M"}},
+				{Name: "Colour", Values: []string{"Red"}},
+			}},
+			mock: func() {
+				mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Product{ProductID: "1"}, nil)
+				mockVariantRepo.EXPECT().ReplaceOptions(gomock.Any(), "1", []domain.ProductOption{
+					{ProductID: "1", Name: "Size", Values: `["S","M"]`, Position: 0},
+					{ProductID: "1", Name: "Colour", Values: `["Red"]`, Position: 1},
+				}).DoAndReturn(func(ctx context.Context, productId string, options []domain.ProductOption) ([]domain.ProductOption, error) {
+					return options, nil
+				})
+			},
+			expect: []web.ProductOptionResponse{{Name: "Size", Values: []string{"S", "M"}}, {Name: "Colour", Values: []string{"Red"}}},
+		},
+		{
+			name: "duplicate value",
+			input: web.ProductOptionsRequest{ProductID: "1", Options: []web.ProductOptionRequest{
+				{Name: "Size", Values: []string{"S", "s"}},
+			}},
+			mock: func() {
+				mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Product{ProductID: "1"}, nil)
+			},
+			expectErr: exception.NewBadRequestError(`option "Size" has the value "s" twice`),
+		},
+		{
+			name: "too many combinations",
+			input: web.ProductOptionsRequest{ProductID: "1", Options: []web.ProductOptionRequest{
+				{Name: "A", Values: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}},
+				{Name: "B", Values: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}},
+			}},
+			mock: func() {
+				mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(domain.Product{ProductID: "1"}, nil)
+			},
+			expectErr: exception.NewBadRequestError("options allow more than 100 variants"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			resp, err := variantService.SetOptions(context.Background(), tt.input)
+			if tt.expectErr != nil {
+				assert.Equal(t, tt.expectErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, resp)
+			}
+		})
+	}
+}
+
+func TestGenerateProductVariants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockVariantRepo := mocks.NewMockProductVariantRepository(ctrl)
+	variantService := NewProductVariantService(mockProductRepo, mockVariantRepo, validator.New())
+
+	override := 12.5
+	product := domain.Product{
+		ProductID: "1",
+		SKU:       "tee",
+		Price:     10,
+		Options: []domain.ProductOption{
+			{Name: "Size", Values: `["S","M"]`, Position: 0},
+			{Name: "Colour", Values: `["Navy Blue"]`, Position: 1},
+		},
+		Variants: []domain.ProductVariant{
+			{Id: 7, ProductID: "1", SKU: "TEE-S-NAVYBLUE", Options: `{"Colour":"Navy Blue","Size":"S"}`, Price: &override, Version: 1},
+		},
+	}
+
+	mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(product, nil)
+	mockVariantRepo.EXPECT().FindAllBySKU(gomock.Any(), []string{"TEE-M-NAVYBLUE"}).Return(nil, nil)
+	mockVariantRepo.EXPECT().
+		SaveAll(gomock.Any(), []domain.ProductVariant{{ProductID: "1", SKU: "TEE-M-NAVYBLUE", Options: `{"Colour":"Navy Blue","Size":"M"}`}}).
+		DoAndReturn(func(ctx context.Context, variants []domain.ProductVariant) ([]domain.ProductVariant, error) {
+			variants[0].Id = 8
+			variants[0].Version = 1
+			return variants, nil
+		})
+
+	resp, err := variantService.GenerateVariants(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, []web.ProductVariantResponse{
+		{VariantID: 7, ProductID: "1", SKU: "TEE-S-NAVYBLUE", Options: map[string]string{"Size": "S", "Colour": "Navy Blue"}, Price: 12.5, PriceOverride: &override, Version: 1},
+		{VariantID: 8, ProductID: "1", SKU: "TEE-M-NAVYBLUE", Options: map[string]string{"Size": "M", "Colour": "Navy Blue"}, Price: 10, Version: 1},
+	}, resp)
+}
+
+func TestUpdateProductVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockVariantRepo := mocks.NewMockProductVariantRepository(ctrl)
+	variantService := NewProductVariantService(mockProductRepo, mockVariantRepo, validator.New())
+
+	product := domain.Product{ProductID: "1", Price: 10}
+	variant := domain.ProductVariant{Id: 7, ProductID: "1", SKU: "TEE-S", Options: `{"Size":"S"}`, Version: 2}
+
+	t.Run("variant of another product", func(t *testing.T) {
+		mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(product, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), uint64(9)).Return(domain.ProductVariant{Id: 9, ProductID: "2"}, nil)
+
+		_, err := variantService.Update(context.Background(), web.ProductVariantUpdateRequest{ProductID: "1", VariantID: 9, SKU: "X"})
+		assert.Equal(t, exception.NewNotFoundError("Variant not found"), err)
+	})
+
+	t.Run("sku used by another variant", func(t *testing.T) {
+		mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(product, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), uint64(7)).Return(variant, nil)
+		mockVariantRepo.EXPECT().FindAllBySKU(gomock.Any(), []string{"TEE-M"}).Return([]domain.ProductVariant{{Id: 8, SKU: "TEE-M"}}, nil)
+
+		_, err := variantService.Update(context.Background(), web.ProductVariantUpdateRequest{ProductID: "1", VariantID: 7, SKU: "TEE-M"})
+		assert.Equal(t, exception.NewConflictError("sku TEE-M is already used"), err)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		mockProductRepo.EXPECT().FindById(gomock.Any(), "1").Return(product, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), uint64(7)).Return(variant, nil)
+		mockVariantRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(domain.ProductVariant{}, repository.ErrVersionConflict)
+
+		_, err := variantService.Update(context.Background(), web.ProductVariantUpdateRequest{ProductID: "1", VariantID: 7, SKU: "TEE-S", Version: 2})
+		assert.Equal(t, exception.NewConflictError("Variant has been modified"), err)
+	})
+}
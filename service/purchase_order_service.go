@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type PurchaseOrderService interface {
+	Create(ctx context.Context, request web.PurchaseOrderCreateRequest) (web.PurchaseOrderResponse, error)
+	Update(ctx context.Context, request web.PurchaseOrderUpdateRequest) (web.PurchaseOrderResponse, error)
+	Delete(ctx context.Context, orderId uint64, version uint64) error
+	Send(ctx context.Context, orderId uint64, version uint64) (web.PurchaseOrderResponse, error)
+	Receive(ctx context.Context, request web.GoodsReceiptRequest) (web.PurchaseOrderResponse, error)
+	Close(ctx context.Context, orderId uint64, version uint64) (web.PurchaseOrderResponse, error)
+	FindById(ctx context.Context, orderId uint64) (web.PurchaseOrderResponse, error)
+	FindAll(ctx context.Context, filter web.PurchaseOrderFilterRequest) ([]web.PurchaseOrderResponse, error)
+	Generate(ctx context.Context) (web.PurchaseOrderGenerateResponse, error)
+}
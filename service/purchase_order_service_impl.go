@@ -0,0 +1,413 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PurchaseOrderServiceImpl struct {
+	PurchaseOrderRepository  repository.PurchaseOrderRepository
+	SupplierRepository       repository.SupplierRepository
+	InventoryRepository      repository.InventoryRepository
+	StoreStockRepository     repository.StoreStockRepository
+	ProductRepository        repository.ProductRepository
+	ProductVariantRepository repository.ProductVariantRepository
+	OutboxRepository         repository.OutboxRepository
+	TransactionManager       repository.TransactionManager
+	Validate                 *validator.Validate
+}
+
+func NewPurchaseOrderService(purchaseOrderRepository repository.PurchaseOrderRepository, supplierRepository repository.SupplierRepository, inventoryRepository repository.InventoryRepository, storeStockRepository repository.StoreStockRepository, productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, outboxRepository repository.OutboxRepository, transactionManager repository.TransactionManager, validate *validator.Validate) PurchaseOrderService {
+	return &PurchaseOrderServiceImpl{
+		PurchaseOrderRepository:  purchaseOrderRepository,
+		SupplierRepository:       supplierRepository,
+		InventoryRepository:      inventoryRepository,
+		StoreStockRepository:     storeStockRepository,
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+		OutboxRepository:         outboxRepository,
+		TransactionManager:       transactionManager,
+		Validate:                 validate,
+	}
+}
+
+// Create a draft purchase order for the store of the request
+func (service *PurchaseOrderServiceImpl) Create(ctx context.Context, request web.PurchaseOrderCreateRequest) (web.PurchaseOrderResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	if err := service.Validate.Struct(request); err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	if _, err := findSupplier(ctx, service.SupplierRepository, request.SupplierID); err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	lines, err := service.orderLines(ctx, request.Lines)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	order, err := service.PurchaseOrderRepository.Save(ctx, domain.PurchaseOrder{
+		SupplierID: request.SupplierID,
+		Status:     domain.PurchaseOrderStatusDraft,
+		Note:       request.Note,
+		CreatedBy:  helper.ActorFromContext(ctx),
+		CreatedAt:  time.Now(),
+		Lines:      lines,
+	})
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return helper.ToPurchaseOrderResponse(order), nil
+}
+
+// Update the supplier, note and lines of a draft purchase order
+func (service *PurchaseOrderServiceImpl) Update(ctx context.Context, request web.PurchaseOrderUpdateRequest) (web.PurchaseOrderResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	order, err := service.findDraft(ctx, request.Id, request.Version)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	if _, err := findSupplier(ctx, service.SupplierRepository, request.SupplierID); err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	lines, err := service.orderLines(ctx, request.Lines)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	order.SupplierID = request.SupplierID
+	order.Note = request.Note
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if order, err = service.updateOrder(ctx, order); err != nil {
+			return err
+		}
+		order.Lines, err = service.PurchaseOrderRepository.ReplaceLines(ctx, order.Id, lines)
+		return err
+	})
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return helper.ToPurchaseOrderResponse(order), nil
+}
+
+// Delete a draft purchase order
+func (service *PurchaseOrderServiceImpl) Delete(ctx context.Context, orderId uint64, version uint64) error {
+	order, err := service.findDraft(ctx, orderId, version)
+	if err != nil {
+		return err
+	}
+
+	err = service.PurchaseOrderRepository.Delete(ctx, order)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Purchase order has been modified")
+	}
+	return err
+}
+
+// Send a draft purchase order to the supplier, after which its lines are fixed
+func (service *PurchaseOrderServiceImpl) Send(ctx context.Context, orderId uint64, version uint64) (web.PurchaseOrderResponse, error) {
+	order, err := service.findDraft(ctx, orderId, version)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	now := time.Now()
+	order.Status = domain.PurchaseOrderStatusSent
+	order.SentAt = &now
+	if order, err = service.updateOrder(ctx, order); err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return helper.ToPurchaseOrderResponse(order), nil
+}
+
+// Receive books goods delivered for a sent purchase order into the store of the request,
+// at the cost they were ordered at unless the receipt says otherwise. The order is received
+// once every line is, and partially received until then.
+func (service *PurchaseOrderServiceImpl) Receive(ctx context.Context, request web.GoodsReceiptRequest) (web.PurchaseOrderResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	order, err := service.findOrder(ctx, request.PurchaseOrderID, request.Version)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	if order.Status != domain.PurchaseOrderStatusSent && order.Status != domain.PurchaseOrderStatusPartiallyReceived {
+		return web.PurchaseOrderResponse{}, exception.NewConflictError(fmt.Sprintf("Goods cannot be received for a purchase order that is %s", order.Status))
+	}
+
+	lineIndexes := make(map[uint64]int, len(order.Lines))
+	for i, line := range order.Lines {
+		lineIndexes[line.Id] = i
+	}
+	received := make([]int, 0, len(request.Lines))
+	for _, receipt := range request.Lines {
+		i, ok := lineIndexes[receipt.LineID]
+		if !ok {
+			return web.PurchaseOrderResponse{}, exception.NewBadRequestError(fmt.Sprintf("line %d is not on purchase order %d", receipt.LineID, order.Id))
+		}
+		line := &order.Lines[i]
+		if line.ReceivedQuantity+receipt.Quantity > line.Quantity {
+			return web.PurchaseOrderResponse{}, exception.NewBadRequestError(fmt.Sprintf("line %d expects %d more, not %d", line.Id, line.Quantity-line.ReceivedQuantity, receipt.Quantity))
+		}
+		line.ReceivedQuantity += receipt.Quantity
+		if receipt.UnitCost != nil {
+			line.UnitCost = *receipt.UnitCost
+		}
+		received = append(received, i)
+	}
+
+	order.Status = domain.PurchaseOrderStatusReceived
+	for _, line := range order.Lines {
+		if line.ReceivedQuantity < line.Quantity {
+			order.Status = domain.PurchaseOrderStatusPartiallyReceived
+		}
+	}
+
+	reference := fmt.Sprintf("purchase order %d", order.Id)
+	if request.Reference != "" {
+		reference += " " + request.Reference
+	}
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		for n, i := range received {
+			line := order.Lines[i]
+			product, err := findProduct(ctx, service.ProductRepository, line.ProductID)
+			if err != nil {
+				return err
+			}
+			_, err = restockItem(ctx, service.StoreStockRepository, service.ProductRepository, service.ProductVariantRepository, service.InventoryRepository, service.OutboxRepository, product, domain.InventoryMovement{
+				ProductID: line.ProductID,
+				VariantID: line.VariantID,
+				Type:      domain.MovementTypeRestock,
+				Quantity:  request.Lines[n].Quantity,
+				UnitCost:  line.UnitCost,
+				Reference: reference,
+			})
+			if err != nil {
+				return err
+			}
+			if err := service.PurchaseOrderRepository.UpdateLine(ctx, line); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		order, err = service.updateOrder(ctx, order)
+		return err
+	})
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return helper.ToPurchaseOrderResponse(order), nil
+}
+
+// Close a purchase order nothing more is expected for, also when it is short delivered
+func (service *PurchaseOrderServiceImpl) Close(ctx context.Context, orderId uint64, version uint64) (web.PurchaseOrderResponse, error) {
+	order, err := service.findOrder(ctx, orderId, version)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	if order.Status == domain.PurchaseOrderStatusDraft || order.Status == domain.PurchaseOrderStatusClosed {
+		return web.PurchaseOrderResponse{}, exception.NewConflictError(fmt.Sprintf("A purchase order that is %s cannot be closed", order.Status))
+	}
+
+	now := time.Now()
+	order.Status = domain.PurchaseOrderStatusClosed
+	order.ClosedAt = &now
+	if order, err = service.updateOrder(ctx, order); err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return helper.ToPurchaseOrderResponse(order), nil
+}
+
+// FindById returns a purchase order of the store of the request
+func (service *PurchaseOrderServiceImpl) FindById(ctx context.Context, orderId uint64) (web.PurchaseOrderResponse, error) {
+	order, err := service.findOrder(ctx, orderId, 0)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return helper.ToPurchaseOrderResponse(order), nil
+}
+
+// FindAll returns the purchase orders of the store of the request
+func (service *PurchaseOrderServiceImpl) FindAll(ctx context.Context, filter web.PurchaseOrderFilterRequest) ([]web.PurchaseOrderResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return nil, err
+	}
+	if err := service.Validate.Struct(filter); err != nil {
+		return nil, err
+	}
+
+	orders, err := service.PurchaseOrderRepository.FindAll(ctx, filter.Status, filter.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToPurchaseOrderResponses(orders), nil
+}
+
+// Generate raises draft purchase orders, one per preferred supplier, for the products of
+// the store of the request that are at or below their restock level, counting what is
+// already on order. Products without a preferred supplier are listed instead.
+func (service *PurchaseOrderServiceImpl) Generate(ctx context.Context) (web.PurchaseOrderGenerateResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return web.PurchaseOrderGenerateResponse{}, err
+	}
+
+	response := web.PurchaseOrderGenerateResponse{
+		PurchaseOrders:  []web.PurchaseOrderResponse{},
+		WithoutSupplier: []string{},
+	}
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		inventories, err := service.InventoryRepository.FindInventories(ctx, true)
+		if err != nil {
+			return err
+		}
+		outstanding, err := service.PurchaseOrderRepository.FindOutstanding(ctx)
+		if err != nil {
+			return err
+		}
+		onOrder := make(map[string]int, len(outstanding))
+		for _, item := range outstanding {
+			onOrder[stockItemKey(item.ProductID, item.VariantID)] += item.Quantity
+		}
+
+		var orders []domain.PurchaseOrder
+		supplierOrders := map[uint64]int{}
+		for _, inventory := range inventories {
+			available := inventory.StockQty + onOrder[stockItemKey(inventory.ProductID, inventory.VariantID)]
+			if available > inventory.RestockLevel {
+				continue
+			}
+			quantity := inventory.ReorderQuantity
+			if quantity == 0 {
+				quantity = 2*inventory.RestockLevel - available
+			}
+			if quantity <= 0 {
+				continue
+			}
+
+			product, err := findProduct(ctx, service.ProductRepository, inventory.ProductID)
+			if err != nil {
+				return err
+			}
+			if product.SupplierID == nil {
+				response.WithoutSupplier = append(response.WithoutSupplier, product.ProductID)
+				continue
+			}
+			i, ok := supplierOrders[*product.SupplierID]
+			if !ok {
+				i = len(orders)
+				supplierOrders[*product.SupplierID] = i
+				orders = append(orders, domain.PurchaseOrder{
+					SupplierID: *product.SupplierID,
+					Status:     domain.PurchaseOrderStatusDraft,
+					Note:       "Generated for low stock",
+					CreatedBy:  helper.ActorFromContext(ctx),
+					CreatedAt:  time.Now(),
+				})
+			}
+			orders[i].Lines = append(orders[i].Lines, domain.PurchaseOrderLine{
+				ProductID: inventory.ProductID,
+				VariantID: inventory.VariantID,
+				Quantity:  quantity,
+				UnitCost:  product.CostPrice,
+			})
+		}
+
+		for _, order := range orders {
+			order, err := service.PurchaseOrderRepository.Save(ctx, order)
+			if err != nil {
+				return err
+			}
+			response.PurchaseOrders = append(response.PurchaseOrders, helper.ToPurchaseOrderResponse(order))
+		}
+		return nil
+	})
+	if err != nil {
+		return web.PurchaseOrderGenerateResponse{}, err
+	}
+
+	return response, nil
+}
+
+// orderLines checks the products and variants of the requested lines.
+func (service *PurchaseOrderServiceImpl) orderLines(ctx context.Context, requests []web.PurchaseOrderLineRequest) ([]domain.PurchaseOrderLine, error) {
+	lines := make([]domain.PurchaseOrderLine, 0, len(requests))
+	for _, request := range requests {
+		_, variantId, err := findStockItem(ctx, service.ProductRepository, service.ProductVariantRepository, request.ProductID, request.VariantID)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, domain.PurchaseOrderLine{
+			ProductID: request.ProductID,
+			VariantID: variantId,
+			Quantity:  request.Quantity,
+			UnitCost:  request.UnitCost,
+		})
+	}
+	return lines, nil
+}
+
+// findOrder loads a purchase order of the store of the request, checking its version
+// when one is given.
+func (service *PurchaseOrderServiceImpl) findOrder(ctx context.Context, orderId uint64, version uint64) (domain.PurchaseOrder, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return domain.PurchaseOrder{}, err
+	}
+	order, err := service.PurchaseOrderRepository.FindById(ctx, orderId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.PurchaseOrder{}, exception.NewNotFoundError("Purchase order not found")
+	} else if err != nil {
+		return domain.PurchaseOrder{}, err
+	}
+	if version != 0 && version != order.Version {
+		return domain.PurchaseOrder{}, exception.NewConflictError("Purchase order has been modified")
+	}
+	return order, nil
+}
+
+// findDraft loads a purchase order that can still be changed.
+func (service *PurchaseOrderServiceImpl) findDraft(ctx context.Context, orderId uint64, version uint64) (domain.PurchaseOrder, error) {
+	order, err := service.findOrder(ctx, orderId, version)
+	if err != nil {
+		return domain.PurchaseOrder{}, err
+	}
+	if order.Status != domain.PurchaseOrderStatusDraft {
+		return domain.PurchaseOrder{}, exception.NewConflictError(fmt.Sprintf("Purchase order is already %s", order.Status))
+	}
+	return order, nil
+}
+
+func (service *PurchaseOrderServiceImpl) updateOrder(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+	updatedOrder, err := service.PurchaseOrderRepository.Update(ctx, order)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return domain.PurchaseOrder{}, exception.NewConflictError("Purchase order has been modified")
+	} else if err != nil {
+		return domain.PurchaseOrder{}, err
+	}
+	updatedOrder.Lines = order.Lines
+	return updatedOrder, nil
+}
+
+func stockItemKey(productId string, variantId uint64) string {
+	return fmt.Sprintf("%s/%d", productId, variantId)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type purchaseOrderRepos struct {
+	order     *mocks.MockPurchaseOrderRepository
+	supplier  *mocks.MockSupplierRepository
+	inventory *mocks.MockInventoryRepository
+	stock     *mocks.MockStoreStockRepository
+	product   *mocks.MockProductRepository
+	variant   *mocks.MockProductVariantRepository
+}
+
+func newTestPurchaseOrderService(ctrl *gomock.Controller) (PurchaseOrderService, purchaseOrderRepos) {
+	r := purchaseOrderRepos{
+		order:     mocks.NewMockPurchaseOrderRepository(ctrl),
+		supplier:  mocks.NewMockSupplierRepository(ctrl),
+		inventory: mocks.NewMockInventoryRepository(ctrl),
+		stock:     mocks.NewMockStoreStockRepository(ctrl),
+		product:   mocks.NewMockProductRepository(ctrl),
+		variant:   mocks.NewMockProductVariantRepository(ctrl),
+	}
+	tx := mocks.NewMockTransactionManager(ctrl)
+	tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	return NewPurchaseOrderService(r.order, r.supplier, r.inventory, r.stock, r.product, r.variant, newMockOutboxRepository(ctrl), tx, validator.New()), r
+}
+
+func TestReceivePurchaseOrder(t *testing.T) {
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+	invoiceCost := 5.0
+	sentOrder := func() domain.PurchaseOrder {
+		return domain.PurchaseOrder{Id: 7, StoreID: 1, SupplierID: 3, Status: domain.PurchaseOrderStatusSent, Version: 2, Lines: []domain.PurchaseOrderLine{
+			{Id: 1, PurchaseOrderID: 7, ProductID: "p-1", Quantity: 10, UnitCost: 4},
+			{Id: 2, PurchaseOrderID: 7, ProductID: "p-2", Quantity: 5, UnitCost: 2},
+		}}
+	}
+	restocks := func(r purchaseOrderRepos, productId string, quantity int, unitCost float64) {
+		r.product.EXPECT().FindById(gomock.Any(), productId).Return(domain.Product{ProductID: productId, CostMethod: domain.CostMethodFIFO}, nil)
+		r.stock.EXPECT().Adjust(gomock.Any(), productId, uint64(0), quantity).Return(nil)
+		r.product.EXPECT().AdjustStock(gomock.Any(), productId, quantity).Return(nil)
+		r.inventory.EXPECT().FindCostLayers(gomock.Any(), productId, uint64(0)).Return(nil, nil)
+		r.inventory.EXPECT().SaveCostLayer(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, layer domain.CostLayer) (domain.CostLayer, error) {
+				assert.Equal(t, quantity, layer.Quantity)
+				assert.Equal(t, unitCost, layer.UnitCost)
+				return layer, nil
+			})
+		r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+				assert.Equal(t, domain.MovementTypeRestock, movement.Type)
+				assert.Equal(t, "purchase order 7 DN-1", movement.Reference)
+				return movement, nil
+			})
+		r.inventory.EXPECT().MarkRestocked(gomock.Any(), productId, uint64(0), gomock.Any()).Return(nil)
+	}
+
+	tests := []struct {
+		name         string
+		request      web.GoodsReceiptRequest
+		mock         func(r purchaseOrderRepos)
+		expectStatus string
+		expectErr    error
+	}{
+		{
+			name:    "part of the order is partially received",
+			request: web.GoodsReceiptRequest{PurchaseOrderID: 7, Reference: "DN-1", Lines: []web.GoodsReceiptLineRequest{{LineID: 1, Quantity: 6}}},
+			mock: func(r purchaseOrderRepos) {
+				r.order.EXPECT().FindById(gomock.Any(), uint64(7)).Return(sentOrder(), nil)
+				restocks(r, "p-1", 6, 4)
+				r.order.EXPECT().UpdateLine(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, line domain.PurchaseOrderLine) error {
+						assert.Equal(t, 6, line.ReceivedQuantity)
+						return nil
+					})
+				r.order.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+						order.Version++
+						return order, nil
+					})
+			},
+			expectStatus: domain.PurchaseOrderStatusPartiallyReceived,
+		},
+		{
+			name: "the whole order is received at the invoiced cost",
+			request: web.GoodsReceiptRequest{PurchaseOrderID: 7, Reference: "DN-1", Lines: []web.GoodsReceiptLineRequest{
+				{LineID: 1, Quantity: 10, UnitCost: &invoiceCost},
+				{LineID: 2, Quantity: 5},
+			}},
+			mock: func(r purchaseOrderRepos) {
+				r.order.EXPECT().FindById(gomock.Any(), uint64(7)).Return(sentOrder(), nil)
+				restocks(r, "p-1", 10, 5)
+				restocks(r, "p-2", 5, 2)
+				r.order.EXPECT().UpdateLine(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				r.order.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+						return order, nil
+					})
+			},
+			expectStatus: domain.PurchaseOrderStatusReceived,
+		},
+		{
+			name:    "more than is outstanding is rejected",
+			request: web.GoodsReceiptRequest{PurchaseOrderID: 7, Lines: []web.GoodsReceiptLineRequest{{LineID: 2, Quantity: 6}}},
+			mock: func(r purchaseOrderRepos) {
+				r.order.EXPECT().FindById(gomock.Any(), uint64(7)).Return(sentOrder(), nil)
+			},
+			expectErr: exception.BadRequestError{},
+		},
+		{
+			name:    "a line of another order is rejected",
+			request: web.GoodsReceiptRequest{PurchaseOrderID: 7, Lines: []web.GoodsReceiptLineRequest{{LineID: 9, Quantity: 1}}},
+			mock: func(r purchaseOrderRepos) {
+				r.order.EXPECT().FindById(gomock.Any(), uint64(7)).Return(sentOrder(), nil)
+			},
+			expectErr: exception.BadRequestError{},
+		},
+		{
+			name:    "a draft cannot be received",
+			request: web.GoodsReceiptRequest{PurchaseOrderID: 7, Lines: []web.GoodsReceiptLineRequest{{LineID: 1, Quantity: 1}}},
+			mock: func(r purchaseOrderRepos) {
+				order := sentOrder()
+				order.Status = domain.PurchaseOrderStatusDraft
+				r.order.EXPECT().FindById(gomock.Any(), uint64(7)).Return(order, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:    "a stale version is rejected",
+			request: web.GoodsReceiptRequest{PurchaseOrderID: 7, Version: 1, Lines: []web.GoodsReceiptLineRequest{{LineID: 1, Quantity: 1}}},
+			mock: func(r purchaseOrderRepos) {
+				r.order.EXPECT().FindById(gomock.Any(), uint64(7)).Return(sentOrder(), nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			purchaseOrderService, r := newTestPurchaseOrderService(ctrl)
+			tt.mock(r)
+
+			response, err := purchaseOrderService.Receive(storeCtx, tt.request)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectStatus, response.Status)
+		})
+	}
+}
+
+func TestGeneratePurchaseOrders(t *testing.T) {
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+	supplierId := uint64(3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	purchaseOrderService, r := newTestPurchaseOrderService(ctrl)
+
+	r.inventory.EXPECT().FindInventories(gomock.Any(), true).Return([]domain.Inventory{
+		{ProductID: "p-1", StockQty: 2, RestockLevel: 5},
+		{ProductID: "p-2", StockQty: 0, RestockLevel: 4, ReorderQuantity: 12},
+		{ProductID: "p-3", StockQty: 1, RestockLevel: 5},
+		{ProductID: "p-4", StockQty: 0, RestockLevel: 3},
+	}, nil)
+	r.order.EXPECT().FindOutstanding(gomock.Any()).Return([]domain.OutstandingQuantity{{ProductID: "p-3", Quantity: 10}}, nil)
+	r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1", SupplierID: &supplierId, CostPrice: 4}, nil)
+	r.product.EXPECT().FindById(gomock.Any(), "p-2").Return(domain.Product{ProductID: "p-2", SupplierID: &supplierId, CostPrice: 2}, nil)
+	r.product.EXPECT().FindById(gomock.Any(), "p-4").Return(domain.Product{ProductID: "p-4"}, nil)
+	r.order.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, order domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+			assert.Equal(t, supplierId, order.SupplierID)
+			assert.Equal(t, domain.PurchaseOrderStatusDraft, order.Status)
+			assert.Equal(t, []domain.PurchaseOrderLine{
+				{ProductID: "p-1", Quantity: 8, UnitCost: 4},
+				{ProductID: "p-2", Quantity: 12, UnitCost: 2},
+			}, order.Lines)
+			order.Id = 9
+			return order, nil
+		})
+
+	response, err := purchaseOrderService.Generate(storeCtx)
+	assert.NoError(t, err)
+	assert.Len(t, response.PurchaseOrders, 1)
+	assert.Equal(t, uint64(9), response.PurchaseOrders[0].Id)
+	assert.Equal(t, 56.0, response.PurchaseOrders[0].Total)
+	assert.Equal(t, []string{"p-4"}, response.WithoutSupplier)
+}
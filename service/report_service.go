@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type ReportService interface {
+	Sales(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error)
+	TopProducts(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error)
+	Categories(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error)
+	PaymentTypes(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error)
+	Taxes(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error)
+	Discounts(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error)
+	Margins(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error)
+	InventoryValuation(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error)
+}
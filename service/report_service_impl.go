@@ -0,0 +1,445 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
+const (
+	// MaxReportDays is the longest date range a report can cover.
+	MaxReportDays = 366
+	// DefaultReportDays is the date range of a report without from, ending today.
+	DefaultReportDays = 7
+	// DefaultReportLimit is the number of rows of a top-N report without a limit.
+	DefaultReportLimit = 10
+
+	reportDateLayout = "2006-01-02"
+)
+
+type ReportServiceImpl struct {
+	ReportRepository repository.ReportRepository
+	Validate         *validator.Validate
+}
+
+func NewReportService(reportRepository repository.ReportRepository, validate *validator.Validate) ReportService {
+	return &ReportServiceImpl{
+		ReportRepository: reportRepository,
+		Validate:         validate,
+	}
+}
+
+// reportPeriod is the resolved date range of a report request.
+type reportPeriod struct {
+	from     time.Time
+	to       time.Time
+	location *time.Location
+}
+
+// Sales sums up the orders by local day, hour of the day, store or employee
+func (service *ReportServiceImpl) Sales(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	if request.GroupBy == "" {
+		request.GroupBy = "day"
+	}
+	if request.GroupBy == "product" || request.GroupBy == "category" {
+		return web.ReportResponse{}, exception.NewBadRequestError("sales are grouped by day, hour, store or employee")
+	}
+	ctx, period, response, err := service.begin(ctx, "sales", request)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	response.GroupBy = request.GroupBy
+
+	var rows []web.SalesReportRow
+	switch request.GroupBy {
+	case "day", "hour":
+		rows, err = service.salesByTime(ctx, period, request.GroupBy)
+	case "store":
+		rows, err = service.salesTotals(service.ReportRepository.SalesByStore(ctx, period.from, period.to))
+	case "employee":
+		rows, err = service.salesTotals(service.ReportRepository.SalesByEmployee(ctx, period.from, period.to))
+	}
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+
+	for _, row := range rows {
+		response.Rows = append(response.Rows, row)
+	}
+	return response, nil
+}
+
+// TopProducts returns the best selling products by revenue or quantity
+func (service *ReportServiceImpl) TopProducts(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	if request.By == "" {
+		request.By = "revenue"
+	}
+	if request.Limit == 0 {
+		request.Limit = DefaultReportLimit
+	}
+	ctx, period, response, err := service.begin(ctx, "top-products", request)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+
+	sales, err := service.ReportRepository.ProductSales(ctx, period.from, period.to, request.By, request.Limit)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	for _, product := range sales {
+		response.Rows = append(response.Rows, web.ProductReportRow{
+			ProductID:   product.ProductID,
+			ProductName: product.ProductName,
+			Quantity:    product.Quantity,
+			Revenue:     helper.RoundMoney(product.Revenue),
+		})
+	}
+	return response, nil
+}
+
+// Categories sums up the sales of the products of every category
+func (service *ReportServiceImpl) Categories(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	ctx, period, response, err := service.begin(ctx, "categories", request)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+
+	sales, err := service.ReportRepository.CategorySales(ctx, period.from, period.to)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	for _, category := range sales {
+		response.Rows = append(response.Rows, web.CategoryReportRow{
+			CategoryID:   category.CategoryID,
+			CategoryName: category.CategoryName,
+			OrderCount:   category.OrderCount,
+			Quantity:     category.Quantity,
+			Revenue:      helper.RoundMoney(category.Revenue),
+		})
+	}
+	return response, nil
+}
+
+// PaymentTypes sums up the completed payments by payment type
+func (service *ReportServiceImpl) PaymentTypes(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	ctx, period, response, err := service.begin(ctx, "payment-types", request)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+
+	totals, err := service.ReportRepository.PaymentTypeTotals(ctx, period.from, period.to)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	var amount float64
+	for _, total := range totals {
+		amount += total.Amount
+	}
+	for _, total := range totals {
+		row := web.PaymentTypeReportRow{
+			PaymentType:  total.PaymentType,
+			PaymentCount: total.PaymentCount,
+			Amount:       helper.RoundMoney(total.Amount),
+		}
+		if amount > 0 {
+			row.Share = helper.RoundMoney(total.Amount / amount * 100)
+		}
+		response.Rows = append(response.Rows, row)
+	}
+	return response, nil
+}
+
+// Taxes sums up the tax collected by tax type and rate
+func (service *ReportServiceImpl) Taxes(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	ctx, period, response, err := service.begin(ctx, "taxes", request)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+
+	totals, err := service.ReportRepository.TaxTotals(ctx, period.from, period.to)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	for _, total := range totals {
+		taxType := total.TaxType
+		if taxType == "" {
+			taxType = domain.DefaultTaxType
+		}
+		response.Rows = append(response.Rows, web.TaxReportRow{
+			TaxType:    taxType,
+			TaxRate:    total.TaxRate,
+			GrossSales: helper.RoundMoney(total.Revenue),
+			NetSales:   helper.RoundMoney(total.Revenue - total.TaxAmount),
+			TaxAmount:  helper.RoundMoney(total.TaxAmount),
+		})
+	}
+	return response, nil
+}
+
+// Discounts returns what selling products below their regular price cost, by product
+func (service *ReportServiceImpl) Discounts(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	ctx, period, response, err := service.begin(ctx, "discounts", request)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+
+	sales, err := service.ReportRepository.DiscountTotals(ctx, period.from, period.to)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	for _, product := range sales {
+		response.Rows = append(response.Rows, web.DiscountReportRow{
+			ProductID:      product.ProductID,
+			ProductName:    product.ProductName,
+			Quantity:       product.Quantity,
+			RegularRevenue: helper.RoundMoney(product.RegularRevenue),
+			Revenue:        helper.RoundMoney(product.Revenue),
+			DiscountAmount: helper.RoundMoney(product.DiscountAmount),
+		})
+	}
+	return response, nil
+}
+
+// Margins sums up the sales, net of tax, and their cost of goods by product or category
+func (service *ReportServiceImpl) Margins(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	if request.GroupBy == "" {
+		request.GroupBy = "product"
+	}
+	if request.GroupBy != "product" && request.GroupBy != "category" {
+		return web.ReportResponse{}, exception.NewBadRequestError("margins are grouped by product or category")
+	}
+	ctx, period, response, err := service.begin(ctx, "margins", request)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	response.GroupBy = request.GroupBy
+
+	var margins []domain.MarginTotal
+	if request.GroupBy == "category" {
+		margins, err = service.ReportRepository.CategoryMargins(ctx, period.from, period.to)
+	} else {
+		margins, err = service.ReportRepository.ProductMargins(ctx, period.from, period.to)
+	}
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	for _, margin := range margins {
+		row := web.MarginReportRow{
+			Key:         margin.Key,
+			Label:       margin.Label,
+			Quantity:    margin.Quantity,
+			NetSales:    helper.RoundMoney(margin.Revenue),
+			Cost:        helper.RoundMoney(margin.Cost),
+			GrossMargin: helper.RoundMoney(margin.Revenue - margin.Cost),
+		}
+		if margin.Revenue != 0 {
+			row.MarginPercent = helper.RoundMoney((margin.Revenue - margin.Cost) / margin.Revenue * 100)
+		}
+		response.Rows = append(response.Rows, row)
+	}
+	return response, nil
+}
+
+// InventoryValuation values the stock on hand at a moment, the end of the day for a date,
+// at cost from the inventory ledger
+func (service *ReportServiceImpl) InventoryValuation(ctx context.Context, request web.ReportRequest) (web.ReportResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.ReportResponse{}, err
+	}
+	location, err := reportLocation(request.TimeZone)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	at := time.Now().In(location)
+	if request.At != "" {
+		if at, err = parseReportTime("at", request.At, location, true); err != nil {
+			return web.ReportResponse{}, err
+		}
+	}
+
+	values, err := service.ReportRepository.InventoryValuation(reportScope(ctx), at)
+	if err != nil {
+		return web.ReportResponse{}, err
+	}
+	response := web.ReportResponse{
+		Report:   "inventory-valuation",
+		At:       &at,
+		TimeZone: location.String(),
+		Rows:     []interface{}{},
+	}
+	for _, value := range values {
+		row := web.InventoryValuationRow{
+			ProductID:   value.ProductID,
+			ProductName: value.ProductName,
+			Quantity:    value.Quantity,
+			Value:       helper.RoundMoney(value.Value),
+		}
+		if value.VariantID != 0 {
+			variantId := value.VariantID
+			row.VariantID = &variantId
+		}
+		if value.Quantity != 0 {
+			row.UnitCost = helper.RoundMoney(value.Value / float64(value.Quantity))
+		}
+		response.Rows = append(response.Rows, row)
+	}
+	return response, nil
+}
+
+// begin validates a report request and resolves its period. Requests made for a store
+// report on that store only; requests without a store report on all stores.
+func (service *ReportServiceImpl) begin(ctx context.Context, report string, request web.ReportRequest) (context.Context, reportPeriod, web.ReportResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return ctx, reportPeriod{}, web.ReportResponse{}, err
+	}
+	period, err := resolveReportPeriod(request, time.Now())
+	if err != nil {
+		return ctx, reportPeriod{}, web.ReportResponse{}, err
+	}
+
+	return reportScope(ctx), period, web.ReportResponse{
+		Report:   report,
+		From:     &period.from,
+		To:       &period.to,
+		TimeZone: period.location.String(),
+		Rows:     []interface{}{},
+	}, nil
+}
+
+// reportScope lets requests made without a store report on all stores.
+func reportScope(ctx context.Context) context.Context {
+	if _, ok := helper.StoreIdFromContext(ctx); !ok {
+		return repository.WithoutStoreScope(ctx)
+	}
+	return ctx
+}
+
+// salesByTime buckets the orders by local day or hour of the day. Every day of the period
+// and every hour gets a row, also those without orders.
+func (service *ReportServiceImpl) salesByTime(ctx context.Context, period reportPeriod, groupBy string) ([]web.SalesReportRow, error) {
+	var keys []string
+	bucket := func(t time.Time) string { return t.In(period.location).Format(reportDateLayout) }
+	if groupBy == "hour" {
+		bucket = func(t time.Time) string { return t.In(period.location).Format("15") + ":00" }
+		for hour := 0; hour < 24; hour++ {
+			keys = append(keys, fmt.Sprintf("%02d:00", hour))
+		}
+	} else {
+		last := bucket(period.to.Add(-time.Nanosecond))
+		for day := period.from.In(period.location); ; day = day.AddDate(0, 0, 1) {
+			keys = append(keys, bucket(day))
+			if bucket(day) == last {
+				break
+			}
+		}
+	}
+
+	rows := make(map[string]*web.SalesReportRow, len(keys))
+	for _, key := range keys {
+		rows[key] = &web.SalesReportRow{Key: key, Label: key}
+	}
+	err := service.ReportRepository.WalkOrderTotals(ctx, period.from, period.to, ExportBatchSize, func(totals []domain.OrderTotal) error {
+		for _, total := range totals {
+			if row, ok := rows[bucket(total.OrderDate)]; ok {
+				row.OrderCount++
+				row.Revenue += total.TotalAmount
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]web.SalesReportRow, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, salesRow(*rows[key]))
+	}
+	return result, nil
+}
+
+func (service *ReportServiceImpl) salesTotals(totals []domain.SalesTotal, err error) ([]web.SalesReportRow, error) {
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]web.SalesReportRow, 0, len(totals))
+	for _, total := range totals {
+		rows = append(rows, salesRow(web.SalesReportRow{Key: total.Key, Label: total.Label, OrderCount: total.OrderCount, Revenue: total.Revenue}))
+	}
+	return rows, nil
+}
+
+func salesRow(row web.SalesReportRow) web.SalesReportRow {
+	row.Revenue = helper.RoundMoney(row.Revenue)
+	if row.OrderCount > 0 {
+		row.AverageOrder = helper.RoundMoney(row.Revenue / float64(row.OrderCount))
+	}
+	return row
+}
+
+// resolveReportPeriod turns the from, to and tz of a request into the range [from, to).
+// Without to the period ends with today, without from it starts DefaultReportDays earlier.
+func resolveReportPeriod(request web.ReportRequest, now time.Time) (reportPeriod, error) {
+	location, err := reportLocation(request.TimeZone)
+	if err != nil {
+		return reportPeriod{}, err
+	}
+	period := reportPeriod{location: location}
+	if request.To != "" {
+		if period.to, err = parseReportTime("to", request.To, location, true); err != nil {
+			return reportPeriod{}, err
+		}
+	} else {
+		today := now.In(location)
+		period.to = time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, location)
+	}
+	if request.From != "" {
+		if period.from, err = parseReportTime("from", request.From, location, false); err != nil {
+			return reportPeriod{}, err
+		}
+	} else {
+		period.from = period.to.In(location).AddDate(0, 0, -DefaultReportDays)
+	}
+
+	if !period.from.Before(period.to) {
+		return reportPeriod{}, exception.NewBadRequestError("from must be before to")
+	}
+	if period.to.Sub(period.from) > MaxReportDays*24*time.Hour+time.Hour {
+		return reportPeriod{}, exception.NewBadRequestError(fmt.Sprintf("a report covers at most %d days", MaxReportDays))
+	}
+	period.from, period.to = period.from.In(location), period.to.In(location)
+	return period, nil
+}
+
+// reportLocation loads the IANA time zone of a report, UTC when none is given.
+func reportLocation(timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, exception.NewBadRequestError(fmt.Sprintf("unknown time zone %q", timeZone))
+	}
+	return location, nil
+}
+
+// parseReportTime parses a date in location, as its start or with endOfDay as the start
+// of the next day, or an RFC3339 timestamp.
+func parseReportTime(field string, value string, location *time.Location, endOfDay bool) (time.Time, error) {
+	if day, err := time.ParseInLocation(reportDateLayout, value, location); err == nil {
+		if endOfDay {
+			day = day.AddDate(0, 0, 1)
+		}
+		return day, nil
+	}
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+	return time.Time{}, exception.NewBadRequestError(fmt.Sprintf("%s must be a date (YYYY-MM-DD) or an RFC3339 timestamp", field))
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSalesReportByDay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mocks.NewMockReportRepository(ctrl)
+	reportService := NewReportService(reportRepo, validator.New())
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, jakarta)
+	to := time.Date(2024, 3, 4, 0, 0, 0, 0, jakarta)
+
+	reportRepo.EXPECT().WalkOrderTotals(gomock.Any(), from, to, ExportBatchSize, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, from time.Time, to time.Time, batchSize int, fn func(totals []domain.OrderTotal) error) error {
+			return fn([]domain.OrderTotal{
+				// 1 March 23:30 in Jakarta
+				{OrderDate: time.Date(2024, 3, 1, 16, 30, 0, 0, time.UTC), TotalAmount: 100},
+				// 2 March 01:00 in Jakarta, still 1 March in UTC
+				{OrderDate: time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC), TotalAmount: 50},
+				{OrderDate: time.Date(2024, 3, 2, 3, 0, 0, 0, time.UTC), TotalAmount: 25.5},
+			})
+		})
+
+	response, err := reportService.Sales(context.Background(), web.ReportRequest{From: "2024-03-01", To: "2024-03-03", TimeZone: "Asia/Jakarta"})
+	assert.NoError(t, err)
+	assert.Equal(t, "day", response.GroupBy)
+	assert.Equal(t, "Asia/Jakarta", response.TimeZone)
+	assert.Equal(t, []interface{}{
+		web.SalesReportRow{Key: "2024-03-01", Label: "2024-03-01", OrderCount: 1, Revenue: 100, AverageOrder: 100},
+		web.SalesReportRow{Key: "2024-03-02", Label: "2024-03-02", OrderCount: 2, Revenue: 75.5, AverageOrder: 37.75},
+		web.SalesReportRow{Key: "2024-03-03", Label: "2024-03-03"},
+	}, response.Rows)
+}
+
+func TestSalesReportByHour(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mocks.NewMockReportRepository(ctrl)
+	reportService := NewReportService(reportRepo, validator.New())
+
+	reportRepo.EXPECT().WalkOrderTotals(gomock.Any(), gomock.Any(), gomock.Any(), ExportBatchSize, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, from time.Time, to time.Time, batchSize int, fn func(totals []domain.OrderTotal) error) error {
+			return fn([]domain.OrderTotal{
+				{OrderDate: time.Date(2024, 3, 1, 9, 15, 0, 0, time.UTC), TotalAmount: 10},
+				{OrderDate: time.Date(2024, 3, 2, 9, 45, 0, 0, time.UTC), TotalAmount: 20},
+			})
+		})
+
+	response, err := reportService.Sales(context.Background(), web.ReportRequest{From: "2024-03-01", To: "2024-03-02", GroupBy: "hour"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Rows, 24)
+	assert.Equal(t, web.SalesReportRow{Key: "09:00", Label: "09:00", OrderCount: 2, Revenue: 30, AverageOrder: 15}, response.Rows[9])
+	assert.Equal(t, web.SalesReportRow{Key: "10:00", Label: "10:00"}, response.Rows[10])
+}
+
+func TestReportPeriod(t *testing.T) {
+	now := time.Date(2024, 3, 10, 20, 0, 0, 0, time.UTC)
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+
+	tests := []struct {
+		name      string
+		request   web.ReportRequest
+		from      time.Time
+		to        time.Time
+		expectErr bool
+	}{
+		{
+			name:    "defaults to the last seven days including today",
+			request: web.ReportRequest{},
+			from:    time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "today follows the time zone",
+			request: web.ReportRequest{TimeZone: "Asia/Jakarta"},
+			from:    time.Date(2024, 3, 5, 0, 0, 0, 0, jakarta),
+			to:      time.Date(2024, 3, 12, 0, 0, 0, 0, jakarta),
+		},
+		{
+			name:    "accepts RFC3339 timestamps",
+			request: web.ReportRequest{From: "2024-03-01T08:00:00Z", To: "2024-03-01T17:00:00Z"},
+			from:    time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+			to:      time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{name: "rejects an unknown time zone", request: web.ReportRequest{TimeZone: "Mars/Olympus"}, expectErr: true},
+		{name: "rejects a malformed date", request: web.ReportRequest{From: "01/03/2024"}, expectErr: true},
+		{name: "rejects from after to", request: web.ReportRequest{From: "2024-03-05", To: "2024-03-01"}, expectErr: true},
+		{name: "rejects more than a year", request: web.ReportRequest{From: "2023-01-01", To: "2024-03-01"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period, err := resolveReportPeriod(tt.request, now)
+			if tt.expectErr {
+				assert.IsType(t, exception.BadRequestError{}, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.from.Equal(period.from), "from %s", period.from)
+			assert.True(t, tt.to.Equal(period.to), "to %s", period.to)
+		})
+	}
+}
+
+func TestTopProductsReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mocks.NewMockReportRepository(ctrl)
+	reportService := NewReportService(reportRepo, validator.New())
+
+	reportRepo.EXPECT().ProductSales(gomock.Any(), gomock.Any(), gomock.Any(), "revenue", DefaultReportLimit).
+		Return([]domain.ProductSales{{ProductID: "p-1", ProductName: "Coffee", Quantity: 3, Revenue: 29.997}}, nil)
+
+	response, err := reportService.TopProducts(context.Background(), web.ReportRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{web.ProductReportRow{ProductID: "p-1", ProductName: "Coffee", Quantity: 3, Revenue: 30}}, response.Rows)
+
+	_, err = reportService.TopProducts(context.Background(), web.ReportRequest{By: "margin"})
+	assert.Error(t, err)
+}
+
+func TestPaymentTypesReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mocks.NewMockReportRepository(ctrl)
+	reportService := NewReportService(reportRepo, validator.New())
+
+	reportRepo.EXPECT().PaymentTypeTotals(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]domain.PaymentTypeTotal{{PaymentType: "cash", PaymentCount: 3, Amount: 75}, {PaymentType: "card", PaymentCount: 1, Amount: 25}}, nil)
+
+	response, err := reportService.PaymentTypes(context.Background(), web.ReportRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		web.PaymentTypeReportRow{PaymentType: "cash", PaymentCount: 3, Amount: 75, Share: 75},
+		web.PaymentTypeReportRow{PaymentType: "card", PaymentCount: 1, Amount: 25, Share: 25},
+	}, response.Rows)
+}
+
+func TestMarginsReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mocks.NewMockReportRepository(ctrl)
+	reportService := NewReportService(reportRepo, validator.New())
+
+	reportRepo.EXPECT().CategoryMargins(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]domain.MarginTotal{{Key: "2", Label: "Drinks", Quantity: 10, Revenue: 200, Cost: 150}}, nil)
+
+	response, err := reportService.Margins(context.Background(), web.ReportRequest{GroupBy: "category"})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		web.MarginReportRow{Key: "2", Label: "Drinks", Quantity: 10, NetSales: 200, Cost: 150, GrossMargin: 50, MarginPercent: 25},
+	}, response.Rows)
+
+	_, err = reportService.Margins(context.Background(), web.ReportRequest{GroupBy: "day"})
+	assert.IsType(t, exception.BadRequestError{}, err)
+}
+
+func TestInventoryValuationReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mocks.NewMockReportRepository(ctrl)
+	reportService := NewReportService(reportRepo, validator.New())
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	endOfDay := time.Date(2024, 3, 2, 0, 0, 0, 0, jakarta)
+	reportRepo.EXPECT().InventoryValuation(gomock.Any(), endOfDay).
+		Return([]domain.InventoryValue{{ProductID: "p-1", ProductName: "Coffee", VariantID: 3, Quantity: 4, Value: 10}}, nil)
+
+	response, err := reportService.InventoryValuation(context.Background(), web.ReportRequest{At: "2024-03-01", TimeZone: "Asia/Jakarta"})
+	assert.NoError(t, err)
+	assert.True(t, endOfDay.Equal(*response.At))
+	variantId := uint64(3)
+	assert.Equal(t, []interface{}{
+		web.InventoryValuationRow{ProductID: "p-1", ProductName: "Coffee", VariantID: &variantId, Quantity: 4, UnitCost: 2.5, Value: 10},
+	}, response.Rows)
+}
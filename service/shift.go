@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// requestEmployee loads the employee the request is made on behalf of. An admin may act
+// for another store than their own, so the employee is looked up in every store.
+func requestEmployee(ctx context.Context, employeeRepository repository.EmployeeRepository) (domain.Employee, error) {
+	employeeId := helper.EmployeeIdFromContext(ctx)
+	if employeeId == "" {
+		return domain.Employee{}, exception.NewForbiddenError("The request must be made with an employee's API key")
+	}
+
+	employee, err := employeeRepository.FindById(repository.WithoutStoreScope(ctx), employeeId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Employee{}, exception.NewForbiddenError("Employee " + employeeId + " does not exist")
+	}
+	return employee, err
+}
+
+// requestCashier loads the employee the request is made on behalf of, who must be a cashier.
+func requestCashier(ctx context.Context, employeeRepository repository.EmployeeRepository) (domain.Employee, error) {
+	employee, err := requestEmployee(ctx, employeeRepository)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+	if !strings.EqualFold(employee.Role, domain.EmployeeRoleCashier) {
+		return domain.Employee{}, exception.NewForbiddenError("Only cashiers can do this")
+	}
+	return employee, nil
+}
+
+// activeCashierShift returns the open shift of the cashier making the request.
+func activeCashierShift(ctx context.Context, employeeRepository repository.EmployeeRepository, shiftRepository repository.ShiftRepository) (domain.Shift, error) {
+	cashier, err := requestCashier(ctx, employeeRepository)
+	if err != nil {
+		return domain.Shift{}, err
+	}
+
+	shift, err := shiftRepository.FindOpenByEmployeeId(ctx, cashier.EmployeeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Shift{}, exception.NewForbiddenError("Cashier " + cashier.EmployeeID + " has no open shift")
+	}
+	return shift, err
+}
+
+// requestManager loads the employee the request is made on behalf of, who must be a manager.
+func requestManager(ctx context.Context, employeeRepository repository.EmployeeRepository) (domain.Employee, error) {
+	employee, err := requestEmployee(ctx, employeeRepository)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+	if !strings.EqualFold(employee.Role, domain.EmployeeRoleManager) {
+		return domain.Employee{}, exception.NewForbiddenError("Only managers can do this")
+	}
+	return employee, nil
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type ShiftService interface {
+	Open(ctx context.Context, request web.ShiftOpenRequest) (web.ShiftResponse, error)
+	Close(ctx context.Context, request web.ShiftCloseRequest) (web.ShiftResponse, error)
+	RecordCashMovement(ctx context.Context, request web.CashMovementRequest) (web.ShiftResponse, error)
+	FindCurrent(ctx context.Context) (web.ShiftResponse, error)
+	FindById(ctx context.Context, shiftId uint64) (web.ShiftResponse, error)
+	FindAll(ctx context.Context, filter web.ShiftFilterRequest) ([]web.ShiftResponse, error)
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+type ShiftServiceImpl struct {
+	ShiftRepository    repository.ShiftRepository
+	EmployeeRepository repository.EmployeeRepository
+	TransactionManager repository.TransactionManager
+	Validate           *validator.Validate
+}
+
+func NewShiftService(shiftRepository repository.ShiftRepository, employeeRepository repository.EmployeeRepository, transactionManager repository.TransactionManager, validate *validator.Validate) ShiftService {
+	return &ShiftServiceImpl{
+		ShiftRepository:    shiftRepository,
+		EmployeeRepository: employeeRepository,
+		TransactionManager: transactionManager,
+		Validate:           validate,
+	}
+}
+
+// Open a shift for the cashier making the request
+func (service *ShiftServiceImpl) Open(ctx context.Context, request web.ShiftOpenRequest) (web.ShiftResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	cashier, err := requestCashier(ctx, service.EmployeeRepository)
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	_, err = service.ShiftRepository.FindOpenByEmployeeId(ctx, cashier.EmployeeID)
+	if err == nil {
+		return web.ShiftResponse{}, exception.NewConflictError("Cashier " + cashier.EmployeeID + " already has an open shift")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.ShiftResponse{}, err
+	}
+
+	shift, err := service.ShiftRepository.Save(ctx, domain.Shift{
+		EmployeeID:   cashier.EmployeeID,
+		Status:       domain.ShiftStatusOpen,
+		OpeningFloat: request.OpeningFloat,
+		OpenedAt:     time.Now(),
+		OpenKey:      &cashier.EmployeeID,
+	})
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	return helper.ToShiftResponse(shift, domain.ShiftCashTotals{}, nil), nil
+}
+
+// Close a shift with the cash counted in the till, recording how far it is off from the expected cash
+func (service *ShiftServiceImpl) Close(ctx context.Context, request web.ShiftCloseRequest) (web.ShiftResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	var response web.ShiftResponse
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		shift, err := service.findOpenShift(ctx, request.ShiftID)
+		if err != nil {
+			return err
+		}
+		if request.Version != 0 && request.Version != shift.Version {
+			return exception.NewConflictError("Shift has been modified")
+		}
+		if err := service.checkShiftOwner(ctx, shift); err != nil {
+			return err
+		}
+
+		totals, err := service.ShiftRepository.CashTotals(ctx, shift.Id)
+		if err != nil {
+			return err
+		}
+		expectedCash := helper.RoundMoney(shift.OpeningFloat + totals.CashSales + totals.CashIn - totals.CashOut)
+		variance := helper.RoundMoney(request.CountedCash - expectedCash)
+
+		now := time.Now()
+		shift.Status = domain.ShiftStatusClosed
+		shift.ClosedAt = &now
+		shift.ExpectedCash = &expectedCash
+		shift.CountedCash = &request.CountedCash
+		shift.Variance = &variance
+		shift.Note = request.Note
+		shift.OpenKey = nil
+
+		shift, err = service.ShiftRepository.Update(ctx, shift)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return exception.NewConflictError("Shift has been modified")
+		} else if err != nil {
+			return err
+		}
+
+		movements, err := service.ShiftRepository.FindCashMovements(ctx, shift.Id)
+		if err != nil {
+			return err
+		}
+		response = helper.ToShiftResponse(shift, totals, movements)
+		return nil
+	})
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	return response, nil
+}
+
+// RecordCashMovement records cash put into or taken out of the till of an open shift
+func (service *ShiftServiceImpl) RecordCashMovement(ctx context.Context, request web.CashMovementRequest) (web.ShiftResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	var response web.ShiftResponse
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		shift, err := service.findOpenShift(ctx, request.ShiftID)
+		if err != nil {
+			return err
+		}
+		if err := service.checkShiftOwner(ctx, shift); err != nil {
+			return err
+		}
+
+		totals, err := service.ShiftRepository.CashTotals(ctx, shift.Id)
+		if err != nil {
+			return err
+		}
+		inTill := helper.RoundMoney(shift.OpeningFloat + totals.CashSales + totals.CashIn - totals.CashOut)
+		if request.Type == domain.CashMovementOut && request.Amount > inTill {
+			return exception.NewBadRequestError("cash out is more than the cash in the till")
+		}
+
+		_, err = service.ShiftRepository.SaveCashMovement(ctx, domain.CashMovement{
+			ShiftID:   shift.Id,
+			Type:      request.Type,
+			Amount:    request.Amount,
+			Reason:    request.Reason,
+			Actor:     helper.ActorFromContext(ctx),
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if request.Type == domain.CashMovementIn {
+			totals.CashIn += request.Amount
+		} else {
+			totals.CashOut += request.Amount
+		}
+
+		movements, err := service.ShiftRepository.FindCashMovements(ctx, shift.Id)
+		if err != nil {
+			return err
+		}
+		response = helper.ToShiftResponse(shift, totals, movements)
+		return nil
+	})
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	return response, nil
+}
+
+// FindCurrent returns the open shift of the cashier making the request
+func (service *ShiftServiceImpl) FindCurrent(ctx context.Context) (web.ShiftResponse, error) {
+	employee, err := requestEmployee(ctx, service.EmployeeRepository)
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	shift, err := service.ShiftRepository.FindOpenByEmployeeId(ctx, employee.EmployeeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.ShiftResponse{}, exception.NewNotFoundError("No open shift")
+	} else if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	return service.toShiftResponse(ctx, shift)
+}
+
+// FindById Shift
+func (service *ShiftServiceImpl) FindById(ctx context.Context, shiftId uint64) (web.ShiftResponse, error) {
+	shift, err := service.ShiftRepository.FindById(ctx, shiftId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.ShiftResponse{}, exception.NewNotFoundError("Shift not found")
+	} else if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	return service.toShiftResponse(ctx, shift)
+}
+
+// FindAll Shifts
+func (service *ShiftServiceImpl) FindAll(ctx context.Context, filter web.ShiftFilterRequest) ([]web.ShiftResponse, error) {
+	shifts, err := service.ShiftRepository.FindAll(ctx, filter.EmployeeID, filter.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []web.ShiftResponse
+	for _, shift := range shifts {
+		totals, err := service.ShiftRepository.CashTotals(ctx, shift.Id)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, helper.ToShiftResponse(shift, totals, nil))
+	}
+	return responses, nil
+}
+
+func (service *ShiftServiceImpl) toShiftResponse(ctx context.Context, shift domain.Shift) (web.ShiftResponse, error) {
+	totals, err := service.ShiftRepository.CashTotals(ctx, shift.Id)
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+	movements, err := service.ShiftRepository.FindCashMovements(ctx, shift.Id)
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+	return helper.ToShiftResponse(shift, totals, movements), nil
+}
+
+func (service *ShiftServiceImpl) findOpenShift(ctx context.Context, shiftId uint64) (domain.Shift, error) {
+	shift, err := service.ShiftRepository.FindById(ctx, shiftId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Shift{}, exception.NewNotFoundError("Shift not found")
+	} else if err != nil {
+		return domain.Shift{}, err
+	}
+	if shift.Status != domain.ShiftStatusOpen {
+		return domain.Shift{}, exception.NewConflictError("Shift is already closed")
+	}
+	return shift, nil
+}
+
+// checkShiftOwner allows the cashier of the shift, or a manager, to work on the shift.
+func (service *ShiftServiceImpl) checkShiftOwner(ctx context.Context, shift domain.Shift) error {
+	employee, err := requestEmployee(ctx, service.EmployeeRepository)
+	if err != nil {
+		return err
+	}
+	if employee.EmployeeID != shift.EmployeeID && !strings.EqualFold(employee.Role, domain.EmployeeRoleManager) {
+		return exception.NewForbiddenError("Shift belongs to another cashier")
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestOpenShift(t *testing.T) {
+	cashierCtx := context.WithValue(context.Background(), helper.ContextKeyEmployee, "e-1")
+
+	tests := []struct {
+		name      string
+		mock      func(shiftRepo *mocks.MockShiftRepository, employeeRepo *mocks.MockEmployeeRepository)
+		expectErr error
+	}{
+		{
+			name: "success",
+			mock: func(shiftRepo *mocks.MockShiftRepository, employeeRepo *mocks.MockEmployeeRepository) {
+				employeeRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(domain.Employee{EmployeeID: "e-1", Role: "cashier"}, nil)
+				shiftRepo.EXPECT().FindOpenByEmployeeId(gomock.Any(), "e-1").Return(domain.Shift{}, gorm.ErrRecordNotFound)
+				shiftRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, shift domain.Shift) (domain.Shift, error) {
+						assert.Equal(t, domain.ShiftStatusOpen, shift.Status)
+						assert.Equal(t, "e-1", *shift.OpenKey)
+						shift.Id, shift.Version = 1, 1
+						return shift, nil
+					})
+			},
+		},
+		{
+			name: "already open",
+			mock: func(shiftRepo *mocks.MockShiftRepository, employeeRepo *mocks.MockEmployeeRepository) {
+				employeeRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(domain.Employee{EmployeeID: "e-1", Role: "Cashier"}, nil)
+				shiftRepo.EXPECT().FindOpenByEmployeeId(gomock.Any(), "e-1").Return(domain.Shift{Id: 1}, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name: "not a cashier",
+			mock: func(shiftRepo *mocks.MockShiftRepository, employeeRepo *mocks.MockEmployeeRepository) {
+				employeeRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(domain.Employee{EmployeeID: "e-1", Role: "Stocker"}, nil)
+			},
+			expectErr: exception.ForbiddenError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			shiftRepo := mocks.NewMockShiftRepository(ctrl)
+			employeeRepo := mocks.NewMockEmployeeRepository(ctrl)
+			tt.mock(shiftRepo, employeeRepo)
+
+			shiftService := NewShiftService(shiftRepo, employeeRepo, mocks.NewMockTransactionManager(ctrl), validator.New())
+			response, err := shiftService.Open(cashierCtx, web.ShiftOpenRequest{OpeningFloat: 200})
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 200.0, response.ExpectedCash)
+		})
+	}
+}
+
+func TestCloseShift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cashierCtx := context.WithValue(context.Background(), helper.ContextKeyEmployee, "e-1")
+	shiftRepo := mocks.NewMockShiftRepository(ctrl)
+	employeeRepo := mocks.NewMockEmployeeRepository(ctrl)
+	tx := mocks.NewMockTransactionManager(ctrl)
+	tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	shiftService := NewShiftService(shiftRepo, employeeRepo, tx, validator.New())
+
+	openKey := "e-1"
+	shift := domain.Shift{Id: 1, EmployeeID: "e-1", Status: domain.ShiftStatusOpen, OpeningFloat: 200, OpenKey: &openKey, Version: 3}
+	employeeRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(domain.Employee{EmployeeID: "e-1", Role: "Cashier"}, nil).AnyTimes()
+
+	// 200 float + 310.50 cash sales + 50 top-up - 100 cash drop = 460.50 expected
+	shiftRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(shift, nil)
+	shiftRepo.EXPECT().CashTotals(gomock.Any(), uint64(1)).Return(domain.ShiftCashTotals{CashSales: 310.5, CashIn: 50, CashOut: 100}, nil)
+	shiftRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, shift domain.Shift) (domain.Shift, error) {
+			assert.Equal(t, domain.ShiftStatusClosed, shift.Status)
+			assert.Nil(t, shift.OpenKey)
+			shift.Version++
+			return shift, nil
+		})
+	shiftRepo.EXPECT().FindCashMovements(gomock.Any(), uint64(1)).Return(nil, nil)
+
+	response, err := shiftService.Close(cashierCtx, web.ShiftCloseRequest{ShiftID: 1, CountedCash: 455, Version: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 460.5, response.ExpectedCash)
+	assert.Equal(t, 455.0, *response.CountedCash)
+	assert.Equal(t, -5.5, *response.Variance)
+
+	closed := shift
+	closed.Status = domain.ShiftStatusClosed
+	shiftRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(closed, nil)
+	_, err = shiftService.Close(cashierCtx, web.ShiftCloseRequest{ShiftID: 1, CountedCash: 455})
+	assert.IsType(t, exception.ConflictError{}, err)
+}
+
+func TestRecordCashMovement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cashierCtx := context.WithValue(context.Background(), helper.ContextKeyEmployee, "e-1")
+	shiftRepo := mocks.NewMockShiftRepository(ctrl)
+	employeeRepo := mocks.NewMockEmployeeRepository(ctrl)
+	tx := mocks.NewMockTransactionManager(ctrl)
+	tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	shiftService := NewShiftService(shiftRepo, employeeRepo, tx, validator.New())
+
+	shift := domain.Shift{Id: 1, EmployeeID: "e-2", Status: domain.ShiftStatusOpen, OpeningFloat: 100}
+	shiftRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(shift, nil).AnyTimes()
+	shiftRepo.EXPECT().CashTotals(gomock.Any(), uint64(1)).Return(domain.ShiftCashTotals{CashSales: 50}, nil).AnyTimes()
+
+	// another cashier may not touch the till
+	employeeRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(domain.Employee{EmployeeID: "e-1", Role: "Cashier"}, nil)
+	_, err := shiftService.RecordCashMovement(cashierCtx, web.CashMovementRequest{ShiftID: 1, Type: domain.CashMovementOut, Amount: 20, Reason: "drop"})
+	assert.IsType(t, exception.ForbiddenError{}, err)
+
+	// a manager may, but not take out more than the 150 in the till
+	employeeRepo.EXPECT().FindById(gomock.Any(), "e-1").Return(domain.Employee{EmployeeID: "e-1", Role: "Manager"}, nil).AnyTimes()
+	_, err = shiftService.RecordCashMovement(cashierCtx, web.CashMovementRequest{ShiftID: 1, Type: domain.CashMovementOut, Amount: 200, Reason: "drop"})
+	assert.IsType(t, exception.BadRequestError{}, err)
+
+	shiftRepo.EXPECT().SaveCashMovement(gomock.Any(), gomock.Any()).Return(domain.CashMovement{}, nil)
+	shiftRepo.EXPECT().FindCashMovements(gomock.Any(), uint64(1)).Return([]domain.CashMovement{{Type: domain.CashMovementOut, Amount: 120}}, nil)
+	response, err := shiftService.RecordCashMovement(cashierCtx, web.CashMovementRequest{ShiftID: 1, Type: domain.CashMovementOut, Amount: 120, Reason: "drop"})
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, response.ExpectedCash)
+	assert.Len(t, response.CashMovements, 1)
+}
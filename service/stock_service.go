@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type StockService interface {
+	FindLevels(ctx context.Context, productId string) ([]web.StoreStockResponse, error)
+	SetLevel(ctx context.Context, request web.StoreStockSetRequest) (web.StoreStockResponse, error)
+	Restock(ctx context.Context, request web.StockRestockRequest) (web.InventoryMovementResponse, error)
+	FindMovements(ctx context.Context, filter web.InventoryMovementFilterRequest) ([]web.InventoryMovementResponse, error)
+	SetRestockLevel(ctx context.Context, request web.RestockLevelRequest) (web.InventoryResponse, error)
+	FindInventories(ctx context.Context, lowOnly bool) ([]web.InventoryResponse, error)
+	CreateTransfer(ctx context.Context, request web.StockTransferCreateRequest) (web.StockTransferResponse, error)
+	ReceiveTransfer(ctx context.Context, transferId uint64, version uint64) (web.StockTransferResponse, error)
+	CancelTransfer(ctx context.Context, transferId uint64, version uint64) (web.StockTransferResponse, error)
+	FindTransferById(ctx context.Context, transferId uint64) (web.StockTransferResponse, error)
+	FindTransfers(ctx context.Context, filter web.StockTransferFilterRequest) ([]web.StockTransferResponse, error)
+}
@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"time"
+)
+
+// DefaultMovementLimit is the number of ledger movements returned without a limit.
+const DefaultMovementLimit = 100
+
+type StockServiceImpl struct {
+	StoreStockRepository     repository.StoreStockRepository
+	InventoryRepository      repository.InventoryRepository
+	StockTransferRepository  repository.StockTransferRepository
+	StoreRepository          repository.StoreRepository
+	ProductRepository        repository.ProductRepository
+	ProductVariantRepository repository.ProductVariantRepository
+	OutboxRepository         repository.OutboxRepository
+	TransactionManager       repository.TransactionManager
+	Validate                 *validator.Validate
+}
+
+func NewStockService(storeStockRepository repository.StoreStockRepository, inventoryRepository repository.InventoryRepository, stockTransferRepository repository.StockTransferRepository, storeRepository repository.StoreRepository, productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, outboxRepository repository.OutboxRepository, transactionManager repository.TransactionManager, validate *validator.Validate) StockService {
+	return &StockServiceImpl{
+		StoreStockRepository:     storeStockRepository,
+		InventoryRepository:      inventoryRepository,
+		StockTransferRepository:  stockTransferRepository,
+		StoreRepository:          storeRepository,
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+		OutboxRepository:         outboxRepository,
+		TransactionManager:       transactionManager,
+		Validate:                 validate,
+	}
+}
+
+// FindLevels returns the stock on hand at the store of the request
+func (service *StockServiceImpl) FindLevels(ctx context.Context, productId string) ([]web.StoreStockResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return nil, err
+	}
+
+	stocks, err := service.StoreStockRepository.FindAll(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToStoreStockResponses(stocks), nil
+}
+
+// SetLevel sets the stock on hand at the store of the request, e.g. after a count, and
+// moves the stock across all stores by the same amount. Stock found is valued at the
+// product's cost price, stock lost at what it cost.
+func (service *StockServiceImpl) SetLevel(ctx context.Context, request web.StoreStockSetRequest) (web.StoreStockResponse, error) {
+	storeId, err := requestStore(ctx)
+	if err != nil {
+		return web.StoreStockResponse{}, err
+	}
+	if err := service.Validate.Struct(request); err != nil {
+		return web.StoreStockResponse{}, err
+	}
+	product, variantId, err := findStockItem(ctx, service.ProductRepository, service.ProductVariantRepository, request.ProductID, request.VariantID)
+	if err != nil {
+		return web.StoreStockResponse{}, err
+	}
+
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		current, err := service.StoreStockRepository.Find(ctx, request.ProductID, variantId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		delta := request.Quantity - current.Quantity
+		if delta == 0 {
+			return nil
+		}
+
+		_, err = adjustItem(ctx, service.StoreStockRepository, service.ProductRepository, service.ProductVariantRepository, service.InventoryRepository, service.OutboxRepository, product, domain.InventoryMovement{
+			ProductID: request.ProductID,
+			VariantID: variantId,
+			Quantity:  delta,
+		})
+		return err
+	})
+	if err != nil {
+		return web.StoreStockResponse{}, err
+	}
+
+	return web.StoreStockResponse{
+		StoreID:   storeId,
+		ProductID: request.ProductID,
+		VariantID: request.VariantID,
+		Quantity:  request.Quantity,
+	}, nil
+}
+
+// Restock books a delivery into the store of the request, at the price it was bought at,
+// and adds it to the stock across all stores
+func (service *StockServiceImpl) Restock(ctx context.Context, request web.StockRestockRequest) (web.InventoryMovementResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return web.InventoryMovementResponse{}, err
+	}
+	if err := service.Validate.Struct(request); err != nil {
+		return web.InventoryMovementResponse{}, err
+	}
+	product, variantId, err := findStockItem(ctx, service.ProductRepository, service.ProductVariantRepository, request.ProductID, request.VariantID)
+	if err != nil {
+		return web.InventoryMovementResponse{}, err
+	}
+
+	var movement domain.InventoryMovement
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		movement, err = restockItem(ctx, service.StoreStockRepository, service.ProductRepository, service.ProductVariantRepository, service.InventoryRepository, service.OutboxRepository, product, domain.InventoryMovement{
+			ProductID: request.ProductID,
+			VariantID: variantId,
+			Type:      domain.MovementTypeRestock,
+			Quantity:  request.Quantity,
+			UnitCost:  *request.UnitCost,
+			Reference: request.Reference,
+		})
+		return err
+	})
+	if err != nil {
+		return web.InventoryMovementResponse{}, err
+	}
+
+	return helper.ToInventoryMovementResponse(movement), nil
+}
+
+// FindMovements returns the newest entries of the inventory ledger of the store of the request
+func (service *StockServiceImpl) FindMovements(ctx context.Context, filter web.InventoryMovementFilterRequest) ([]web.InventoryMovementResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return nil, err
+	}
+	if err := service.Validate.Struct(filter); err != nil {
+		return nil, err
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultMovementLimit
+	}
+
+	movements, err := service.InventoryRepository.FindMovements(ctx, filter.ProductID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToInventoryMovementResponses(movements), nil
+}
+
+// SetRestockLevel sets when the stock of a product at the store of the request runs low
+// and how much to reorder then
+func (service *StockServiceImpl) SetRestockLevel(ctx context.Context, request web.RestockLevelRequest) (web.InventoryResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return web.InventoryResponse{}, err
+	}
+	if err := service.Validate.Struct(request); err != nil {
+		return web.InventoryResponse{}, err
+	}
+	_, variantId, err := findStockItem(ctx, service.ProductRepository, service.ProductVariantRepository, request.ProductID, request.VariantID)
+	if err != nil {
+		return web.InventoryResponse{}, err
+	}
+
+	inventory, err := service.InventoryRepository.SaveInventory(ctx, domain.Inventory{
+		ProductID:       request.ProductID,
+		VariantID:       variantId,
+		RestockLevel:    request.RestockLevel,
+		ReorderQuantity: request.ReorderQuantity,
+	})
+	if err != nil {
+		return web.InventoryResponse{}, err
+	}
+
+	return helper.ToInventoryResponse(inventory), nil
+}
+
+// FindInventories returns the restock settings and stock on hand of the store of the
+// request, optionally only of the products that run low
+func (service *StockServiceImpl) FindInventories(ctx context.Context, lowOnly bool) ([]web.InventoryResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return nil, err
+	}
+
+	inventories, err := service.InventoryRepository.FindInventories(ctx, lowOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToInventoryResponses(inventories), nil
+}
+
+// CreateTransfer takes the stock out of the store of the request and puts it in transit
+// to the destination store
+func (service *StockServiceImpl) CreateTransfer(ctx context.Context, request web.StockTransferCreateRequest) (web.StockTransferResponse, error) {
+	storeId, err := requestStore(ctx)
+	if err != nil {
+		return web.StockTransferResponse{}, err
+	}
+	if err := service.Validate.Struct(request); err != nil {
+		return web.StockTransferResponse{}, err
+	}
+	if request.ToStoreID == storeId {
+		return web.StockTransferResponse{}, exception.NewBadRequestError("Stock cannot be transferred to the same store")
+	}
+	if _, err := findStore(ctx, service.StoreRepository, request.ToStoreID); err != nil {
+		return web.StockTransferResponse{}, err
+	}
+
+	transfer := domain.StockTransfer{
+		FromStoreID: storeId,
+		ToStoreID:   request.ToStoreID,
+		Status:      domain.StockTransferStatusInTransit,
+		Note:        request.Note,
+		CreatedBy:   helper.ActorFromContext(ctx),
+		ShippedAt:   time.Now(),
+	}
+	products := make([]domain.Product, 0, len(request.Items))
+	for _, item := range request.Items {
+		product, variantId, err := findStockItem(ctx, service.ProductRepository, service.ProductVariantRepository, item.ProductID, item.VariantID)
+		if err != nil {
+			return web.StockTransferResponse{}, err
+		}
+		products = append(products, product)
+		transfer.Items = append(transfer.Items, domain.StockTransferItem{ProductID: item.ProductID, VariantID: variantId, Quantity: item.Quantity})
+	}
+
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		movements := make([]domain.InventoryMovement, 0, len(transfer.Items))
+		for i, item := range transfer.Items {
+			err := service.StoreStockRepository.Adjust(ctx, item.ProductID, item.VariantID, -item.Quantity)
+			if errors.Is(err, repository.ErrInsufficientStock) {
+				return exception.NewConflictError("Insufficient stock at this store for product " + item.ProductID)
+			} else if err != nil {
+				return err
+			}
+
+			movement, err := takeCostLayers(ctx, service.InventoryRepository, products[i], domain.InventoryMovement{
+				ProductID: item.ProductID,
+				VariantID: item.VariantID,
+				Type:      domain.MovementTypeTransferOut,
+				Quantity:  item.Quantity,
+			})
+			if err != nil {
+				return err
+			}
+			transfer.Items[i].UnitCost = movement.UnitCost
+			movements = append(movements, movement)
+		}
+
+		var err error
+		if transfer, err = service.StockTransferRepository.Save(ctx, transfer); err != nil {
+			return err
+		}
+		for _, movement := range movements {
+			movement.Reference = transferReference(transfer)
+			if _, err := saveMovement(ctx, service.InventoryRepository, service.OutboxRepository, movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return web.StockTransferResponse{}, err
+	}
+
+	return helper.ToStockTransferResponse(transfer), nil
+}
+
+// ReceiveTransfer books the stock in transit into the destination store, which must be
+// the store of the request
+func (service *StockServiceImpl) ReceiveTransfer(ctx context.Context, transferId uint64, version uint64) (web.StockTransferResponse, error) {
+	return service.closeTransfer(ctx, transferId, version, domain.StockTransferStatusReceived)
+}
+
+// CancelTransfer returns the stock in transit to the source store, which must be the
+// store of the request
+func (service *StockServiceImpl) CancelTransfer(ctx context.Context, transferId uint64, version uint64) (web.StockTransferResponse, error) {
+	return service.closeTransfer(ctx, transferId, version, domain.StockTransferStatusCancelled)
+}
+
+// FindTransferById returns a transfer from or to the store of the request
+func (service *StockServiceImpl) FindTransferById(ctx context.Context, transferId uint64) (web.StockTransferResponse, error) {
+	storeId, err := requestStore(ctx)
+	if err != nil {
+		return web.StockTransferResponse{}, err
+	}
+
+	transfer, err := service.findTransfer(ctx, storeId, transferId)
+	if err != nil {
+		return web.StockTransferResponse{}, err
+	}
+
+	return helper.ToStockTransferResponse(transfer), nil
+}
+
+// FindTransfers returns the transfers from or to the store of the request
+func (service *StockServiceImpl) FindTransfers(ctx context.Context, filter web.StockTransferFilterRequest) ([]web.StockTransferResponse, error) {
+	storeId, err := requestStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.Validate.Struct(filter); err != nil {
+		return nil, err
+	}
+
+	transfers, err := service.StockTransferRepository.FindAll(ctx, storeId, filter.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToStockTransferResponses(transfers), nil
+}
+
+// closeTransfer ends a transfer that is in transit: received puts its stock into the
+// destination store, cancelled puts it back into the source store, in both cases at the
+// cost it left the source store at.
+func (service *StockServiceImpl) closeTransfer(ctx context.Context, transferId uint64, version uint64, status string) (web.StockTransferResponse, error) {
+	storeId, err := requestStore(ctx)
+	if err != nil {
+		return web.StockTransferResponse{}, err
+	}
+
+	transfer, err := service.findTransfer(ctx, storeId, transferId)
+	if err != nil {
+		return web.StockTransferResponse{}, err
+	}
+	if status == domain.StockTransferStatusReceived && transfer.ToStoreID != storeId {
+		return web.StockTransferResponse{}, exception.NewForbiddenError("Only the destination store can receive a stock transfer")
+	}
+	if status == domain.StockTransferStatusCancelled && transfer.FromStoreID != storeId {
+		return web.StockTransferResponse{}, exception.NewForbiddenError("Only the source store can cancel a stock transfer")
+	}
+	if transfer.Status != domain.StockTransferStatusInTransit {
+		return web.StockTransferResponse{}, exception.NewConflictError(fmt.Sprintf("Stock transfer is already %s", transfer.Status))
+	}
+	if version != 0 && version != transfer.Version {
+		return web.StockTransferResponse{}, exception.NewConflictError("Stock transfer has been modified")
+	}
+
+	now := time.Now()
+	transfer.Status = status
+	transfer.ClosedAt = &now
+	if status == domain.StockTransferStatusReceived {
+		transfer.ReceivedBy = helper.ActorFromContext(ctx)
+	}
+
+	var updatedTransfer domain.StockTransfer
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTransfer, err = service.StockTransferRepository.Update(ctx, transfer)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return exception.NewConflictError("Stock transfer has been modified")
+		} else if err != nil {
+			return err
+		}
+
+		for _, item := range transfer.Items {
+			if err := service.StoreStockRepository.Adjust(ctx, item.ProductID, item.VariantID, item.Quantity); err != nil {
+				return err
+			}
+
+			product, err := findProduct(ctx, service.ProductRepository, item.ProductID)
+			if err != nil {
+				return err
+			}
+			movement, err := addCostLayers(ctx, service.InventoryRepository, product, domain.InventoryMovement{
+				ProductID: item.ProductID,
+				VariantID: item.VariantID,
+				Type:      domain.MovementTypeTransferIn,
+				Quantity:  item.Quantity,
+				UnitCost:  item.UnitCost,
+				Reference: transferReference(transfer),
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := saveMovement(ctx, service.InventoryRepository, service.OutboxRepository, movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return web.StockTransferResponse{}, err
+	}
+
+	return helper.ToStockTransferResponse(updatedTransfer), nil
+}
+
+// findTransfer loads a transfer from or to the store, reporting any other as NotFoundError.
+func (service *StockServiceImpl) findTransfer(ctx context.Context, storeId uint64, transferId uint64) (domain.StockTransfer, error) {
+	transfer, err := service.StockTransferRepository.FindById(ctx, transferId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && transfer.FromStoreID != storeId && transfer.ToStoreID != storeId) {
+		return domain.StockTransfer{}, exception.NewNotFoundError("Stock transfer not found")
+	}
+	return transfer, err
+}
+
+// findStockItem checks that the product, and the variant when given, exist and returns
+// the product and the variant id stock rows use, 0 for the product itself.
+func findStockItem(ctx context.Context, productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, productId string, variantId *uint64) (domain.Product, uint64, error) {
+	product, err := findProduct(ctx, productRepository, productId)
+	if err != nil {
+		return domain.Product{}, 0, err
+	}
+	if variantId == nil {
+		return product, 0, nil
+	}
+
+	variant, err := productVariantRepository.FindById(ctx, *variantId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && variant.ProductID != productId) {
+		return domain.Product{}, 0, exception.NewNotFoundError("Product variant not found")
+	} else if err != nil {
+		return domain.Product{}, 0, err
+	}
+	return product, variant.Id, nil
+}
+
+// transferReference is the reference of the ledger movements of a transfer.
+func transferReference(transfer domain.StockTransfer) string {
+	return fmt.Sprintf("stock transfer %d", transfer.Id)
+}
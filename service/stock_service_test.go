@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+type stockRepos struct {
+	stock     *mocks.MockStoreStockRepository
+	inventory *mocks.MockInventoryRepository
+	transfer  *mocks.MockStockTransferRepository
+	store     *mocks.MockStoreRepository
+	product   *mocks.MockProductRepository
+	variant   *mocks.MockProductVariantRepository
+}
+
+func newTestStockService(ctrl *gomock.Controller) (StockService, stockRepos) {
+	r := stockRepos{
+		stock:     mocks.NewMockStoreStockRepository(ctrl),
+		inventory: mocks.NewMockInventoryRepository(ctrl),
+		transfer:  mocks.NewMockStockTransferRepository(ctrl),
+		store:     mocks.NewMockStoreRepository(ctrl),
+		product:   mocks.NewMockProductRepository(ctrl),
+		variant:   mocks.NewMockProductVariantRepository(ctrl),
+	}
+	tx := mocks.NewMockTransactionManager(ctrl)
+	tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	return NewStockService(r.stock, r.inventory, r.transfer, r.store, r.product, r.variant, newMockOutboxRepository(ctrl), tx, validator.New()), r
+}
+
+func TestSetStockLevel(t *testing.T) {
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		mock      func(r stockRepos)
+		expectErr error
+	}{
+		{
+			name: "raises the store and the total by the difference at the cost price",
+			ctx:  storeCtx,
+			mock: func(r stockRepos) {
+				r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1", CostPrice: 2}, nil)
+				r.stock.EXPECT().Find(gomock.Any(), "p-1", uint64(0)).Return(domain.StoreStock{Quantity: 4}, nil)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), 6).Return(nil)
+				r.product.EXPECT().AdjustStock(gomock.Any(), "p-1", 6).Return(nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return(nil, nil)
+				r.inventory.EXPECT().SaveCostLayer(gomock.Any(), gomock.Any()).Return(domain.CostLayer{}, nil)
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+						assert.Equal(t, domain.MovementTypeAdjustment, movement.Type)
+						assert.Equal(t, 6, movement.Quantity)
+						assert.Equal(t, 12.0, movement.TotalCost)
+						return movement, nil
+					})
+			},
+		},
+		{
+			name: "lowers the stock at what it cost",
+			ctx:  storeCtx,
+			mock: func(r stockRepos) {
+				r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1", CostPrice: 2}, nil)
+				r.stock.EXPECT().Find(gomock.Any(), "p-1", uint64(0)).Return(domain.StoreStock{Quantity: 12}, nil)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), -2).Return(nil)
+				r.product.EXPECT().AdjustStock(gomock.Any(), "p-1", -2).Return(nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return([]domain.CostLayer{{Id: 1, Quantity: 5, UnitCost: 3}}, nil)
+				r.inventory.EXPECT().SaveCostLayer(gomock.Any(), domain.CostLayer{Id: 1, Quantity: 3, UnitCost: 3}).Return(domain.CostLayer{}, nil)
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+						assert.Equal(t, -2, movement.Quantity)
+						assert.Equal(t, -6.0, movement.TotalCost)
+						return movement, nil
+					})
+			},
+		},
+		{
+			name: "first stock of a product at the store",
+			ctx:  storeCtx,
+			mock: func(r stockRepos) {
+				r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+				r.stock.EXPECT().Find(gomock.Any(), "p-1", uint64(0)).Return(domain.StoreStock{}, gorm.ErrRecordNotFound)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), 10).Return(nil)
+				r.product.EXPECT().AdjustStock(gomock.Any(), "p-1", 10).Return(nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return(nil, nil)
+				r.inventory.EXPECT().SaveCostLayer(gomock.Any(), gomock.Any()).Return(domain.CostLayer{}, nil)
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).Return(domain.InventoryMovement{}, nil)
+			},
+		},
+		{
+			name: "store stock refusing to go below zero",
+			ctx:  storeCtx,
+			mock: func(r stockRepos) {
+				r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+				r.stock.EXPECT().Find(gomock.Any(), "p-1", uint64(0)).Return(domain.StoreStock{Quantity: 12}, nil)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), -2).Return(repository.ErrInsufficientStock)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:      "no store",
+			ctx:       context.Background(),
+			mock:      func(r stockRepos) {},
+			expectErr: exception.BadRequestError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			stockService, r := newTestStockService(ctrl)
+			tt.mock(r)
+
+			response, err := stockService.SetLevel(tt.ctx, web.StoreStockSetRequest{ProductID: "p-1", Quantity: 10})
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, web.StoreStockResponse{StoreID: 1, ProductID: "p-1", Quantity: 10}, response)
+		})
+	}
+}
+
+func TestCreateStockTransfer(t *testing.T) {
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+	variantId := uint64(3)
+	request := web.StockTransferCreateRequest{ToStoreID: 2, Items: []web.StockTransferItemRequest{
+		{ProductID: "p-1", Quantity: 5},
+		{ProductID: "p-2", VariantID: &variantId, Quantity: 1},
+	}}
+	withItems := func(r stockRepos) {
+		r.store.EXPECT().FindById(gomock.Any(), uint64(2)).Return(domain.Store{Id: 2}, nil)
+		r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+		r.product.EXPECT().FindById(gomock.Any(), "p-2").Return(domain.Product{ProductID: "p-2"}, nil)
+		r.variant.EXPECT().FindById(gomock.Any(), variantId).Return(domain.ProductVariant{Id: variantId, ProductID: "p-2"}, nil)
+	}
+
+	tests := []struct {
+		name      string
+		request   web.StockTransferCreateRequest
+		mock      func(r stockRepos)
+		expectErr error
+	}{
+		{
+			name:    "ships the stock in transit",
+			request: request,
+			mock: func(r stockRepos) {
+				withItems(r)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), -5).Return(nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return([]domain.CostLayer{{Id: 1, Quantity: 5, UnitCost: 4}}, nil)
+				r.inventory.EXPECT().DeleteCostLayer(gomock.Any(), gomock.Any()).Return(nil)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-2", variantId, -1).Return(nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-2", variantId).Return(nil, nil)
+				r.transfer.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transfer domain.StockTransfer) (domain.StockTransfer, error) {
+						assert.Equal(t, uint64(1), transfer.FromStoreID)
+						assert.Equal(t, domain.StockTransferStatusInTransit, transfer.Status)
+						assert.Equal(t, 4.0, transfer.Items[0].UnitCost, "the stock travels at what it cost")
+						transfer.Id, transfer.Version = 9, 1
+						return transfer, nil
+					})
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+						assert.Equal(t, domain.MovementTypeTransferOut, movement.Type)
+						assert.Equal(t, "stock transfer 9", movement.Reference)
+						return movement, nil
+					}).Times(2)
+			},
+		},
+		{
+			name:    "insufficient stock at the source store",
+			request: request,
+			mock: func(r stockRepos) {
+				withItems(r)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), -5).Return(repository.ErrInsufficientStock)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:      "to the same store",
+			request:   web.StockTransferCreateRequest{ToStoreID: 1, Items: request.Items},
+			mock:      func(r stockRepos) {},
+			expectErr: exception.BadRequestError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			stockService, r := newTestStockService(ctrl)
+			tt.mock(r)
+
+			response, err := stockService.CreateTransfer(storeCtx, tt.request)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(9), response.Id)
+			assert.Equal(t, &variantId, response.Items[1].VariantID)
+		})
+	}
+}
+
+func TestCloseStockTransfer(t *testing.T) {
+	inTransit := domain.StockTransfer{Id: 9, FromStoreID: 1, ToStoreID: 2, Status: domain.StockTransferStatusInTransit, Version: 1,
+		Items: []domain.StockTransferItem{{ProductID: "p-1", Quantity: 5, UnitCost: 4}}}
+	storeCtx := func(storeId string) context.Context {
+		return context.WithValue(context.Background(), helper.ContextKeyStore, storeId)
+	}
+	returned := func(r stockRepos) {
+		r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), 5).Return(nil)
+		r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+		r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return(nil, nil)
+		r.inventory.EXPECT().SaveCostLayer(gomock.Any(), gomock.Any()).Return(domain.CostLayer{}, nil)
+		r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+				assert.Equal(t, domain.MovementTypeTransferIn, movement.Type)
+				assert.Equal(t, 20.0, movement.TotalCost)
+				return movement, nil
+			})
+	}
+	closed := func(r stockRepos, status string) {
+		r.transfer.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, transfer domain.StockTransfer) (domain.StockTransfer, error) {
+				assert.Equal(t, status, transfer.Status)
+				assert.NotNil(t, transfer.ClosedAt)
+				transfer.Version++
+				return transfer, nil
+			})
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		close     func(service StockService, ctx context.Context) (web.StockTransferResponse, error)
+		mock      func(r stockRepos)
+		expectErr error
+	}{
+		{
+			name: "destination receives the stock",
+			ctx:  storeCtx("2"),
+			close: func(service StockService, ctx context.Context) (web.StockTransferResponse, error) {
+				return service.ReceiveTransfer(ctx, 9, 0)
+			},
+			mock: func(r stockRepos) {
+				r.transfer.EXPECT().FindById(gomock.Any(), uint64(9)).Return(inTransit, nil)
+				closed(r, domain.StockTransferStatusReceived)
+				returned(r)
+			},
+		},
+		{
+			name: "source cancels and gets the stock back",
+			ctx:  storeCtx("1"),
+			close: func(service StockService, ctx context.Context) (web.StockTransferResponse, error) {
+				return service.CancelTransfer(ctx, 9, 0)
+			},
+			mock: func(r stockRepos) {
+				r.transfer.EXPECT().FindById(gomock.Any(), uint64(9)).Return(inTransit, nil)
+				closed(r, domain.StockTransferStatusCancelled)
+				returned(r)
+			},
+		},
+		{
+			name: "source cannot receive",
+			ctx:  storeCtx("1"),
+			close: func(service StockService, ctx context.Context) (web.StockTransferResponse, error) {
+				return service.ReceiveTransfer(ctx, 9, 0)
+			},
+			mock: func(r stockRepos) {
+				r.transfer.EXPECT().FindById(gomock.Any(), uint64(9)).Return(inTransit, nil)
+			},
+			expectErr: exception.ForbiddenError{},
+		},
+		{
+			name: "other stores do not see the transfer",
+			ctx:  storeCtx("3"),
+			close: func(service StockService, ctx context.Context) (web.StockTransferResponse, error) {
+				return service.ReceiveTransfer(ctx, 9, 0)
+			},
+			mock: func(r stockRepos) {
+				r.transfer.EXPECT().FindById(gomock.Any(), uint64(9)).Return(inTransit, nil)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+		{
+			name: "already received",
+			ctx:  storeCtx("2"),
+			close: func(service StockService, ctx context.Context) (web.StockTransferResponse, error) {
+				return service.ReceiveTransfer(ctx, 9, 0)
+			},
+			mock: func(r stockRepos) {
+				received := inTransit
+				received.Status = domain.StockTransferStatusReceived
+				r.transfer.EXPECT().FindById(gomock.Any(), uint64(9)).Return(received, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			stockService, r := newTestStockService(ctrl)
+			tt.mock(r)
+
+			response, err := tt.close(stockService, tt.ctx)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(2), response.Version)
+		})
+	}
+}
+
+func TestRestock(t *testing.T) {
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+	unitCost := 4.0
+
+	tests := []struct {
+		name      string
+		request   web.StockRestockRequest
+		mock      func(r stockRepos)
+		expectErr error
+	}{
+		{
+			name:    "weighted average folds the delivery into the average",
+			request: web.StockRestockRequest{ProductID: "p-1", Quantity: 10, UnitCost: &unitCost, Reference: "DN-1"},
+			mock: func(r stockRepos) {
+				r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), 10).Return(nil)
+				r.product.EXPECT().AdjustStock(gomock.Any(), "p-1", 10).Return(nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).
+					Return([]domain.CostLayer{{Id: 1, Quantity: 6, UnitCost: 2}, {Id: 2, Quantity: 4, UnitCost: 2}}, nil)
+				r.inventory.EXPECT().DeleteCostLayer(gomock.Any(), domain.CostLayer{Id: 2, Quantity: 4, UnitCost: 2}).Return(nil)
+				r.inventory.EXPECT().SaveCostLayer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, layer domain.CostLayer) (domain.CostLayer, error) {
+						assert.Equal(t, uint64(1), layer.Id)
+						assert.Equal(t, 20, layer.Quantity)
+						assert.Equal(t, 3.0, layer.UnitCost)
+						return layer, nil
+					})
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+						movement.Id = 5
+						return movement, nil
+					})
+				r.inventory.EXPECT().MarkRestocked(gomock.Any(), "p-1", uint64(0), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "fifo keeps a layer per delivery",
+			request: web.StockRestockRequest{ProductID: "p-1", Quantity: 10, UnitCost: &unitCost},
+			mock: func(r stockRepos) {
+				r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1", CostMethod: domain.CostMethodFIFO}, nil)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), 10).Return(nil)
+				r.product.EXPECT().AdjustStock(gomock.Any(), "p-1", 10).Return(nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return([]domain.CostLayer{{Id: 1, Quantity: 6, UnitCost: 2}}, nil)
+				r.inventory.EXPECT().SaveCostLayer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, layer domain.CostLayer) (domain.CostLayer, error) {
+						assert.Zero(t, layer.Id)
+						assert.Equal(t, 10, layer.Quantity)
+						assert.Equal(t, 4.0, layer.UnitCost)
+						return layer, nil
+					})
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+						movement.Id = 5
+						return movement, nil
+					})
+				r.inventory.EXPECT().MarkRestocked(gomock.Any(), "p-1", uint64(0), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "unit cost is required",
+			request:   web.StockRestockRequest{ProductID: "p-1", Quantity: 10},
+			mock:      func(r stockRepos) {},
+			expectErr: validator.ValidationErrors{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			stockService, r := newTestStockService(ctrl)
+			tt.mock(r)
+
+			response, err := stockService.Restock(storeCtx, tt.request)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(5), response.Id)
+			assert.Equal(t, domain.MovementTypeRestock, response.Type)
+			assert.Equal(t, 40.0, response.TotalCost)
+		})
+	}
+}
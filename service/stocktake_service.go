@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type StocktakeService interface {
+	Create(ctx context.Context, request web.StocktakeCreateRequest) (web.StocktakeResponse, error)
+	Count(ctx context.Context, request web.StocktakeCountRequest) (web.StocktakeResponse, error)
+	Upload(ctx context.Context, request web.StocktakeUploadRequest) (web.StocktakeResponse, error)
+	Approve(ctx context.Context, request web.StocktakeApproveRequest) (web.StocktakeResponse, error)
+	Cancel(ctx context.Context, stocktakeId uint64, version uint64) (web.StocktakeResponse, error)
+	FindById(ctx context.Context, stocktakeId uint64) (web.StocktakeResponse, error)
+	FindAll(ctx context.Context, filter web.StocktakeFilterRequest) ([]web.StocktakeResponse, error)
+	Variances(ctx context.Context, stocktakeId uint64) (web.StocktakeVarianceResponse, error)
+}
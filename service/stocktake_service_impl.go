@@ -0,0 +1,477 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type StocktakeServiceImpl struct {
+	StocktakeRepository      repository.StocktakeRepository
+	StoreStockRepository     repository.StoreStockRepository
+	InventoryRepository      repository.InventoryRepository
+	ProductRepository        repository.ProductRepository
+	ProductVariantRepository repository.ProductVariantRepository
+	EmployeeRepository       repository.EmployeeRepository
+	ProductBarcodeService    ProductBarcodeService
+	OutboxRepository         repository.OutboxRepository
+	TransactionManager       repository.TransactionManager
+	Validate                 *validator.Validate
+}
+
+func NewStocktakeService(stocktakeRepository repository.StocktakeRepository, storeStockRepository repository.StoreStockRepository, inventoryRepository repository.InventoryRepository, productRepository repository.ProductRepository, productVariantRepository repository.ProductVariantRepository, employeeRepository repository.EmployeeRepository, productBarcodeService ProductBarcodeService, outboxRepository repository.OutboxRepository, transactionManager repository.TransactionManager, validate *validator.Validate) StocktakeService {
+	return &StocktakeServiceImpl{
+		StocktakeRepository:      stocktakeRepository,
+		StoreStockRepository:     storeStockRepository,
+		InventoryRepository:      inventoryRepository,
+		ProductRepository:        productRepository,
+		ProductVariantRepository: productVariantRepository,
+		EmployeeRepository:       employeeRepository,
+		ProductBarcodeService:    productBarcodeService,
+		OutboxRepository:         outboxRepository,
+		TransactionManager:       transactionManager,
+		Validate:                 validate,
+	}
+}
+
+// Create starts a stocktake at the store of the request, taking the quantities on hand of
+// the products listed, or of everything the store has stock of, as the quantities expected
+func (service *StocktakeServiceImpl) Create(ctx context.Context, request web.StocktakeCreateRequest) (web.StocktakeResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	if err := service.Validate.Struct(request); err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	var stocktake domain.Stocktake
+	err := service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		lines, err := service.snapshot(ctx, request.ProductIDs)
+		if err != nil {
+			return err
+		}
+		stocktake, err = service.StocktakeRepository.Save(ctx, domain.Stocktake{
+			Status:    domain.StocktakeStatusCounting,
+			Note:      request.Note,
+			CreatedBy: helper.ActorFromContext(ctx),
+			CreatedAt: time.Now(),
+			Lines:     lines,
+		})
+		return err
+	})
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	return helper.ToStocktakeResponse(stocktake), nil
+}
+
+// Count records counted quantities on a stocktake. Products or variants that are not on
+// it yet, e.g. found on a shelf they were not expected on, are added with the quantity
+// on hand as the quantity expected.
+func (service *StocktakeServiceImpl) Count(ctx context.Context, request web.StocktakeCountRequest) (web.StocktakeResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	stocktake, err := service.findCounting(ctx, request.StocktakeID, request.Version)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		lineIndexes := make(map[string]int, len(stocktake.Lines))
+		for i, line := range stocktake.Lines {
+			lineIndexes[stockItemKey(line.ProductID, line.VariantID)] = i
+		}
+
+		counted := map[int]bool{}
+		for _, count := range request.Lines {
+			productId, variantId, err := service.countedItem(ctx, count)
+			if err != nil {
+				return err
+			}
+			i, ok := lineIndexes[stockItemKey(productId, variantId)]
+			if !ok {
+				current, err := service.StoreStockRepository.Find(ctx, productId, variantId)
+				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return err
+				}
+				i = len(stocktake.Lines)
+				lineIndexes[stockItemKey(productId, variantId)] = i
+				stocktake.Lines = append(stocktake.Lines, domain.StocktakeLine{
+					StocktakeID:      stocktake.Id,
+					ProductID:        productId,
+					VariantID:        variantId,
+					ExpectedQuantity: current.Quantity,
+				})
+			}
+
+			line := &stocktake.Lines[i]
+			quantity := count.Quantity
+			if request.Add && line.CountedQuantity != nil {
+				quantity += *line.CountedQuantity
+			}
+			line.CountedQuantity = &quantity
+			if count.Reason != "" {
+				line.Reason = count.Reason
+			}
+			counted[i] = true
+		}
+
+		return service.saveLines(ctx, &stocktake, counted)
+	})
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	return helper.ToStocktakeResponse(stocktake), nil
+}
+
+// Upload records the rows of a scanner export on a stocktake, adding them to what was
+// counted before. A header row starting with "barcode" is skipped.
+func (service *StocktakeServiceImpl) Upload(ctx context.Context, request web.StocktakeUploadRequest) (web.StocktakeResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	countRequest := web.StocktakeCountRequest{
+		StocktakeID: request.StocktakeID,
+		Add:         true,
+		Version:     request.Version,
+	}
+	for i, row := range request.Rows {
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		barcode := strings.TrimSpace(row[0])
+		if i == 0 && strings.EqualFold(barcode, "barcode") {
+			continue
+		}
+		quantity := 1
+		if len(row) > 1 && strings.TrimSpace(row[1]) != "" {
+			var err error
+			if quantity, err = strconv.Atoi(strings.TrimSpace(row[1])); err != nil || quantity < 0 {
+				return web.StocktakeResponse{}, exception.NewBadRequestError(fmt.Sprintf("row %d: invalid quantity %q", i+1, row[1]))
+			}
+		}
+		countRequest.Lines = append(countRequest.Lines, web.StocktakeCountLineRequest{Barcode: barcode, Quantity: quantity})
+	}
+	if len(countRequest.Lines) == 0 {
+		return web.StocktakeResponse{}, exception.NewBadRequestError("upload has no scans")
+	}
+
+	return service.Count(ctx, countRequest)
+}
+
+// Approve posts the variances of the counted lines of a stocktake to the inventory ledger
+// as adjustments, with their shrinkage reason. The variance is taken against the quantity
+// expected when the stocktake started, so stock sold or received while counting is kept.
+// Lines that were not counted are left as they are. Only managers can approve.
+func (service *StocktakeServiceImpl) Approve(ctx context.Context, request web.StocktakeApproveRequest) (web.StocktakeResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	manager, err := requestManager(ctx, service.EmployeeRepository)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	stocktake, err := service.findCounting(ctx, request.StocktakeID, request.Version)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	defaultReason := request.Reason
+	if defaultReason == "" {
+		defaultReason = domain.ShrinkageReasonUnknown
+	}
+
+	err = service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		reference := fmt.Sprintf("stocktake %d", stocktake.Id)
+		adjusted := map[int]bool{}
+		for i := range stocktake.Lines {
+			line := &stocktake.Lines[i]
+			variance := helper.StocktakeVariance(*line)
+			if variance == 0 {
+				continue
+			}
+			if line.Reason == "" {
+				line.Reason = defaultReason
+			}
+
+			product, err := findProduct(ctx, service.ProductRepository, line.ProductID)
+			if err != nil {
+				return err
+			}
+			movement, err := adjustItem(ctx, service.StoreStockRepository, service.ProductRepository, service.ProductVariantRepository, service.InventoryRepository, service.OutboxRepository, product, domain.InventoryMovement{
+				ProductID: line.ProductID,
+				VariantID: line.VariantID,
+				Quantity:  variance,
+				Reference: reference,
+				Reason:    line.Reason,
+			})
+			if err != nil {
+				return err
+			}
+			line.AdjustmentCost = movement.TotalCost
+			adjusted[i] = true
+		}
+
+		now := time.Now()
+		stocktake.Status = domain.StocktakeStatusApproved
+		stocktake.ApprovedBy = manager.EmployeeID
+		stocktake.ClosedAt = &now
+		return service.saveLines(ctx, &stocktake, adjusted)
+	})
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	return helper.ToStocktakeResponse(stocktake), nil
+}
+
+// Cancel a stocktake that is still being counted, leaving the stock as it is
+func (service *StocktakeServiceImpl) Cancel(ctx context.Context, stocktakeId uint64, version uint64) (web.StocktakeResponse, error) {
+	stocktake, err := service.findCounting(ctx, stocktakeId, version)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	now := time.Now()
+	stocktake.Status = domain.StocktakeStatusCancelled
+	stocktake.ClosedAt = &now
+	if err := service.saveLines(ctx, &stocktake, nil); err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	return helper.ToStocktakeResponse(stocktake), nil
+}
+
+// FindById returns a stocktake of the store of the request with its lines
+func (service *StocktakeServiceImpl) FindById(ctx context.Context, stocktakeId uint64) (web.StocktakeResponse, error) {
+	stocktake, err := service.findStocktake(ctx, stocktakeId, 0)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	return helper.ToStocktakeResponse(stocktake), nil
+}
+
+// FindAll returns the stocktakes of the store of the request, without their lines
+func (service *StocktakeServiceImpl) FindAll(ctx context.Context, filter web.StocktakeFilterRequest) ([]web.StocktakeResponse, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return nil, err
+	}
+	if err := service.Validate.Struct(filter); err != nil {
+		return nil, err
+	}
+
+	stocktakes, err := service.StocktakeRepository.FindAll(ctx, filter.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToStocktakeResponses(stocktakes), nil
+}
+
+// Variances reports the counted lines of a stocktake that differ from what was expected,
+// valued at what their adjustment was posted at once approved and at the cost price of
+// the product before
+func (service *StocktakeServiceImpl) Variances(ctx context.Context, stocktakeId uint64) (web.StocktakeVarianceResponse, error) {
+	stocktake, err := service.findStocktake(ctx, stocktakeId, 0)
+	if err != nil {
+		return web.StocktakeVarianceResponse{}, err
+	}
+
+	response := web.StocktakeVarianceResponse{
+		StocktakeID: stocktake.Id,
+		Status:      stocktake.Status,
+		Rows:        []web.StocktakeVarianceRow{},
+	}
+	products := map[string]domain.Product{}
+	for _, line := range stocktake.Lines {
+		if line.CountedQuantity == nil {
+			response.LinesUncounted++
+			continue
+		}
+		response.LinesCounted++
+		variance := helper.StocktakeVariance(line)
+		if variance == 0 {
+			continue
+		}
+
+		product, ok := products[line.ProductID]
+		if !ok {
+			if product, err = findProduct(ctx, service.ProductRepository, line.ProductID); err != nil {
+				return web.StocktakeVarianceResponse{}, err
+			}
+			products[line.ProductID] = product
+		}
+		value := helper.RoundMoney(product.CostPrice * float64(variance))
+		if stocktake.Status == domain.StocktakeStatusApproved {
+			value = line.AdjustmentCost
+		}
+
+		row := web.StocktakeVarianceRow{
+			ProductID:     line.ProductID,
+			ProductName:   product.Name,
+			Expected:      line.ExpectedQuantity,
+			Counted:       *line.CountedQuantity,
+			Variance:      variance,
+			UnitCost:      helper.RoundMoney(value / float64(variance)),
+			VarianceValue: value,
+			Reason:        line.Reason,
+		}
+		if line.VariantID != 0 {
+			variantId := line.VariantID
+			row.VariantID = &variantId
+		}
+		response.Rows = append(response.Rows, row)
+		if variance < 0 {
+			response.ShrinkageQty -= variance
+			response.ShrinkageValue = helper.RoundMoney(response.ShrinkageValue - value)
+		} else {
+			response.OverageQty += variance
+			response.OverageValue = helper.RoundMoney(response.OverageValue + value)
+		}
+	}
+	response.NetValue = helper.RoundMoney(response.OverageValue - response.ShrinkageValue)
+
+	return response, nil
+}
+
+// snapshot lists what the store of the request has on hand of the products, or of
+// everything when no products are given, as stocktake lines. Products or variants the
+// store never had stock of are expected to be at 0.
+func (service *StocktakeServiceImpl) snapshot(ctx context.Context, productIds []string) ([]domain.StocktakeLine, error) {
+	if len(productIds) == 0 {
+		stocks, err := service.StoreStockRepository.FindAll(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		lines := make([]domain.StocktakeLine, 0, len(stocks))
+		for _, stock := range stocks {
+			lines = append(lines, domain.StocktakeLine{ProductID: stock.ProductID, VariantID: stock.VariantID, ExpectedQuantity: stock.Quantity})
+		}
+		return lines, nil
+	}
+
+	var lines []domain.StocktakeLine
+	for _, productId := range productIds {
+		product, err := findProduct(ctx, service.ProductRepository, productId)
+		if err != nil {
+			return nil, err
+		}
+		stocks, err := service.StoreStockRepository.FindAll(ctx, productId)
+		if err != nil {
+			return nil, err
+		}
+		expected := make(map[uint64]int, len(stocks))
+		for _, stock := range stocks {
+			expected[stock.VariantID] = stock.Quantity
+		}
+
+		variantIds := []uint64{0}
+		if len(product.Variants) > 0 {
+			variantIds = variantIds[:0]
+			for _, variant := range product.Variants {
+				variantIds = append(variantIds, variant.Id)
+			}
+		}
+		for _, variantId := range variantIds {
+			lines = append(lines, domain.StocktakeLine{ProductID: productId, VariantID: variantId, ExpectedQuantity: expected[variantId]})
+		}
+	}
+	return lines, nil
+}
+
+// countedItem resolves the product and variant of a count, by its barcode if it has one.
+func (service *StocktakeServiceImpl) countedItem(ctx context.Context, count web.StocktakeCountLineRequest) (string, uint64, error) {
+	if count.Barcode == "" {
+		_, variantId, err := findStockItem(ctx, service.ProductRepository, service.ProductVariantRepository, count.ProductID, count.VariantID)
+		return count.ProductID, variantId, err
+	}
+
+	lookup, err := service.ProductBarcodeService.Lookup(ctx, count.Barcode)
+	if _, ok := err.(exception.NotFoundError); ok {
+		return "", 0, exception.NewBadRequestError(fmt.Sprintf("barcode %s not found", count.Barcode))
+	} else if err != nil {
+		return "", 0, err
+	}
+	var variantId uint64
+	if lookup.Barcode.VariantID != nil {
+		variantId = *lookup.Barcode.VariantID
+	}
+	return lookup.Barcode.ProductID, variantId, nil
+}
+
+// saveLines saves the lines of a stocktake at the given indexes and then the stocktake
+// itself, which bumps its version so that counts made at the same time conflict.
+func (service *StocktakeServiceImpl) saveLines(ctx context.Context, stocktake *domain.Stocktake, indexes map[int]bool) error {
+	return service.TransactionManager.Transaction(ctx, func(ctx context.Context) error {
+		changed := make([]domain.StocktakeLine, 0, len(indexes))
+		positions := make([]int, 0, len(indexes))
+		for i := range stocktake.Lines {
+			if indexes[i] {
+				changed = append(changed, stocktake.Lines[i])
+				positions = append(positions, i)
+			}
+		}
+		saved, err := service.StocktakeRepository.SaveLines(ctx, changed)
+		if err != nil {
+			return err
+		}
+		for n, i := range positions {
+			stocktake.Lines[i] = saved[n]
+		}
+
+		lines := stocktake.Lines
+		updated, err := service.StocktakeRepository.Update(ctx, *stocktake)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return exception.NewConflictError("Stocktake has been modified")
+		} else if err != nil {
+			return err
+		}
+		*stocktake = updated
+		stocktake.Lines = lines
+		return nil
+	})
+}
+
+// findStocktake loads a stocktake of the store of the request, checking its version when
+// one is given.
+func (service *StocktakeServiceImpl) findStocktake(ctx context.Context, stocktakeId uint64, version uint64) (domain.Stocktake, error) {
+	if _, err := requestStore(ctx); err != nil {
+		return domain.Stocktake{}, err
+	}
+	stocktake, err := service.StocktakeRepository.FindById(ctx, stocktakeId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Stocktake{}, exception.NewNotFoundError("Stocktake not found")
+	} else if err != nil {
+		return domain.Stocktake{}, err
+	}
+	if version != 0 && version != stocktake.Version {
+		return domain.Stocktake{}, exception.NewConflictError("Stocktake has been modified")
+	}
+	return stocktake, nil
+}
+
+// findCounting loads a stocktake that is still being counted.
+func (service *StocktakeServiceImpl) findCounting(ctx context.Context, stocktakeId uint64, version uint64) (domain.Stocktake, error) {
+	stocktake, err := service.findStocktake(ctx, stocktakeId, version)
+	if err != nil {
+		return domain.Stocktake{}, err
+	}
+	if stocktake.Status != domain.StocktakeStatusCounting {
+		return domain.Stocktake{}, exception.NewConflictError(fmt.Sprintf("Stocktake is already %s", stocktake.Status))
+	}
+	return stocktake, nil
+}
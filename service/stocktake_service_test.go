@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	servicemocks "github.com/aronipurwanto/go-restful-api/service/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+type stocktakeRepos struct {
+	stocktake *mocks.MockStocktakeRepository
+	stock     *mocks.MockStoreStockRepository
+	inventory *mocks.MockInventoryRepository
+	product   *mocks.MockProductRepository
+	variant   *mocks.MockProductVariantRepository
+	employee  *mocks.MockEmployeeRepository
+	barcode   *servicemocks.MockProductBarcodeService
+}
+
+func newTestStocktakeService(ctrl *gomock.Controller) (StocktakeService, stocktakeRepos) {
+	r := stocktakeRepos{
+		stocktake: mocks.NewMockStocktakeRepository(ctrl),
+		stock:     mocks.NewMockStoreStockRepository(ctrl),
+		inventory: mocks.NewMockInventoryRepository(ctrl),
+		product:   mocks.NewMockProductRepository(ctrl),
+		variant:   mocks.NewMockProductVariantRepository(ctrl),
+		employee:  mocks.NewMockEmployeeRepository(ctrl),
+		barcode:   servicemocks.NewMockProductBarcodeService(ctrl),
+	}
+	tx := mocks.NewMockTransactionManager(ctrl)
+	tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	return NewStocktakeService(r.stocktake, r.stock, r.inventory, r.product, r.variant, r.employee, r.barcode, newMockOutboxRepository(ctrl), tx, validator.New()), r
+}
+
+func counted(quantity int) *int {
+	return &quantity
+}
+
+func TestCreateStocktake(t *testing.T) {
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	stocktakeService, r := newTestStocktakeService(ctrl)
+
+	r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1"}, nil)
+	r.stock.EXPECT().FindAll(gomock.Any(), "p-1").Return([]domain.StoreStock{{ProductID: "p-1", Quantity: 7}}, nil)
+	r.product.EXPECT().FindById(gomock.Any(), "p-2").Return(domain.Product{ProductID: "p-2", Variants: []domain.ProductVariant{{Id: 3}, {Id: 4}}}, nil)
+	r.stock.EXPECT().FindAll(gomock.Any(), "p-2").Return([]domain.StoreStock{{ProductID: "p-2", VariantID: 4, Quantity: 2}}, nil)
+	r.stocktake.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, stocktake domain.Stocktake) (domain.Stocktake, error) {
+			assert.Equal(t, domain.StocktakeStatusCounting, stocktake.Status)
+			assert.Equal(t, []domain.StocktakeLine{
+				{ProductID: "p-1", ExpectedQuantity: 7},
+				{ProductID: "p-2", VariantID: 3, ExpectedQuantity: 0},
+				{ProductID: "p-2", VariantID: 4, ExpectedQuantity: 2},
+			}, stocktake.Lines)
+			stocktake.Id = 1
+			stocktake.Version = 1
+			return stocktake, nil
+		})
+
+	response, err := stocktakeService.Create(storeCtx, web.StocktakeCreateRequest{ProductIDs: []string{"p-1", "p-2"}})
+	assert.NoError(t, err)
+	assert.Len(t, response.Lines, 3)
+}
+
+func TestCountStocktake(t *testing.T) {
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+	variantId := uint64(4)
+	stocktake := func() domain.Stocktake {
+		return domain.Stocktake{Id: 1, Status: domain.StocktakeStatusCounting, Version: 3, Lines: []domain.StocktakeLine{
+			{Id: 10, StocktakeID: 1, ProductID: "p-1", ExpectedQuantity: 7, CountedQuantity: counted(2)},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		request     web.StocktakeUploadRequest
+		mock        func(r stocktakeRepos)
+		expectLines []domain.StocktakeLine
+		expectErr   error
+	}{
+		{
+			name:    "scans are added up and items not on the stocktake are added to it",
+			request: web.StocktakeUploadRequest{StocktakeID: 1, Rows: [][]string{{"barcode", "quantity"}, {"111"}, {"111", "3"}, {"222", "5"}}},
+			mock: func(r stocktakeRepos) {
+				r.stocktake.EXPECT().FindById(gomock.Any(), uint64(1)).Return(stocktake(), nil)
+				r.barcode.EXPECT().Lookup(gomock.Any(), "111").
+					Return(web.ProductBarcodeLookupResponse{Barcode: web.ProductBarcodeResponse{ProductID: "p-1"}}, nil).Times(2)
+				r.barcode.EXPECT().Lookup(gomock.Any(), "222").
+					Return(web.ProductBarcodeLookupResponse{Barcode: web.ProductBarcodeResponse{ProductID: "p-2", VariantID: &variantId}}, nil)
+				r.stock.EXPECT().Find(gomock.Any(), "p-2", variantId).Return(domain.StoreStock{}, gorm.ErrRecordNotFound)
+				r.stocktake.EXPECT().SaveLines(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, lines []domain.StocktakeLine) ([]domain.StocktakeLine, error) {
+						lines[1].Id = 11
+						return lines, nil
+					})
+				r.stocktake.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, stocktake domain.Stocktake) (domain.Stocktake, error) {
+						assert.Equal(t, uint64(3), stocktake.Version)
+						stocktake.Version++
+						return stocktake, nil
+					})
+			},
+			expectLines: []domain.StocktakeLine{
+				{Id: 10, StocktakeID: 1, ProductID: "p-1", ExpectedQuantity: 7, CountedQuantity: counted(6)},
+				{Id: 11, StocktakeID: 1, ProductID: "p-2", VariantID: 4, ExpectedQuantity: 0, CountedQuantity: counted(5)},
+			},
+		},
+		{
+			name:    "an unknown barcode is rejected",
+			request: web.StocktakeUploadRequest{StocktakeID: 1, Rows: [][]string{{"999"}}},
+			mock: func(r stocktakeRepos) {
+				r.stocktake.EXPECT().FindById(gomock.Any(), uint64(1)).Return(stocktake(), nil)
+				r.barcode.EXPECT().Lookup(gomock.Any(), "999").Return(web.ProductBarcodeLookupResponse{}, exception.NewNotFoundError("Barcode not found"))
+			},
+			expectErr: exception.BadRequestError{},
+		},
+		{
+			name:      "an invalid quantity is rejected",
+			request:   web.StocktakeUploadRequest{StocktakeID: 1, Rows: [][]string{{"111", "two"}}},
+			mock:      func(r stocktakeRepos) {},
+			expectErr: exception.BadRequestError{},
+		},
+		{
+			name:    "an approved stocktake cannot be counted",
+			request: web.StocktakeUploadRequest{StocktakeID: 1, Rows: [][]string{{"111"}}},
+			mock: func(r stocktakeRepos) {
+				approved := stocktake()
+				approved.Status = domain.StocktakeStatusApproved
+				r.stocktake.EXPECT().FindById(gomock.Any(), uint64(1)).Return(approved, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			stocktakeService, r := newTestStocktakeService(ctrl)
+			tt.mock(r)
+
+			response, err := stocktakeService.Upload(storeCtx, tt.request)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(4), response.Version)
+			assert.Equal(t, helper.ToStocktakeResponse(domain.Stocktake{Lines: tt.expectLines}).Lines, response.Lines)
+		})
+	}
+}
+
+func TestApproveStocktake(t *testing.T) {
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+	managerCtx := context.WithValue(storeCtx, helper.ContextKeyEmployee, "m-1")
+	cashierCtx := context.WithValue(storeCtx, helper.ContextKeyEmployee, "c-1")
+	stocktake := domain.Stocktake{Id: 1, Status: domain.StocktakeStatusCounting, Version: 3, Lines: []domain.StocktakeLine{
+		{Id: 10, ProductID: "p-1", ExpectedQuantity: 7, CountedQuantity: counted(5), Reason: domain.ShrinkageReasonDamaged},
+		{Id: 11, ProductID: "p-2", ExpectedQuantity: 2, CountedQuantity: counted(3)},
+		{Id: 12, ProductID: "p-3", ExpectedQuantity: 4},
+		{Id: 13, ProductID: "p-4", ExpectedQuantity: 1, CountedQuantity: counted(1)},
+	}}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		mock      func(r stocktakeRepos)
+		expectErr error
+	}{
+		{
+			name: "posts the variances of the counted lines with their reason",
+			ctx:  managerCtx,
+			mock: func(r stocktakeRepos) {
+				r.employee.EXPECT().FindById(gomock.Any(), "m-1").Return(domain.Employee{EmployeeID: "m-1", Role: domain.EmployeeRoleManager}, nil)
+				r.stocktake.EXPECT().FindById(gomock.Any(), uint64(1)).Return(stocktake, nil)
+
+				r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1", CostPrice: 3}, nil)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-1", uint64(0), -2).Return(nil)
+				r.product.EXPECT().AdjustStock(gomock.Any(), "p-1", -2).Return(nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-1", uint64(0)).Return([]domain.CostLayer{{Id: 1, Quantity: 7, UnitCost: 2.5}}, nil)
+				r.inventory.EXPECT().SaveCostLayer(gomock.Any(), domain.CostLayer{Id: 1, Quantity: 5, UnitCost: 2.5}).Return(domain.CostLayer{}, nil)
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+						assert.Equal(t, domain.MovementTypeAdjustment, movement.Type)
+						assert.Equal(t, -2, movement.Quantity)
+						assert.Equal(t, -5.0, movement.TotalCost)
+						assert.Equal(t, "stocktake 1", movement.Reference)
+						assert.Equal(t, domain.ShrinkageReasonDamaged, movement.Reason)
+						return movement, nil
+					})
+
+				r.product.EXPECT().FindById(gomock.Any(), "p-2").Return(domain.Product{ProductID: "p-2", CostPrice: 4}, nil)
+				r.stock.EXPECT().Adjust(gomock.Any(), "p-2", uint64(0), 1).Return(nil)
+				r.product.EXPECT().AdjustStock(gomock.Any(), "p-2", 1).Return(nil)
+				r.inventory.EXPECT().FindCostLayers(gomock.Any(), "p-2", uint64(0)).Return(nil, nil)
+				r.inventory.EXPECT().SaveCostLayer(gomock.Any(), gomock.Any()).Return(domain.CostLayer{}, nil)
+				r.inventory.EXPECT().SaveMovement(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+						assert.Equal(t, 1, movement.Quantity)
+						assert.Equal(t, 4.0, movement.TotalCost)
+						assert.Equal(t, domain.ShrinkageReasonCountError, movement.Reason)
+						return movement, nil
+					})
+
+				r.stocktake.EXPECT().SaveLines(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, lines []domain.StocktakeLine) ([]domain.StocktakeLine, error) {
+						assert.Len(t, lines, 2)
+						assert.Equal(t, -5.0, lines[0].AdjustmentCost)
+						assert.Equal(t, 4.0, lines[1].AdjustmentCost)
+						return lines, nil
+					})
+				r.stocktake.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, stocktake domain.Stocktake) (domain.Stocktake, error) {
+						assert.Equal(t, domain.StocktakeStatusApproved, stocktake.Status)
+						assert.Equal(t, "m-1", stocktake.ApprovedBy)
+						return stocktake, nil
+					})
+			},
+		},
+		{
+			name: "only managers can approve",
+			ctx:  cashierCtx,
+			mock: func(r stocktakeRepos) {
+				r.employee.EXPECT().FindById(gomock.Any(), "c-1").Return(domain.Employee{EmployeeID: "c-1", Role: domain.EmployeeRoleCashier}, nil)
+			},
+			expectErr: exception.ForbiddenError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			stocktakeService, r := newTestStocktakeService(ctrl)
+			tt.mock(r)
+
+			response, err := stocktakeService.Approve(tt.ctx, web.StocktakeApproveRequest{StocktakeID: 1, Reason: domain.ShrinkageReasonCountError})
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, domain.StocktakeStatusApproved, response.Status)
+		})
+	}
+}
+
+func TestStocktakeVariances(t *testing.T) {
+	storeCtx := context.WithValue(context.Background(), helper.ContextKeyStore, "1")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	stocktakeService, r := newTestStocktakeService(ctrl)
+
+	r.stocktake.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Stocktake{Id: 1, Status: domain.StocktakeStatusCounting, Lines: []domain.StocktakeLine{
+		{ProductID: "p-1", ExpectedQuantity: 7, CountedQuantity: counted(5), Reason: domain.ShrinkageReasonTheft},
+		{ProductID: "p-2", ExpectedQuantity: 2, CountedQuantity: counted(3)},
+		{ProductID: "p-3", ExpectedQuantity: 4},
+		{ProductID: "p-4", ExpectedQuantity: 1, CountedQuantity: counted(1)},
+	}}, nil)
+	r.product.EXPECT().FindById(gomock.Any(), "p-1").Return(domain.Product{ProductID: "p-1", Name: "Tea", CostPrice: 3}, nil)
+	r.product.EXPECT().FindById(gomock.Any(), "p-2").Return(domain.Product{ProductID: "p-2", Name: "Milk", CostPrice: 4}, nil)
+
+	response, err := stocktakeService.Variances(storeCtx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, response.LinesCounted)
+	assert.Equal(t, 1, response.LinesUncounted)
+	assert.Equal(t, 2, response.ShrinkageQty)
+	assert.Equal(t, 6.0, response.ShrinkageValue)
+	assert.Equal(t, 1, response.OverageQty)
+	assert.Equal(t, 4.0, response.OverageValue)
+	assert.Equal(t, -2.0, response.NetValue)
+	assert.Equal(t, []web.StocktakeVarianceRow{
+		{ProductID: "p-1", ProductName: "Tea", Expected: 7, Counted: 5, Variance: -2, UnitCost: 3, VarianceValue: -6, Reason: domain.ShrinkageReasonTheft},
+		{ProductID: "p-2", ProductName: "Milk", Expected: 2, Counted: 3, Variance: 1, UnitCost: 4, VarianceValue: 4},
+	}, response.Rows)
+}
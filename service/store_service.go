@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type StoreService interface {
+	Create(ctx context.Context, request web.StoreCreateRequest) (web.StoreResponse, error)
+	Update(ctx context.Context, request web.StoreUpdateRequest) (web.StoreResponse, error)
+	Delete(ctx context.Context, storeId uint64, version uint64) error
+	FindById(ctx context.Context, storeId uint64) (web.StoreResponse, error)
+	FindAll(ctx context.Context) ([]web.StoreResponse, error)
+}
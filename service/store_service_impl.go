@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type StoreServiceImpl struct {
+	StoreRepository repository.StoreRepository
+	Validate        *validator.Validate
+}
+
+func NewStoreService(storeRepository repository.StoreRepository, validate *validator.Validate) StoreService {
+	return &StoreServiceImpl{
+		StoreRepository: storeRepository,
+		Validate:        validate,
+	}
+}
+
+// Create Store
+func (service *StoreServiceImpl) Create(ctx context.Context, request web.StoreCreateRequest) (web.StoreResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.StoreResponse{}, err
+	}
+
+	store, err := service.StoreRepository.Save(ctx, domain.Store{Code: request.Code, Name: request.Name, Address: request.Address})
+	if err != nil {
+		return web.StoreResponse{}, err
+	}
+
+	return helper.ToStoreResponse(store), nil
+}
+
+// Update Store
+func (service *StoreServiceImpl) Update(ctx context.Context, request web.StoreUpdateRequest) (web.StoreResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.StoreResponse{}, err
+	}
+
+	store, err := findStore(ctx, service.StoreRepository, request.Id)
+	if err != nil {
+		return web.StoreResponse{}, err
+	}
+	if request.Version != 0 && request.Version != store.Version {
+		return web.StoreResponse{}, exception.NewConflictError("Store has been modified")
+	}
+
+	store.Code = request.Code
+	store.Name = request.Name
+	store.Address = request.Address
+
+	updatedStore, err := service.StoreRepository.Update(ctx, store)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return web.StoreResponse{}, exception.NewConflictError("Store has been modified")
+	} else if err != nil {
+		return web.StoreResponse{}, err
+	}
+
+	return helper.ToStoreResponse(updatedStore), nil
+}
+
+// Delete Store, which is only allowed once nothing refers to it any more
+func (service *StoreServiceImpl) Delete(ctx context.Context, storeId uint64, version uint64) error {
+	store, err := findStore(ctx, service.StoreRepository, storeId)
+	if err != nil {
+		return err
+	}
+	if version != 0 && version != store.Version {
+		return exception.NewConflictError("Store has been modified")
+	}
+
+	inUse, err := service.StoreRepository.IsInUse(ctx, storeId)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return exception.NewConflictError("Store still has employees, shifts, orders, stock or transfers in transit")
+	}
+
+	err = service.StoreRepository.Delete(ctx, store)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Store has been modified")
+	}
+	return err
+}
+
+// FindById Store
+func (service *StoreServiceImpl) FindById(ctx context.Context, storeId uint64) (web.StoreResponse, error) {
+	store, err := findStore(ctx, service.StoreRepository, storeId)
+	if err != nil {
+		return web.StoreResponse{}, err
+	}
+
+	return helper.ToStoreResponse(store), nil
+}
+
+// FindAll Stores
+func (service *StoreServiceImpl) FindAll(ctx context.Context) ([]web.StoreResponse, error) {
+	stores, err := service.StoreRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToStoreResponses(stores), nil
+}
+
+// findStore loads a store, reporting a missing one as NotFoundError.
+func findStore(ctx context.Context, storeRepository repository.StoreRepository, storeId uint64) (domain.Store, error) {
+	store, err := storeRepository.FindById(ctx, storeId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Store{}, exception.NewNotFoundError("Store not found")
+	}
+	return store, err
+}
+
+// requestStore returns the store the request is made for.
+func requestStore(ctx context.Context) (uint64, error) {
+	storeId, ok := helper.StoreIdFromContext(ctx)
+	if !ok {
+		return 0, repository.ErrStoreRequired
+	}
+	return storeId, nil
+}
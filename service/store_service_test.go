@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestCreateStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockStoreRepository(ctrl)
+	storeService := NewStoreService(mockRepo, validator.New())
+
+	tests := []struct {
+		name      string
+		input     web.StoreCreateRequest
+		mock      func()
+		expect    web.StoreResponse
+		expectErr bool
+	}{
+		{
+			name:  "success",
+			input: web.StoreCreateRequest{Code: "JKT", Name: "Jakarta", Address: "Jl. Sudirman 1"},
+			mock: func() {
+				mockRepo.EXPECT().Save(gomock.Any(), domain.Store{Code: "JKT", Name: "Jakarta", Address: "Jl. Sudirman 1"}).
+					Return(domain.Store{Id: 1, Code: "JKT", Name: "Jakarta", Address: "Jl. Sudirman 1", Version: 1}, nil)
+			},
+			expect: web.StoreResponse{Id: 1, Code: "JKT", Name: "Jakarta", Address: "Jl. Sudirman 1", Version: 1},
+		},
+		{
+			name:      "validation error",
+			input:     web.StoreCreateRequest{Name: "Jakarta"},
+			mock:      func() {},
+			expectErr: true,
+		},
+		{
+			name:  "repository error",
+			input: web.StoreCreateRequest{Code: "BDG", Name: "Bandung"},
+			mock: func() {
+				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Store{}, errors.New("database error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			resp, err := storeService.Create(context.Background(), tt.input)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, resp)
+			}
+		})
+	}
+}
+
+func TestUpdateStore(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     web.StoreUpdateRequest
+		mock      func(mockRepo *mocks.MockStoreRepository)
+		expect    web.StoreResponse
+		expectErr error
+	}{
+		{
+			name:  "success",
+			input: web.StoreUpdateRequest{Id: 1, Code: "JKT", Name: "Jakarta Pusat", Version: 2},
+			mock: func(mockRepo *mocks.MockStoreRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Store{Id: 1, Code: "JKT", Name: "Jakarta", Version: 2}, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), domain.Store{Id: 1, Code: "JKT", Name: "Jakarta Pusat", Version: 2}).
+					Return(domain.Store{Id: 1, Code: "JKT", Name: "Jakarta Pusat", Version: 3}, nil)
+			},
+			expect: web.StoreResponse{Id: 1, Code: "JKT", Name: "Jakarta Pusat", Version: 3},
+		},
+		{
+			name:  "stale version",
+			input: web.StoreUpdateRequest{Id: 1, Code: "JKT", Name: "Jakarta Pusat", Version: 1},
+			mock: func(mockRepo *mocks.MockStoreRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Store{Id: 1, Code: "JKT", Name: "Jakarta", Version: 2}, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:  "modified while saving",
+			input: web.StoreUpdateRequest{Id: 1, Code: "JKT", Name: "Jakarta Pusat"},
+			mock: func(mockRepo *mocks.MockStoreRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Store{Id: 1, Code: "JKT", Name: "Jakarta", Version: 2}, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(domain.Store{}, repository.ErrVersionConflict)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:  "store not found",
+			input: web.StoreUpdateRequest{Id: 9, Code: "SBY", Name: "Surabaya"},
+			mock: func(mockRepo *mocks.MockStoreRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(9)).Return(domain.Store{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+		{
+			name:      "validation error",
+			input:     web.StoreUpdateRequest{Id: 1, Code: "JKT"},
+			mock:      func(mockRepo *mocks.MockStoreRepository) {},
+			expectErr: validator.ValidationErrors{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockStoreRepository(ctrl)
+			tt.mock(mockRepo)
+
+			storeService := NewStoreService(mockRepo, validator.New())
+			resp, err := storeService.Update(context.Background(), tt.input)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, resp)
+		})
+	}
+}
+
+func TestDeleteStore(t *testing.T) {
+	tests := []struct {
+		name      string
+		storeId   uint64
+		version   uint64
+		mock      func(mockRepo *mocks.MockStoreRepository)
+		expectErr error
+	}{
+		{
+			name:    "success",
+			storeId: 1,
+			version: 2,
+			mock: func(mockRepo *mocks.MockStoreRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Store{Id: 1, Version: 2}, nil)
+				mockRepo.EXPECT().IsInUse(gomock.Any(), uint64(1)).Return(false, nil)
+				mockRepo.EXPECT().Delete(gomock.Any(), domain.Store{Id: 1, Version: 2}).Return(nil)
+			},
+		},
+		{
+			name:    "store still in use",
+			storeId: 1,
+			mock: func(mockRepo *mocks.MockStoreRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Store{Id: 1, Version: 2}, nil)
+				mockRepo.EXPECT().IsInUse(gomock.Any(), uint64(1)).Return(true, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:    "stale version",
+			storeId: 1,
+			version: 1,
+			mock: func(mockRepo *mocks.MockStoreRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Store{Id: 1, Version: 2}, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:    "modified while deleting",
+			storeId: 1,
+			mock: func(mockRepo *mocks.MockStoreRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Store{Id: 1, Version: 2}, nil)
+				mockRepo.EXPECT().IsInUse(gomock.Any(), uint64(1)).Return(false, nil)
+				mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(repository.ErrVersionConflict)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:    "store not found",
+			storeId: 9,
+			mock: func(mockRepo *mocks.MockStoreRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(9)).Return(domain.Store{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockStoreRepository(ctrl)
+			tt.mock(mockRepo)
+
+			storeService := NewStoreService(mockRepo, validator.New())
+			err := storeService.Delete(context.Background(), tt.storeId, tt.version)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFindStores(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockStoreRepository(ctrl)
+	storeService := NewStoreService(mockRepo, validator.New())
+
+	t.Run("find by id", func(t *testing.T) {
+		mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Store{Id: 1, Code: "JKT", Name: "Jakarta", Version: 2}, nil)
+
+		resp, err := storeService.FindById(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, web.StoreResponse{Id: 1, Code: "JKT", Name: "Jakarta", Version: 2}, resp)
+	})
+
+	t.Run("find by id not found", func(t *testing.T) {
+		mockRepo.EXPECT().FindById(gomock.Any(), uint64(9)).Return(domain.Store{}, gorm.ErrRecordNotFound)
+
+		_, err := storeService.FindById(context.Background(), 9)
+		assert.IsType(t, exception.NotFoundError{}, err)
+	})
+
+	t.Run("find all", func(t *testing.T) {
+		mockRepo.EXPECT().FindAll(gomock.Any()).Return([]domain.Store{{Id: 1, Code: "JKT", Name: "Jakarta"}, {Id: 2, Code: "BDG", Name: "Bandung"}}, nil)
+
+		resp, err := storeService.FindAll(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []web.StoreResponse{{Id: 1, Code: "JKT", Name: "Jakarta"}, {Id: 2, Code: "BDG", Name: "Bandung"}}, resp)
+	})
+}
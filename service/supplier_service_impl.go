@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type SupplierServiceImpl struct {
+	SupplierRepository repository.SupplierRepository
+	Validate           *validator.Validate
+}
+
+func NewSupplierService(supplierRepository repository.SupplierRepository, validate *validator.Validate) SupplierService {
+	return &SupplierServiceImpl{
+		SupplierRepository: supplierRepository,
+		Validate:           validate,
+	}
+}
+
+// Create Supplier
+func (service *SupplierServiceImpl) Create(ctx context.Context, request web.SupplierCreateRequest) (web.SupplierResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.SupplierResponse{}, err
+	}
+
+	supplier, err := service.SupplierRepository.Save(ctx, domain.Supplier{
+		Code:        request.Code,
+		Name:        request.Name,
+		ContactName: request.ContactName,
+		Email:       request.Email,
+		Phone:       request.Phone,
+		Address:     request.Address,
+	})
+	if err != nil {
+		return web.SupplierResponse{}, err
+	}
+
+	return helper.ToSupplierResponse(supplier), nil
+}
+
+// Update Supplier
+func (service *SupplierServiceImpl) Update(ctx context.Context, request web.SupplierUpdateRequest) (web.SupplierResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.SupplierResponse{}, err
+	}
+
+	supplier, err := findSupplier(ctx, service.SupplierRepository, request.Id)
+	if err != nil {
+		return web.SupplierResponse{}, err
+	}
+	if request.Version != 0 && request.Version != supplier.Version {
+		return web.SupplierResponse{}, exception.NewConflictError("Supplier has been modified")
+	}
+
+	supplier.Code = request.Code
+	supplier.Name = request.Name
+	supplier.ContactName = request.ContactName
+	supplier.Email = request.Email
+	supplier.Phone = request.Phone
+	supplier.Address = request.Address
+
+	updatedSupplier, err := service.SupplierRepository.Update(ctx, supplier)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return web.SupplierResponse{}, exception.NewConflictError("Supplier has been modified")
+	} else if err != nil {
+		return web.SupplierResponse{}, err
+	}
+
+	return helper.ToSupplierResponse(updatedSupplier), nil
+}
+
+// Delete Supplier, which is only allowed once nothing refers to it any more
+func (service *SupplierServiceImpl) Delete(ctx context.Context, supplierId uint64, version uint64) error {
+	supplier, err := findSupplier(ctx, service.SupplierRepository, supplierId)
+	if err != nil {
+		return err
+	}
+	if version != 0 && version != supplier.Version {
+		return exception.NewConflictError("Supplier has been modified")
+	}
+
+	inUse, err := service.SupplierRepository.IsInUse(ctx, supplierId)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return exception.NewConflictError("Supplier is still the preferred supplier of products or has purchase orders")
+	}
+
+	err = service.SupplierRepository.Delete(ctx, supplier)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Supplier has been modified")
+	}
+	return err
+}
+
+// FindById Supplier
+func (service *SupplierServiceImpl) FindById(ctx context.Context, supplierId uint64) (web.SupplierResponse, error) {
+	supplier, err := findSupplier(ctx, service.SupplierRepository, supplierId)
+	if err != nil {
+		return web.SupplierResponse{}, err
+	}
+
+	return helper.ToSupplierResponse(supplier), nil
+}
+
+// FindAll Suppliers
+func (service *SupplierServiceImpl) FindAll(ctx context.Context) ([]web.SupplierResponse, error) {
+	suppliers, err := service.SupplierRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToSupplierResponses(suppliers), nil
+}
+
+// findSupplier loads a supplier, reporting a missing one as NotFoundError.
+func findSupplier(ctx context.Context, supplierRepository repository.SupplierRepository, supplierId uint64) (domain.Supplier, error) {
+	supplier, err := supplierRepository.FindById(ctx, supplierId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Supplier{}, exception.NewNotFoundError("Supplier not found")
+	}
+	return supplier, err
+}
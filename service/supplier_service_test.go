@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestCreateSupplier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSupplierRepository(ctrl)
+	supplierService := NewSupplierService(mockRepo, validator.New())
+
+	tests := []struct {
+		name      string
+		input     web.SupplierCreateRequest
+		mock      func()
+		expect    web.SupplierResponse
+		expectErr bool
+	}{
+		{
+			name:  "success",
+			input: web.SupplierCreateRequest{Code: "ACME", Name: "Acme", ContactName: "Siti", Email: "siti@acme.id", Phone: "0812"},
+			mock: func() {
+				mockRepo.EXPECT().Save(gomock.Any(), domain.Supplier{Code: "ACME", Name: "Acme", ContactName: "Siti", Email: "siti@acme.id", Phone: "0812"}).
+					Return(domain.Supplier{Id: 1, Code: "ACME", Name: "Acme", ContactName: "Siti", Email: "siti@acme.id", Phone: "0812", Version: 1}, nil)
+			},
+			expect: web.SupplierResponse{Id: 1, Code: "ACME", Name: "Acme", ContactName: "Siti", Email: "siti@acme.id", Phone: "0812", Version: 1},
+		},
+		{
+			name:      "invalid email",
+			input:     web.SupplierCreateRequest{Code: "ACME", Name: "Acme", Email: "siti"},
+			mock:      func() {},
+			expectErr: true,
+		},
+		{
+			name:  "repository error",
+			input: web.SupplierCreateRequest{Code: "ACME", Name: "Acme"},
+			mock: func() {
+				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Supplier{}, errors.New("database error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			resp, err := supplierService.Create(context.Background(), tt.input)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, resp)
+			}
+		})
+	}
+}
+
+func TestUpdateSupplier(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     web.SupplierUpdateRequest
+		mock      func(mockRepo *mocks.MockSupplierRepository)
+		expect    web.SupplierResponse
+		expectErr error
+	}{
+		{
+			name:  "success",
+			input: web.SupplierUpdateRequest{Id: 1, Code: "ACME", Name: "Acme Jaya", Phone: "0813", Version: 2},
+			mock: func(mockRepo *mocks.MockSupplierRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Supplier{Id: 1, Code: "ACME", Name: "Acme", Phone: "0812", Version: 2}, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), domain.Supplier{Id: 1, Code: "ACME", Name: "Acme Jaya", Phone: "0813", Version: 2}).
+					Return(domain.Supplier{Id: 1, Code: "ACME", Name: "Acme Jaya", Phone: "0813", Version: 3}, nil)
+			},
+			expect: web.SupplierResponse{Id: 1, Code: "ACME", Name: "Acme Jaya", Phone: "0813", Version: 3},
+		},
+		{
+			name:  "stale version",
+			input: web.SupplierUpdateRequest{Id: 1, Code: "ACME", Name: "Acme Jaya", Version: 1},
+			mock: func(mockRepo *mocks.MockSupplierRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Supplier{Id: 1, Code: "ACME", Name: "Acme", Version: 2}, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:  "modified while saving",
+			input: web.SupplierUpdateRequest{Id: 1, Code: "ACME", Name: "Acme Jaya"},
+			mock: func(mockRepo *mocks.MockSupplierRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Supplier{Id: 1, Code: "ACME", Name: "Acme", Version: 2}, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(domain.Supplier{}, repository.ErrVersionConflict)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:  "supplier not found",
+			input: web.SupplierUpdateRequest{Id: 9, Code: "ACME", Name: "Acme"},
+			mock: func(mockRepo *mocks.MockSupplierRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(9)).Return(domain.Supplier{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+		{
+			name:      "validation error",
+			input:     web.SupplierUpdateRequest{Id: 1, Name: "Acme"},
+			mock:      func(mockRepo *mocks.MockSupplierRepository) {},
+			expectErr: validator.ValidationErrors{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockSupplierRepository(ctrl)
+			tt.mock(mockRepo)
+
+			supplierService := NewSupplierService(mockRepo, validator.New())
+			resp, err := supplierService.Update(context.Background(), tt.input)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, resp)
+		})
+	}
+}
+
+func TestDeleteSupplier(t *testing.T) {
+	tests := []struct {
+		name       string
+		supplierId uint64
+		version    uint64
+		mock       func(mockRepo *mocks.MockSupplierRepository)
+		expectErr  error
+	}{
+		{
+			name:       "success",
+			supplierId: 1,
+			version:    2,
+			mock: func(mockRepo *mocks.MockSupplierRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Supplier{Id: 1, Version: 2}, nil)
+				mockRepo.EXPECT().IsInUse(gomock.Any(), uint64(1)).Return(false, nil)
+				mockRepo.EXPECT().Delete(gomock.Any(), domain.Supplier{Id: 1, Version: 2}).Return(nil)
+			},
+		},
+		{
+			name:       "supplier still in use",
+			supplierId: 1,
+			mock: func(mockRepo *mocks.MockSupplierRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Supplier{Id: 1, Version: 2}, nil)
+				mockRepo.EXPECT().IsInUse(gomock.Any(), uint64(1)).Return(true, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:       "stale version",
+			supplierId: 1,
+			version:    1,
+			mock: func(mockRepo *mocks.MockSupplierRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Supplier{Id: 1, Version: 2}, nil)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:       "modified while deleting",
+			supplierId: 1,
+			mock: func(mockRepo *mocks.MockSupplierRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Supplier{Id: 1, Version: 2}, nil)
+				mockRepo.EXPECT().IsInUse(gomock.Any(), uint64(1)).Return(false, nil)
+				mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(repository.ErrVersionConflict)
+			},
+			expectErr: exception.ConflictError{},
+		},
+		{
+			name:       "supplier not found",
+			supplierId: 9,
+			mock: func(mockRepo *mocks.MockSupplierRepository) {
+				mockRepo.EXPECT().FindById(gomock.Any(), uint64(9)).Return(domain.Supplier{}, gorm.ErrRecordNotFound)
+			},
+			expectErr: exception.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockSupplierRepository(ctrl)
+			tt.mock(mockRepo)
+
+			supplierService := NewSupplierService(mockRepo, validator.New())
+			err := supplierService.Delete(context.Background(), tt.supplierId, tt.version)
+			if tt.expectErr != nil {
+				assert.IsType(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFindSuppliers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSupplierRepository(ctrl)
+	supplierService := NewSupplierService(mockRepo, validator.New())
+
+	t.Run("find by id", func(t *testing.T) {
+		mockRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.Supplier{Id: 1, Code: "ACME", Name: "Acme", Version: 2}, nil)
+
+		resp, err := supplierService.FindById(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, web.SupplierResponse{Id: 1, Code: "ACME", Name: "Acme", Version: 2}, resp)
+	})
+
+	t.Run("find by id not found", func(t *testing.T) {
+		mockRepo.EXPECT().FindById(gomock.Any(), uint64(9)).Return(domain.Supplier{}, gorm.ErrRecordNotFound)
+
+		_, err := supplierService.FindById(context.Background(), 9)
+		assert.IsType(t, exception.NotFoundError{}, err)
+	})
+
+	t.Run("find all", func(t *testing.T) {
+		mockRepo.EXPECT().FindAll(gomock.Any()).Return([]domain.Supplier{{Id: 1, Code: "ACME", Name: "Acme"}, {Id: 2, Code: "SMBR", Name: "Sumber"}}, nil)
+
+		resp, err := supplierService.FindAll(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []web.SupplierResponse{{Id: 1, Code: "ACME", Name: "Acme"}, {Id: 2, Code: "SMBR", Name: "Sumber"}}, resp)
+	})
+}
@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+)
+
+type SyncService interface {
+	Changes(ctx context.Context, request web.ChangeFeedRequest) (web.ChangeFeedResponse, error)
+}
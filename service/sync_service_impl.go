@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultChangeFeedLimit is the number of changes in a page when no limit is given.
+	DefaultChangeFeedLimit = 200
+	// ChangeFeedGapTimeout is how long a gap in the sequence numbers is waited for to be
+	// filled by a transaction that has not committed yet, before it is taken to be from
+	// one that rolled back.
+	ChangeFeedGapTimeout = 30 * time.Second
+)
+
+type SyncServiceImpl struct {
+	EntityChangeRepository repository.EntityChangeRepository
+	ProductRepository      repository.ProductRepository
+	CategoryRepository     repository.CategoryRepository
+	TaxRepository          repository.TaxRepository
+	DiscountRepository     repository.DiscountRepository
+	Validate               *validator.Validate
+}
+
+func NewSyncService(entityChangeRepository repository.EntityChangeRepository, productRepository repository.ProductRepository, categoryRepository repository.CategoryRepository, taxRepository repository.TaxRepository, discountRepository repository.DiscountRepository, validate *validator.Validate) SyncService {
+	return &SyncServiceImpl{
+		EntityChangeRepository: entityChangeRepository,
+		ProductRepository:      productRepository,
+		CategoryRepository:     categoryRepository,
+		TaxRepository:          taxRepository,
+		DiscountRepository:     discountRepository,
+		Validate:               validate,
+	}
+}
+
+// Changes returns the products, categories, taxes and discounts changed after the cursor
+// since, in order and with only the last change of an entity within a page. Upserts
+// carry the current state of the entity, so a terminal applies them as they come.
+//
+// Sequence numbers are assigned on insert but become visible on commit, so a page ends
+// before a gap that a transaction still in progress may fill. A gap older than
+// ChangeFeedGapTimeout is passed as a rollback, so a change committed later than that is
+// not seen by a terminal whose cursor has moved past it.
+func (service *SyncServiceImpl) Changes(ctx context.Context, request web.ChangeFeedRequest) (web.ChangeFeedResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.ChangeFeedResponse{}, err
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = DefaultChangeFeedLimit
+	}
+
+	changes, err := service.EntityChangeRepository.FindAfter(ctx, request.Since, limit+1)
+	if err != nil {
+		return web.ChangeFeedResponse{}, err
+	}
+	hasMore := len(changes) > limit
+	if hasMore {
+		changes = changes[:limit]
+	}
+
+	gapCutoff := time.Now().Add(-ChangeFeedGapTimeout)
+	expectedSeq := request.Since + 1
+	for i, change := range changes {
+		if change.Seq != expectedSeq && change.ChangedAt.After(gapCutoff) {
+			changes, hasMore = changes[:i], true
+			break
+		}
+		expectedSeq = change.Seq + 1
+	}
+
+	response := web.ChangeFeedResponse{Changes: []web.ChangeResponse{}, Cursor: request.Since, HasMore: hasMore}
+	if len(changes) == 0 {
+		return response, nil
+	}
+	response.Cursor = changes[len(changes)-1].Seq
+
+	lastChange := map[string]int{}
+	for i, change := range changes {
+		lastChange[change.EntityType+":"+change.EntityId] = i
+	}
+	var productIds []string
+	var categoryIds []uint64
+	var taxIds, discountIds []string
+	for i, change := range changes {
+		if lastChange[change.EntityType+":"+change.EntityId] != i || change.Action != domain.ChangeUpsert {
+			continue
+		}
+		switch change.EntityType {
+		case domain.ChangeEntityProduct:
+			productIds = append(productIds, change.EntityId)
+		case domain.ChangeEntityCategory:
+			if categoryId, err := strconv.ParseUint(change.EntityId, 10, 64); err == nil {
+				categoryIds = append(categoryIds, categoryId)
+			}
+		case domain.ChangeEntityTax:
+			taxIds = append(taxIds, change.EntityId)
+		case domain.ChangeEntityDiscount:
+			discountIds = append(discountIds, change.EntityId)
+		}
+	}
+
+	entities := map[string]interface{}{}
+	products, err := service.ProductRepository.FindAllById(ctx, productIds)
+	if err != nil {
+		return web.ChangeFeedResponse{}, err
+	}
+	for _, product := range products {
+		entities[domain.ChangeEntityProduct+":"+product.ProductID] = helper.ToProductResponse(product)
+	}
+	categories, err := service.CategoryRepository.FindAllById(ctx, categoryIds)
+	if err != nil {
+		return web.ChangeFeedResponse{}, err
+	}
+	for _, category := range categories {
+		entities[domain.ChangeEntityCategory+":"+strconv.FormatUint(category.Id, 10)] = helper.ToCategoryResponse(category)
+	}
+	taxes, err := service.TaxRepository.FindAllById(ctx, taxIds)
+	if err != nil {
+		return web.ChangeFeedResponse{}, err
+	}
+	for _, tax := range taxes {
+		entities[domain.ChangeEntityTax+":"+tax.TaxID] = helper.ToTaxResponse(tax)
+	}
+	discounts, err := service.DiscountRepository.FindAllById(ctx, discountIds)
+	if err != nil {
+		return web.ChangeFeedResponse{}, err
+	}
+	for _, discount := range discounts {
+		entities[domain.ChangeEntityDiscount+":"+discount.DiscountID] = helper.ToDiscountResponse(discount)
+	}
+
+	for i, change := range changes {
+		key := change.EntityType + ":" + change.EntityId
+		if lastChange[key] != i {
+			continue
+		}
+		changeResponse := web.ChangeResponse{
+			Seq:        change.Seq,
+			EntityType: change.EntityType,
+			EntityId:   change.EntityId,
+			Action:     change.Action,
+		}
+		if change.Action == domain.ChangeUpsert {
+			// An entity that is gone by now was deleted by a later change
+			if changeResponse.Data = entities[key]; changeResponse.Data == nil {
+				changeResponse.Action = domain.ChangeDelete
+			}
+		}
+		response.Changes = append(response.Changes, changeResponse)
+	}
+	return response, nil
+}
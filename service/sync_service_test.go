@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestChangeFeed(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	recent := time.Now()
+
+	tests := []struct {
+		name          string
+		since         uint64
+		changes       []domain.EntityChange
+		mock          func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, taxRepo *mocks.MockTaxRepository, discountRepo *mocks.MockDiscountRepository)
+		expectChanges []web.ChangeResponse
+		expectCursor  uint64
+		expectMore    bool
+	}{
+		{
+			name:  "upserts carry the current entity and deletes are tombstones",
+			since: 10,
+			changes: []domain.EntityChange{
+				{Seq: 11, EntityType: domain.ChangeEntityProduct, EntityId: "p-1", Action: domain.ChangeUpsert, ChangedAt: old},
+				{Seq: 12, EntityType: domain.ChangeEntityCategory, EntityId: "3", Action: domain.ChangeUpsert, ChangedAt: old},
+				{Seq: 13, EntityType: domain.ChangeEntityProduct, EntityId: "p-2", Action: domain.ChangeDelete, ChangedAt: old},
+				{Seq: 14, EntityType: domain.ChangeEntityProduct, EntityId: "p-1", Action: domain.ChangeUpsert, ChangedAt: old},
+				{Seq: 15, EntityType: domain.ChangeEntityProduct, EntityId: "p-3", Action: domain.ChangeUpsert, ChangedAt: old},
+			},
+			mock: func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, taxRepo *mocks.MockTaxRepository, discountRepo *mocks.MockDiscountRepository) {
+				// p-3 was deleted after this page was read
+				productRepo.EXPECT().FindAllById(gomock.Any(), []string{"p-1", "p-3"}).Return([]domain.Product{{ProductID: "p-1", Name: "Laptop", Version: 4}}, nil)
+				categoryRepo.EXPECT().FindAllById(gomock.Any(), []uint64{3}).Return([]domain.Category{{Id: 3, Name: "Drinks", Version: 2}}, nil)
+				taxRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+				discountRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+			},
+			expectChanges: []web.ChangeResponse{
+				{Seq: 12, EntityType: domain.ChangeEntityCategory, EntityId: "3", Action: domain.ChangeUpsert, Data: web.CategoryResponse{Id: 3, Name: "Drinks", Version: 2}},
+				{Seq: 13, EntityType: domain.ChangeEntityProduct, EntityId: "p-2", Action: domain.ChangeDelete},
+				{Seq: 14, EntityType: domain.ChangeEntityProduct, EntityId: "p-1", Action: domain.ChangeUpsert, Data: web.ProductResponse{ProductID: "p-1", Name: "Laptop", Version: 4}},
+				{Seq: 15, EntityType: domain.ChangeEntityProduct, EntityId: "p-3", Action: domain.ChangeDelete},
+			},
+			expectCursor: 15,
+		},
+		{
+			name:  "taxes and discounts are fed like the catalogue",
+			since: 20,
+			changes: []domain.EntityChange{
+				{Seq: 21, EntityType: domain.ChangeEntityTax, EntityId: "vat", Action: domain.ChangeUpsert, ChangedAt: old},
+				{Seq: 22, EntityType: domain.ChangeEntityDiscount, EntityId: "summer", Action: domain.ChangeUpsert, ChangedAt: old},
+				{Seq: 23, EntityType: domain.ChangeEntityDiscount, EntityId: "spring", Action: domain.ChangeDelete, ChangedAt: old},
+			},
+			mock: func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, taxRepo *mocks.MockTaxRepository, discountRepo *mocks.MockDiscountRepository) {
+				productRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+				categoryRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+				taxRepo.EXPECT().FindAllById(gomock.Any(), []string{"vat"}).Return([]domain.Tax{{TaxID: "vat", TaxRate: 11, TaxType: "VAT", Version: 2}}, nil)
+				discountRepo.EXPECT().FindAllById(gomock.Any(), []string{"summer"}).Return([]domain.Discount{{DiscountID: "summer", DiscountPct: 10, Version: 1}}, nil)
+			},
+			expectChanges: []web.ChangeResponse{
+				{Seq: 21, EntityType: domain.ChangeEntityTax, EntityId: "vat", Action: domain.ChangeUpsert, Data: web.TaxResponse{TaxID: "vat", TaxRate: 11, TaxType: "VAT", Version: 2}},
+				{Seq: 22, EntityType: domain.ChangeEntityDiscount, EntityId: "summer", Action: domain.ChangeUpsert, Data: web.DiscountResponse{DiscountID: "summer", DiscountPct: 10, Version: 1}},
+				{Seq: 23, EntityType: domain.ChangeEntityDiscount, EntityId: "spring", Action: domain.ChangeDelete},
+			},
+			expectCursor: 23,
+		},
+		{
+			name:  "page ends before a recent gap that an open transaction may fill",
+			since: 10,
+			changes: []domain.EntityChange{
+				{Seq: 11, EntityType: domain.ChangeEntityCategory, EntityId: "3", Action: domain.ChangeDelete, ChangedAt: old},
+				{Seq: 13, EntityType: domain.ChangeEntityCategory, EntityId: "4", Action: domain.ChangeDelete, ChangedAt: recent},
+			},
+			mock: func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, taxRepo *mocks.MockTaxRepository, discountRepo *mocks.MockDiscountRepository) {
+				productRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+				categoryRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+				taxRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+				discountRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+			},
+			expectChanges: []web.ChangeResponse{
+				{Seq: 11, EntityType: domain.ChangeEntityCategory, EntityId: "3", Action: domain.ChangeDelete},
+			},
+			expectCursor: 11,
+			expectMore:   true,
+		},
+		{
+			name:  "an old gap is from a rolled back transaction",
+			since: 10,
+			changes: []domain.EntityChange{
+				{Seq: 12, EntityType: domain.ChangeEntityCategory, EntityId: "4", Action: domain.ChangeDelete, ChangedAt: old},
+			},
+			mock: func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, taxRepo *mocks.MockTaxRepository, discountRepo *mocks.MockDiscountRepository) {
+				productRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+				categoryRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+				taxRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+				discountRepo.EXPECT().FindAllById(gomock.Any(), gomock.Nil()).Return(nil, nil)
+			},
+			expectChanges: []web.ChangeResponse{
+				{Seq: 12, EntityType: domain.ChangeEntityCategory, EntityId: "4", Action: domain.ChangeDelete},
+			},
+			expectCursor: 12,
+		},
+		{
+			name:  "nothing new keeps the cursor",
+			since: 15,
+			mock: func(productRepo *mocks.MockProductRepository, categoryRepo *mocks.MockCategoryRepository, taxRepo *mocks.MockTaxRepository, discountRepo *mocks.MockDiscountRepository) {
+			},
+			expectChanges: []web.ChangeResponse{},
+			expectCursor:  15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			changeRepo := mocks.NewMockEntityChangeRepository(ctrl)
+			productRepo := mocks.NewMockProductRepository(ctrl)
+			categoryRepo := mocks.NewMockCategoryRepository(ctrl)
+			taxRepo := mocks.NewMockTaxRepository(ctrl)
+			discountRepo := mocks.NewMockDiscountRepository(ctrl)
+			syncService := NewSyncService(changeRepo, productRepo, categoryRepo, taxRepo, discountRepo, validator.New())
+
+			changeRepo.EXPECT().FindAfter(gomock.Any(), tt.since, DefaultChangeFeedLimit+1).Return(tt.changes, nil)
+			tt.mock(productRepo, categoryRepo, taxRepo, discountRepo)
+
+			response, err := syncService.Changes(context.Background(), web.ChangeFeedRequest{Since: tt.since})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectChanges, response.Changes)
+			assert.Equal(t, tt.expectCursor, response.Cursor)
+			assert.Equal(t, tt.expectMore, response.HasMore)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"time"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, request web.WebhookSubscriptionCreateRequest) (web.WebhookSubscriptionResponse, error)
+	Update(ctx context.Context, request web.WebhookSubscriptionUpdateRequest) (web.WebhookSubscriptionResponse, error)
+	Delete(ctx context.Context, subscriptionId uint64, version uint64) error
+	FindById(ctx context.Context, subscriptionId uint64) (web.WebhookSubscriptionResponse, error)
+	FindAll(ctx context.Context) ([]web.WebhookSubscriptionResponse, error)
+	FindDeliveries(ctx context.Context, request web.WebhookDeliveryFilterRequest) ([]web.WebhookDeliveryResponse, error)
+	Redeliver(ctx context.Context, deliveryId uint64) (web.WebhookDeliveryResponse, error)
+	Enqueue(ctx context.Context, event web.EventEnvelope) error
+	Dispatch(ctx context.Context, now time.Time) (int, error)
+}
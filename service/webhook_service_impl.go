@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/helper"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookMaxAttempts is how often a delivery is attempted before it goes to the dead
+	// letters. With the backoff of the outbox that is about an hour and a half.
+	WebhookMaxAttempts = 10
+	// DefaultWebhookDeliveryLimit is the number of deliveries listed when no limit is given.
+	DefaultWebhookDeliveryLimit = 100
+)
+
+type WebhookServiceImpl struct {
+	WebhookSubscriptionRepository repository.WebhookSubscriptionRepository
+	WebhookDeliveryRepository     repository.WebhookDeliveryRepository
+	Client                        *http.Client
+	Validate                      *validator.Validate
+}
+
+func NewWebhookService(webhookSubscriptionRepository repository.WebhookSubscriptionRepository, webhookDeliveryRepository repository.WebhookDeliveryRepository, client *http.Client, validate *validator.Validate) WebhookService {
+	return &WebhookServiceImpl{
+		WebhookSubscriptionRepository: webhookSubscriptionRepository,
+		WebhookDeliveryRepository:     webhookDeliveryRepository,
+		Client:                        client,
+		Validate:                      validate,
+	}
+}
+
+// Create Webhook Subscription. Without a secret one is generated; the response is the only
+// place it can be read.
+func (service *WebhookServiceImpl) Create(ctx context.Context, request web.WebhookSubscriptionCreateRequest) (web.WebhookSubscriptionResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.WebhookSubscriptionResponse{}, err
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		var err error
+		if secret, err = generateWebhookSecret(); err != nil {
+			return web.WebhookSubscriptionResponse{}, err
+		}
+	}
+
+	subscription, err := service.WebhookSubscriptionRepository.Save(ctx, domain.WebhookSubscription{
+		URL:        request.URL,
+		EventTypes: strings.Join(request.EventTypes, ","),
+		Secret:     secret,
+		Active:     true,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return web.WebhookSubscriptionResponse{}, err
+	}
+
+	response := helper.ToWebhookSubscriptionResponse(subscription)
+	response.Secret = subscription.Secret
+	return response, nil
+}
+
+// Update Webhook Subscription. The secret is only replaced when a new one is given.
+func (service *WebhookServiceImpl) Update(ctx context.Context, request web.WebhookSubscriptionUpdateRequest) (web.WebhookSubscriptionResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.WebhookSubscriptionResponse{}, err
+	}
+
+	subscription, err := findWebhookSubscription(ctx, service.WebhookSubscriptionRepository, request.Id)
+	if err != nil {
+		return web.WebhookSubscriptionResponse{}, err
+	}
+	if request.Version != 0 && request.Version != subscription.Version {
+		return web.WebhookSubscriptionResponse{}, exception.NewConflictError("Webhook subscription has been modified")
+	}
+
+	subscription.URL = request.URL
+	subscription.EventTypes = strings.Join(request.EventTypes, ",")
+	subscription.Active = request.Active
+	if request.Secret != "" {
+		subscription.Secret = request.Secret
+	}
+
+	updatedSubscription, err := service.WebhookSubscriptionRepository.Update(ctx, subscription)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return web.WebhookSubscriptionResponse{}, exception.NewConflictError("Webhook subscription has been modified")
+	} else if err != nil {
+		return web.WebhookSubscriptionResponse{}, err
+	}
+
+	return helper.ToWebhookSubscriptionResponse(updatedSubscription), nil
+}
+
+// Delete Webhook Subscription. Its deliveries stay in the log; pending ones go to the dead
+// letters on their next attempt.
+func (service *WebhookServiceImpl) Delete(ctx context.Context, subscriptionId uint64, version uint64) error {
+	subscription, err := findWebhookSubscription(ctx, service.WebhookSubscriptionRepository, subscriptionId)
+	if err != nil {
+		return err
+	}
+	if version != 0 && version != subscription.Version {
+		return exception.NewConflictError("Webhook subscription has been modified")
+	}
+
+	err = service.WebhookSubscriptionRepository.Delete(ctx, subscription)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return exception.NewConflictError("Webhook subscription has been modified")
+	}
+	return err
+}
+
+// FindById Webhook Subscription
+func (service *WebhookServiceImpl) FindById(ctx context.Context, subscriptionId uint64) (web.WebhookSubscriptionResponse, error) {
+	subscription, err := findWebhookSubscription(ctx, service.WebhookSubscriptionRepository, subscriptionId)
+	if err != nil {
+		return web.WebhookSubscriptionResponse{}, err
+	}
+
+	return helper.ToWebhookSubscriptionResponse(subscription), nil
+}
+
+// FindAll Webhook Subscriptions
+func (service *WebhookServiceImpl) FindAll(ctx context.Context) ([]web.WebhookSubscriptionResponse, error) {
+	subscriptions, err := service.WebhookSubscriptionRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToWebhookSubscriptionResponses(subscriptions), nil
+}
+
+// FindDeliveries is the delivery log, latest first. The dead letters are the deliveries
+// with status dead.
+func (service *WebhookServiceImpl) FindDeliveries(ctx context.Context, request web.WebhookDeliveryFilterRequest) ([]web.WebhookDeliveryResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return nil, err
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = DefaultWebhookDeliveryLimit
+	}
+
+	deliveries, err := service.WebhookDeliveryRepository.FindAll(ctx, request.SubscriptionID, request.Status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.ToWebhookDeliveryResponses(deliveries), nil
+}
+
+// Redeliver sends a delivery again right away, whatever its status. It counts as an
+// attempt: when it fails, a delivery with attempts left is retried as usual and one
+// without stays dead.
+func (service *WebhookServiceImpl) Redeliver(ctx context.Context, deliveryId uint64) (web.WebhookDeliveryResponse, error) {
+	delivery, err := service.WebhookDeliveryRepository.FindById(ctx, deliveryId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return web.WebhookDeliveryResponse{}, exception.NewNotFoundError("Webhook delivery not found")
+	} else if err != nil {
+		return web.WebhookDeliveryResponse{}, err
+	}
+
+	claimed, err := service.WebhookDeliveryRepository.Claim(ctx, delivery, time.Now().Add(OutboxClaimTimeout))
+	if err != nil {
+		return web.WebhookDeliveryResponse{}, err
+	}
+	if !claimed {
+		return web.WebhookDeliveryResponse{}, exception.NewConflictError("Webhook delivery is being sent")
+	}
+
+	delivery, err = service.send(ctx, delivery)
+	if err != nil {
+		return web.WebhookDeliveryResponse{}, err
+	}
+	return helper.ToWebhookDeliveryResponse(delivery), nil
+}
+
+// Enqueue adds a delivery of an event for every active subscription to its type. It is
+// subscribed to the event bus, so it sees every event at least once; an event that is
+// seen again does not add a second delivery.
+func (service *WebhookServiceImpl) Enqueue(ctx context.Context, event web.EventEnvelope) error {
+	subscriptions, err := service.WebhookSubscriptionRepository.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		eventTypes := strings.Split(subscription.EventTypes, ",")
+		if !slices.Contains(eventTypes, event.Type) && !slices.Contains(eventTypes, AllEvents) {
+			continue
+		}
+
+		err := service.WebhookDeliveryRepository.Save(ctx, domain.WebhookDelivery{
+			SubscriptionID: subscription.Id,
+			EventID:        event.Id,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         domain.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dispatch attempts the deliveries that are due, oldest first, and returns how many were
+// delivered. Failed ones are retried after the backoff of the outbox, until they run out
+// of attempts.
+func (service *WebhookServiceImpl) Dispatch(ctx context.Context, now time.Time) (int, error) {
+	deliveries, err := service.WebhookDeliveryRepository.FindDue(ctx, now, OutboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		claimed, err := service.WebhookDeliveryRepository.Claim(ctx, delivery, now.Add(OutboxClaimTimeout))
+		if err != nil {
+			return delivered, err
+		}
+		if !claimed {
+			continue
+		}
+
+		delivery, err = service.send(ctx, delivery)
+		if err != nil {
+			return delivered, err
+		}
+		if delivery.Status == domain.WebhookDeliveryDelivered {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// send makes one attempt at a claimed delivery and records its outcome. A subscription
+// that is gone or inactive sends the delivery straight to the dead letters.
+func (service *WebhookServiceImpl) send(ctx context.Context, delivery domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	subscription, err := service.WebhookSubscriptionRepository.FindById(ctx, delivery.SubscriptionID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return delivery, err
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = 0
+	retry := true
+	switch {
+	case err != nil:
+		err, retry = errors.New("webhook subscription has been deleted"), false
+	case !subscription.Active:
+		err, retry = errors.New("webhook subscription is inactive"), false
+	default:
+		var event web.EventEnvelope
+		if err = json.Unmarshal([]byte(delivery.Payload), &event); err != nil {
+			retry = false
+			break
+		}
+		sink := &WebhookSink{URL: subscription.URL, Secret: subscription.Secret, Client: service.Client}
+		err = sink.Deliver(ctx, event)
+		var responseErr WebhookResponseError
+		if errors.As(err, &responseErr) {
+			delivery.LastStatusCode = responseErr.StatusCode
+		}
+	}
+
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = domain.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = now
+		delivery.LastError = ""
+	case retry && delivery.Attempts < WebhookMaxAttempts:
+		delivery.Status = domain.WebhookDeliveryPending
+		delivery.NextAttemptAt = now.Add(outboxBackoff(delivery.Attempts))
+		delivery.LastError = truncate(err.Error(), 1000)
+	default:
+		delivery.Status = domain.WebhookDeliveryDead
+		delivery.NextAttemptAt = now
+		delivery.LastError = truncate(err.Error(), 1000)
+	}
+
+	return delivery, service.WebhookDeliveryRepository.UpdateAttempt(ctx, delivery)
+}
+
+// findWebhookSubscription loads a webhook subscription, reporting a missing one as NotFoundError.
+func findWebhookSubscription(ctx context.Context, webhookSubscriptionRepository repository.WebhookSubscriptionRepository, subscriptionId uint64) (domain.WebhookSubscription, error) {
+	subscription, err := webhookSubscriptionRepository.FindById(ctx, subscriptionId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.WebhookSubscription{}, exception.NewNotFoundError("Webhook subscription not found")
+	}
+	return subscription, err
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
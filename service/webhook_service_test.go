@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/aronipurwanto/go-restful-api/exception"
+	"github.com/aronipurwanto/go-restful-api/model/domain"
+	"github.com/aronipurwanto/go-restful-api/model/web"
+	"github.com/aronipurwanto/go-restful-api/repository/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "0123456789abcdef0123456789abcdef"
+
+// newWebhookReceiver is a partner endpoint that checks the signature of every request and
+// answers with the given status.
+func newWebhookReceiver(t *testing.T, status int, received *[]web.EventEnvelope) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		unix, err := strconv.ParseInt(r.Header.Get(WebhookTimestampHeader), 10, 64)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), time.Unix(unix, 0), time.Minute)
+		assert.Equal(t, SignWebhookPayload(testWebhookSecret, time.Unix(unix, 0), body), r.Header.Get(WebhookSignatureHeader))
+
+		var event web.EventEnvelope
+		assert.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, strconv.FormatUint(event.Id, 10), r.Header.Get("X-Event-Id"))
+		*received = append(*received, event)
+		w.WriteHeader(status)
+	}))
+}
+
+func decodeEnvelope(t *testing.T, payload string) web.EventEnvelope {
+	var event web.EventEnvelope
+	assert.NoError(t, json.Unmarshal([]byte(payload), &event))
+	return event
+}
+
+func TestEnqueueWebhookDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	subscriptionRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+	deliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	webhookService := NewWebhookService(subscriptionRepo, deliveryRepo, http.DefaultClient, validator.New())
+
+	subscriptionRepo.EXPECT().FindActive(gomock.Any()).Return([]domain.WebhookSubscription{
+		{Id: 1, EventTypes: "ProductCreated,OrderPaid"},
+		{Id: 2, EventTypes: "PriceChanged"},
+		{Id: 3, EventTypes: "*"},
+	}, nil)
+	var saved []uint64
+	deliveryRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, delivery domain.WebhookDelivery) error {
+			assert.Equal(t, uint64(42), delivery.EventID)
+			assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+			assert.JSONEq(t, `{"order_id":"o-1"}`, string(decodeEnvelope(t, delivery.Payload).Data))
+			saved = append(saved, delivery.SubscriptionID)
+			return nil
+		}).Times(2)
+
+	err := webhookService.Enqueue(context.Background(), web.EventEnvelope{Id: 42, Type: domain.EventOrderPaid, Data: json.RawMessage(`{"order_id":"o-1"}`)})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 3}, saved)
+}
+
+func TestDispatchWebhooks(t *testing.T) {
+	now := time.Now()
+	payload := `{"id":42,"type":"OrderPaid","aggregate_type":"order","aggregate_id":"o-1","occurred_at":"2025-03-01T12:00:00Z","data":{"order_id":"o-1"}}`
+
+	tests := []struct {
+		name         string
+		status       int
+		subscription *domain.WebhookSubscription
+		attempts     int
+		expectStatus string
+		expectCode   int
+		expectSent   bool
+		expectCount  int
+	}{
+		{
+			name:         "signed delivery is accepted",
+			status:       http.StatusNoContent,
+			subscription: &domain.WebhookSubscription{Id: 1, Secret: testWebhookSecret, Active: true},
+			expectStatus: domain.WebhookDeliveryDelivered,
+			expectSent:   true,
+			expectCount:  1,
+		},
+		{
+			name:         "failed delivery is retried",
+			status:       http.StatusInternalServerError,
+			subscription: &domain.WebhookSubscription{Id: 1, Secret: testWebhookSecret, Active: true},
+			attempts:     2,
+			expectStatus: domain.WebhookDeliveryPending,
+			expectCode:   http.StatusInternalServerError,
+			expectSent:   true,
+		},
+		{
+			name:         "last failed attempt goes to the dead letters",
+			status:       http.StatusBadGateway,
+			subscription: &domain.WebhookSubscription{Id: 1, Secret: testWebhookSecret, Active: true},
+			attempts:     WebhookMaxAttempts - 1,
+			expectStatus: domain.WebhookDeliveryDead,
+			expectCode:   http.StatusBadGateway,
+			expectSent:   true,
+		},
+		{
+			name:         "inactive subscription goes to the dead letters",
+			subscription: &domain.WebhookSubscription{Id: 1, Secret: testWebhookSecret},
+			expectStatus: domain.WebhookDeliveryDead,
+		},
+		{
+			name:         "deleted subscription goes to the dead letters",
+			expectStatus: domain.WebhookDeliveryDead,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			subscriptionRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+			deliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+			webhookService := NewWebhookService(subscriptionRepo, deliveryRepo, http.DefaultClient, validator.New())
+
+			var received []web.EventEnvelope
+			receiver := newWebhookReceiver(t, tt.status, &received)
+			defer receiver.Close()
+
+			delivery := domain.WebhookDelivery{Id: 5, SubscriptionID: 1, EventID: 42, EventType: domain.EventOrderPaid, Payload: payload, Status: domain.WebhookDeliveryPending, Attempts: tt.attempts, NextAttemptAt: now}
+			deliveryRepo.EXPECT().FindDue(gomock.Any(), now, OutboxBatchSize).Return([]domain.WebhookDelivery{delivery}, nil)
+			deliveryRepo.EXPECT().Claim(gomock.Any(), delivery, now.Add(OutboxClaimTimeout)).Return(true, nil)
+			if tt.subscription != nil {
+				subscription := *tt.subscription
+				subscription.URL = receiver.URL
+				subscriptionRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(subscription, nil)
+			} else {
+				subscriptionRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.WebhookSubscription{}, gorm.ErrRecordNotFound)
+			}
+			deliveryRepo.EXPECT().UpdateAttempt(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, delivery domain.WebhookDelivery) error {
+					assert.Equal(t, tt.expectStatus, delivery.Status)
+					assert.Equal(t, tt.attempts+1, delivery.Attempts)
+					assert.Equal(t, tt.expectCode, delivery.LastStatusCode)
+					if tt.expectStatus == domain.WebhookDeliveryPending {
+						assert.Equal(t, outboxBackoff(tt.attempts+1), delivery.NextAttemptAt.Sub(time.Now()).Round(time.Second))
+					}
+					if tt.expectStatus == domain.WebhookDeliveryDelivered {
+						assert.NotNil(t, delivery.DeliveredAt)
+						assert.Empty(t, delivery.LastError)
+					} else {
+						assert.NotEmpty(t, delivery.LastError)
+					}
+					return nil
+				})
+
+			count, err := webhookService.Dispatch(context.Background(), now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectCount, count)
+			if tt.expectSent {
+				assert.Len(t, received, 1)
+				assert.Equal(t, uint64(42), received[0].Id)
+				assert.JSONEq(t, `{"order_id":"o-1"}`, string(received[0].Data))
+			} else {
+				assert.Empty(t, received)
+			}
+		})
+	}
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	subscriptionRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+	deliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	webhookService := NewWebhookService(subscriptionRepo, deliveryRepo, http.DefaultClient, validator.New())
+
+	var received []web.EventEnvelope
+	receiver := newWebhookReceiver(t, http.StatusOK, &received)
+	defer receiver.Close()
+
+	dead := domain.WebhookDelivery{Id: 5, SubscriptionID: 1, EventID: 42, Payload: `{"id":42,"type":"OrderPaid","data":{}}`, Status: domain.WebhookDeliveryDead, Attempts: WebhookMaxAttempts}
+	deliveryRepo.EXPECT().FindById(gomock.Any(), uint64(5)).Return(dead, nil)
+	deliveryRepo.EXPECT().Claim(gomock.Any(), dead, gomock.Any()).Return(true, nil)
+	subscriptionRepo.EXPECT().FindById(gomock.Any(), uint64(1)).Return(domain.WebhookSubscription{Id: 1, URL: receiver.URL, Secret: testWebhookSecret, Active: true}, nil)
+	deliveryRepo.EXPECT().UpdateAttempt(gomock.Any(), gomock.Any()).Return(nil)
+
+	response, err := webhookService.Redeliver(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliveryDelivered, response.Status)
+	assert.Equal(t, WebhookMaxAttempts+1, response.Attempts)
+	assert.Len(t, received, 1)
+
+	// A delivery that a dispatcher is sending cannot be redelivered at the same time
+	deliveryRepo.EXPECT().FindById(gomock.Any(), uint64(5)).Return(dead, nil)
+	deliveryRepo.EXPECT().Claim(gomock.Any(), dead, gomock.Any()).Return(false, nil)
+	_, err = webhookService.Redeliver(context.Background(), 5)
+	assert.IsType(t, exception.ConflictError{}, err)
+}